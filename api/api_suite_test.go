@@ -1,6 +1,8 @@
 package api_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -17,6 +19,7 @@ import (
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/auth"
 
+	"github.com/concourse/atc/api/buildqueueserver/buildqueueserverfakes"
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
@@ -43,6 +46,7 @@ var (
 	authValidator                 *authfakes.FakeValidator
 	userContextReader             *authfakes.FakeUserContextReader
 	fakeTokenGenerator            *authfakes.FakeTokenGenerator
+	signingKey                    *rsa.PrivateKey
 	providerFactory               *authfakes.FakeProviderFactory
 	fakeEngine                    *enginefakes.FakeEngine
 	fakeWorkerClient              *workerfakes.FakeClient
@@ -50,6 +54,7 @@ var (
 	volumesDB                     *volumeserverfakes.FakeVolumesDB
 	workerDB                      *workerserverfakes.FakeWorkerDB
 	containerDB                   *containerserverfakes.FakeContainerDB
+	hijackSessionDB               *containerserverfakes.FakeHijackSessionDB
 	pipeDB                        *pipesfakes.FakePipeDB
 	pipelineDBFactory             *dbfakes.FakePipelineDBFactory
 	teamDBFactory                 *dbfakes.FakeTeamDBFactory
@@ -57,6 +62,7 @@ var (
 	pipelinesDB                   *dbfakes.FakePipelinesDB
 	buildsDB                      *authfakes.FakeBuildsDB
 	buildServerDB                 *buildserverfakes.FakeBuildsDB
+	buildQueueDB                  *buildqueueserverfakes.FakeBuildQueueDB
 	build                         *dbfakes.FakeBuild
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
@@ -102,7 +108,9 @@ var _ = BeforeEach(func() {
 	teamDBFactory.GetTeamDBReturns(teamDB)
 	workerDB = new(workerserverfakes.FakeWorkerDB)
 	buildServerDB = new(buildserverfakes.FakeBuildsDB)
+	buildQueueDB = new(buildqueueserverfakes.FakeBuildQueueDB)
 	containerDB = new(containerserverfakes.FakeContainerDB)
+	hijackSessionDB = new(containerserverfakes.FakeHijackSessionDB)
 	volumesDB = new(volumeserverfakes.FakeVolumesDB)
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
@@ -129,6 +137,9 @@ var _ = BeforeEach(func() {
 	cliDownloadsDir, err = ioutil.TempDir("", "cli-downloads")
 	Expect(err).NotTo(HaveOccurred())
 
+	signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+	Expect(err).NotTo(HaveOccurred())
+
 	constructedEventHandler = &fakeEventHandlerFactory{}
 
 	logger = lagertest.NewTestLogger("callbacks")
@@ -151,16 +162,20 @@ var _ = BeforeEach(func() {
 
 		externalURL,
 
-		wrappa.NewAPIAuthWrappa(
-			authValidator,
-			authValidator,
-			userContextReader,
-			checkPipelineAccessHandlerFactory,
-			checkBuildReadAccessHandlerFactory,
-			checkBuildWriteAccessHandlerFactory,
-		),
+		wrappa.MultiWrappa{
+			wrappa.NewAPITokenScopeWrappa(),
+			wrappa.NewAPIAuthWrappa(
+				authValidator,
+				authValidator,
+				userContextReader,
+				checkPipelineAccessHandlerFactory,
+				checkBuildReadAccessHandlerFactory,
+				checkBuildWriteAccessHandlerFactory,
+			),
+		},
 
 		fakeTokenGenerator,
+		auth.VerificationKeys{&signingKey.PublicKey},
 		providerFactory,
 		oAuthBaseURL,
 
@@ -170,7 +185,9 @@ var _ = BeforeEach(func() {
 		teamServerDB,
 		workerDB,
 		buildServerDB,
+		buildQueueDB,
 		containerDB,
+		hijackSessionDB,
 		volumesDB,
 		pipeDB,
 		pipelinesDB,
@@ -190,6 +207,8 @@ var _ = BeforeEach(func() {
 
 		sink,
 
+		false,
+
 		expire,
 
 		cliDownloadsDir,
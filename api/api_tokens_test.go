@@ -0,0 +1,319 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API Tokens API", func() {
+	Describe("GET /api/v1/teams/:team_name/api-tokens", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/api-tokens")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when getting the tokens succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns([]db.SavedAPIToken{
+						{
+							ID: 1,
+							APIToken: db.APIToken{
+								Name:      "some-bot",
+								Scope:     atc.APITokenScopeTriggerBuilds,
+								ExpiresAt: time.Unix(200, 0),
+							},
+							CreatedAt: time.Unix(100, 0),
+						},
+						{
+							ID: 2,
+							APIToken: db.APIToken{
+								Name:  "some-other-bot",
+								Scope: atc.APITokenScopeReadOnly,
+							},
+							CreatedAt: time.Unix(150, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the tokens without their values", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"name":"some-bot","scope":"trigger-builds","created_at":100,"expires_at":200},
+						{"name":"some-other-bot","scope":"read-only","created_at":150}
+					]`))
+				})
+
+				It("looks up the tokens of the requested team", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+				})
+			})
+
+			Context("when getting the tokens fails", func() {
+				BeforeEach(func() {
+					teamDB.GetAPITokensReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the request was made with an API token", func() {
+				BeforeEach(func() {
+					userContextReader.GetAPITokenScopeReturns(atc.APITokenScopeSetPipelines, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-other-team", 43, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/api-tokens", func() {
+		var (
+			requestBody atc.APIToken
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = atc.APIToken{
+				Name:  "some-bot",
+				Scope: atc.APITokenScopeTriggerBuilds,
+			}
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/api-tokens", jsonEncode(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+
+				teamDB.CreateAPITokenStub = func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+					return db.SavedAPIToken{
+						ID:        1,
+						APIToken:  token,
+						TeamName:  "some-team",
+						CreatedAt: time.Unix(100, 0),
+					}, nil
+				}
+			})
+
+			It("returns 201 Created", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			})
+
+			It("returns the token's value, and stores only its hash", func() {
+				var token atc.APIToken
+				err := json.NewDecoder(response.Body).Decode(&token)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(token.Name).To(Equal("some-bot"))
+				Expect(token.Scope).To(Equal(atc.APITokenScopeTriggerBuilds))
+				Expect(token.CreatedAt).To(Equal(int64(100)))
+				Expect(token.Value).To(HavePrefix(auth.APITokenPrefix))
+
+				Expect(teamDB.CreateAPITokenCallCount()).To(Equal(1))
+				savedToken, tokenHash := teamDB.CreateAPITokenArgsForCall(0)
+				Expect(savedToken).To(Equal(db.APIToken{
+					Name:  "some-bot",
+					Scope: atc.APITokenScopeTriggerBuilds,
+				}))
+				Expect(tokenHash).To(Equal(auth.HashAPIToken(token.Value)))
+			})
+
+			Context("when an expiry is given", func() {
+				var expiresAt time.Time
+
+				BeforeEach(func() {
+					expiresAt = time.Now().Add(time.Hour)
+					requestBody.ExpiresAt = expiresAt.Unix()
+				})
+
+				It("saves it", func() {
+					savedToken, _ := teamDB.CreateAPITokenArgsForCall(0)
+					Expect(savedToken.ExpiresAt.Unix()).To(Equal(expiresAt.Unix()))
+				})
+			})
+
+			Context("when the expiry is in the past", func() {
+				BeforeEach(func() {
+					requestBody.ExpiresAt = time.Now().Add(-time.Hour).Unix()
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the name is missing", func() {
+				BeforeEach(func() {
+					requestBody.Name = ""
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the scope is invalid", func() {
+				BeforeEach(func() {
+					requestBody.Scope = "everything"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.CreateAPITokenCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a token with the same name exists", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenStub = nil
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, db.ErrAPITokenNameTaken)
+				})
+
+				It("returns 409 Conflict", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusConflict))
+				})
+			})
+
+			Context("when creating the token fails", func() {
+				BeforeEach(func() {
+					teamDB.CreateAPITokenStub = nil
+					teamDB.CreateAPITokenReturns(db.SavedAPIToken{}, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/api-tokens/:api_token_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/api-tokens/some-bot", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when the token exists", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(true, nil)
+				})
+
+				It("deletes it and returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					Expect(teamDB.DeleteAPITokenCallCount()).To(Equal(1))
+					Expect(teamDB.DeleteAPITokenArgsForCall(0)).To(Equal("some-bot"))
+				})
+			})
+
+			Context("when the token does not exist", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when deleting the token fails", func() {
+				BeforeEach(func() {
+					teamDB.DeleteAPITokenReturns(false, errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
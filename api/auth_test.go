@@ -1,11 +1,14 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,7 +72,7 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
@@ -77,6 +80,42 @@ var _ = Describe("Auth API", func() {
 					})
 				})
 
+				Context("when the request has a user in its context", func() {
+					BeforeEach(func() {
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+						userContextReader.GetUserReturns(atc.User{
+							ID:    "some-user-id",
+							Name:  "Some User",
+							Email: "some-user@example.com",
+						}, true)
+					})
+
+					It("carries the user over into the new token", func() {
+						_, _, _, _, _, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(user).To(Equal(atc.User{
+							ID:    "some-user-id",
+							Name:  "Some User",
+							Email: "some-user@example.com",
+						}))
+					})
+				})
+			})
+
+			Context("when the request uses basic auth", func() {
+				BeforeEach(func() {
+					request.SetBasicAuth("some-username", "some-password")
+					fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+				})
+
+				It("generates a token for the basic auth user", func() {
+					Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(Equal(1))
+					_, _, _, _, _, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Expect(user).To(Equal(atc.User{
+						ID:   "some-username",
+						Name: "some-username",
+					}))
+				})
+
 				Context("when generating the token fails", func() {
 					BeforeEach(func() {
 						fakeTokenGenerator.GenerateTokenReturns("", "", errors.New("nope"))
@@ -98,6 +137,60 @@ var _ = Describe("Auth API", func() {
 					})
 				})
 			})
+
+			Context("when the request uses a session token to switch teams", func() {
+				BeforeEach(func() {
+					savedTeam.Admin = false
+					savedTeam.BasicAuth = &db.BasicAuth{
+						BasicAuthUsername: "some-username",
+						BasicAuthPassword: "some-password",
+					}
+					teamDB.GetTeamReturns(savedTeam, true, nil)
+
+					fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+				})
+
+				Context("when the user is a member of the team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 1, false, true)
+						userContextReader.GetTeamsReturns([]string{"some-other-team", "some-team"}, true)
+					})
+
+					It("generates a token carrying the user's memberships", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(Equal(1))
+						_, teamName, _, _, teams, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(teamName).To(Equal("some-team"))
+						Expect(teams).To(Equal([]string{"some-other-team", "some-team"}))
+					})
+				})
+
+				Context("when the user is not a member of the team", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 1, false, true)
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(fakeTokenGenerator.GenerateTokenCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("main", 1, true, true)
+					})
+
+					It("generates an admin token for the team", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						_, teamName, _, isAdmin, _, _ := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(teamName).To(Equal("some-team"))
+						Expect(isAdmin).To(BeTrue())
+					})
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -376,7 +469,41 @@ var _ = Describe("Auth API", func() {
 								body, err := ioutil.ReadAll(response.Body)
 								Expect(err).NotTo(HaveOccurred())
 
-								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"}}`))
+								Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"teams":["some-team"]}`))
+							})
+
+							Context("when the user is a member of other teams", func() {
+								BeforeEach(func() {
+									userContextReader.GetTeamsReturns([]string{"some-team", "some-other-team"}, true)
+								})
+
+								It("returns every team the user can switch to", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{"team":{"id":5,"name":"some-team"},"teams":["some-team","some-other-team"]}`))
+								})
+							})
+
+							Context("when the user is in the context", func() {
+								BeforeEach(func() {
+									userContextReader.GetUserReturns(atc.User{
+										ID:    "some-user-id",
+										Name:  "Some User",
+										Email: "some-user@example.com",
+									}, true)
+								})
+
+								It("returns the team and the user", func() {
+									body, err := ioutil.ReadAll(response.Body)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(body).To(MatchJSON(`{
+										"team":{"id":5,"name":"some-team"},
+										"teams":["some-team"],
+										"user":{"id":"some-user-id","name":"Some User","email":"some-user@example.com"}
+									}`))
+								})
 							})
 						})
 					})
@@ -394,4 +521,31 @@ var _ = Describe("Auth API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/auth/keys", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/auth/keys")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns 200 OK without requiring authentication", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(authValidator.IsAuthenticatedCallCount()).To(BeZero())
+		})
+
+		It("returns the keys that session tokens are verified with", func() {
+			Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			var jwks auth.JSONWebKeySet
+			err := json.NewDecoder(response.Body).Decode(&jwks)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(jwks).To(Equal(auth.VerificationKeys{&signingKey.PublicKey}.JWKS()))
+			Expect(jwks.Keys[0].KeyID).To(Equal(auth.KeyID(&signingKey.PublicKey)))
+		})
+	})
 })
@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,7 +34,34 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin)
+	// switching teams with a session token is only allowed for teams the
+	// token's user is a member of
+	isAdmin := team.Admin
+	var teams []string
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if authTeamFound && team.IsAuthConfigured() {
+		if !authTeam.IsAdmin() && !authTeam.IsAuthorized(team.Name) {
+			logger.Info("not-a-member-of-team", lager.Data{
+				"teamName": team.Name,
+			})
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		isAdmin = isAdmin || authTeam.IsAdmin()
+		teams = authTeam.Teams()
+	}
+
+	user, found := auth.GetUser(r)
+	if !found {
+		username, _, isBasicAuth := r.BasicAuth()
+		if isBasicAuth {
+			user = atc.User{ID: username, Name: username}
+		}
+	}
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, isAdmin, teams, user)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,11 @@
+package authserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) GetSigningKeys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.publicKeys.JWKS())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -116,6 +117,23 @@ func (s *Server) authMethods(team db.SavedTeam) ([]atc.AuthMethod, error) {
 		})
 	}
 
+	if team.OIDCAuth != nil {
+		path, err := auth.OAuthRoutes.CreatePathForRoute(
+			auth.OAuthBegin,
+			rata.Params{"provider": oidc.ProviderName},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		path = path + fmt.Sprintf("?team_name=%s", team.Name)
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeOAuth,
+			DisplayName: team.OIDCAuth.DisplayName,
+			AuthURL:     s.oAuthBaseURL + path,
+		})
+	}
+
 	if team.BasicAuth != nil {
 		path, err := web.Routes.CreatePathForRoute(
 			web.TeamLogIn,
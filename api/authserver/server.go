@@ -13,6 +13,7 @@ type Server struct {
 	externalURL     string
 	oAuthBaseURL    string
 	tokenGenerator  auth.TokenGenerator
+	publicKeys      auth.VerificationKeys
 	providerFactory auth.ProviderFactory
 	teamDBFactory   db.TeamDBFactory
 	expire          time.Duration
@@ -23,6 +24,7 @@ func NewServer(
 	externalURL string,
 	oAuthBaseURL string,
 	tokenGenerator auth.TokenGenerator,
+	publicKeys auth.VerificationKeys,
 	providerFactory auth.ProviderFactory,
 	teamDBFactory db.TeamDBFactory,
 	expire time.Duration,
@@ -32,6 +34,7 @@ func NewServer(
 		externalURL:     externalURL,
 		oAuthBaseURL:    oAuthBaseURL,
 		tokenGenerator:  tokenGenerator,
+		publicKeys:      publicKeys,
 		providerFactory: providerFactory,
 		teamDBFactory:   teamDBFactory,
 		expire:          expire,
@@ -37,7 +37,13 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 		} else {
 			presentedTeam := present.Team(savedTeam)
 			user = User{
-				Team: &presentedTeam,
+				Team:  &presentedTeam,
+				Teams: authTeam.Teams(),
+			}
+
+			authUser, found := auth.GetUser(r)
+			if found {
+				user.User = &authUser
 			}
 		}
 	}
@@ -48,5 +54,7 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 
 type User struct {
 	Team   *atc.Team `json:"team,omitempty"`
+	Teams  []string  `json:"teams,omitempty"`
+	User   *atc.User `json:"user,omitempty"`
 	System *bool     `json:"system,omitempty"`
 }
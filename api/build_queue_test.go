@@ -0,0 +1,135 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build Queue API", func() {
+	Describe("GET /api/v1/build-queue", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/build-queue")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when getting the build queue succeeds", func() {
+				BeforeEach(func() {
+					buildQueueDB.GetBuildQueueReturns(db.BuildQueue{
+						Builds: []db.QueuedBuild{
+							{
+								BuildID:      3,
+								BuildName:    "7",
+								JobName:      "some-job",
+								PipelineName: "some-pipeline",
+								TeamID:       42,
+								TeamName:     "some-team",
+								Priority:     10,
+								QueuedAt:     time.Now().Add(-time.Minute),
+							},
+							{
+								BuildID:      1,
+								BuildName:    "2",
+								JobName:      "other-job",
+								PipelineName: "other-pipeline",
+								TeamID:       43,
+								TeamName:     "other-team",
+								QueuedAt:     time.Now().Add(-time.Hour),
+							},
+						},
+					}, nil)
+				})
+
+				decodeBody := func() []atc.QueuedBuild {
+					var builds []atc.QueuedBuild
+					err := json.NewDecoder(response.Body).Decode(&builds)
+					Expect(err).NotTo(HaveOccurred())
+					return builds
+				}
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns only the team's builds, with their position in the whole queue", func() {
+					builds := decodeBody()
+					Expect(builds).To(HaveLen(1))
+					Expect(builds[0].ID).To(Equal(3))
+					Expect(builds[0].Name).To(Equal("7"))
+					Expect(builds[0].JobName).To(Equal("some-job"))
+					Expect(builds[0].PipelineName).To(Equal("some-pipeline"))
+					Expect(builds[0].TeamName).To(Equal("some-team"))
+					Expect(builds[0].Priority).To(Equal(10))
+					Expect(builds[0].Position).To(Equal(1))
+					Expect(builds[0].WaitInSeconds).To(BeNumerically(">=", 60))
+				})
+
+				Context("when the team is an admin", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 42, true, true)
+					})
+
+					It("returns every build in the queue", func() {
+						builds := decodeBody()
+						Expect(builds).To(HaveLen(2))
+						Expect(builds[1].ID).To(Equal(1))
+						Expect(builds[1].TeamName).To(Equal("other-team"))
+						Expect(builds[1].Position).To(Equal(2))
+						Expect(builds[1].WaitInSeconds).To(BeNumerically(">=", 3600))
+					})
+				})
+			})
+
+			Context("when the build queue is empty", func() {
+				BeforeEach(func() {
+					buildQueueDB.GetBuildQueueReturns(db.BuildQueue{}, nil)
+				})
+
+				It("returns an empty list", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var builds []atc.QueuedBuild
+					err := json.NewDecoder(response.Body).Decode(&builds)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builds).To(BeEmpty())
+				})
+			})
+
+			Context("when getting the build queue fails", func() {
+				BeforeEach(func() {
+					buildQueueDB.GetBuildQueueReturns(db.BuildQueue{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,69 @@
+// This file was generated by counterfeiter
+package buildqueueserverfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/api/buildqueueserver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeBuildQueueDB struct {
+	GetBuildQueueStub        func() (db.BuildQueue, error)
+	getBuildQueueMutex       sync.RWMutex
+	getBuildQueueArgsForCall []struct{}
+	getBuildQueueReturns     struct {
+		result1 db.BuildQueue
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildQueueDB) GetBuildQueue() (db.BuildQueue, error) {
+	fake.getBuildQueueMutex.Lock()
+	fake.getBuildQueueArgsForCall = append(fake.getBuildQueueArgsForCall, struct{}{})
+	fake.recordInvocation("GetBuildQueue", []interface{}{})
+	fake.getBuildQueueMutex.Unlock()
+	if fake.GetBuildQueueStub != nil {
+		return fake.GetBuildQueueStub()
+	} else {
+		return fake.getBuildQueueReturns.result1, fake.getBuildQueueReturns.result2
+	}
+}
+
+func (fake *FakeBuildQueueDB) GetBuildQueueCallCount() int {
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
+	return len(fake.getBuildQueueArgsForCall)
+}
+
+func (fake *FakeBuildQueueDB) GetBuildQueueReturns(result1 db.BuildQueue, result2 error) {
+	fake.GetBuildQueueStub = nil
+	fake.getBuildQueueReturns = struct {
+		result1 db.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildQueueDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBuildQueueDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueueserver.BuildQueueDB = new(FakeBuildQueueDB)
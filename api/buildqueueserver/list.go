@@ -0,0 +1,47 @@
+package buildqueueserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) ListBuildQueue(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-build-queue")
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound {
+		hLog.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	queue, err := s.db.GetBuildQueue()
+	if err != nil {
+		hLog.Error("failed-to-get-build-queue", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Debug("listed", lager.Data{"build-count": len(queue.Builds)})
+
+	now := time.Now()
+
+	presentedBuilds := []atc.QueuedBuild{}
+	for i, build := range queue.Builds {
+		if !authTeam.IsAdmin() && !authTeam.IsAuthorized(build.TeamName) {
+			continue
+		}
+
+		presentedBuilds = append(presentedBuilds, present.QueuedBuild(build, i+1, now))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedBuilds)
+}
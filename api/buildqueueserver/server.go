@@ -0,0 +1,28 @@
+package buildqueueserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	db BuildQueueDB
+}
+
+//go:generate counterfeiter . BuildQueueDB
+
+type BuildQueueDB interface {
+	GetBuildQueue() (db.BuildQueue, error)
+}
+
+func NewServer(
+	logger lager.Logger,
+	db BuildQueueDB,
+) *Server {
+	return &Server{
+		logger: logger,
+		db:     db,
+	}
+}
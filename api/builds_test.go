@@ -1166,6 +1166,10 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					TeamQuota:        db.BuildPreparationStatusNotBlocking,
+					BuildQueue:       db.BuildPreparationStatusBlocking,
+					QueuePosition:    3,
+					QueueWaitTime:    90 * time.Second,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusUnknown,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -1273,6 +1277,10 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"team_quota": "not_blocking",
+					"build_queue": "blocking",
+					"queue_position": 3,
+					"queue_wait_in_seconds": 90,
 					"inputs": {
 						"foo": "unknown",
 						"bar": "blocking"
@@ -1329,6 +1337,139 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/test-reports", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/builds/42/test-reports")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.GetTestReportsReturns([]atc.TestReport{
+					{
+						StepName: "unit",
+						PlanID:   "some-plan-id",
+						Format:   "junit",
+						Path:     "out/junit.xml",
+						Tests:    3,
+						Failures: 1,
+						Skipped:  1,
+						Duration: 1.5,
+						FailedCases: []atc.TestCaseFailure{
+							{
+								Suite:     "models",
+								Classname: "models.UserTest",
+								Name:      "test_save",
+								Type:      "AssertionError",
+								Message:   "expected true",
+							},
+						},
+					},
+				}, nil)
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+				})
+
+				Context("when the job is private", func() {
+					BeforeEach(func() {
+						build.GetConfigReturns(atc.Config{
+							Jobs: atc.JobConfigs{
+								{Name: "job1", Public: false},
+							},
+						}, 1, nil)
+					})
+
+					It("returns 401", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+				})
+
+				Context("when the job is public", func() {
+					BeforeEach(func() {
+						build.GetConfigReturns(atc.Config{
+							Jobs: atc.JobConfigs{
+								{Name: "job1", Public: true},
+							},
+						}, 1, nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", 5, false, true)
+				})
+
+				It("returns OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the build's test reports", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"step_name": "unit",
+							"plan_id": "some-plan-id",
+							"format": "junit",
+							"path": "out/junit.xml",
+							"tests": 3,
+							"failures": 1,
+							"errors": 0,
+							"skipped": 1,
+							"duration": 1.5,
+							"failed_cases": [
+								{
+									"suite": "models",
+									"classname": "models.UserTest",
+									"name": "test_save",
+									"type": "AssertionError",
+									"message": "expected true"
+								}
+							]
+						}
+					]`))
+				})
+
+				Context("when getting the test reports fails", func() {
+					BeforeEach(func() {
+						build.GetTestReportsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var publicPlan atc.PublicBuildPlan
 
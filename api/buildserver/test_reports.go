@@ -0,0 +1,24 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetBuildTestReports(build db.Build) http.Handler {
+	log := s.logger.Session("build-test-reports", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reports, err := build.GetTestReports()
+		if err != nil {
+			log.Error("cannot-get-test-reports", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(reports)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
@@ -124,8 +125,13 @@ var _ = Describe("Config API", func() {
 	Describe("GET /api/v1/teams/:team_name/pipelines/:name/config", func() {
 		var (
 			response *http.Response
+			query    url.Values
 		)
 
+		BeforeEach(func() {
+			query = url.Values{}
+		})
+
 		JustBeforeEach(func() {
 			req, err := requestGenerator.CreateRequest(atc.GetConfig, rata.Params{
 				"team_name":     "a-team",
@@ -133,6 +139,8 @@ var _ = Describe("Config API", func() {
 			}, nil)
 			Expect(err).NotTo(HaveOccurred())
 
+			req.URL.RawQuery = query.Encode()
+
 			response, err = client.Do(req)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -206,6 +214,35 @@ var _ = Describe("Config API", func() {
 					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("42"))
 				})
 			})
+
+			Context("when instance vars are given", func() {
+				BeforeEach(func() {
+					query.Set("instance_vars", `{"branch":"foo"}`)
+					teamDB.GetInstanceConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 3, nil)
+				})
+
+				It("returns the config of the instance", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get(atc.ConfigVersionHeader)).To(Equal("3"))
+
+					Expect(teamDB.GetConfigCallCount()).To(BeZero())
+					Expect(teamDB.GetInstanceConfigCallCount()).To(Equal(1))
+
+					pipelineName, instanceVars := teamDB.GetInstanceConfigArgsForCall(0)
+					Expect(pipelineName).To(Equal("something-else"))
+					Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "foo"}))
+				})
+			})
+
+			Context("when the instance vars are malformed", func() {
+				BeforeEach(func() {
+					query.Set("instance_vars", `{"branch":`)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
 		})
 
 		Context("when not authorized", func() {
@@ -545,6 +582,68 @@ jobs:
 						})
 					})
 
+					Context("for an instance of the pipeline", func() {
+						BeforeEach(func() {
+							request.URL.RawQuery = url.Values{
+								"instance_vars": {`{"branch":"foo","replicas":2}`},
+							}.Encode()
+
+							request.Header.Set("Content-Type", "application/x-yaml")
+							request.Body = gbytes.BufferWithBytes([]byte(`
+resources:
+- name: some-resource
+  type: git
+  source:
+    branch: ((branch))
+    uri: https://example.com/((branch)).git
+
+jobs:
+- name: some-job
+  max_in_flight: ((replicas))
+  plan:
+  - get: some-resource
+`))
+						})
+
+						It("saves the instance with its vars interpolated", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+							Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+							Expect(teamDB.SaveInstanceConfigCallCount()).To(Equal(1))
+
+							name, instanceVars, savedConfig, id, pipelineState := teamDB.SaveInstanceConfigArgsForCall(0)
+							Expect(name).To(Equal("a-pipeline"))
+							Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "foo", "replicas": float64(2)}))
+							Expect(id).To(Equal(db.ConfigVersion(42)))
+							Expect(pipelineState).To(Equal(db.PipelineNoChange))
+
+							Expect(savedConfig.Resources[0].Source).To(Equal(atc.Source{
+								"branch": "foo",
+								"uri":    "https://example.com/foo.git",
+							}))
+							Expect(savedConfig.Jobs[0].RawMaxInFlight).To(Equal(2))
+						})
+					})
+
+					Context("with malformed instance vars", func() {
+						BeforeEach(func() {
+							request.URL.RawQuery = url.Values{"instance_vars": {`{"branch":`}}.Encode()
+
+							request.Header.Set("Content-Type", "application/json")
+
+							payload, err := json.Marshal(pipelineConfig)
+							Expect(err).NotTo(HaveOccurred())
+
+							request.Body = gbytes.BufferWithBytes(payload)
+						})
+
+						It("returns 400 without saving anything", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+							Expect(teamDB.SaveInstanceConfigCallCount()).To(BeZero())
+						})
+					})
+
 					Context("multi-part requests", func() {
 						var pausedValue string
 						var expectedDBValue db.PipelinePausedState
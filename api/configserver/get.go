@@ -6,14 +6,31 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
 
 func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("get-config")
 	pipelineName := rata.Param(r, "pipeline_name")
+
+	instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+	if err != nil {
+		logger.Error("malformed-instance-vars", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
-	config, rawConfig, id, err := teamDB.GetConfig(pipelineName)
+
+	var config atc.Config
+	var rawConfig atc.RawConfig
+	var id db.ConfigVersion
+	if instanceVars != nil {
+		config, rawConfig, id, err = teamDB.GetInstanceConfig(pipelineName, instanceVars)
+	} else {
+		config, rawConfig, id, err = teamDB.GetConfig(pipelineName)
+	}
 	if err != nil {
 		if malformedErr, ok := err.(atc.MalformedConfigError); ok {
 			getConfigResponse := atc.ConfigResponse{
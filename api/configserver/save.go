@@ -65,7 +65,14 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
+	instanceVars, err := atc.ParseInstanceVars(r.URL.Query().Get(atc.InstanceVarsQueryParam))
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
+	config, pausedState, err := saveConfigRequestUnmarshaler(r, instanceVars)
 
 	switch err {
 	case ErrStatusUnsupportedMediaType:
@@ -116,7 +123,13 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	teamName := rata.Param(r, "team_name")
 
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+
+	var created bool
+	if instanceVars != nil {
+		_, created, err = teamDB.SaveInstanceConfig(pipelineName, instanceVars, config, version, pausedState)
+	} else {
+		_, created, err = teamDB.SaveConfig(pipelineName, config, version, pausedState)
+	}
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -221,13 +234,17 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 	return pausedState, nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request, instanceVars atc.InstanceVars) (atc.Config, db.PipelinePausedState, error) {
 	var configStructure interface{}
 	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
 	if err != nil {
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
+	if instanceVars != nil {
+		configStructure = instanceVars.Interpolate(configStructure)
+	}
+
 	var config atc.Config
 	var md mapstructure.Metadata
 	msConfig := &mapstructure.DecoderConfig{
@@ -617,10 +617,38 @@ var _ = Describe("Containers API", func() {
 					fakeDBContainer = db.SavedContainer{}
 					teamDB.GetContainerReturns(fakeDBContainer, true, nil)
 
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID:   42,
+						Team: db.Team{Name: "some-team"},
+					}, true, nil)
+
 					fakeContainer = new(workerfakes.FakeContainer)
 					fakeWorkerClient.LookupContainerReturns(fakeContainer, true, nil)
 				})
 
+				Context("when the team requires hijack recording but the session cannot be created", func() {
+					BeforeEach(func() {
+						teamDB.GetTeamReturns(db.SavedTeam{
+							ID:   42,
+							Team: db.Team{Name: "some-team", RequireHijackRecording: true},
+						}, true, nil)
+
+						hijackSessionDB.CreateHijackSessionReturns(db.SavedHijackSession{}, errors.New("nope"))
+					})
+
+					It("closes the websocket connection with an error", func() {
+						_, _, err := conn.ReadMessage()
+
+						Expect(websocket.IsCloseError(err, 1011)).To(BeTrue()) // internal server error
+						Expect(err).To(MatchError(ContainSubstring("failed to start recording hijack session")))
+					})
+
+					It("does not run the process", func() {
+						conn.ReadMessage()
+						Expect(fakeContainer.RunCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when the call to lookup the container returns an error", func() {
 					BeforeEach(func() {
 						fakeWorkerClient.LookupContainerReturns(nil, false, errors.New("nope"))
@@ -699,6 +727,128 @@ var _ = Describe("Containers API", func() {
 						Expect(io.Stderr).NotTo(BeNil())
 					})
 
+					It("does not record the session", func() {
+						Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+						Expect(hijackSessionDB.CreateHijackSessionCallCount()).To(BeZero())
+					})
+
+					Context("when the team requires hijack recording", func() {
+						BeforeEach(func() {
+							teamDB.GetTeamReturns(db.SavedTeam{
+								ID:   42,
+								Team: db.Team{Name: "some-team", RequireHijackRecording: true},
+							}, true, nil)
+
+							hijackSessionDB.CreateHijackSessionReturns(db.SavedHijackSession{ID: 7}, nil)
+						})
+
+						It("creates a hijack session", func() {
+							Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+							Expect(hijackSessionDB.CreateHijackSessionCallCount()).To(Equal(1))
+							Expect(hijackSessionDB.CreateHijackSessionArgsForCall(0)).To(Equal(db.HijackSession{
+								TeamID:          42,
+								HijackedBy:      "some-team",
+								ContainerHandle: handle,
+								Process: atc.HijackProcessSpec{
+									Path: "ls",
+									User: "snoopy",
+								},
+							}))
+						})
+
+						Context("when the user is known", func() {
+							BeforeEach(func() {
+								userContextReader.GetUserReturns(atc.User{ID: "some-user-id"}, true)
+							})
+
+							It("records the session as hijacked by the user", func() {
+								Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+								Expect(hijackSessionDB.CreateHijackSessionCallCount()).To(Equal(1))
+								Expect(hijackSessionDB.CreateHijackSessionArgsForCall(0).HijackedBy).To(Equal("some-user-id"))
+							})
+						})
+
+						Context("when stdin is sent over the API", func() {
+							JustBeforeEach(func() {
+								err := conn.WriteJSON(atc.HijackInput{
+									Stdin: []byte("some stdin\n"),
+								})
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("records it as input", func() {
+								Eventually(hijackSessionDB.SaveHijackSessionEventCallCount).Should(Equal(1))
+
+								sessionID, event := hijackSessionDB.SaveHijackSessionEventArgsForCall(0)
+								Expect(sessionID).To(Equal(7))
+								Expect(event.Type).To(Equal(db.HijackSessionEventInput))
+								Expect(event.Data).To(Equal([]byte("some stdin\n")))
+							})
+						})
+
+						Context("when the process prints to stdout", func() {
+							JustBeforeEach(func() {
+								Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+								_, io := fakeContainer.RunArgsForCall(0)
+
+								_, err := fmt.Fprintf(io.Stdout, "some stdout\n")
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("records it as output", func() {
+								var hijackOutput atc.HijackOutput
+								err := conn.ReadJSON(&hijackOutput)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(hijackSessionDB.SaveHijackSessionEventCallCount()).To(Equal(1))
+
+								sessionID, event := hijackSessionDB.SaveHijackSessionEventArgsForCall(0)
+								Expect(sessionID).To(Equal(7))
+								Expect(event.Type).To(Equal(db.HijackSessionEventOutput))
+								Expect(event.Data).To(Equal([]byte("some stdout\n")))
+							})
+						})
+
+						Context("when recording the process's output fails", func() {
+							BeforeEach(func() {
+								hijackSessionDB.SaveHijackSessionEventReturns(errors.New("nope"))
+							})
+
+							JustBeforeEach(func() {
+								Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+								_, io := fakeContainer.RunArgsForCall(0)
+
+								_, err := fmt.Fprintf(io.Stdout, "some stdout\n")
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("closes the websocket connection without sending the output", func() {
+								_, _, err := conn.ReadMessage()
+
+								Expect(websocket.IsCloseError(err, 1011)).To(BeTrue()) // internal server error
+								Expect(err).To(MatchError(ContainSubstring("failed to record hijack session")))
+							})
+						})
+
+						Context("when the process exits", func() {
+							JustBeforeEach(func() {
+								Eventually(processExit).Should(BeSent(123))
+							})
+
+							It("finishes the session with the exit status", func() {
+								Eventually(hijackSessionDB.FinishHijackSessionCallCount).Should(Equal(1))
+
+								sessionID, exitStatus := hijackSessionDB.FinishHijackSessionArgsForCall(0)
+								Expect(sessionID).To(Equal(7))
+								Expect(*exitStatus).To(Equal(123))
+							})
+						})
+					})
+
 					Context("when stdin is sent over the API", func() {
 						JustBeforeEach(func() {
 							err := conn.WriteJSON(atc.HijackInput{
@@ -868,6 +1018,19 @@ var _ = Describe("Containers API", func() {
 				})
 			})
 
+			Context("when looking up the team fails", func() {
+				BeforeEach(func() {
+					expectBadHandshake = true
+
+					teamDB.GetContainerReturns(db.SavedContainer{}, true, nil)
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("error"))
+				})
+
+				It("returns 500 internal error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
 			Context("when the db request fails", func() {
 				BeforeEach(func() {
 					expectBadHandshake = true
@@ -898,4 +1061,307 @@ var _ = Describe("Containers API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/inspect/containers", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/inspect/containers" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not inspect the containers", func() {
+				Expect(containerDB.InspectContainersCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when inspecting the containers succeeds", func() {
+				BeforeEach(func() {
+					containerDB.InspectContainersReturns([]db.InspectedContainer{
+						{
+							SavedContainer: db.SavedContainer{
+								Container: db.Container{
+									ContainerIdentifier: db.ContainerIdentifier{
+										BuildID: 7,
+									},
+									ContainerMetadata: db.ContainerMetadata{
+										Handle:       "some-handle",
+										WorkerName:   "some-worker",
+										PipelineName: "some-pipeline",
+										JobName:      "some-job",
+										BuildName:    "3",
+										Type:         db.ContainerTypeTask,
+										StepName:     "some-step",
+									},
+								},
+								TTL:       10 * time.Minute,
+								ExpiresIn: 2 * time.Minute,
+							},
+							TeamName: "some-team",
+							Owner:    &db.Owner{Type: db.OwnerBuild, ID: 7},
+						},
+						{
+							SavedContainer: db.SavedContainer{
+								Container: db.Container{
+									ContainerMetadata: db.ContainerMetadata{
+										Handle:     "some-ownerless-handle",
+										WorkerName: "some-other-worker",
+										Type:       db.ContainerTypeCheck,
+									},
+								},
+							},
+							TeamName: "some-team",
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the containers with their owners", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": "some-handle",
+							"ttl_in_seconds": 120,
+							"validity_in_seconds": 600,
+							"worker_name": "some-worker",
+							"pipeline_name": "some-pipeline",
+							"job_name": "some-job",
+							"build_name": "3",
+							"build_id": 7,
+							"step_type": "task",
+							"step_name": "some-step",
+							"team_name": "some-team",
+							"owner": {"type": "build", "id": 7}
+						},
+						{
+							"id": "some-ownerless-handle",
+							"ttl_in_seconds": 0,
+							"validity_in_seconds": 0,
+							"worker_name": "some-other-worker",
+							"pipeline_name": "",
+							"team_name": "some-team"
+						}
+					]`))
+				})
+
+				It("only inspects the team's own containers", func() {
+					Expect(containerDB.InspectContainersCallCount()).To(Equal(1))
+					Expect(containerDB.InspectContainersArgsForCall(0)).To(Equal(db.InspectionFilter{
+						TeamName: "some-team",
+					}))
+				})
+
+				Context("when filtering", func() {
+					BeforeEach(func() {
+						query = "?pipeline_name=some-pipeline&job_name=some-job&build_id=7&worker_name=some-worker&type=task"
+					})
+
+					It("passes the filter along", func() {
+						Expect(containerDB.InspectContainersArgsForCall(0)).To(Equal(db.InspectionFilter{
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      7,
+							WorkerName:   "some-worker",
+							Type:         "task",
+						}))
+					})
+				})
+
+				Context("when filtering by an unknown type", func() {
+					BeforeEach(func() {
+						query = "?type=bogus"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when asking for a team the user is a member of", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamsReturns([]string{"some-team", "some-other-team"}, true)
+						query = "?team_name=some-other-team"
+					})
+
+					It("inspects that team's containers", func() {
+						Expect(containerDB.InspectContainersArgsForCall(0)).To(Equal(db.InspectionFilter{
+							TeamName: "some-other-team",
+						}))
+					})
+				})
+
+				Context("when asking for a team the user is not a member of", func() {
+					BeforeEach(func() {
+						query = "?team_name=some-other-team"
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("main", 1, true, true)
+					})
+
+					It("inspects every team's containers", func() {
+						Expect(containerDB.InspectContainersArgsForCall(0)).To(Equal(db.InspectionFilter{}))
+					})
+				})
+			})
+
+			Context("when inspecting the containers fails", func() {
+				BeforeEach(func() {
+					containerDB.InspectContainersReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/containers/:id", func() {
+		var (
+			fakeWorker *workerfakes.FakeWorker
+			response   *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeWorker = new(workerfakes.FakeWorker)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/containers/some-handle", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not reap the container", func() {
+				Expect(containerDB.ReapContainerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+			})
+
+			Context("when the container exists", func() {
+				BeforeEach(func() {
+					containerDB.GetContainerReturns(db.SavedContainer{
+						Container: db.Container{
+							ContainerMetadata: db.ContainerMetadata{
+								Handle:     "some-handle",
+								WorkerName: "some-worker",
+							},
+						},
+					}, true, nil)
+
+					fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+				})
+
+				It("destroys the container on its worker", func() {
+					Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+					Expect(fakeWorker.DestroyContainerCallCount()).To(Equal(1))
+					_, handle := fakeWorker.DestroyContainerArgsForCall(0)
+					Expect(handle).To(Equal("some-handle"))
+				})
+
+				It("reaps the container", func() {
+					Expect(containerDB.ReapContainerCallCount()).To(Equal(1))
+					Expect(containerDB.ReapContainerArgsForCall(0)).To(Equal("some-handle"))
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				Context("when the worker no longer has the container", func() {
+					BeforeEach(func() {
+						fakeWorker.DestroyContainerReturns(garden.ContainerNotFoundError{Handle: "some-handle"})
+					})
+
+					It("still reaps the container", func() {
+						Expect(containerDB.ReapContainerCallCount()).To(Equal(1))
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+
+				Context("when destroying the container fails", func() {
+					BeforeEach(func() {
+						fakeWorker.DestroyContainerReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not reap the container", func() {
+						Expect(containerDB.ReapContainerCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the container does not exist", func() {
+				BeforeEach(func() {
+					containerDB.GetContainerReturns(db.SavedContainer{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not reap anything", func() {
+					Expect(containerDB.ReapContainerCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
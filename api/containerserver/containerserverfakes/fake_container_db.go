@@ -19,6 +19,23 @@ type FakeContainerDB struct {
 		result2 bool
 		result3 error
 	}
+	InspectContainersStub        func(filter db.InspectionFilter) ([]db.InspectedContainer, error)
+	inspectContainersMutex       sync.RWMutex
+	inspectContainersArgsForCall []struct {
+		filter db.InspectionFilter
+	}
+	inspectContainersReturns struct {
+		result1 []db.InspectedContainer
+		result2 error
+	}
+	ReapContainerStub        func(handle string) error
+	reapContainerMutex       sync.RWMutex
+	reapContainerArgsForCall []struct {
+		handle string
+	}
+	reapContainerReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -58,11 +75,82 @@ func (fake *FakeContainerDB) GetContainerReturns(result1 db.SavedContainer, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeContainerDB) InspectContainers(filter db.InspectionFilter) ([]db.InspectedContainer, error) {
+	fake.inspectContainersMutex.Lock()
+	fake.inspectContainersArgsForCall = append(fake.inspectContainersArgsForCall, struct {
+		filter db.InspectionFilter
+	}{filter})
+	fake.recordInvocation("InspectContainers", []interface{}{filter})
+	fake.inspectContainersMutex.Unlock()
+	if fake.InspectContainersStub != nil {
+		return fake.InspectContainersStub(filter)
+	} else {
+		return fake.inspectContainersReturns.result1, fake.inspectContainersReturns.result2
+	}
+}
+
+func (fake *FakeContainerDB) InspectContainersCallCount() int {
+	fake.inspectContainersMutex.RLock()
+	defer fake.inspectContainersMutex.RUnlock()
+	return len(fake.inspectContainersArgsForCall)
+}
+
+func (fake *FakeContainerDB) InspectContainersArgsForCall(i int) db.InspectionFilter {
+	fake.inspectContainersMutex.RLock()
+	defer fake.inspectContainersMutex.RUnlock()
+	return fake.inspectContainersArgsForCall[i].filter
+}
+
+func (fake *FakeContainerDB) InspectContainersReturns(result1 []db.InspectedContainer, result2 error) {
+	fake.InspectContainersStub = nil
+	fake.inspectContainersReturns = struct {
+		result1 []db.InspectedContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerDB) ReapContainer(handle string) error {
+	fake.reapContainerMutex.Lock()
+	fake.reapContainerArgsForCall = append(fake.reapContainerArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("ReapContainer", []interface{}{handle})
+	fake.reapContainerMutex.Unlock()
+	if fake.ReapContainerStub != nil {
+		return fake.ReapContainerStub(handle)
+	} else {
+		return fake.reapContainerReturns.result1
+	}
+}
+
+func (fake *FakeContainerDB) ReapContainerCallCount() int {
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
+	return len(fake.reapContainerArgsForCall)
+}
+
+func (fake *FakeContainerDB) ReapContainerArgsForCall(i int) string {
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
+	return fake.reapContainerArgsForCall[i].handle
+}
+
+func (fake *FakeContainerDB) ReapContainerReturns(result1 error) {
+	fake.ReapContainerStub = nil
+	fake.reapContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getContainerMutex.RLock()
 	defer fake.getContainerMutex.RUnlock()
+	fake.inspectContainersMutex.RLock()
+	defer fake.inspectContainersMutex.RUnlock()
+	fake.reapContainerMutex.RLock()
+	defer fake.reapContainerMutex.RUnlock()
 	return fake.invocations
 }
 
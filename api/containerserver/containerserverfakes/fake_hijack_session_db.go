@@ -0,0 +1,296 @@
+// This file was generated by counterfeiter
+package containerserverfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/api/containerserver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeHijackSessionDB struct {
+	CreateHijackSessionStub        func(session db.HijackSession) (db.SavedHijackSession, error)
+	createHijackSessionMutex       sync.RWMutex
+	createHijackSessionArgsForCall []struct {
+		session db.HijackSession
+	}
+	createHijackSessionReturns struct {
+		result1 db.SavedHijackSession
+		result2 error
+	}
+	SaveHijackSessionEventStub        func(sessionID int, event db.HijackSessionEvent) error
+	saveHijackSessionEventMutex       sync.RWMutex
+	saveHijackSessionEventArgsForCall []struct {
+		sessionID int
+		event     db.HijackSessionEvent
+	}
+	saveHijackSessionEventReturns struct {
+		result1 error
+	}
+	FinishHijackSessionStub        func(sessionID int, exitStatus *int) error
+	finishHijackSessionMutex       sync.RWMutex
+	finishHijackSessionArgsForCall []struct {
+		sessionID  int
+		exitStatus *int
+	}
+	finishHijackSessionReturns struct {
+		result1 error
+	}
+	GetHijackSessionsStub        func() ([]db.SavedHijackSession, error)
+	getHijackSessionsMutex       sync.RWMutex
+	getHijackSessionsArgsForCall []struct{}
+	getHijackSessionsReturns     struct {
+		result1 []db.SavedHijackSession
+		result2 error
+	}
+	GetHijackSessionStub        func(sessionID int) (db.SavedHijackSession, bool, error)
+	getHijackSessionMutex       sync.RWMutex
+	getHijackSessionArgsForCall []struct {
+		sessionID int
+	}
+	getHijackSessionReturns struct {
+		result1 db.SavedHijackSession
+		result2 bool
+		result3 error
+	}
+	GetHijackSessionEventsStub        func(sessionID int) ([]db.HijackSessionEvent, error)
+	getHijackSessionEventsMutex       sync.RWMutex
+	getHijackSessionEventsArgsForCall []struct {
+		sessionID int
+	}
+	getHijackSessionEventsReturns struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHijackSessionDB) CreateHijackSession(session db.HijackSession) (db.SavedHijackSession, error) {
+	fake.createHijackSessionMutex.Lock()
+	fake.createHijackSessionArgsForCall = append(fake.createHijackSessionArgsForCall, struct {
+		session db.HijackSession
+	}{session})
+	fake.recordInvocation("CreateHijackSession", []interface{}{session})
+	fake.createHijackSessionMutex.Unlock()
+	if fake.CreateHijackSessionStub != nil {
+		return fake.CreateHijackSessionStub(session)
+	} else {
+		return fake.createHijackSessionReturns.result1, fake.createHijackSessionReturns.result2
+	}
+}
+
+func (fake *FakeHijackSessionDB) CreateHijackSessionCallCount() int {
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	return len(fake.createHijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) CreateHijackSessionArgsForCall(i int) db.HijackSession {
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	return fake.createHijackSessionArgsForCall[i].session
+}
+
+func (fake *FakeHijackSessionDB) CreateHijackSessionReturns(result1 db.SavedHijackSession, result2 error) {
+	fake.CreateHijackSessionStub = nil
+	fake.createHijackSessionReturns = struct {
+		result1 db.SavedHijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionDB) SaveHijackSessionEvent(sessionID int, event db.HijackSessionEvent) error {
+	fake.saveHijackSessionEventMutex.Lock()
+	fake.saveHijackSessionEventArgsForCall = append(fake.saveHijackSessionEventArgsForCall, struct {
+		sessionID int
+		event     db.HijackSessionEvent
+	}{sessionID, event})
+	fake.recordInvocation("SaveHijackSessionEvent", []interface{}{sessionID, event})
+	fake.saveHijackSessionEventMutex.Unlock()
+	if fake.SaveHijackSessionEventStub != nil {
+		return fake.SaveHijackSessionEventStub(sessionID, event)
+	} else {
+		return fake.saveHijackSessionEventReturns.result1
+	}
+}
+
+func (fake *FakeHijackSessionDB) SaveHijackSessionEventCallCount() int {
+	fake.saveHijackSessionEventMutex.RLock()
+	defer fake.saveHijackSessionEventMutex.RUnlock()
+	return len(fake.saveHijackSessionEventArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) SaveHijackSessionEventArgsForCall(i int) (int, db.HijackSessionEvent) {
+	fake.saveHijackSessionEventMutex.RLock()
+	defer fake.saveHijackSessionEventMutex.RUnlock()
+	return fake.saveHijackSessionEventArgsForCall[i].sessionID, fake.saveHijackSessionEventArgsForCall[i].event
+}
+
+func (fake *FakeHijackSessionDB) SaveHijackSessionEventReturns(result1 error) {
+	fake.SaveHijackSessionEventStub = nil
+	fake.saveHijackSessionEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionDB) FinishHijackSession(sessionID int, exitStatus *int) error {
+	fake.finishHijackSessionMutex.Lock()
+	fake.finishHijackSessionArgsForCall = append(fake.finishHijackSessionArgsForCall, struct {
+		sessionID  int
+		exitStatus *int
+	}{sessionID, exitStatus})
+	fake.recordInvocation("FinishHijackSession", []interface{}{sessionID, exitStatus})
+	fake.finishHijackSessionMutex.Unlock()
+	if fake.FinishHijackSessionStub != nil {
+		return fake.FinishHijackSessionStub(sessionID, exitStatus)
+	} else {
+		return fake.finishHijackSessionReturns.result1
+	}
+}
+
+func (fake *FakeHijackSessionDB) FinishHijackSessionCallCount() int {
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	return len(fake.finishHijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) FinishHijackSessionArgsForCall(i int) (int, *int) {
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	return fake.finishHijackSessionArgsForCall[i].sessionID, fake.finishHijackSessionArgsForCall[i].exitStatus
+}
+
+func (fake *FakeHijackSessionDB) FinishHijackSessionReturns(result1 error) {
+	fake.FinishHijackSessionStub = nil
+	fake.finishHijackSessionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessions() ([]db.SavedHijackSession, error) {
+	fake.getHijackSessionsMutex.Lock()
+	fake.getHijackSessionsArgsForCall = append(fake.getHijackSessionsArgsForCall, struct{}{})
+	fake.recordInvocation("GetHijackSessions", []interface{}{})
+	fake.getHijackSessionsMutex.Unlock()
+	if fake.GetHijackSessionsStub != nil {
+		return fake.GetHijackSessionsStub()
+	} else {
+		return fake.getHijackSessionsReturns.result1, fake.getHijackSessionsReturns.result2
+	}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionsCallCount() int {
+	fake.getHijackSessionsMutex.RLock()
+	defer fake.getHijackSessionsMutex.RUnlock()
+	return len(fake.getHijackSessionsArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionsReturns(result1 []db.SavedHijackSession, result2 error) {
+	fake.GetHijackSessionsStub = nil
+	fake.getHijackSessionsReturns = struct {
+		result1 []db.SavedHijackSession
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSession(sessionID int) (db.SavedHijackSession, bool, error) {
+	fake.getHijackSessionMutex.Lock()
+	fake.getHijackSessionArgsForCall = append(fake.getHijackSessionArgsForCall, struct {
+		sessionID int
+	}{sessionID})
+	fake.recordInvocation("GetHijackSession", []interface{}{sessionID})
+	fake.getHijackSessionMutex.Unlock()
+	if fake.GetHijackSessionStub != nil {
+		return fake.GetHijackSessionStub(sessionID)
+	} else {
+		return fake.getHijackSessionReturns.result1, fake.getHijackSessionReturns.result2, fake.getHijackSessionReturns.result3
+	}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionCallCount() int {
+	fake.getHijackSessionMutex.RLock()
+	defer fake.getHijackSessionMutex.RUnlock()
+	return len(fake.getHijackSessionArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionArgsForCall(i int) int {
+	fake.getHijackSessionMutex.RLock()
+	defer fake.getHijackSessionMutex.RUnlock()
+	return fake.getHijackSessionArgsForCall[i].sessionID
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionReturns(result1 db.SavedHijackSession, result2 bool, result3 error) {
+	fake.GetHijackSessionStub = nil
+	fake.getHijackSessionReturns = struct {
+		result1 db.SavedHijackSession
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionEvents(sessionID int) ([]db.HijackSessionEvent, error) {
+	fake.getHijackSessionEventsMutex.Lock()
+	fake.getHijackSessionEventsArgsForCall = append(fake.getHijackSessionEventsArgsForCall, struct {
+		sessionID int
+	}{sessionID})
+	fake.recordInvocation("GetHijackSessionEvents", []interface{}{sessionID})
+	fake.getHijackSessionEventsMutex.Unlock()
+	if fake.GetHijackSessionEventsStub != nil {
+		return fake.GetHijackSessionEventsStub(sessionID)
+	} else {
+		return fake.getHijackSessionEventsReturns.result1, fake.getHijackSessionEventsReturns.result2
+	}
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionEventsCallCount() int {
+	fake.getHijackSessionEventsMutex.RLock()
+	defer fake.getHijackSessionEventsMutex.RUnlock()
+	return len(fake.getHijackSessionEventsArgsForCall)
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionEventsArgsForCall(i int) int {
+	fake.getHijackSessionEventsMutex.RLock()
+	defer fake.getHijackSessionEventsMutex.RUnlock()
+	return fake.getHijackSessionEventsArgsForCall[i].sessionID
+}
+
+func (fake *FakeHijackSessionDB) GetHijackSessionEventsReturns(result1 []db.HijackSessionEvent, result2 error) {
+	fake.GetHijackSessionEventsStub = nil
+	fake.getHijackSessionEventsReturns = struct {
+		result1 []db.HijackSessionEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHijackSessionDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createHijackSessionMutex.RLock()
+	defer fake.createHijackSessionMutex.RUnlock()
+	fake.saveHijackSessionEventMutex.RLock()
+	defer fake.saveHijackSessionEventMutex.RUnlock()
+	fake.finishHijackSessionMutex.RLock()
+	defer fake.finishHijackSessionMutex.RUnlock()
+	fake.getHijackSessionsMutex.RLock()
+	defer fake.getHijackSessionsMutex.RUnlock()
+	fake.getHijackSessionMutex.RLock()
+	defer fake.getHijackSessionMutex.RUnlock()
+	fake.getHijackSessionEventsMutex.RLock()
+	defer fake.getHijackSessionEventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHijackSessionDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ containerserver.HijackSessionDB = new(FakeHijackSessionDB)
@@ -0,0 +1,63 @@
+package containerserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/worker"
+)
+
+func (s *Server) DestroyContainer(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":id")
+
+	hLog := s.logger.Session("destroy-container", lager.Data{
+		"handle": handle,
+	})
+
+	container, found, err := s.db.GetContainer(handle)
+	if err != nil {
+		hLog.Error("failed-to-lookup-container", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		hLog.Debug("container-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	containerWorker, err := s.workerClient.GetWorker(container.WorkerName)
+	switch err {
+	case nil:
+		err = containerWorker.DestroyContainer(hLog, handle)
+		if _, ok := err.(garden.ContainerNotFoundError); ok {
+			err = nil
+		}
+
+		if err != nil {
+			hLog.Error("failed-to-destroy-container", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	case worker.ErrNoWorkers:
+		// the container went away with its worker
+		hLog.Info("worker-not-found", lager.Data{"worker": container.WorkerName})
+	default:
+		hLog.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.db.ReapContainer(handle)
+	if err != nil {
+		hLog.Error("failed-to-reap-container", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("destroyed")
+
+	w.WriteHeader(http.StatusNoContent)
+}
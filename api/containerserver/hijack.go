@@ -1,6 +1,7 @@
 package containerserver
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/gorilla/websocket"
 )
@@ -40,6 +42,26 @@ func (s *Server) HijackContainer(teamDB db.TeamDB) http.Handler {
 
 		hLog.Debug("found-container")
 
+		authTeam, authTeamFound := auth.GetTeam(r)
+		if !authTeamFound {
+			hLog.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		team, found, err := teamDB.GetTeam()
+		if err != nil {
+			hLog.Error("failed-to-get-team", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			hLog.Info("team-not-found")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			hLog.Error("unable-to-upgrade-connection-for-websockets", err)
@@ -61,6 +83,35 @@ func (s *Server) HijackContainer(teamDB db.TeamDB) http.Handler {
 			Process:         processSpec,
 		}
 
+		if s.recordHijackSessions || team.RequireHijackRecording {
+			hijackedBy := authTeam.Name()
+			if user, found := auth.GetUser(r); found {
+				hijackedBy = user.ID
+			}
+
+			session, err := s.hijackSessionDB.CreateHijackSession(db.HijackSession{
+				TeamID:          team.ID,
+				HijackedBy:      hijackedBy,
+				ContainerHandle: handle,
+				Process:         processSpec,
+			})
+			if err != nil {
+				hLog.Error("failed-to-create-hijack-session", err)
+				closeWithErr(hLog, conn, websocket.CloseInternalServerErr, "failed to start recording hijack session")
+				return
+			}
+
+			hLog.Info("recording", lager.Data{"session": session.ID})
+
+			hijackRequest.Recorder = &hijackRecorder{
+				logger:    hLog.Session("recorder", lager.Data{"session": session.ID}),
+				db:        s.hijackSessionDB,
+				sessionID: session.ID,
+				started:   time.Now(),
+				required:  team.RequireHijackRecording,
+			}
+		}
+
 		s.hijack(hLog, conn, hijackRequest)
 	})
 }
@@ -68,6 +119,7 @@ func (s *Server) HijackContainer(teamDB db.TeamDB) http.Handler {
 type hijackRequest struct {
 	ContainerHandle string
 	Process         atc.HijackProcessSpec
+	Recorder        *hijackRecorder
 }
 
 func closeWithErr(log lager.Logger, conn *websocket.Conn, code int, reason string) {
@@ -88,6 +140,11 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 		"process": request.Process,
 	})
 
+	var exitStatus *int
+	defer func() {
+		request.Recorder.finish(exitStatus)
+	}()
+
 	container, found, err := s.workerClient.LookupContainer(hLog, request.ContainerHandle)
 	if err != nil {
 		hLog.Error("failed-to-lookup-container", err)
@@ -186,7 +243,14 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 			if input.Closed {
 				stdinW.Close()
 			} else if input.TTYSpec != nil {
-				err := process.SetTTY(garden.TTYSpec{
+				windowSize := input.TTYSpec.WindowSize
+				err := request.Recorder.record(db.HijackSessionEventResize, []byte(fmt.Sprintf("%dx%d", windowSize.Columns, windowSize.Rows)))
+				if err != nil {
+					closeWithErr(hLog, conn, websocket.CloseInternalServerErr, "failed to record hijack session")
+					return
+				}
+
+				err = process.SetTTY(garden.TTYSpec{
 					WindowSize: &garden.WindowSize{
 						Columns: input.TTYSpec.WindowSize.Columns,
 						Rows:    input.TTYSpec.WindowSize.Rows,
@@ -198,16 +262,36 @@ func (s *Server) hijack(hLog lager.Logger, conn *websocket.Conn, request hijackR
 					})
 				}
 			} else {
+				err := request.Recorder.record(db.HijackSessionEventInput, input.Stdin)
+				if err != nil {
+					closeWithErr(hLog, conn, websocket.CloseInternalServerErr, "failed to record hijack session")
+					return
+				}
+
 				stdinW.Write(input.Stdin)
 			}
 
 		case output := <-outputs:
-			err := conn.WriteJSON(output)
+			var err error
+			if output.Stdout != nil {
+				err = request.Recorder.record(db.HijackSessionEventOutput, output.Stdout)
+			} else if output.Stderr != nil {
+				err = request.Recorder.record(db.HijackSessionEventOutput, output.Stderr)
+			}
+
+			if err != nil {
+				closeWithErr(hLog, conn, websocket.CloseInternalServerErr, "failed to record hijack session")
+				return
+			}
+
+			err = conn.WriteJSON(output)
 			if err != nil {
 				return
 			}
 
 		case status := <-exited:
+			exitStatus = &status
+
 			conn.WriteJSON(atc.HijackOutput{
 				ExitStatus: &status,
 			})
@@ -0,0 +1,102 @@
+package containerserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListHijackSessions(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-hijack-sessions")
+
+	sessions, err := s.hijackSessionDB.GetHijackSessions()
+	if err != nil {
+		hLog.Error("failed-to-get-hijack-sessions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Debug("listed", lager.Data{"session-count": len(sessions)})
+
+	presentedSessions := make([]atc.HijackSession, len(sessions))
+	for i, session := range sessions {
+		presentedSessions[i] = present.HijackSession(session)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedSessions)
+}
+
+type asciicastHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Command   string `json:"command"`
+	Title     string `json:"title"`
+}
+
+// GetHijackSessionRecording writes the session in asciicast v2 format: a
+// header line followed by one [elapsed, type, data] line per event.
+func (s *Server) GetHijackSessionRecording(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(r.FormValue(":hijack_session_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	hLog := s.logger.Session("hijack-session-recording", lager.Data{
+		"session": sessionID,
+	})
+
+	session, found, err := s.hijackSessionDB.GetHijackSession(sessionID)
+	if err != nil {
+		hLog.Error("failed-to-get-hijack-session", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	events, err := s.hijackSessionDB.GetHijackSessionEvents(sessionID)
+	if err != nil {
+		hLog.Error("failed-to-get-hijack-session-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	header := asciicastHeader{
+		Version:   2,
+		Width:     80,
+		Height:    24,
+		Timestamp: session.StartTime.Unix(),
+		Command:   strings.Join(append([]string{session.Process.Path}, session.Process.Args...), " "),
+		Title:     session.HijackedBy + "@" + session.ContainerHandle,
+	}
+
+	if session.Process.TTY != nil {
+		header.Width = session.Process.TTY.WindowSize.Columns
+		header.Height = session.Process.TTY.WindowSize.Rows
+	}
+
+	w.Header().Set("Content-Type", "application/x-asciicast")
+
+	encoder := json.NewEncoder(w)
+	encoder.Encode(header)
+
+	for _, event := range events {
+		encoder.Encode([]interface{}{
+			event.Elapsed.Seconds(),
+			event.Type,
+			string(event.Data),
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package containerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/inspection"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) InspectContainers(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("inspect-containers", lager.Data{
+		"params": r.URL.RawQuery,
+	})
+
+	filter, err := inspection.Filter(r)
+	if err == inspection.ErrNotAuthorizedForTeam {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-parse-request", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.Type != "" {
+		_, err := db.ContainerTypeFromString(filter.Type)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	containers, err := s.db.InspectContainers(filter)
+	if err != nil {
+		hLog.Error("failed-to-inspect-containers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Debug("inspected", lager.Data{"container-count": len(containers)})
+
+	presentedContainers := make([]atc.ContainerDetails, len(containers))
+	for i, container := range containers {
+		presentedContainers[i] = present.ContainerDetails(container)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedContainers)
+}
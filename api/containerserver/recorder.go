@@ -0,0 +1,55 @@
+package containerserver
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// hijackRecorder saves the I/O of a hijack session as it happens. A nil
+// recorder records nothing, so that unrecorded sessions share the same code
+// path.
+//
+// If the team requires its sessions to be recorded, failing to record an
+// event is an error, and the session must end rather than go on unrecorded.
+type hijackRecorder struct {
+	logger lager.Logger
+
+	db        HijackSessionDB
+	sessionID int
+	started   time.Time
+	required  bool
+}
+
+func (recorder *hijackRecorder) record(eventType string, data []byte) error {
+	if recorder == nil {
+		return nil
+	}
+
+	err := recorder.db.SaveHijackSessionEvent(recorder.sessionID, db.HijackSessionEvent{
+		Elapsed: time.Since(recorder.started),
+		Type:    eventType,
+		Data:    data,
+	})
+	if err != nil {
+		recorder.logger.Error("failed-to-record-event", err)
+
+		if recorder.required {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (recorder *hijackRecorder) finish(exitStatus *int) {
+	if recorder == nil {
+		return
+	}
+
+	err := recorder.db.FinishHijackSession(recorder.sessionID, exitStatus)
+	if err != nil {
+		recorder.logger.Error("failed-to-finish-recording", err)
+	}
+}
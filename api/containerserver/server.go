@@ -13,6 +13,9 @@ type Server struct {
 
 	db ContainerDB
 
+	hijackSessionDB      HijackSessionDB
+	recordHijackSessions bool
+
 	teamDBFactory db.TeamDBFactory
 }
 
@@ -20,18 +23,36 @@ type Server struct {
 
 type ContainerDB interface {
 	GetContainer(handle string) (db.SavedContainer, bool, error)
+	InspectContainers(filter db.InspectionFilter) ([]db.InspectedContainer, error)
+	ReapContainer(handle string) error
+}
+
+//go:generate counterfeiter . HijackSessionDB
+
+type HijackSessionDB interface {
+	CreateHijackSession(session db.HijackSession) (db.SavedHijackSession, error)
+	SaveHijackSessionEvent(sessionID int, event db.HijackSessionEvent) error
+	FinishHijackSession(sessionID int, exitStatus *int) error
+
+	GetHijackSessions() ([]db.SavedHijackSession, error)
+	GetHijackSession(sessionID int) (db.SavedHijackSession, bool, error)
+	GetHijackSessionEvents(sessionID int) ([]db.HijackSessionEvent, error)
 }
 
 func NewServer(
 	logger lager.Logger,
 	workerClient worker.Client,
 	db ContainerDB,
+	hijackSessionDB HijackSessionDB,
+	recordHijackSessions bool,
 	teamDBFactory db.TeamDBFactory,
 ) *Server {
 	return &Server{
-		logger:        logger,
-		workerClient:  workerClient,
-		db:            db,
-		teamDBFactory: teamDBFactory,
+		logger:               logger,
+		workerClient:         workerClient,
+		db:                   db,
+		hijackSessionDB:      hijackSessionDB,
+		recordHijackSessions: recordHijackSessions,
+		teamDBFactory:        teamDBFactory,
 	}
 }
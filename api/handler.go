@@ -10,6 +10,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/authserver"
+	"github.com/concourse/atc/api/buildqueueserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
@@ -19,6 +20,7 @@ import (
 	"github.com/concourse/atc/api/loglevelserver"
 	"github.com/concourse/atc/api/pipelineserver"
 	"github.com/concourse/atc/api/pipes"
+	"github.com/concourse/atc/api/provenanceserver"
 	"github.com/concourse/atc/api/resourceserver"
 	"github.com/concourse/atc/api/resourceserver/versionserver"
 	"github.com/concourse/atc/api/teamserver"
@@ -40,6 +42,7 @@ func NewHandler(
 	wrapper wrappa.Wrappa,
 
 	tokenGenerator auth.TokenGenerator,
+	publicKeys auth.VerificationKeys,
 	providerFactory auth.ProviderFactory,
 	oAuthBaseURL string,
 
@@ -49,7 +52,9 @@ func NewHandler(
 	teamsDB teamserver.TeamsDB,
 	workerDB workerserver.WorkerDB,
 	buildsDB buildserver.BuildsDB,
+	buildQueueDB buildqueueserver.BuildQueueDB,
 	containerDB containerserver.ContainerDB,
+	hijackSessionDB containerserver.HijackSessionDB,
 	volumesDB volumeserver.VolumesDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
@@ -67,6 +72,8 @@ func NewHandler(
 
 	sink *lager.ReconfigurableSink,
 
+	recordHijackSessions bool,
+
 	expire time.Duration,
 
 	cliDownloadsDir string,
@@ -86,6 +93,7 @@ func NewHandler(
 		externalURL,
 		oAuthBaseURL,
 		tokenGenerator,
+		publicKeys,
 		providerFactory,
 		teamDBFactory,
 		expire,
@@ -102,6 +110,8 @@ func NewHandler(
 		drain,
 	)
 
+	buildQueueServer := buildqueueserver.NewServer(logger, buildQueueDB)
+
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
@@ -117,17 +127,20 @@ func NewHandler(
 
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 
-	containerServer := containerserver.NewServer(logger, workerClient, containerDB, teamDBFactory)
+	containerServer := containerserver.NewServer(logger, workerClient, containerDB, hijackSessionDB, recordHijackSessions, teamDBFactory)
 
-	volumesServer := volumeserver.NewServer(logger, volumesDB, teamDBFactory)
+	volumesServer := volumeserver.NewServer(logger, workerClient, volumesDB, teamDBFactory)
 
 	teamServer := teamserver.NewServer(logger, teamDBFactory, teamsDB)
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	provenanceServer := provenanceserver.NewServer(logger, teamDBFactory)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
+		atc.GetSigningKeys:  http.HandlerFunc(authServer.GetSigningKeys),
 
 		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
 		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
@@ -139,49 +152,58 @@ func NewHandler(
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.GetBuildTestReports: buildHandlerFactory.HandlerFor(buildServer.GetBuildTestReports),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
-		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.GetBuildProvenance:  http.HandlerFunc(provenanceServer.GetBuildProvenance),
+
+		atc.ListBuildQueue: http.HandlerFunc(buildQueueServer.ListBuildQueue),
+
+		atc.ListJobs:             pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:               pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:        pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:        pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.ExplainJob:           pipelineHandlerFactory.HandlerFor(jobServer.ExplainJob),
+		atc.GetJobTestStatistics: pipelineHandlerFactory.HandlerFor(jobServer.GetJobTestStatistics),
+		atc.GetJobBuild:          pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:       pipelineHandlerFactory.MutatingHandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:             pipelineHandlerFactory.MutatingHandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:           pipelineHandlerFactory.MutatingHandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:             pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.MainJobBadge:         mainredirect.Handler{atc.Routes, atc.JobBadge},
 
 		atc.ListAllPipelines: http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
 		atc.DeletePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline),
 		atc.OrderPipelines:   http.HandlerFunc(pipelineServer.OrderPipelines),
-		atc.PausePipeline:    pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline),
-		atc.UnpausePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline),
+		atc.PausePipeline:    pipelineHandlerFactory.MutatingHandlerFor(pipelineServer.PausePipeline),
+		atc.UnpausePipeline:  pipelineHandlerFactory.MutatingHandlerFor(pipelineServer.UnpausePipeline),
+		atc.ArchivePipeline:  pipelineHandlerFactory.HandlerFor(pipelineServer.ArchivePipeline),
 		atc.ExposePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
 		atc.HidePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
-		atc.RenamePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
+		atc.RenamePipeline:   pipelineHandlerFactory.MutatingHandlerFor(pipelineServer.RenamePipeline),
 
 		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.PauseResource:   pipelineHandlerFactory.MutatingHandlerFor(resourceServer.PauseResource),
+		atc.UnpauseResource: pipelineHandlerFactory.MutatingHandlerFor(resourceServer.UnpauseResource),
+		atc.CheckResource:   pipelineHandlerFactory.MutatingHandlerFor(resourceServer.CheckResource),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
-		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
-		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.EnableResourceVersion:         pipelineHandlerFactory.MutatingHandlerFor(versionServer.EnableResourceVersion),
+		atc.DisableResourceVersion:        pipelineHandlerFactory.MutatingHandlerFor(versionServer.DisableResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
+		atc.GetVersionProvenance:          http.HandlerFunc(provenanceServer.GetVersionProvenance),
 
 		atc.CreatePipe: http.HandlerFunc(pipeServer.CreatePipe),
 		atc.WritePipe:  http.HandlerFunc(pipeServer.WritePipe),
 		atc.ReadPipe:   http.HandlerFunc(pipeServer.ReadPipe),
 
-		atc.ListWorkers:    teamHandlerFactory.HandlerFor(workerServer.ListWorkers),
-		atc.RegisterWorker: http.HandlerFunc(workerServer.RegisterWorker),
+		atc.ListWorkers:     teamHandlerFactory.HandlerFor(workerServer.ListWorkers),
+		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
+		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -194,10 +216,24 @@ func NewHandler(
 		atc.GetContainer:    teamHandlerFactory.HandlerFor(containerServer.GetContainer),
 		atc.HijackContainer: teamHandlerFactory.HandlerFor(containerServer.HijackContainer),
 
-		atc.ListVolumes: teamHandlerFactory.HandlerFor(volumesServer.ListVolumes),
+		atc.InspectContainers: http.HandlerFunc(containerServer.InspectContainers),
+		atc.DestroyContainer:  http.HandlerFunc(containerServer.DestroyContainer),
+
+		atc.ListHijackSessions:        http.HandlerFunc(containerServer.ListHijackSessions),
+		atc.GetHijackSessionRecording: http.HandlerFunc(containerServer.GetHijackSessionRecording),
+
+		atc.ListVolumes:    teamHandlerFactory.HandlerFor(volumesServer.ListVolumes),
+		atc.InspectVolumes: http.HandlerFunc(volumesServer.InspectVolumes),
+		atc.DestroyVolume:  http.HandlerFunc(volumesServer.DestroyVolume),
+
+		atc.ListTeams:  http.HandlerFunc(teamServer.ListTeams),
+		atc.SetTeam:    http.HandlerFunc(teamServer.SetTeam),
+		atc.ExportTeam: http.HandlerFunc(teamServer.ExportTeam),
+		atc.ImportTeam: http.HandlerFunc(teamServer.ImportTeam),
 
-		atc.ListTeams: http.HandlerFunc(teamServer.ListTeams),
-		atc.SetTeam:   http.HandlerFunc(teamServer.SetTeam),
+		atc.ListAPITokens:  http.HandlerFunc(teamServer.ListAPITokens),
+		atc.CreateAPIToken: http.HandlerFunc(teamServer.CreateAPIToken),
+		atc.RevokeAPIToken: http.HandlerFunc(teamServer.RevokeAPIToken),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
@@ -0,0 +1,224 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hijack Sessions API", func() {
+	Describe("GET /api/v1/hijack-sessions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/hijack-sessions")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as a non-admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+			})
+
+			Context("when getting the sessions succeeds", func() {
+				BeforeEach(func() {
+					exitStatus := 2
+
+					hijackSessionDB.GetHijackSessionsReturns([]db.SavedHijackSession{
+						{
+							ID: 2,
+							HijackSession: db.HijackSession{
+								TeamID:          42,
+								HijackedBy:      "main",
+								ContainerHandle: "some-handle",
+								Process:         atc.HijackProcessSpec{Path: "bash"},
+							},
+							TeamName:  "some-team",
+							StartTime: time.Unix(100, 0),
+						},
+						{
+							ID: 1,
+							HijackSession: db.HijackSession{
+								TeamID:          42,
+								HijackedBy:      "some-team",
+								ContainerHandle: "other-handle",
+								Process:         atc.HijackProcessSpec{Path: "ls", Args: []string{"-al"}},
+							},
+							TeamName:   "some-team",
+							StartTime:  time.Unix(50, 0),
+							EndTime:    time.Unix(60, 0),
+							ExitStatus: &exitStatus,
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the sessions", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"team_name": "some-team",
+							"hijacked_by": "main",
+							"container_handle": "some-handle",
+							"process": {"path": "bash", "args": null, "env": null, "dir": "", "privileged": false, "user": "", "tty": null},
+							"start_time": 100
+						},
+						{
+							"id": 1,
+							"team_name": "some-team",
+							"hijacked_by": "some-team",
+							"container_handle": "other-handle",
+							"process": {"path": "ls", "args": ["-al"], "env": null, "dir": "", "privileged": false, "user": "", "tty": null},
+							"start_time": 50,
+							"end_time": 60,
+							"exit_status": 2
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the sessions fails", func() {
+				BeforeEach(func() {
+					hijackSessionDB.GetHijackSessionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/hijack-sessions/:hijack_session_id/recording", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/hijack-sessions/7/recording")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as a non-admin team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+			})
+
+			Context("when the session exists", func() {
+				BeforeEach(func() {
+					hijackSessionDB.GetHijackSessionReturns(db.SavedHijackSession{
+						ID: 7,
+						HijackSession: db.HijackSession{
+							TeamID:          42,
+							HijackedBy:      "main",
+							ContainerHandle: "some-handle",
+							Process: atc.HijackProcessSpec{
+								Path: "bash",
+								Args: []string{"-l"},
+								TTY: &atc.HijackTTYSpec{
+									WindowSize: atc.HijackWindowSize{Columns: 120, Rows: 40},
+								},
+							},
+						},
+						TeamName:  "some-team",
+						StartTime: time.Unix(100, 0),
+					}, true, nil)
+
+					hijackSessionDB.GetHijackSessionEventsReturns([]db.HijackSessionEvent{
+						{Elapsed: 500 * time.Millisecond, Type: "o", Data: []byte("$ ")},
+						{Elapsed: time.Second, Type: "i", Data: []byte("ls\r")},
+						{Elapsed: 2 * time.Second, Type: "r", Data: []byte("80x24")},
+					}, nil)
+				})
+
+				It("looks up the requested session", func() {
+					Expect(hijackSessionDB.GetHijackSessionArgsForCall(0)).To(Equal(7))
+					Expect(hijackSessionDB.GetHijackSessionEventsArgsForCall(0)).To(Equal(7))
+				})
+
+				It("returns the recording in asciicast format", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/x-asciicast"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(Equal(
+						`{"version":2,"width":120,"height":40,"timestamp":100,"command":"bash -l","title":"main@some-handle"}` + "\n" +
+							`[0.5,"o","$ "]` + "\n" +
+							`[1,"i","ls\r"]` + "\n" +
+							`[2,"r","80x24"]` + "\n",
+					))
+				})
+			})
+
+			Context("when the session does not exist", func() {
+				BeforeEach(func() {
+					hijackSessionDB.GetHijackSessionReturns(db.SavedHijackSession{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the session fails", func() {
+				BeforeEach(func() {
+					hijackSessionDB.GetHijackSessionReturns(db.SavedHijackSession{}, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
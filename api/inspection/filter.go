@@ -0,0 +1,51 @@
+package inspection
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+var ErrNotAuthorizedForTeam = errors.New("not authorized for team")
+
+// Filter parses the filter for inspecting containers or volumes from the
+// request. Teams only get to see their own, unless they are admin, in which
+// case they see every team's unless they ask for a specific one.
+func Filter(r *http.Request) (db.InspectionFilter, error) {
+	filter := db.InspectionFilter{
+		TeamName:     r.URL.Query().Get("team_name"),
+		PipelineName: r.URL.Query().Get("pipeline_name"),
+		JobName:      r.URL.Query().Get("job_name"),
+		WorkerName:   r.URL.Query().Get("worker_name"),
+		Type:         r.URL.Query().Get("type"),
+	}
+
+	authTeam, found := auth.GetTeam(r)
+	if !found {
+		return db.InspectionFilter{}, ErrNotAuthorizedForTeam
+	}
+
+	if !authTeam.IsAdmin() {
+		if filter.TeamName == "" {
+			filter.TeamName = authTeam.Name()
+		} else if !authTeam.IsAuthorized(filter.TeamName) {
+			return db.InspectionFilter{}, ErrNotAuthorizedForTeam
+		}
+	}
+
+	buildIDParam := r.URL.Query().Get("build_id")
+	if buildIDParam != "" {
+		buildID, err := strconv.Atoi(buildIDParam)
+		if err != nil {
+			return db.InspectionFilter{}, fmt.Errorf("malformed build ID: %s", err)
+		}
+
+		filter.BuildID = buildID
+	}
+
+	return filter, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/explainer"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
 
@@ -83,6 +84,7 @@ var _ = Describe("Jobs API", func() {
 					build1.StatusReturns(db.StatusSucceeded)
 					build1.StartTimeReturns(time.Unix(1, 0))
 					build1.EndTimeReturns(time.Unix(100, 0))
+					build1.TriggerReasonReturns("schedule")
 
 					build2 := new(dbfakes.FakeBuild)
 					build2.IDReturns(3)
@@ -192,7 +194,8 @@ var _ = Describe("Jobs API", func() {
 								"pipeline_name": "some-pipeline",
 								"team_name": "some-team",
 								"start_time": 1,
-								"end_time": 100
+								"end_time": 100,
+								"trigger_reason": "schedule"
 							},
 							"inputs": [
 								{
@@ -222,6 +225,42 @@ var _ = Describe("Jobs API", func() {
 
 					})
 
+					Context("when the pipeline is an instance", func() {
+						BeforeEach(func() {
+							pipelineDB.PipelineReturns(db.SavedPipeline{
+								InstanceVars: atc.InstanceVars{"branch": "foo"},
+							})
+
+							build := new(dbfakes.FakeBuild)
+							build.IDReturns(1)
+							build.NameReturns("1")
+							build.JobNameReturns("some-job")
+							build.PipelineNameReturns("some-pipeline")
+							build.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "foo"})
+							build.TeamNameReturns("some-team")
+							build.StatusReturns(db.StatusSucceeded)
+
+							pipelineDB.GetJobFinishedAndNextBuildReturns(build, nil, nil)
+						})
+
+						It("reports the instance vars of its builds' pipeline", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.FinishedBuild.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "foo"}))
+						})
+
+						It("does not address the instance in the urls of the job and its builds", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.URL).To(Equal("/teams/some-team/pipelines/some-pipeline/jobs/some-job"))
+							Expect(job.FinishedBuild.URL).To(Equal("/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/1"))
+						})
+					})
+
 					Context("when there are no running or finished builds", func() {
 						BeforeEach(func() {
 							pipelineDB.GetJobFinishedAndNextBuildReturns(nil, nil, nil)
@@ -237,6 +276,32 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the job has a schedule", func() {
+						BeforeEach(func() {
+							pipelineDB.GetJobReturns(db.SavedJob{
+								PipelineName: "some-pipeline",
+								Job: db.Job{
+									Name: "some-job",
+								},
+								Config: atc.JobConfig{
+									Name:     "some-job",
+									Schedule: &atc.ScheduleConfig{Cron: "*/5 * * * *"},
+								},
+							}, true, nil)
+						})
+
+						It("returns the next scheduled run", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							nextRun := time.Unix(job.NextScheduledRun, 0)
+							Expect(nextRun).To(BeTemporally(">", time.Now().Add(-time.Second)))
+							Expect(nextRun).To(BeTemporally("<=", time.Now().Add(5*time.Minute)))
+							Expect(nextRun.Minute() % 5).To(BeZero())
+						})
+					})
+
 					Context("when getting the job's builds fails", func() {
 						BeforeEach(func() {
 							pipelineDB.GetJobFinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -1472,6 +1537,321 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/explain", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/explain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the pipeline contains the requested job", func() {
+				someJob := atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-input", Resource: "some-resource", Passed: []string{"job-a"}, Trigger: true},
+					},
+				}
+
+				var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+				BeforeEach(func() {
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{someJob},
+					})
+				})
+
+				Context("when the job can be explained", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainJobReturns(explainer.Explanation{
+							Inputs: []explainer.InputExplanation{
+								{
+									Name:     "some-input",
+									Resource: "some-resource",
+									Passed:   []string{"job-a"},
+									Trigger:  true,
+									Candidates: []explainer.Candidate{
+										{
+											Version: db.SavedVersionedResource{
+												ID:      2,
+												Enabled: true,
+												VersionedResource: db.VersionedResource{
+													Resource:   "some-resource",
+													Type:       "some-type",
+													Version:    db.Version{"some": "version"},
+													PipelineID: 42,
+												},
+											},
+											Status:    explainer.CandidateNotPassed,
+											PassedJob: "job-a",
+										},
+									},
+								},
+							},
+							InputsSatisfied: false,
+							Holds: []explainer.Hold{
+								{
+									Reason:  explainer.HoldInputsUnsatisfied,
+									Message: "no set of versions satisfies all of the job's inputs",
+								},
+							},
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("explains the job with a scheduler for the pipeline", func() {
+						actualPipelineDB, actualExternalURL := fakeSchedulerFactory.BuildSchedulerArgsForCall(0)
+						Expect(actualPipelineDB).To(Equal(pipelineDB))
+						Expect(actualExternalURL).To(Equal(externalURL))
+
+						Expect(fakeScheduler.ExplainJobCallCount()).To(Equal(1))
+						_, actualJobConfig := fakeScheduler.ExplainJobArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(someJob))
+					})
+
+					It("does not save anything", func() {
+						Expect(fakeScheduler.SaveNextInputMappingCallCount()).To(BeZero())
+					})
+
+					It("returns the explanation", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"passed": ["job-a"],
+									"trigger": true,
+									"candidates": [
+										{
+											"version": {
+												"id": 2,
+												"pipeline_id": 42,
+												"resource": "some-resource",
+												"type": "some-type",
+												"metadata": null,
+												"version": {"some": "version"},
+												"enabled": true
+											},
+											"status": "not_passed",
+											"passed_job": "job-a"
+										}
+									],
+									"truncated": false
+								}
+							],
+							"inputs_satisfied": false,
+							"holds": [
+								{
+									"reason": "inputs_unsatisfied",
+									"message": "no set of versions satisfies all of the job's inputs"
+								}
+							]
+						}`))
+					})
+				})
+
+				Context("when explaining the job fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainJobReturns(explainer.Explanation{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the pipeline does not contain the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-bogus-job"},
+						},
+					})
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-statistics", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+
+			pipelineDB.ConfigReturns(atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			})
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/test-statistics" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", 0, false, false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(true)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, true, true)
+			})
+
+			Context("when the statistics can be computed", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobTestStatisticsReturns(atc.JobTestStatistics{
+						Builds: 3,
+						Tests: []atc.TestCaseStatistics{
+							{
+								StepName:        "unit",
+								Suite:           "models",
+								Classname:       "models.UserTest",
+								Name:            "test_save",
+								Runs:            3,
+								Failures:        1,
+								Flaky:           true,
+								LastFailedBuild: "12",
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("covers the default number of builds", func() {
+					Expect(pipelineDB.GetJobTestStatisticsCallCount()).To(Equal(1))
+
+					jobName, builds := pipelineDB.GetJobTestStatisticsArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(builds).To(Equal(50))
+				})
+
+				It("returns the statistics", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"builds": 3,
+						"tests": [
+							{
+								"step_name": "unit",
+								"suite": "models",
+								"classname": "models.UserTest",
+								"name": "test_save",
+								"runs": 3,
+								"failures": 1,
+								"flaky": true,
+								"last_failed_build": "12"
+							}
+						]
+					}`))
+				})
+
+				Context("when the number of builds is specified", func() {
+					BeforeEach(func() {
+						queryParams = "?builds=10"
+					})
+
+					It("covers that many builds", func() {
+						_, builds := pipelineDB.GetJobTestStatisticsArgsForCall(0)
+						Expect(builds).To(Equal(10))
+					})
+				})
+
+				Context("when too many builds are requested", func() {
+					BeforeEach(func() {
+						queryParams = "?builds=100000"
+					})
+
+					It("caps the number of builds", func() {
+						_, builds := pipelineDB.GetJobTestStatisticsArgsForCall(0)
+						Expect(builds).To(Equal(500))
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{})
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when computing the statistics fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobTestStatisticsReturns(atc.JobTestStatistics{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
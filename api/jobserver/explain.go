@@ -0,0 +1,34 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ExplainJob(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("explain-job")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		jobConfig, found := pipelineDB.Config().Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
+
+		explanation, err := scheduler.ExplainJob(logger, jobConfig)
+		if err != nil {
+			logger.Error("failed-to-explain-job", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(present.JobExplanation(explanation))
+	})
+}
@@ -0,0 +1,44 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	defaultTestStatisticsBuilds = 50
+	maxTestStatisticsBuilds     = 500
+)
+
+func (s *Server) GetJobTestStatistics(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("get-job-test-statistics")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		_, found := pipelineDB.Config().Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		builds, _ := strconv.Atoi(r.FormValue("builds"))
+		if builds <= 0 {
+			builds = defaultTestStatisticsBuilds
+		} else if builds > maxTestStatisticsBuilds {
+			builds = maxTestStatisticsBuilds
+		}
+
+		statistics, err := pipelineDB.GetJobTestStatistics(jobName, builds)
+		if err != nil {
+			logger.Error("failed-to-get-job-test-statistics", err, lager.Data{"job": jobName})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(statistics)
+	})
+}
@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -137,6 +138,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -151,11 +153,28 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/another/pipelines/another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
 		})
 
+		Context("when the token has been revoked", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", 0, false, false)
+				userContextReader.GetTeamsReturns(nil, false)
+			})
+
+			It("does not return the token's team's private pipelines", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(body)).NotTo(ContainSubstring("private-pipeline"))
+				Expect(teamDB.GetPrivateAndAllPublicPipelinesCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when authenticated", func() {
 			BeforeEach(func() {
 				userContextReader.GetTeamReturns("main", 5, false, true)
@@ -172,6 +191,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/private-pipeline",
 					"paused": false,
 					"public": false,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -186,6 +206,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/main/pipelines/public-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "main",
 					"groups": [
 						{
@@ -200,6 +221,7 @@ var _ = Describe("Pipelines API", func() {
 					"url": "/teams/another/pipelines/another-pipeline",
 					"paused": true,
 					"public": true,
+					"archived": false,
 					"team_name": "another"
 				}]`))
 			})
@@ -213,6 +235,65 @@ var _ = Describe("Pipelines API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("when the user is also a member of other teams", func() {
+				var anotherTeamDB *dbfakes.FakeTeamDB
+
+				BeforeEach(func() {
+					userContextReader.GetTeamsReturns([]string{"main", "another"}, true)
+
+					anotherTeamDB = new(dbfakes.FakeTeamDB)
+					anotherTeamDB.GetPipelinesReturns([]db.SavedPipeline{
+						{
+							ID:       3,
+							Public:   true,
+							TeamName: "another",
+							Pipeline: db.Pipeline{Name: "another-pipeline"},
+						},
+						{
+							ID:       4,
+							TeamName: "another",
+							Pipeline: db.Pipeline{Name: "another-private-pipeline"},
+						},
+					}, nil)
+
+					teamDBFactory.GetTeamDBStub = func(teamName string) db.TeamDB {
+						if teamName == "another" {
+							return anotherTeamDB
+						}
+
+						return teamDB
+					}
+				})
+
+				It("returns the pipelines of every team followed by the remaining public pipelines", func() {
+					var pipelines []atc.Pipeline
+					err := json.NewDecoder(response.Body).Decode(&pipelines)
+					Expect(err).NotTo(HaveOccurred())
+
+					names := []string{}
+					for _, pipeline := range pipelines {
+						names = append(names, pipeline.TeamName+"/"+pipeline.Name)
+					}
+
+					Expect(names).To(Equal([]string{
+						"main/private-pipeline",
+						"main/public-pipeline",
+						"another/another-pipeline",
+						"another/another-private-pipeline",
+					}))
+				})
+
+				Context("when getting another team's pipelines fails", func() {
+					BeforeEach(func() {
+						anotherTeamDB.GetPipelinesReturns(nil, errors.New("disaster"))
+					})
+
+					It("returns 500 internal server error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
 		})
 	})
 
@@ -258,6 +339,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/private-pipeline",
 						"paused": false,
 						"public": false,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -272,6 +354,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -310,6 +393,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -338,6 +422,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/main/pipelines/public-pipeline",
 						"paused": true,
 						"public": true,
+						"archived": false,
 						"team_name": "main",
 						"groups": [
 							{
@@ -427,6 +512,7 @@ var _ = Describe("Pipelines API", func() {
 						"url": "/teams/a-team/pipelines/some-specific-pipeline",
 						"paused": false,
 						"public": true,
+						"archived": false,
 						"team_name": "a-team",
 						"groups": [
 							{
@@ -705,6 +791,95 @@ var _ = Describe("Pipelines API", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when the pipeline is archived", func() {
+					BeforeEach(func() {
+						pipelineDB.IsArchivedReturns(true)
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not unpause it", func() {
+						Expect(pipelineDB.UnpauseCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when requester does not belong to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("another-team", 42, true, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/archive", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/archive", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			Context("when requester belongs to the team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("a-team", 42, true, true)
+				})
+
+				It("injects the proper pipelineDB", func() {
+					pipelineName := teamDB.GetPipelineByNameArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(pipelineDBFactory.BuildCallCount()).To(Equal(1))
+				})
+
+				Context("when archiving the pipeline succeeds", func() {
+					BeforeEach(func() {
+						pipelineDB.ArchiveReturns(nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("archives the pipeline rather than destroying it", func() {
+						Expect(pipelineDB.ArchiveCallCount()).To(Equal(1))
+						Expect(pipelineDB.DestroyCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when archiving the pipeline fails", func() {
+					BeforeEach(func() {
+						pipelineDB.ArchiveReturns(errors.New("welp"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
 			})
 
 			Context("when requester does not belong to the team", func() {
@@ -1011,7 +1186,7 @@ var _ = Describe("Pipelines API", func() {
 				userContextReader.GetTeamReturns("a-team", 42, true, true)
 				//construct Version db
 
-				pipelineDB.LoadVersionsDBReturns(
+				pipelineDB.GetVersionsDBReturns(
 					&algorithm.VersionsDB{
 						ResourceVersions: []algorithm.ResourceVersion{
 							{
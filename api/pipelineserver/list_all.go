@@ -9,7 +9,8 @@ import (
 	"github.com/concourse/atc/db"
 )
 
-// show all public pipelines and team private pipelines if authorized
+// show all public pipelines and private pipelines of every team the user is a
+// member of
 func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("list-all-pipelines")
 	authTeam, authTeamFound := auth.GetTeam(r)
@@ -17,7 +18,7 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 	var pipelines []db.SavedPipeline
 	var err error
 	if authTeamFound {
-		pipelines, err = s.teamDBFactory.GetTeamDB(authTeam.Name()).GetPrivateAndAllPublicPipelines()
+		pipelines, err = s.visiblePipelines(authTeam)
 	} else {
 		pipelines, err = s.pipelinesDB.GetAllPublicPipelines()
 	}
@@ -32,3 +33,54 @@ func (s *Server) ListAllPipelines(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(present.Pipelines(pipelines))
 }
+
+func (s *Server) visiblePipelines(authTeam auth.Team) ([]db.SavedPipeline, error) {
+	pipelines, err := s.teamDBFactory.GetTeamDB(authTeam.Name()).GetPrivateAndAllPublicPipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	otherTeams := []string{}
+	for _, teamName := range authTeam.Teams() {
+		if teamName != authTeam.Name() {
+			otherTeams = append(otherTeams, teamName)
+		}
+	}
+
+	if len(otherTeams) == 0 {
+		return pipelines, nil
+	}
+
+	visible := []db.SavedPipeline{}
+	seen := map[int]bool{}
+
+	for _, pipeline := range pipelines {
+		if pipeline.TeamName == authTeam.Name() {
+			visible = append(visible, pipeline)
+			seen[pipeline.ID] = true
+		}
+	}
+
+	for _, teamName := range otherTeams {
+		teamPipelines, err := s.teamDBFactory.GetTeamDB(teamName).GetPipelines()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pipeline := range teamPipelines {
+			if !seen[pipeline.ID] {
+				visible = append(visible, pipeline)
+				seen[pipeline.ID] = true
+			}
+		}
+	}
+
+	// the remaining public pipelines of teams the user is not a member of
+	for _, pipeline := range pipelines {
+		if !seen[pipeline.ID] {
+			visible = append(visible, pipeline)
+		}
+	}
+
+	return visible, nil
+}
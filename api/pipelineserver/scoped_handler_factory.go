@@ -3,6 +3,7 @@ package pipelineserver
 import (
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
@@ -29,8 +30,21 @@ func (pdbh *ScopedHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pipel
 			pipelineName := r.FormValue(":pipeline_name")
 			requestTeamName := r.FormValue(":team_name")
 
+			instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
 			teamDB := pdbh.teamDBFactory.GetTeamDB(requestTeamName)
-			savedPipeline, found, err := teamDB.GetPipelineByName(pipelineName)
+
+			var savedPipeline db.SavedPipeline
+			var found bool
+			if instanceVars != nil {
+				savedPipeline, found, err = teamDB.GetPipelineInstance(pipelineName, instanceVars)
+			} else {
+				savedPipeline, found, err = teamDB.GetPipelineByName(pipelineName)
+			}
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 				return
@@ -47,3 +61,18 @@ func (pdbh *ScopedHandlerFactory) HandlerFor(pipelineScopedHandler func(db.Pipel
 		pipelineScopedHandler(pipelineDB).ServeHTTP(w, r)
 	}
 }
+
+// MutatingHandlerFor is HandlerFor for handlers that change the pipeline or
+// make it do something, which its config being read-only while it is
+// archived rules out.
+func (pdbh *ScopedHandlerFactory) MutatingHandlerFor(pipelineScopedHandler func(db.PipelineDB) http.Handler) http.HandlerFunc {
+	return pdbh.HandlerFor(func(pipelineDB db.PipelineDB) http.Handler {
+		if pipelineDB.IsArchived() {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusConflict)
+			})
+		}
+
+		return pipelineScopedHandler(pipelineDB)
+	})
+}
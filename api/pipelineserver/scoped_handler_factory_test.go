@@ -4,7 +4,9 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/pipelineserver"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
@@ -23,9 +25,12 @@ var _ = Describe("Handler", func() {
 		teamDB        *dbfakes.FakeTeamDB
 		pipelineDB    *dbfakes.FakePipelineDB
 		handler       http.Handler
+		query         string
 	)
 
 	BeforeEach(func() {
+		query = ""
+
 		teamDBFactory = new(dbfakes.FakeTeamDBFactory)
 		teamDB = new(dbfakes.FakeTeamDB)
 		teamDBFactory.GetTeamDBReturns(teamDB)
@@ -43,7 +48,7 @@ var _ = Describe("Handler", func() {
 	JustBeforeEach(func() {
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline"+query, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -106,6 +111,108 @@ var _ = Describe("Handler", func() {
 				Expect(delegate.IsCalled).To(BeTrue())
 			})
 		})
+
+		Context("when instance vars are given", func() {
+			BeforeEach(func() {
+				query = "&" + url.Values{"instance_vars": {`{"branch":"foo"}`}}.Encode()
+			})
+
+			Context("when the instance exists", func() {
+				BeforeEach(func() {
+					teamDB.GetPipelineInstanceReturns(db.SavedPipeline{Pipeline: db.Pipeline{Name: "some-pipeline"}}, true, nil)
+				})
+
+				It("looks up the instance by its vars", func() {
+					Expect(teamDB.GetPipelineByNameCallCount()).To(BeZero())
+					Expect(teamDB.GetPipelineInstanceCallCount()).To(Equal(1))
+
+					pipelineName, instanceVars := teamDB.GetPipelineInstanceArgsForCall(0)
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(instanceVars).To(Equal(atc.InstanceVars{"branch": "foo"}))
+				})
+
+				It("calls the scoped handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(delegate.IsCalled).To(BeTrue())
+				})
+			})
+
+			Context("when the instance does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetPipelineInstanceReturns(db.SavedPipeline{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the instance vars are malformed", func() {
+				BeforeEach(func() {
+					query = "&" + url.Values{"instance_vars": {`{"branch":`}}.Encode()
+				})
+
+				It("returns 400 without looking up a pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(teamDB.GetPipelineInstanceCallCount()).To(BeZero())
+					Expect(teamDB.GetPipelineByNameCallCount()).To(BeZero())
+					Expect(delegate.IsCalled).To(BeFalse())
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("MutatingHandler", func() {
+	var (
+		response   *http.Response
+		server     *httptest.Server
+		delegate   *delegateHandler
+		pipelineDB *dbfakes.FakePipelineDB
+	)
+
+	BeforeEach(func() {
+		teamDBFactory := new(dbfakes.FakeTeamDBFactory)
+		teamDB := new(dbfakes.FakeTeamDB)
+		teamDBFactory.GetTeamDBReturns(teamDB)
+		teamDB.GetPipelineByNameReturns(db.SavedPipeline{Pipeline: db.Pipeline{Name: "some-pipeline"}}, true, nil)
+
+		pipelineDB = new(dbfakes.FakePipelineDB)
+		delegate = &delegateHandler{}
+
+		pipelineDBFactory := new(dbfakes.FakePipelineDBFactory)
+		pipelineDBFactory.BuildReturns(pipelineDB)
+
+		handlerFactory := pipelineserver.NewScopedHandlerFactory(pipelineDBFactory, teamDBFactory)
+		server = httptest.NewServer(handlerFactory.MutatingHandlerFor(delegate.GetHandler))
+	})
+
+	JustBeforeEach(func() {
+		request, err := http.NewRequest("PUT", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		response, err = new(http.Client).Do(request)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("calls the scoped handler", func() {
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(delegate.IsCalled).To(BeTrue())
+	})
+
+	Context("when the pipeline is archived", func() {
+		BeforeEach(func() {
+			pipelineDB.IsArchivedReturns(true)
+		})
+
+		It("returns 409 without calling the scoped handler", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			Expect(delegate.IsCalled).To(BeFalse())
+		})
 	})
 })
 
@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func APIToken(savedToken db.SavedAPIToken) atc.APIToken {
+	token := atc.APIToken{
+		Name:      savedToken.Name,
+		Scope:     savedToken.Scope,
+		CreatedAt: savedToken.CreatedAt.Unix(),
+	}
+
+	if !savedToken.ExpiresAt.IsZero() {
+		token.ExpiresAt = savedToken.ExpiresAt.Unix()
+	}
+
+	return token
+}
@@ -44,14 +44,20 @@ func Build(build db.Build) atc.Build {
 	}
 
 	atcBuild := atc.Build{
-		ID:           build.ID(),
-		Name:         build.Name(),
-		Status:       string(build.Status()),
-		JobName:      build.JobName(),
-		PipelineName: build.PipelineName(),
-		TeamName:     build.TeamName(),
-		URL:          reqURL,
-		APIURL:       apiURL,
+		ID:                   build.ID(),
+		Name:                 build.Name(),
+		Status:               string(build.Status()),
+		JobName:              build.JobName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		TeamName:             build.TeamName(),
+		URL:                  reqURL,
+		APIURL:               apiURL,
+
+		TriggerReason: build.TriggerReason(),
+
+		Metadata: build.Annotations().Metadata,
+		Links:    build.Annotations().Links,
 	}
 
 	if !build.StartTime().IsZero() {
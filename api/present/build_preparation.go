@@ -17,6 +17,10 @@ func BuildPreparation(preparation db.BuildPreparation) atc.BuildPreparation {
 		PausedPipeline:      atc.BuildPreparationStatus(preparation.PausedPipeline),
 		PausedJob:           atc.BuildPreparationStatus(preparation.PausedJob),
 		MaxRunningBuilds:    atc.BuildPreparationStatus(preparation.MaxRunningBuilds),
+		TeamQuota:           atc.BuildPreparationStatus(preparation.TeamQuota),
+		BuildQueue:          atc.BuildPreparationStatus(preparation.BuildQueue),
+		QueuePosition:       preparation.QueuePosition,
+		QueueWaitInSeconds:  int64(preparation.QueueWaitTime.Seconds()),
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
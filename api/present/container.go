@@ -28,3 +28,22 @@ func Container(container db.SavedContainer) atc.Container {
 		User:                 container.User,
 	}
 }
+
+func ContainerDetails(container db.InspectedContainer) atc.ContainerDetails {
+	return atc.ContainerDetails{
+		Container: Container(container.SavedContainer),
+		TeamName:  container.TeamName,
+		Owner:     owner(container.Owner),
+	}
+}
+
+func owner(owner *db.Owner) *atc.Owner {
+	if owner == nil {
+		return nil
+	}
+
+	return &atc.Owner{
+		Type: string(owner.Type),
+		ID:   owner.ID,
+	}
+}
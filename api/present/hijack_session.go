@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func HijackSession(session db.SavedHijackSession) atc.HijackSession {
+	presented := atc.HijackSession{
+		ID:              session.ID,
+		TeamName:        session.TeamName,
+		HijackedBy:      session.HijackedBy,
+		ContainerHandle: session.ContainerHandle,
+		Process:         session.Process,
+		StartTime:       session.StartTime.Unix(),
+		ExitStatus:      session.ExitStatus,
+	}
+
+	if !session.EndTime.IsZero() {
+		presented.EndTime = session.EndTime.Unix()
+	}
+
+	return presented
+}
@@ -1,8 +1,11 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
 	"github.com/tedsuo/rata"
@@ -69,6 +72,14 @@ func Job(
 		})
 	}
 
+	var nextScheduledRun int64
+	if job.Config.Schedule != nil {
+		schedule, err := cron.Parse(job.Config.Schedule.Cron, job.Config.Schedule.Location)
+		if err == nil {
+			nextScheduledRun = schedule.Next(time.Now()).Unix()
+		}
+	}
+
 	return atc.Job{
 		Name:                 job.Name,
 		URL:                  req.URL.String(),
@@ -77,6 +88,7 @@ func Job(
 		FirstLoggedBuildID:   job.FirstLoggedBuildID,
 		FinishedBuild:        presentedFinishedBuild,
 		NextBuild:            presentedNextBuild,
+		NextScheduledRun:     nextScheduledRun,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
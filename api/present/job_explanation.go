@@ -0,0 +1,46 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/explainer"
+)
+
+func JobExplanation(explanation explainer.Explanation) atc.JobExplanation {
+	presented := atc.JobExplanation{
+		Inputs:          []atc.InputExplanation{},
+		InputsSatisfied: explanation.InputsSatisfied,
+		Holds:           []atc.JobHold{},
+	}
+
+	for _, input := range explanation.Inputs {
+		presentedInput := atc.InputExplanation{
+			Name:          input.Name,
+			Resource:      input.Resource,
+			Passed:        input.Passed,
+			Trigger:       input.Trigger,
+			Every:         input.Every,
+			PinnedVersion: input.PinnedVersion,
+			Candidates:    []atc.CandidateExplanation{},
+			Truncated:     input.Truncated,
+		}
+
+		for _, candidate := range input.Candidates {
+			presentedInput.Candidates = append(presentedInput.Candidates, atc.CandidateExplanation{
+				Version:   SavedVersionedResource(candidate.Version),
+				Status:    string(candidate.Status),
+				PassedJob: candidate.PassedJob,
+			})
+		}
+
+		presented.Inputs = append(presented.Inputs, presentedInput)
+	}
+
+	for _, hold := range explanation.Holds {
+		presented.Holds = append(presented.Holds, atc.JobHold{
+			Reason:  string(hold.Reason),
+			Message: hold.Message,
+		})
+	}
+
+	return presented
+}
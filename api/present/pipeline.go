@@ -17,12 +17,16 @@ func Pipeline(savedPipeline db.SavedPipeline) atc.Pipeline {
 		panic("failed to generate url: " + err.Error())
 	}
 
+	// the web UI does not forward instance vars to its API requests yet, so
+	// the URL of an instance is that of its pipeline's name
 	return atc.Pipeline{
-		Name:     savedPipeline.Name,
-		TeamName: savedPipeline.TeamName,
-		URL:      pathForRoute,
-		Paused:   savedPipeline.Paused,
-		Public:   savedPipeline.Public,
-		Groups:   savedPipeline.Config.Groups,
+		Name:         savedPipeline.Name,
+		InstanceVars: savedPipeline.InstanceVars,
+		TeamName:     savedPipeline.TeamName,
+		URL:          pathForRoute,
+		Paused:       savedPipeline.Paused,
+		Public:       savedPipeline.Public,
+		Archived:     savedPipeline.Archived,
+		Groups:       savedPipeline.Config.Groups,
 	}
 }
@@ -0,0 +1,49 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func Provenance(provenance db.Provenance) atc.Provenance {
+	presented := atc.Provenance{
+		Builds:       []atc.Build{},
+		Versions:     []atc.VersionedResource{},
+		Inputs:       []atc.ProvenanceEdge{},
+		Outputs:      []atc.ProvenanceEdge{},
+		SameVersions: []atc.ProvenanceLink{},
+		Truncated:    provenance.Truncated,
+	}
+
+	for _, build := range provenance.Builds {
+		presented.Builds = append(presented.Builds, Build(build))
+	}
+
+	for _, version := range provenance.Versions {
+		presented.Versions = append(presented.Versions, SavedVersionedResource(version))
+	}
+
+	for _, edge := range provenance.Inputs {
+		presented.Inputs = append(presented.Inputs, provenanceEdge(edge))
+	}
+
+	for _, edge := range provenance.Outputs {
+		presented.Outputs = append(presented.Outputs, provenanceEdge(edge))
+	}
+
+	for _, link := range provenance.SameVersions {
+		presented.SameVersions = append(presented.SameVersions, atc.ProvenanceLink{
+			VersionID:      link.VersionedResourceID,
+			OtherVersionID: link.OtherVersionedResourceID,
+		})
+	}
+
+	return presented
+}
+
+func provenanceEdge(edge db.ProvenanceEdge) atc.ProvenanceEdge {
+	return atc.ProvenanceEdge{
+		BuildID:   edge.BuildID,
+		VersionID: edge.VersionedResourceID,
+	}
+}
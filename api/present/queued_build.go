@@ -0,0 +1,21 @@
+package present
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func QueuedBuild(build db.QueuedBuild, position int, now time.Time) atc.QueuedBuild {
+	return atc.QueuedBuild{
+		ID:            build.BuildID,
+		Name:          build.BuildName,
+		JobName:       build.JobName,
+		PipelineName:  build.PipelineName,
+		TeamName:      build.TeamName,
+		Priority:      build.Priority,
+		Position:      position,
+		WaitInSeconds: int64(now.Sub(build.QueuedAt).Seconds()),
+	}
+}
@@ -6,8 +6,24 @@ import (
 )
 
 func Team(savedTeam db.SavedTeam) atc.Team {
-	return atc.Team{
+	team := atc.Team{
 		ID:   savedTeam.ID,
 		Name: savedTeam.Name,
 	}
+
+	if savedTeam.RequireHijackRecording {
+		required := true
+		team.RequireHijackRecording = &required
+	}
+
+	if savedTeam.Quota.IsConfigured() {
+		team.Quota = &atc.TeamQuota{
+			MaxConcurrentBuilds: savedTeam.Quota.MaxConcurrentBuilds,
+			MaxContainers:       savedTeam.Quota.MaxContainers,
+			MaxVolumeBytes:      savedTeam.Quota.MaxVolumeBytes,
+			BuildQueueWeight:    savedTeam.Quota.BuildQueueWeight,
+		}
+	}
+
+	return team
 }
@@ -16,3 +16,19 @@ func Volume(volume db.SavedVolume) atc.Volume {
 		SizeInBytes:       volume.SizeInBytes,
 	}
 }
+
+func VolumeDetails(volume db.InspectedVolume) atc.VolumeDetails {
+	details := atc.VolumeDetails{
+		Volume:             Volume(volume.SavedVolume),
+		TeamName:           volume.TeamName,
+		Owner:              owner(volume.Owner),
+		ContainerHandle:    volume.ContainerHandle,
+		ChildVolumeHandles: volume.ChildHandles,
+	}
+
+	if volume.Volume.Identifier.COW != nil {
+		details.OriginalVolumeHandle = volume.Volume.Identifier.COW.ParentVolumeHandle
+	}
+
+	return details
+}
@@ -13,10 +13,15 @@ func Worker(workerInfo db.SavedWorker) atc.Worker {
 		HTTPSProxyURL:    workerInfo.HTTPSProxyURL,
 		NoProxy:          workerInfo.NoProxy,
 		ActiveContainers: workerInfo.ActiveContainers,
+		ActiveVolumes:    workerInfo.ActiveVolumes,
+		CPUPressure:      workerInfo.CPUPressure,
+		MemoryPressure:   workerInfo.MemoryPressure,
+		DiskPressure:     workerInfo.DiskPressure,
 		ResourceTypes:    workerInfo.ResourceTypes,
 		Platform:         workerInfo.Platform,
 		Tags:             workerInfo.Tags,
 		Name:             workerInfo.Name,
 		Team:             workerInfo.TeamName,
+		State:            string(workerInfo.State),
 	}
 }
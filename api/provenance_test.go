@@ -0,0 +1,276 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provenance API", func() {
+	var provenance db.Provenance
+
+	BeforeEach(func() {
+		build1 := new(dbfakes.FakeBuild)
+		build1.IDReturns(1)
+		build1.NameReturns("1")
+		build1.JobNameReturns("unit")
+		build1.PipelineNameReturns("some-pipeline")
+		build1.TeamNameReturns("some-team")
+		build1.StatusReturns(db.StatusSucceeded)
+
+		build2 := new(dbfakes.FakeBuild)
+		build2.IDReturns(2)
+		build2.NameReturns("7")
+		build2.JobNameReturns("deploy")
+		build2.PipelineNameReturns("some-other-pipeline")
+		build2.TeamNameReturns("some-team")
+		build2.StatusReturns(db.StatusFailed)
+
+		provenance = db.Provenance{
+			Builds: []db.Build{build1, build2},
+			Versions: []db.SavedVersionedResource{
+				{
+					ID: 10,
+					VersionedResource: db.VersionedResource{
+						Resource:   "some-repo",
+						Type:       "git",
+						Version:    db.Version{"ref": "abc"},
+						PipelineID: 1,
+					},
+				},
+				{
+					ID: 20,
+					VersionedResource: db.VersionedResource{
+						Resource:   "some-repo",
+						Type:       "git",
+						Version:    db.Version{"ref": "abc"},
+						PipelineID: 2,
+					},
+				},
+			},
+			Inputs: []db.ProvenanceEdge{
+				{BuildID: 1, VersionedResourceID: 10},
+				{BuildID: 2, VersionedResourceID: 20},
+			},
+			Outputs: []db.ProvenanceEdge{},
+			SameVersions: []db.ProvenanceLink{
+				{VersionedResourceID: 10, OtherVersionedResourceID: 20},
+			},
+			Truncated: true,
+		}
+	})
+
+	Describe("GET /api/v1/teams/:team_name/builds/:build_id/provenance", func() {
+		var query string
+		var response *http.Response
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/builds/1/provenance" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-other-team", 2, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 1, false, true)
+				teamDB.GetBuildProvenanceReturns(provenance, true, nil)
+			})
+
+			It("looks up the provenance in the team", func() {
+				Expect(teamDBFactory.GetTeamDBArgsForCall(teamDBFactory.GetTeamDBCallCount() - 1)).To(Equal("some-team"))
+			})
+
+			It("walks both directions with the default limit", func() {
+				Expect(teamDB.GetBuildProvenanceCallCount()).To(Equal(1))
+
+				buildID, direction, maxBuilds := teamDB.GetBuildProvenanceArgsForCall(0)
+				Expect(buildID).To(Equal(1))
+				Expect(direction).To(Equal(db.ProvenanceBoth))
+				Expect(maxBuilds).To(Equal(100))
+			})
+
+			It("returns the graph", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				var graph atc.Provenance
+				err := json.NewDecoder(response.Body).Decode(&graph)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(graph.Builds).To(HaveLen(2))
+				Expect(graph.Builds[0].ID).To(Equal(1))
+				Expect(graph.Builds[0].Status).To(Equal("succeeded"))
+				Expect(graph.Builds[1].PipelineName).To(Equal("some-other-pipeline"))
+				Expect(graph.Builds[1].Status).To(Equal("failed"))
+
+				Expect(graph.Versions).To(HaveLen(2))
+				Expect(graph.Versions[0].ID).To(Equal(10))
+				Expect(graph.Versions[0].Version).To(Equal(atc.Version{"ref": "abc"}))
+
+				Expect(graph.Inputs).To(Equal([]atc.ProvenanceEdge{
+					{BuildID: 1, VersionID: 10},
+					{BuildID: 2, VersionID: 20},
+				}))
+				Expect(graph.Outputs).To(BeEmpty())
+				Expect(graph.SameVersions).To(Equal([]atc.ProvenanceLink{
+					{VersionID: 10, OtherVersionID: 20},
+				}))
+				Expect(graph.Truncated).To(BeTrue())
+			})
+
+			Context("when a direction and limit are given", func() {
+				BeforeEach(func() {
+					query = "?direction=upstream&limit=5000"
+				})
+
+				It("walks in that direction, capping the limit", func() {
+					_, direction, maxBuilds := teamDB.GetBuildProvenanceArgsForCall(0)
+					Expect(direction).To(Equal(db.ProvenanceUpstream))
+					Expect(maxBuilds).To(Equal(1000))
+				})
+			})
+
+			Context("when the direction is invalid", func() {
+				BeforeEach(func() {
+					query = "?direction=sideways"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("direction must be one of"))
+				})
+
+				It("does not walk the graph", func() {
+					Expect(teamDB.GetBuildProvenanceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the limit is invalid", func() {
+				BeforeEach(func() {
+					query = "?limit=0"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the build is not found", func() {
+				BeforeEach(func() {
+					teamDB.GetBuildProvenanceReturns(db.Provenance{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when walking the graph fails", func() {
+				BeforeEach(func() {
+					teamDB.GetBuildProvenanceReturns(db.Provenance{}, false, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/provenance", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-repo/versions/10/provenance?direction=downstream")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 1, false, true)
+				teamDB.GetVersionProvenanceReturns(provenance, true, nil)
+			})
+
+			It("walks from the version of the resource in the pipeline", func() {
+				Expect(teamDB.GetVersionProvenanceCallCount()).To(Equal(1))
+
+				pipelineName, resourceName, versionID, direction, maxBuilds := teamDB.GetVersionProvenanceArgsForCall(0)
+				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(resourceName).To(Equal("some-repo"))
+				Expect(versionID).To(Equal(10))
+				Expect(direction).To(Equal(db.ProvenanceDownstream))
+				Expect(maxBuilds).To(Equal(100))
+			})
+
+			It("returns the graph", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				var graph atc.Provenance
+				err := json.NewDecoder(response.Body).Decode(&graph)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(graph.Builds).To(HaveLen(2))
+				Expect(graph.Versions).To(HaveLen(2))
+			})
+
+			Context("when the version is not found", func() {
+				BeforeEach(func() {
+					teamDB.GetVersionProvenanceReturns(db.Provenance{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+})
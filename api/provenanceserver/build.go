@@ -0,0 +1,42 @@
+package provenanceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) GetBuildProvenance(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-build-provenance")
+
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	direction, maxBuilds, err := walkParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	provenance, found, err := teamDB.GetBuildProvenance(buildID, direction, maxBuilds)
+	if err != nil {
+		logger.Error("failed-to-get-provenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(present.Provenance(provenance))
+}
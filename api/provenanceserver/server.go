@@ -0,0 +1,62 @@
+package provenanceserver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+const (
+	defaultMaxBuilds = 100
+	maxMaxBuilds     = 1000
+)
+
+type Server struct {
+	logger        lager.Logger
+	teamDBFactory db.TeamDBFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamDBFactory db.TeamDBFactory,
+) *Server {
+	return &Server{
+		logger:        logger,
+		teamDBFactory: teamDBFactory,
+	}
+}
+
+var errInvalidDirection = errors.New("direction must be one of: upstream, downstream, both")
+var errInvalidLimit = errors.New("limit must be a positive number")
+
+func walkParams(r *http.Request) (db.ProvenanceDirection, int, error) {
+	direction := db.ProvenanceBoth
+	if r.FormValue("direction") != "" {
+		direction = db.ProvenanceDirection(r.FormValue("direction"))
+	}
+
+	switch direction {
+	case db.ProvenanceUpstream, db.ProvenanceDownstream, db.ProvenanceBoth:
+	default:
+		return "", 0, errInvalidDirection
+	}
+
+	maxBuilds := defaultMaxBuilds
+	if r.FormValue("limit") != "" {
+		limit, err := strconv.Atoi(r.FormValue("limit"))
+		if err != nil || limit < 1 {
+			return "", 0, errInvalidLimit
+		}
+
+		maxBuilds = limit
+	}
+
+	if maxBuilds > maxMaxBuilds {
+		maxBuilds = maxMaxBuilds
+	}
+
+	return direction, maxBuilds, nil
+}
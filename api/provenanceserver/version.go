@@ -0,0 +1,48 @@
+package provenanceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) GetVersionProvenance(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-version-provenance")
+
+	versionID, err := strconv.Atoi(r.FormValue(":resource_version_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	direction, maxBuilds, err := walkParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	provenance, found, err := teamDB.GetVersionProvenance(
+		r.FormValue(":pipeline_name"),
+		r.FormValue(":resource_name"),
+		versionID,
+		direction,
+		maxBuilds,
+	)
+	if err != nil {
+		logger.Error("failed-to-get-provenance", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(present.Provenance(provenance))
+}
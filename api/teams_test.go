@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 
@@ -502,6 +503,93 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("OIDC Authentication", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						OIDCAuth: &atc.OIDCAuth{
+							DisplayName:  "Cyborgs",
+							Issuer:       "https://oidc.issuer",
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							Groups:       []string{"osi"},
+						},
+					}
+				})
+
+				Context("when passed a valid team with OIDC Auth", func() {
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("when passed email domains instead of groups", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Groups = nil
+						team.OIDCAuth.EmailDomains = []string{"venture.example.com"}
+					})
+
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("ClientID not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.ClientID = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("Issuer not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Issuer = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("DisplayName not filled in", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.DisplayName = ""
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("neither groups nor email domains are provided", func() {
+					BeforeEach(func() {
+						team.OIDCAuth.Groups = nil
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Describe("Quota", func() {
+				Context("a limit is negative", func() {
+					BeforeEach(func() {
+						team = atc.Team{
+							Quota: &atc.TeamQuota{
+								MaxContainers: -1,
+							},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -538,6 +626,7 @@ var _ = Describe("Teams API", func() {
 					var gitHubAuth *atc.GitHubAuth
 					var uaaAuth *atc.UAAAuth
 					var genericOAuth *atc.GenericOAuth
+					var oidcAuth *atc.OIDCAuth
 
 					BeforeEach(func() {
 						basicAuth = &atc.BasicAuth{
@@ -569,6 +658,15 @@ var _ = Describe("Teams API", func() {
 							DisplayName:   "CSI",
 							Scope:         "readonly",
 						}
+
+						oidcAuth = &atc.OIDCAuth{
+							DisplayName:  "CSI",
+							Issuer:       "https://oidc.issuer",
+							ClientID:     "Dean Venture",
+							ClientSecret: "Giant Boy Detective",
+							GroupsClaim:  "roles",
+							Groups:       []string{"guild-of-calamitous-intent"},
+						}
 					})
 
 					Context("when passed basic auth credentials", func() {
@@ -669,6 +767,151 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed OIDC auth credentials", func() {
+						BeforeEach(func() {
+							teamDB.UpdateOIDCAuthStub = func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+								team.Name = teamName
+								Expect(oidcAuth.Issuer).To(Equal(team.OIDCAuth.Issuer))
+								Expect(oidcAuth.ClientID).To(Equal(team.OIDCAuth.ClientID))
+								Expect(oidcAuth.ClientSecret).To(Equal(team.OIDCAuth.ClientSecret))
+								Expect(oidcAuth.GroupsClaim).To(Equal(team.OIDCAuth.GroupsClaim))
+								Expect(oidcAuth.Groups).To(Equal(team.OIDCAuth.Groups))
+								Expect(oidcAuth.DisplayName).To(Equal(team.OIDCAuth.DisplayName))
+
+								savedTeam.OIDCAuth = oidcAuth
+								return savedTeam, nil
+							}
+
+							team.OIDCAuth = oidcAuth
+						})
+
+						It("updates the OIDC auth for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateOIDCAuthCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when passed a quota", func() {
+						BeforeEach(func() {
+							team.Quota = &atc.TeamQuota{
+								MaxConcurrentBuilds: 2,
+								MaxContainers:       10,
+								MaxVolumeBytes:      1024,
+								BuildQueueWeight:    3,
+							}
+						})
+
+						It("updates the quota for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateQuotaCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateQuotaArgsForCall(0)).To(Equal(db.TeamQuota{
+								MaxConcurrentBuilds: 2,
+								MaxContainers:       10,
+								MaxVolumeBytes:      1024,
+								BuildQueueWeight:    3,
+							}))
+						})
+
+						It("returns the team with its quota", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 2,
+								"name": "team venture",
+								"quota": {
+									"max_concurrent_builds": 2,
+									"max_containers": 10,
+									"max_volume_bytes": 1024,
+									"build_queue_weight": 3
+								}
+							}`))
+						})
+					})
+
+					Context("when not passed a quota", func() {
+						BeforeEach(func() {
+							savedTeam.Quota = db.TeamQuota{MaxConcurrentBuilds: 2}
+							teamDB.GetTeamReturns(savedTeam, true, nil)
+						})
+
+						It("leaves the team's quota as it is", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateQuotaCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when updating the quota fails", func() {
+						BeforeEach(func() {
+							team.Quota = &atc.TeamQuota{MaxConcurrentBuilds: 2}
+							teamDB.UpdateQuotaReturns(db.SavedTeam{}, errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server Error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when requiring hijack recording", func() {
+						BeforeEach(func() {
+							required := true
+							team.RequireHijackRecording = &required
+						})
+
+						It("updates the hijack recording setting for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateRequireHijackRecordingCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateRequireHijackRecordingArgsForCall(0)).To(BeTrue())
+						})
+
+						It("returns the team with the setting", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 2,
+								"name": "team venture",
+								"require_hijack_recording": true
+							}`))
+						})
+
+						Context("when updating the setting fails", func() {
+							BeforeEach(func() {
+								teamDB.UpdateRequireHijackRecordingReturns(db.SavedTeam{}, errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+
+					Context("when the team requires hijack recording", func() {
+						BeforeEach(func() {
+							savedTeam.RequireHijackRecording = true
+							teamDB.GetTeamReturns(savedTeam, true, nil)
+						})
+
+						Context("and the setting is not passed", func() {
+							It("leaves it as it is", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(teamDB.UpdateRequireHijackRecordingCallCount()).To(BeZero())
+							})
+						})
+
+						Context("and the setting is switched off", func() {
+							BeforeEach(func() {
+								required := false
+								team.RequireHijackRecording = &required
+							})
+
+							It("stops requiring it", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+								Expect(teamDB.UpdateRequireHijackRecordingCallCount()).To(Equal(1))
+								Expect(teamDB.UpdateRequireHijackRecordingArgsForCall(0)).To(BeFalse())
+							})
+						})
+					})
 				})
 			})
 
@@ -890,6 +1133,69 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Context("when changing their own team's quota", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID: 5,
+						Team: db.Team{
+							Name:  "non-admin-team",
+							Quota: db.TeamQuota{MaxConcurrentBuilds: 2},
+						},
+					}, true, nil)
+					userContextReader.GetTeamReturns("non-admin-team", 5, false, true)
+
+					team.Quota = &atc.TeamQuota{}
+				})
+
+				It("returns 403 Forbidden without updating anything", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.UpdateQuotaCallCount()).To(BeZero())
+					Expect(teamDB.UpdateBasicAuthCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when switching off hijack recording for their own team", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID: 5,
+						Team: db.Team{
+							Name:                   "non-admin-team",
+							RequireHijackRecording: true,
+						},
+					}, true, nil)
+					userContextReader.GetTeamReturns("non-admin-team", 5, false, true)
+
+					required := false
+					team.RequireHijackRecording = &required
+				})
+
+				It("returns 403 Forbidden without updating anything", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamDB.UpdateRequireHijackRecordingCallCount()).To(BeZero())
+					Expect(teamDB.UpdateBasicAuthCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when passing their own team's quota unchanged", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{
+						ID: 5,
+						Team: db.Team{
+							Name:  "non-admin-team",
+							Quota: db.TeamQuota{MaxConcurrentBuilds: 2},
+						},
+					}, true, nil)
+					userContextReader.GetTeamReturns("non-admin-team", 5, false, true)
+
+					team.Quota = &atc.TeamQuota{MaxConcurrentBuilds: 2}
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(teamDB.UpdateQuotaCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when updating another team", func() {
 				BeforeEach(func() {
 					userContextReader.GetTeamReturns("another-non-admin-team", 5, false, true)
@@ -939,4 +1245,253 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/export", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/export" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not export the team", func() {
+				Expect(teamDB.ExportTeamCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			Context("when the team exists", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{ID: 42}, true, nil)
+				})
+
+				Context("when exporting succeeds", func() {
+					BeforeEach(func() {
+						teamDB.ExportTeamStub = func(w io.Writer, includeEvents bool) error {
+							enc := json.NewEncoder(w)
+							enc.Encode(atc.TeamExportRecord{
+								Header: &atc.TeamExportHeader{Version: atc.TeamExportVersion, Team: "some-team"},
+							})
+							enc.Encode(atc.TeamExportRecord{
+								Pipeline: &atc.PipelineExport{Name: "some-pipeline", Paused: true},
+							})
+							return enc.Encode(atc.TeamExportRecord{End: true})
+						}
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("exports the requested team", func() {
+						Expect(teamDBFactory.GetTeamDBArgsForCall(teamDBFactory.GetTeamDBCallCount() - 1)).To(Equal("some-team"))
+					})
+
+					It("streams the export", func() {
+						dec := json.NewDecoder(response.Body)
+
+						var header, pipeline, end atc.TeamExportRecord
+						Expect(dec.Decode(&header)).To(Succeed())
+						Expect(dec.Decode(&pipeline)).To(Succeed())
+						Expect(dec.Decode(&end)).To(Succeed())
+
+						Expect(header.Header).To(Equal(&atc.TeamExportHeader{Version: atc.TeamExportVersion, Team: "some-team"}))
+						Expect(pipeline.Pipeline.Name).To(Equal("some-pipeline"))
+						Expect(pipeline.Pipeline.Paused).To(BeTrue())
+						Expect(end.End).To(BeTrue())
+					})
+
+					It("leaves out build events", func() {
+						_, includeEvents := teamDB.ExportTeamArgsForCall(0)
+						Expect(includeEvents).To(BeFalse())
+					})
+
+					Context("when asked for build events", func() {
+						BeforeEach(func() {
+							query = "?events=true"
+						})
+
+						It("includes them", func() {
+							_, includeEvents := teamDB.ExportTeamArgsForCall(0)
+							Expect(includeEvents).To(BeTrue())
+						})
+					})
+				})
+
+				Context("when exporting fails", func() {
+					BeforeEach(func() {
+						teamDB.ExportTeamReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when exporting fails part way", func() {
+					BeforeEach(func() {
+						teamDB.ExportTeamStub = func(w io.Writer, includeEvents bool) error {
+							json.NewEncoder(w).Encode(atc.TeamExportRecord{
+								Header: &atc.TeamExportHeader{Version: atc.TeamExportVersion, Team: "some-team"},
+							})
+							return errors.New("nope")
+						}
+					})
+
+					It("leaves the end record out", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).NotTo(ContainSubstring(`"end"`))
+					})
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetTeamReturns(db.SavedTeam{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/import", func() {
+		var (
+			body     io.Reader
+			archive  []byte
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			body = bytes.NewBufferString("some-archive")
+			archive = nil
+
+			teamDB.ImportTeamStub = func(r io.Reader) (atc.TeamImport, error) {
+				var err error
+				archive, err = ioutil.ReadAll(r)
+				Expect(err).NotTo(HaveOccurred())
+
+				return atc.TeamImport{
+					Imported: []string{"some-pipeline"},
+					Skipped:  []string{"some-existing-pipeline"},
+				}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/import", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not import anything", func() {
+				Expect(teamDB.ImportTeamCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns(atc.DefaultTeamName, 1, true, true)
+			})
+
+			It("streams the archive into the given team", func() {
+				Expect(teamDBFactory.GetTeamDBArgsForCall(teamDBFactory.GetTeamDBCallCount() - 1)).To(Equal("some-team"))
+
+				Expect(teamDB.ImportTeamCallCount()).To(Equal(1))
+				Expect(string(archive)).To(Equal("some-archive"))
+			})
+
+			It("returns 200 OK with the imported and skipped pipelines", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{
+					"imported": ["some-pipeline"],
+					"skipped": ["some-existing-pipeline"]
+				}`))
+			})
+
+			Context("when the archive is malformed", func() {
+				BeforeEach(func() {
+					teamDB.ImportTeamReturns(atc.TeamImport{}, db.ErrMalformedExport{Reason: "export ended early"})
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamDB.ImportTeamReturns(atc.TeamImport{}, db.ErrTeamNotFound)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the export is from an unsupported version", func() {
+				BeforeEach(func() {
+					teamDB.ImportTeamReturns(atc.TeamImport{}, db.ErrUnsupportedExportVersion)
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when importing fails", func() {
+				BeforeEach(func() {
+					teamDB.ImportTeamReturns(atc.TeamImport{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,127 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("list-api-tokens")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedTokens, err := teamDB.GetAPITokens()
+	if err != nil {
+		hLog.Error("failed-to-get-api-tokens", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	tokens := make([]atc.APIToken, len(savedTokens))
+	for i, savedToken := range savedTokens {
+		tokens[i] = present.APIToken(savedToken)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("create-api-token")
+
+	var request atc.APIToken
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if request.Name == "" {
+		http.Error(w, "api token name must be specified", http.StatusBadRequest)
+		return
+	}
+
+	if !request.Scope.IsValid() {
+		http.Error(w, "api token scope must be one of: read-only, trigger-builds, set-pipelines", http.StatusBadRequest)
+		return
+	}
+
+	token := db.APIToken{
+		Name:  request.Name,
+		Scope: request.Scope,
+	}
+
+	if request.ExpiresAt != 0 {
+		token.ExpiresAt = time.Unix(request.ExpiresAt, 0)
+
+		if !token.ExpiresAt.After(time.Now()) {
+			http.Error(w, "api token expiry must be in the future", http.StatusBadRequest)
+			return
+		}
+	}
+
+	value, err := auth.GenerateAPIToken()
+	if err != nil {
+		hLog.Error("failed-to-generate-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+
+	savedToken, err := teamDB.CreateAPIToken(token, auth.HashAPIToken(value))
+	if err != nil {
+		if err == db.ErrAPITokenNameTaken {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		hLog.Error("failed-to-create-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("created", lager.Data{
+		"team":  savedToken.TeamName,
+		"name":  savedToken.Name,
+		"scope": savedToken.Scope,
+	})
+
+	presentedToken := present.APIToken(savedToken)
+	presentedToken.Value = value
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(presentedToken)
+}
+
+func (s *Server) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("revoke-api-token")
+
+	teamDB := s.teamDBFactory.GetTeamDB(r.FormValue(":team_name"))
+	tokenName := r.FormValue(":api_token_name")
+
+	deleted, err := teamDB.DeleteAPIToken(tokenName)
+	if err != nil {
+		hLog.Error("failed-to-delete-api-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	hLog.Info("revoked", lager.Data{"name": tokenName})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package teamserver
+
+import (
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+func (s *Server) ExportTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("export-team", lager.Data{
+		"team": teamName,
+	})
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	_, found, err := teamDB.GetTeam()
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", "attachment; filename="+teamName+".jsonl")
+
+	archive := &trackingWriter{Writer: w}
+
+	err = teamDB.ExportTeam(archive, r.FormValue("events") == "true")
+	if err != nil {
+		hLog.Error("failed-to-export-team", err)
+
+		// once the archive has started streaming the status is already sent;
+		// the missing end record marks the archive as incomplete
+		if !archive.written {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	hLog.Info("exported")
+}
+
+type trackingWriter struct {
+	io.Writer
+	written bool
+}
+
+func (w *trackingWriter) Write(p []byte) (int, error) {
+	w.written = true
+	return w.Writer.Write(p)
+}
@@ -0,0 +1,47 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ImportTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("import-team", lager.Data{
+		"team": teamName,
+	})
+
+	imported, err := s.teamDBFactory.GetTeamDB(teamName).ImportTeam(r.Body)
+	switch err.(type) {
+	case nil:
+	case db.ErrMalformedExport:
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	default:
+		switch err {
+		case db.ErrTeamNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case db.ErrUnsupportedExportVersion:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			hLog.Error("failed-to-import-team", err, lager.Data{"imported": imported.Imported})
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	hLog.Info("imported", lager.Data{
+		"imported": imported.Imported,
+		"skipped":  imported.Skipped,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(imported)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
@@ -26,13 +27,31 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.FormValue(":team_name")
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
 
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		hLog.Error("failed-to-read-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	var team db.Team
-	err := json.NewDecoder(r.Body).Decode(&team)
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		hLog.Error("malformed-request", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// settings that are not in the request are left as they are, rather than
+	// reset to their zero values
+	var given givenTeamSettings
+	err = json.Unmarshal(body, &given)
 	if err != nil {
 		hLog.Error("malformed-request", err)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+
 	team.Name = teamName
 	if !authTeam.IsAdmin() && !authTeam.IsAuthorized(teamName) {
 		w.WriteHeader(http.StatusForbidden)
@@ -54,6 +73,24 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if found {
+		quotaChanged := given.Quota != nil && *given.Quota != savedTeam.Quota
+
+		// a team must not be able to lift its own limits
+		if quotaChanged && !authTeam.IsAdmin() {
+			hLog.Info("non-admin-cannot-change-quota")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		recordingChanged := given.RequireHijackRecording != nil && *given.RequireHijackRecording != savedTeam.RequireHijackRecording
+
+		// nor stop its own hijack sessions from being audited
+		if recordingChanged && !authTeam.IsAdmin() {
+			hLog.Info("non-admin-cannot-change-hijack-recording")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("updating credentials")
 		err = s.updateCredentials(team, teamDB)
 		if err != nil {
@@ -62,6 +99,30 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if quotaChanged {
+			hLog.Debug("updating quota")
+			_, err = teamDB.UpdateQuota(team.Quota)
+			if err != nil {
+				hLog.Error("failed-to-update-team-quota", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			savedTeam.Quota = team.Quota
+		}
+
+		if recordingChanged {
+			hLog.Debug("updating hijack recording")
+			_, err = teamDB.UpdateRequireHijackRecording(team.RequireHijackRecording)
+			if err != nil {
+				hLog.Error("failed-to-update-team-hijack-recording", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			savedTeam.RequireHijackRecording = team.RequireHijackRecording
+		}
+
 		w.WriteHeader(http.StatusOK)
 	} else if authTeam.IsAdmin() {
 		hLog.Debug("creating team")
@@ -81,6 +142,11 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(present.Team(savedTeam))
 }
 
+type givenTeamSettings struct {
+	Quota                  *db.TeamQuota `json:"quota"`
+	RequireHijackRecording *bool         `json:"require_hijack_recording"`
+}
+
 func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 	_, err := teamDB.UpdateBasicAuth(team.BasicAuth)
 	if err != nil {
@@ -102,6 +168,11 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateOIDCAuth(team.OIDCAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,5 +237,27 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	if team.OIDCAuth != nil {
+		if team.OIDCAuth.ClientID == "" || team.OIDCAuth.ClientSecret == "" {
+			return errors.New("OIDC auth missing ClientID or ClientSecret")
+		}
+
+		if team.OIDCAuth.Issuer == "" {
+			return errors.New("OIDC auth requires an Issuer")
+		}
+
+		if team.OIDCAuth.DisplayName == "" {
+			return errors.New("OIDC auth requires a Display Name")
+		}
+
+		if len(team.OIDCAuth.Groups) == 0 && len(team.OIDCAuth.EmailDomains) == 0 {
+			return errors.New("OIDC auth requires at least one Group or Email Domain")
+		}
+	}
+
+	if team.Quota.MaxConcurrentBuilds < 0 || team.Quota.MaxContainers < 0 || team.Quota.MaxVolumeBytes < 0 || team.Quota.BuildQueueWeight < 0 {
+		return errors.New("team quota limits must not be negative")
+	}
+
 	return nil
 }
@@ -8,6 +8,8 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -173,4 +175,358 @@ var _ = Describe("Volumes API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/inspect/volumes", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/inspect/volumes" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not inspect the volumes", func() {
+				Expect(volumesDB.InspectVolumesCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when inspecting the volumes succeeds", func() {
+				BeforeEach(func() {
+					volumesDB.InspectVolumesReturns([]db.InspectedVolume{
+						{
+							SavedVolume: db.SavedVolume{
+								ID:        3,
+								ExpiresIn: 2 * time.Minute,
+								Volume: db.Volume{
+									WorkerName: "some-worker",
+									TeamID:     42,
+									TTL:        10 * time.Minute,
+									Handle:     "some-cow-handle",
+									Identifier: db.VolumeIdentifier{
+										COW: &db.COWIdentifier{
+											ParentVolumeHandle: "some-parent-handle",
+										},
+									},
+									SizeInBytes: 1024,
+								},
+							},
+							TeamName:        "some-team",
+							Owner:           &db.Owner{Type: db.OwnerBuild, ID: 7},
+							ContainerHandle: "some-container-handle",
+							ChildHandles:    []string{"some-child-handle"},
+						},
+						{
+							SavedVolume: db.SavedVolume{
+								ID: 4,
+								Volume: db.Volume{
+									WorkerName: "some-other-worker",
+									Handle:     "some-output-handle",
+									Identifier: db.VolumeIdentifier{
+										Output: &db.OutputIdentifier{
+											Name: "some-output",
+										},
+									},
+									SizeInBytes: 2048,
+								},
+							},
+							TeamName:     "some-team",
+							ChildHandles: []string{},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the volumes with their owners and lineage", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": "some-cow-handle",
+							"ttl_in_seconds": 120,
+							"validity_in_seconds": 600,
+							"worker_name": "some-worker",
+							"type": "copy",
+							"identifier": "some-parent-handle",
+							"size_in_bytes": 1024,
+							"team_name": "some-team",
+							"owner": {"type": "build", "id": 7},
+							"container_handle": "some-container-handle",
+							"original_volume_handle": "some-parent-handle",
+							"child_volume_handles": ["some-child-handle"]
+						},
+						{
+							"id": "some-output-handle",
+							"ttl_in_seconds": 0,
+							"validity_in_seconds": 0,
+							"worker_name": "some-other-worker",
+							"type": "output",
+							"identifier": "some-output",
+							"size_in_bytes": 2048,
+							"team_name": "some-team",
+							"child_volume_handles": []
+						}
+					]`))
+				})
+
+				It("only inspects the team's own volumes", func() {
+					Expect(volumesDB.InspectVolumesCallCount()).To(Equal(1))
+					Expect(volumesDB.InspectVolumesArgsForCall(0)).To(Equal(db.InspectionFilter{
+						TeamName: "some-team",
+					}))
+				})
+
+				Context("when filtering", func() {
+					BeforeEach(func() {
+						query = "?pipeline_name=some-pipeline&job_name=some-job&build_id=7&worker_name=some-worker&type=copy"
+					})
+
+					It("passes the filter along", func() {
+						Expect(volumesDB.InspectVolumesArgsForCall(0)).To(Equal(db.InspectionFilter{
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      7,
+							WorkerName:   "some-worker",
+							Type:         "copy",
+						}))
+					})
+				})
+
+				Context("when filtering by an unknown type", func() {
+					BeforeEach(func() {
+						query = "?type=bogus"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the build ID is malformed", func() {
+					BeforeEach(func() {
+						query = "?build_id=nope"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when asking for a team the user is not a member of", func() {
+					BeforeEach(func() {
+						query = "?team_name=some-other-team"
+					})
+
+					It("returns 403 Forbidden", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not inspect the volumes", func() {
+						Expect(volumesDB.InspectVolumesCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("main", 1, true, true)
+					})
+
+					It("inspects every team's volumes", func() {
+						Expect(volumesDB.InspectVolumesArgsForCall(0)).To(Equal(db.InspectionFilter{}))
+					})
+
+					Context("when asking for a specific team", func() {
+						BeforeEach(func() {
+							query = "?team_name=some-other-team"
+						})
+
+						It("inspects that team's volumes", func() {
+							Expect(volumesDB.InspectVolumesArgsForCall(0)).To(Equal(db.InspectionFilter{
+								TeamName: "some-other-team",
+							}))
+						})
+					})
+				})
+			})
+
+			Context("when inspecting the volumes fails", func() {
+				BeforeEach(func() {
+					volumesDB.InspectVolumesReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/volumes/:handle", func() {
+		var (
+			fakeWorker *workerfakes.FakeWorker
+			response   *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeWorker = new(workerfakes.FakeWorker)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/volumes/some-handle", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not reap the volume", func() {
+				Expect(volumesDB.ReapVolumeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", 1, true, true)
+			})
+
+			Context("when the volume exists", func() {
+				BeforeEach(func() {
+					volumesDB.InspectVolumesReturns([]db.InspectedVolume{
+						{
+							SavedVolume: db.SavedVolume{
+								Volume: db.Volume{
+									WorkerName: "some-worker",
+									Handle:     "some-handle",
+								},
+							},
+							ChildHandles: []string{},
+						},
+					}, nil)
+
+					fakeWorkerClient.GetWorkerReturns(fakeWorker, nil)
+				})
+
+				It("looks up the volume by its handle", func() {
+					Expect(volumesDB.InspectVolumesArgsForCall(0)).To(Equal(db.InspectionFilter{
+						Handle: "some-handle",
+					}))
+				})
+
+				It("expires the volume on its worker", func() {
+					Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+
+					Expect(fakeWorker.ExpireVolumeCallCount()).To(Equal(1))
+					_, handle := fakeWorker.ExpireVolumeArgsForCall(0)
+					Expect(handle).To(Equal("some-handle"))
+				})
+
+				It("reaps the volume", func() {
+					Expect(volumesDB.ReapVolumeCallCount()).To(Equal(1))
+					Expect(volumesDB.ReapVolumeArgsForCall(0)).To(Equal("some-handle"))
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				Context("when the worker is gone", func() {
+					BeforeEach(func() {
+						fakeWorkerClient.GetWorkerReturns(nil, worker.ErrNoWorkers)
+					})
+
+					It("still reaps the volume", func() {
+						Expect(volumesDB.ReapVolumeCallCount()).To(Equal(1))
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+
+				Context("when expiring the volume fails", func() {
+					BeforeEach(func() {
+						fakeWorker.ExpireVolumeReturns(errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not reap the volume", func() {
+						Expect(volumesDB.ReapVolumeCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the volume has copy-on-write children", func() {
+					BeforeEach(func() {
+						volumesDB.InspectVolumesReturns([]db.InspectedVolume{
+							{
+								SavedVolume: db.SavedVolume{
+									Volume: db.Volume{
+										WorkerName: "some-worker",
+										Handle:     "some-handle",
+									},
+								},
+								ChildHandles: []string{"some-child-handle"},
+							},
+						}, nil)
+					})
+
+					It("returns 409 Conflict", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("leaves the volume alone", func() {
+						Expect(fakeWorker.ExpireVolumeCallCount()).To(BeZero())
+						Expect(volumesDB.ReapVolumeCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the volume does not exist", func() {
+				BeforeEach(func() {
+					volumesDB.InspectVolumesReturns([]db.InspectedVolume{}, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,68 @@
+package volumeserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+)
+
+func (s *Server) DestroyVolume(w http.ResponseWriter, r *http.Request) {
+	handle := r.FormValue(":handle")
+
+	hLog := s.logger.Session("destroy-volume", lager.Data{
+		"handle": handle,
+	})
+
+	volumes, err := s.db.InspectVolumes(db.InspectionFilter{Handle: handle})
+	if err != nil {
+		hLog.Error("failed-to-lookup-volume", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(volumes) == 0 {
+		hLog.Debug("volume-not-found")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	volume := volumes[0]
+
+	// copy-on-write children depend on the volume, so they have to go first
+	if len(volume.ChildHandles) > 0 {
+		hLog.Info("volume-has-children", lager.Data{"children": volume.ChildHandles})
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	volumeWorker, err := s.workerClient.GetWorker(volume.WorkerName)
+	switch err {
+	case nil:
+		err = volumeWorker.ExpireVolume(hLog, handle)
+		if err != nil {
+			hLog.Error("failed-to-expire-volume", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	case worker.ErrNoWorkers:
+		// the volume went away with its worker
+		hLog.Info("worker-not-found", lager.Data{"worker": volume.WorkerName})
+	default:
+		hLog.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	err = s.db.ReapVolume(handle)
+	if err != nil {
+		hLog.Error("failed-to-reap-volume", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Info("destroyed")
+
+	w.WriteHeader(http.StatusNoContent)
+}
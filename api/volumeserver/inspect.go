@@ -0,0 +1,53 @@
+package volumeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/inspection"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) InspectVolumes(w http.ResponseWriter, r *http.Request) {
+	hLog := s.logger.Session("inspect-volumes", lager.Data{
+		"params": r.URL.RawQuery,
+	})
+
+	filter, err := inspection.Filter(r)
+	if err == inspection.ErrNotAuthorizedForTeam {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-parse-request", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if filter.Type != "" && !db.ValidVolumeType(filter.Type) {
+		http.Error(w, fmt.Sprintf("unknown volume type: %s", filter.Type), http.StatusBadRequest)
+		return
+	}
+
+	volumes, err := s.db.InspectVolumes(filter)
+	if err != nil {
+		hLog.Error("failed-to-inspect-volumes", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	hLog.Debug("inspected", lager.Data{"volume-count": len(volumes)})
+
+	presentedVolumes := make([]atc.VolumeDetails, len(volumes))
+	for i, volume := range volumes {
+		presentedVolumes[i] = present.VolumeDetails(volume)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(presentedVolumes)
+}
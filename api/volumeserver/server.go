@@ -3,11 +3,14 @@ package volumeserver
 import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
 )
 
 type Server struct {
 	logger lager.Logger
 
+	workerClient worker.Client
+
 	db            VolumesDB
 	teamDBFactory db.TeamDBFactory
 }
@@ -16,15 +19,19 @@ type Server struct {
 
 type VolumesDB interface {
 	GetVolumes() ([]db.SavedVolume, error)
+	InspectVolumes(filter db.InspectionFilter) ([]db.InspectedVolume, error)
+	ReapVolume(handle string) error
 }
 
 func NewServer(
 	logger lager.Logger,
+	workerClient worker.Client,
 	db VolumesDB,
 	teamDBFactory db.TeamDBFactory,
 ) *Server {
 	return &Server{
 		logger:        logger,
+		workerClient:  workerClient,
 		db:            db,
 		teamDBFactory: teamDBFactory,
 	}
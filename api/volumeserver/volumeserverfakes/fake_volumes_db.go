@@ -16,6 +16,23 @@ type FakeVolumesDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	InspectVolumesStub        func(filter db.InspectionFilter) ([]db.InspectedVolume, error)
+	inspectVolumesMutex       sync.RWMutex
+	inspectVolumesArgsForCall []struct {
+		filter db.InspectionFilter
+	}
+	inspectVolumesReturns struct {
+		result1 []db.InspectedVolume
+		result2 error
+	}
+	ReapVolumeStub        func(handle string) error
+	reapVolumeMutex       sync.RWMutex
+	reapVolumeArgsForCall []struct {
+		handle string
+	}
+	reapVolumeReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -46,11 +63,82 @@ func (fake *FakeVolumesDB) GetVolumesReturns(result1 []db.SavedVolume, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeVolumesDB) InspectVolumes(filter db.InspectionFilter) ([]db.InspectedVolume, error) {
+	fake.inspectVolumesMutex.Lock()
+	fake.inspectVolumesArgsForCall = append(fake.inspectVolumesArgsForCall, struct {
+		filter db.InspectionFilter
+	}{filter})
+	fake.recordInvocation("InspectVolumes", []interface{}{filter})
+	fake.inspectVolumesMutex.Unlock()
+	if fake.InspectVolumesStub != nil {
+		return fake.InspectVolumesStub(filter)
+	} else {
+		return fake.inspectVolumesReturns.result1, fake.inspectVolumesReturns.result2
+	}
+}
+
+func (fake *FakeVolumesDB) InspectVolumesCallCount() int {
+	fake.inspectVolumesMutex.RLock()
+	defer fake.inspectVolumesMutex.RUnlock()
+	return len(fake.inspectVolumesArgsForCall)
+}
+
+func (fake *FakeVolumesDB) InspectVolumesArgsForCall(i int) db.InspectionFilter {
+	fake.inspectVolumesMutex.RLock()
+	defer fake.inspectVolumesMutex.RUnlock()
+	return fake.inspectVolumesArgsForCall[i].filter
+}
+
+func (fake *FakeVolumesDB) InspectVolumesReturns(result1 []db.InspectedVolume, result2 error) {
+	fake.InspectVolumesStub = nil
+	fake.inspectVolumesReturns = struct {
+		result1 []db.InspectedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumesDB) ReapVolume(handle string) error {
+	fake.reapVolumeMutex.Lock()
+	fake.reapVolumeArgsForCall = append(fake.reapVolumeArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("ReapVolume", []interface{}{handle})
+	fake.reapVolumeMutex.Unlock()
+	if fake.ReapVolumeStub != nil {
+		return fake.ReapVolumeStub(handle)
+	} else {
+		return fake.reapVolumeReturns.result1
+	}
+}
+
+func (fake *FakeVolumesDB) ReapVolumeCallCount() int {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return len(fake.reapVolumeArgsForCall)
+}
+
+func (fake *FakeVolumesDB) ReapVolumeArgsForCall(i int) string {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return fake.reapVolumeArgsForCall[i].handle
+}
+
+func (fake *FakeVolumesDB) ReapVolumeReturns(result1 error) {
+	fake.ReapVolumeStub = nil
+	fake.reapVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolumesDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.inspectVolumesMutex.RLock()
+	defer fake.inspectVolumesMutex.RUnlock()
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -6,9 +6,12 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/gorilla/websocket"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -50,11 +53,16 @@ var _ = Describe("Workers API", func() {
 								HTTPSProxyURL:    "https://some-proxy.com",
 								NoProxy:          "no,proxy",
 								ActiveContainers: 1,
+								ActiveVolumes:    3,
+								CPUPressure:      0.5,
+								MemoryPressure:   0.25,
+								DiskPressure:     0.75,
 								ResourceTypes: []atc.WorkerResourceType{
 									{Type: "some-resource", Image: "some-resource-image"},
 								},
 								Platform: "freebsd",
 								Tags:     []string{"demon"},
+								State:    db.WorkerStateStalled,
 							},
 						},
 						{
@@ -88,11 +96,16 @@ var _ = Describe("Workers API", func() {
 							HTTPSProxyURL:    "https://some-proxy.com",
 							NoProxy:          "no,proxy",
 							ActiveContainers: 1,
+							ActiveVolumes:    3,
+							CPUPressure:      0.5,
+							MemoryPressure:   0.25,
+							DiskPressure:     0.75,
 							ResourceTypes: []atc.WorkerResourceType{
 								{Type: "some-resource", Image: "some-resource-image"},
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
+							State:    "stalled",
 						},
 						{
 							GardenAddr:       "1.2.3.4:8888",
@@ -371,4 +384,171 @@ var _ = Describe("Workers API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/workers/heartbeat", func() {
+		var (
+			registration atc.Worker
+
+			conn     *websocket.Conn
+			response *http.Response
+
+			expectBadHandshake bool
+		)
+
+		BeforeEach(func() {
+			expectBadHandshake = false
+
+			registration = atc.Worker{
+				Name:             "worker-name",
+				GardenAddr:       "1.2.3.4:7777",
+				BaggageclaimURL:  "5.6.7.8:7788",
+				ActiveContainers: 2,
+				ActiveVolumes:    5,
+				CPUPressure:      0.25,
+				Platform:         "haiku",
+			}
+
+			authValidator.IsAuthenticatedReturns(true)
+			userContextReader.GetSystemReturns(true, true)
+
+			workerDB.HeartbeatWorkerReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			wsURL, err := url.Parse(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+
+			wsURL.Scheme = "ws"
+			wsURL.Path = "/api/v1/workers/heartbeat"
+			wsURL.RawQuery = "ttl=30s"
+
+			dialer := websocket.Dialer{}
+			conn, response, err = dialer.Dial(wsURL.String(), nil)
+			if !expectBadHandshake {
+				Expect(err).NotTo(HaveOccurred())
+
+				err = conn.WriteJSON(registration)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			if !expectBadHandshake {
+				conn.Close()
+			}
+		})
+
+		It("registers the worker and records its initial load", func() {
+			Eventually(workerDB.HeartbeatWorkerCallCount).Should(Equal(1))
+
+			Expect(workerDB.SaveWorkerCallCount()).To(Equal(1))
+			savedInfo, savedTTL := workerDB.SaveWorkerArgsForCall(0)
+			Expect(savedInfo.Name).To(Equal("worker-name"))
+			Expect(savedInfo.ActiveVolumes).To(Equal(5))
+			Expect(savedTTL).To(Equal(30 * time.Second))
+
+			name, _, load, ttl := workerDB.HeartbeatWorkerArgsForCall(0)
+			Expect(name).To(Equal("worker-name"))
+			Expect(load).To(Equal(atc.WorkerLoad{
+				ActiveContainers: 2,
+				ActiveVolumes:    5,
+				CPUPressure:      0.25,
+			}))
+			Expect(ttl).To(Equal(30 * time.Second))
+		})
+
+		It("records each load that the worker reports", func() {
+			Eventually(workerDB.HeartbeatWorkerCallCount).Should(Equal(1))
+
+			err := conn.WriteJSON(atc.WorkerLoad{
+				ActiveContainers: 3,
+				MemoryPressure:   0.5,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(workerDB.HeartbeatWorkerCallCount).Should(Equal(2))
+
+			_, firstStreamID, _, _ := workerDB.HeartbeatWorkerArgsForCall(0)
+			name, streamID, load, _ := workerDB.HeartbeatWorkerArgsForCall(1)
+			Expect(name).To(Equal("worker-name"))
+			Expect(streamID).To(Equal(firstStreamID))
+			Expect(load).To(Equal(atc.WorkerLoad{
+				ActiveContainers: 3,
+				MemoryPressure:   0.5,
+			}))
+		})
+
+		It("stalls the worker as soon as the stream drops", func() {
+			Eventually(workerDB.HeartbeatWorkerCallCount).Should(Equal(1))
+
+			conn.Close()
+
+			Eventually(workerDB.StallWorkerCallCount).Should(Equal(1))
+
+			_, heartbeatStreamID, _, _ := workerDB.HeartbeatWorkerArgsForCall(0)
+			name, streamID := workerDB.StallWorkerArgsForCall(0)
+			Expect(name).To(Equal("worker-name"))
+			Expect(streamID).To(Equal(heartbeatStreamID))
+		})
+
+		Context("when the worker is no longer registered", func() {
+			BeforeEach(func() {
+				workerDB.HeartbeatWorkerReturns(false, nil)
+			})
+
+			It("closes the stream so that the worker registers again", func() {
+				_, _, err := conn.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+
+				Expect(workerDB.StallWorkerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the registration has no address", func() {
+			BeforeEach(func() {
+				registration.GardenAddr = ""
+			})
+
+			It("closes the stream with an error", func() {
+				_, _, err := conn.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.ClosePolicyViolation)).To(BeTrue())
+				Expect(err).To(MatchError(ContainSubstring("missing address")))
+
+				Expect(workerDB.SaveWorkerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when saving the worker fails", func() {
+			BeforeEach(func() {
+				workerDB.SaveWorkerReturns(db.SavedWorker{}, errors.New("oh no!"))
+			})
+
+			It("closes the stream with an error", func() {
+				_, _, err := conn.ReadMessage()
+				Expect(websocket.IsCloseError(err, websocket.CloseInternalServerErr)).To(BeTrue())
+			})
+		})
+
+		Context("when the request is not from the tsa", func() {
+			BeforeEach(func() {
+				expectBadHandshake = true
+				userContextReader.GetSystemReturns(false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				expectBadHandshake = true
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,157 @@
+package workerserver
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/metric"
+	"github.com/gorilla/websocket"
+	"github.com/nu7hatch/gouuid"
+)
+
+const defaultHeartbeatTTL = 30 * time.Second
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: 5 * time.Second,
+}
+
+// HeartbeatWorker registers a worker over a websocket that the worker keeps
+// open, sending its registration first and then its load on every tick. The
+// worker is marked as stalled as soon as the connection drops, or if it goes
+// quiet for longer than its TTL.
+func (s *Server) HeartbeatWorker(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("heartbeat-worker")
+
+	isSystem, present := r.Context().Value("system").(bool)
+	if !present || !isSystem {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	ttl := defaultHeartbeatTTL
+
+	ttlStr := r.URL.Query().Get("ttl")
+	if len(ttlStr) > 0 {
+		var err error
+		ttl, err = time.ParseDuration(ttlStr)
+		if err != nil || ttl <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "malformed ttl")
+			return
+		}
+	}
+
+	streamID, err := uuid.NewV4()
+	if err != nil {
+		logger.Error("failed-to-generate-stream-id", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Error("unable-to-upgrade-connection-for-websockets", err)
+		return
+	}
+
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(ttl))
+
+	var registration atc.Worker
+	err = conn.ReadJSON(&registration)
+	if err != nil {
+		logger.Error("malformed-registration", err)
+		closeWithErr(logger, conn, websocket.CloseUnsupportedData, "malformed registration")
+		return
+	}
+
+	if len(registration.GardenAddr) == 0 {
+		closeWithErr(logger, conn, websocket.ClosePolicyViolation, "missing address")
+		return
+	}
+
+	workerInfo, err := s.workerInfo(logger, registration)
+	if err != nil {
+		if err == errTeamNotFound {
+			closeWithErr(logger, conn, websocket.ClosePolicyViolation, "team not found")
+		} else {
+			closeWithErr(logger, conn, websocket.CloseInternalServerErr, "failed to register worker")
+		}
+
+		return
+	}
+
+	logger = logger.WithData(lager.Data{"worker": workerInfo.Name})
+
+	_, err = s.db.SaveWorker(workerInfo, ttl)
+	if err != nil {
+		logger.Error("failed-to-save-worker", err)
+		closeWithErr(logger, conn, websocket.CloseInternalServerErr, "failed to register worker")
+		return
+	}
+
+	load := atc.WorkerLoad{
+		ActiveContainers: workerInfo.ActiveContainers,
+		ActiveVolumes:    workerInfo.ActiveVolumes,
+		CPUPressure:      workerInfo.CPUPressure,
+		MemoryPressure:   workerInfo.MemoryPressure,
+		DiskPressure:     workerInfo.DiskPressure,
+	}
+
+	for {
+		metric.WorkerContainers{
+			WorkerName: workerInfo.Name,
+			Containers: load.ActiveContainers,
+		}.Emit(s.logger)
+
+		found, err := s.db.HeartbeatWorker(workerInfo.Name, streamID.String(), load, ttl)
+		if err != nil {
+			logger.Error("failed-to-heartbeat-worker", err)
+			closeWithErr(logger, conn, websocket.CloseInternalServerErr, "failed to heartbeat worker")
+			s.stallWorker(logger, workerInfo.Name, streamID.String())
+			return
+		}
+
+		if !found {
+			logger.Info("worker-no-longer-registered")
+			closeWithErr(logger, conn, websocket.CloseNormalClosure, "worker is no longer registered")
+			return
+		}
+
+		conn.SetReadDeadline(time.Now().Add(ttl))
+
+		load = atc.WorkerLoad{}
+		err = conn.ReadJSON(&load)
+		if err != nil {
+			logger.Info("stream-dropped", lager.Data{"error": err.Error()})
+			s.stallWorker(logger, workerInfo.Name, streamID.String())
+			return
+		}
+	}
+}
+
+func (s *Server) stallWorker(logger lager.Logger, workerName string, streamID string) {
+	err := s.db.StallWorker(workerName, streamID)
+	if err != nil {
+		logger.Error("failed-to-stall-worker", err)
+		return
+	}
+
+	logger.Info("stalled")
+}
+
+func closeWithErr(logger lager.Logger, conn *websocket.Conn, code int, reason string) {
+	err := conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Time{},
+	)
+
+	if err != nil {
+		logger.Error("failed-to-close-websocket-connection", err)
+	}
+}
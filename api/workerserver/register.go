@@ -37,26 +37,20 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var teamID int
-	if registration.Team != "" {
-		team, found, err := s.teamDBFactory.GetTeamDB(registration.Team).GetTeam()
-		if err != nil {
-			logger.Error("failed-to-get-team", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	if len(registration.GardenAddr) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "missing address")
+		return
+	}
 
-		if !found {
-			logger.Error("team-not-found", errors.New("team-not-found"), lager.Data{"team-name": registration.Team})
+	workerInfo, err := s.workerInfo(logger, registration)
+	if err != nil {
+		if err == errTeamNotFound {
 			w.WriteHeader(http.StatusBadRequest)
-			return
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
 		}
-		teamID = team.ID
-	}
 
-	if len(registration.GardenAddr) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
-		fmt.Fprintf(w, "missing address")
 		return
 	}
 
@@ -72,34 +66,60 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	metric.WorkerContainers{
+		WorkerName: workerInfo.Name,
+		Containers: workerInfo.ActiveContainers,
+	}.Emit(s.logger)
+
+	_, err = s.db.SaveWorker(workerInfo, ttl)
+	if err != nil {
+		logger.Error("failed-to-save-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+var errTeamNotFound = errors.New("team not found")
+
+func (s *Server) workerInfo(logger lager.Logger, registration atc.Worker) (db.WorkerInfo, error) {
+	var teamID int
+	if registration.Team != "" {
+		team, found, err := s.teamDBFactory.GetTeamDB(registration.Team).GetTeam()
+		if err != nil {
+			logger.Error("failed-to-get-team", err)
+			return db.WorkerInfo{}, err
+		}
+
+		if !found {
+			logger.Error("team-not-found", errors.New("team-not-found"), lager.Data{"team-name": registration.Team})
+			return db.WorkerInfo{}, errTeamNotFound
+		}
+
+		teamID = team.ID
+	}
+
 	if registration.Name == "" {
 		registration.Name = registration.GardenAddr
 	}
 
-	metric.WorkerContainers{
-		WorkerName: registration.Name,
-		Containers: registration.ActiveContainers,
-	}.Emit(s.logger)
-
-	_, err = s.db.SaveWorker(db.WorkerInfo{
+	return db.WorkerInfo{
 		GardenAddr:       registration.GardenAddr,
 		BaggageclaimURL:  registration.BaggageclaimURL,
 		HTTPProxyURL:     registration.HTTPProxyURL,
 		HTTPSProxyURL:    registration.HTTPSProxyURL,
 		NoProxy:          registration.NoProxy,
 		ActiveContainers: registration.ActiveContainers,
+		ActiveVolumes:    registration.ActiveVolumes,
+		CPUPressure:      registration.CPUPressure,
+		MemoryPressure:   registration.MemoryPressure,
+		DiskPressure:     registration.DiskPressure,
 		ResourceTypes:    registration.ResourceTypes,
 		Platform:         registration.Platform,
 		Tags:             registration.Tags,
 		TeamID:           teamID,
 		Name:             registration.Name,
 		StartTime:        registration.StartTime,
-	}, ttl)
-	if err != nil {
-		logger.Error("failed-to-save-worker", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
+	}, nil
 }
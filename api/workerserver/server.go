@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -18,6 +19,8 @@ type Server struct {
 
 type WorkerDB interface {
 	SaveWorker(db.WorkerInfo, time.Duration) (db.SavedWorker, error)
+	HeartbeatWorker(name string, streamID string, load atc.WorkerLoad, ttl time.Duration) (bool, error)
+	StallWorker(name string, streamID string) error
 	Workers() ([]db.SavedWorker, error)
 }
 
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/db"
 )
@@ -27,6 +28,27 @@ type FakeWorkerDB struct {
 		result1 []db.SavedWorker
 		result2 error
 	}
+	HeartbeatWorkerStub        func(name string, streamID string, load atc.WorkerLoad, ttl time.Duration) (bool, error)
+	heartbeatWorkerMutex       sync.RWMutex
+	heartbeatWorkerArgsForCall []struct {
+		name     string
+		streamID string
+		load     atc.WorkerLoad
+		ttl      time.Duration
+	}
+	heartbeatWorkerReturns struct {
+		result1 bool
+		result2 error
+	}
+	StallWorkerStub        func(name string, streamID string) error
+	stallWorkerMutex       sync.RWMutex
+	stallWorkerArgsForCall []struct {
+		name     string
+		streamID string
+	}
+	stallWorkerReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +114,77 @@ func (fake *FakeWorkerDB) WorkersReturns(result1 []db.SavedWorker, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerDB) HeartbeatWorker(name string, streamID string, load atc.WorkerLoad, ttl time.Duration) (bool, error) {
+	fake.heartbeatWorkerMutex.Lock()
+	fake.heartbeatWorkerArgsForCall = append(fake.heartbeatWorkerArgsForCall, struct {
+		name     string
+		streamID string
+		load     atc.WorkerLoad
+		ttl      time.Duration
+	}{name, streamID, load, ttl})
+	fake.recordInvocation("HeartbeatWorker", []interface{}{name, streamID, load, ttl})
+	fake.heartbeatWorkerMutex.Unlock()
+	if fake.HeartbeatWorkerStub != nil {
+		return fake.HeartbeatWorkerStub(name, streamID, load, ttl)
+	} else {
+		return fake.heartbeatWorkerReturns.result1, fake.heartbeatWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) HeartbeatWorkerCallCount() int {
+	fake.heartbeatWorkerMutex.RLock()
+	defer fake.heartbeatWorkerMutex.RUnlock()
+	return len(fake.heartbeatWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) HeartbeatWorkerArgsForCall(i int) (string, string, atc.WorkerLoad, time.Duration) {
+	fake.heartbeatWorkerMutex.RLock()
+	defer fake.heartbeatWorkerMutex.RUnlock()
+	return fake.heartbeatWorkerArgsForCall[i].name, fake.heartbeatWorkerArgsForCall[i].streamID, fake.heartbeatWorkerArgsForCall[i].load, fake.heartbeatWorkerArgsForCall[i].ttl
+}
+
+func (fake *FakeWorkerDB) HeartbeatWorkerReturns(result1 bool, result2 error) {
+	fake.HeartbeatWorkerStub = nil
+	fake.heartbeatWorkerReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerDB) StallWorker(name string, streamID string) error {
+	fake.stallWorkerMutex.Lock()
+	fake.stallWorkerArgsForCall = append(fake.stallWorkerArgsForCall, struct {
+		name     string
+		streamID string
+	}{name, streamID})
+	fake.recordInvocation("StallWorker", []interface{}{name, streamID})
+	fake.stallWorkerMutex.Unlock()
+	if fake.StallWorkerStub != nil {
+		return fake.StallWorkerStub(name, streamID)
+	} else {
+		return fake.stallWorkerReturns.result1
+	}
+}
+
+func (fake *FakeWorkerDB) StallWorkerCallCount() int {
+	fake.stallWorkerMutex.RLock()
+	defer fake.stallWorkerMutex.RUnlock()
+	return len(fake.stallWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) StallWorkerArgsForCall(i int) (string, string) {
+	fake.stallWorkerMutex.RLock()
+	defer fake.stallWorkerMutex.RUnlock()
+	return fake.stallWorkerArgsForCall[i].name, fake.stallWorkerArgsForCall[i].streamID
+}
+
+func (fake *FakeWorkerDB) StallWorkerReturns(result1 error) {
+	fake.StallWorkerStub = nil
+	fake.stallWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +192,10 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
+	fake.heartbeatWorkerMutex.RLock()
+	defer fake.heartbeatWorkerMutex.RUnlock()
+	fake.stallWorkerMutex.RLock()
+	defer fake.stallWorkerMutex.RUnlock()
 	return fake.invocations
 }
 
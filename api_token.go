@@ -0,0 +1,48 @@
+package atc
+
+// APITokenScope limits what an API token may be used for. Scopes are
+// ordered; each one also grants everything the scopes before it do.
+type APITokenScope string
+
+const (
+	APITokenScopeReadOnly      APITokenScope = "read-only"
+	APITokenScopeTriggerBuilds APITokenScope = "trigger-builds"
+	APITokenScopeSetPipelines  APITokenScope = "set-pipelines"
+)
+
+var apiTokenScopeLevels = map[APITokenScope]int{
+	APITokenScopeReadOnly:      1,
+	APITokenScopeTriggerBuilds: 2,
+	APITokenScopeSetPipelines:  3,
+}
+
+func (scope APITokenScope) IsValid() bool {
+	_, found := apiTokenScopeLevels[scope]
+	return found
+}
+
+// Allows returns whether a token with this scope may be used for something
+// requiring the given scope.
+func (scope APITokenScope) Allows(required APITokenScope) bool {
+	have, found := apiTokenScopeLevels[scope]
+	if !found {
+		return false
+	}
+
+	want, found := apiTokenScopeLevels[required]
+	if !found {
+		return false
+	}
+
+	return have >= want
+}
+
+type APIToken struct {
+	Name      string        `json:"name"`
+	Scope     APITokenScope `json:"scope"`
+	CreatedAt int64         `json:"created_at,omitempty"`
+	ExpiresAt int64         `json:"expires_at,omitempty"`
+
+	// Value is only ever returned when the token is created.
+	Value string `json:"value,omitempty"`
+}
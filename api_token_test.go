@@ -0,0 +1,24 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenScope", func() {
+	DescribeTable("Allows",
+		func(scope atc.APITokenScope, required atc.APITokenScope, allowed bool) {
+			Expect(scope.Allows(required)).To(Equal(allowed))
+		},
+		Entry("read-only allows read-only", atc.APITokenScopeReadOnly, atc.APITokenScopeReadOnly, true),
+		Entry("read-only does not allow trigger-builds", atc.APITokenScopeReadOnly, atc.APITokenScopeTriggerBuilds, false),
+		Entry("trigger-builds allows read-only", atc.APITokenScopeTriggerBuilds, atc.APITokenScopeReadOnly, true),
+		Entry("trigger-builds does not allow set-pipelines", atc.APITokenScopeTriggerBuilds, atc.APITokenScopeSetPipelines, false),
+		Entry("set-pipelines allows trigger-builds", atc.APITokenScopeSetPipelines, atc.APITokenScopeTriggerBuilds, true),
+		Entry("nothing allows an empty scope", atc.APITokenScopeSetPipelines, atc.APITokenScope(""), false),
+		Entry("an unknown scope allows nothing", atc.APITokenScope("bogus"), atc.APITokenScopeReadOnly, false),
+	)
+})
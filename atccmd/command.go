@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"database/sql"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -28,9 +29,8 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc/buildreaper"
-	"github.com/concourse/atc/gc/containerkeepaliver"
 	"github.com/concourse/atc/gc/dbgc"
-	"github.com/concourse/atc/gc/lostandfound"
+	"github.com/concourse/atc/gc/ownergc"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
@@ -48,6 +48,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/jackc/pgx"
 	"github.com/lib/pq"
+	"github.com/nu7hatch/gouuid"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
@@ -55,6 +56,15 @@ import (
 	"github.com/xoebus/zest"
 )
 
+const (
+	atcHeartbeatInterval = 10 * time.Second
+	atcHeartbeatTTL      = 30 * time.Second
+
+	replicaCheckInterval = 5 * time.Second
+
+	connectionCountingDriverName = "connection-counting"
+)
+
 type ATCCommand struct {
 	BindIP   IPFlag `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16 `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -72,15 +82,29 @@ type ATCCommand struct {
 
 	PostgresDataSource string `long:"postgres-data-source" default:"postgres://127.0.0.1:5432/atc?sslmode=disable" description:"PostgreSQL connection string."`
 
+	PostgresReplicaDataSource string        `long:"postgres-replica-data-source" description:"PostgreSQL connection string for a read replica. Reads that may be slightly out of date, such as listing pipelines and builds, are sent to it."`
+	PostgresReplicaMaxLag     time.Duration `long:"postgres-replica-max-lag" default:"5s" description:"How far behind the read replica may fall before reads are sent back to the primary."`
+
 	DebugBindIP   IPFlag `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16 `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
 
 	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
 
+	PreviousSessionSigningKeys []FileFlag `long:"previous-session-signing-key" description:"File containing an RSA key previously used to sign session tokens. Tokens it signed remain valid until they expire. Can be specified multiple times."`
+
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
+	FinishedBuildContainerGracePeriod time.Duration `long:"finished-build-container-grace-period" default:"5m" description:"How long to keep the containers of a finished build around, e.g. for hijacking. The containers of a job's latest build are kept until the next build finishes if it failed."`
+	WorkerReconcileInterval           time.Duration `long:"worker-reconcile-interval" default:"1m" description:"Interval on which to destroy containers and volumes on workers that are no longer known."`
+
+	ResourcePrefetchWorkers int `long:"resource-prefetch-workers" default:"0" description:"Number of workers to fetch new versions of triggering resources onto as soon as they are found. 0 disables prefetching."`
+
+	MaxBuildsPerWorker int `long:"max-builds-per-worker" default:"0" description:"Number of builds each worker can run at once before builds wait in the build queue. 0 means unlimited."`
+
+	RecordHijackSessions bool `long:"record-hijack-sessions" description:"Record the input and output of every hijacked process. Teams can also require recording for their own containers."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -104,6 +128,8 @@ type ATCCommand struct {
 
 	GenericOAuth atc.GenericOAuthFlag `group:"Generic OAuth Authentication (Allows access to ALL authenticated users)" namespace:"generic-oauth"`
 
+	OIDCAuth atc.OIDCAuthFlag `group:"OpenID Connect Authentication" namespace:"oidc-auth"`
+
 	Metrics struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Tags       []string          `long:"metrics-tag"         description:"Tag to attach to emitted metrics. Can be specified multiple times." value-name:"TAG"`
@@ -151,6 +177,25 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	var replicatedConn *db.ReplicatedConn
+	if cmd.PostgresReplicaDataSource != "" {
+		replicaConn, err := cmd.constructReplicaConn(logger)
+		if err != nil {
+			return nil, err
+		}
+
+		replicatedConn = db.NewReplicatedConn(
+			logger.Session("replica"),
+			dbConn,
+			replicaConn,
+			cmd.PostgresReplicaMaxLag,
+			replicaCheckInterval,
+			clock.NewClock(),
+		)
+
+		dbConn = replicatedConn
+	}
+
 	lockConn, err := cmd.constructLockConn()
 	if err != nil {
 		return nil, err
@@ -161,6 +206,19 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	bus := db.NewNotificationsBus(listener, dbConn)
 
 	sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+
+	atcID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	// join the cluster up front so that the first sync claims this ATC's
+	// share of the pipelines
+	err = sqlDB.HeartbeatATC(atcID.String(), cmd.PeerURL.String(), atcHeartbeatTTL)
+	if err != nil {
+		return nil, err
+	}
+
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
 	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
@@ -171,14 +229,19 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
 	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
 
+	prefetcher := resource.NewPrefetcher(workerClient, sqlDB, cmd.ResourcePrefetchWorkers)
+
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
+		prefetcher,
 		cmd.ResourceCheckingInterval,
 		engine,
+		cmd.MaxBuildsPerWorker,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
+		prefetcher,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
 	)
@@ -188,6 +251,11 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	verificationKeys, err := cmd.loadVerificationKeys(signingKey)
+	if err != nil {
+		return nil, err
+	}
+
 	err = sqlDB.CreateDefaultTeamIfNotExists()
 	if err != nil {
 		return nil, err
@@ -217,6 +285,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		teamDBFactory,
 		providerFactory,
 		signingKey,
+		verificationKeys,
 		pipelineDBFactory,
 		engine,
 		workerClient,
@@ -233,7 +302,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		logger,
 		providerFactory,
 		teamDBFactory,
+		sqlDB,
 		signingKey,
+		verificationKeys,
+		sqlDB,
 		cmd.AuthDuration,
 	)
 	if err != nil {
@@ -301,17 +373,30 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			http.DefaultServeMux,
 		)},
 
+		{"atc-heartbeater", pipelines.NewATCHeartbeater(
+			logger.Session("atc-heartbeater"),
+			sqlDB,
+			clock.NewClock(),
+			atcID.String(),
+			cmd.PeerURL.String(),
+			atcHeartbeatInterval,
+			atcHeartbeatTTL,
+		)},
+
 		{"pipelines", pipelines.SyncRunner{
 			Syncer: cmd.constructPipelineSyncer(
 				logger.Session("syncer"),
 				sqlDB,
 				pipelineDBFactory,
 				radarSchedulerFactory,
+				atcID.String(),
 			),
 			Interval: 10 * time.Second,
 			Clock:    clock.NewClock(),
 		}},
 
+		{"prefetcher", prefetcher},
+
 		{"builds", builds.TrackerRunner{
 			Tracker: builds.NewTracker(
 				logger.Session("build-tracker"),
@@ -322,35 +407,6 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			Clock:    clock.NewClock(),
 		}},
 
-		{"lostandfound", lockrunner.NewRunner(
-			logger.Session("lost-and-found"),
-			lostandfound.NewBaggageCollector(
-				logger.Session("baggage-collector"),
-				workerClient,
-				sqlDB,
-				pipelineDBFactory,
-				cmd.OldResourceGracePeriod,
-				24*time.Hour,
-			),
-			"baggage-collector",
-			sqlDB,
-			clock.NewClock(),
-			cmd.ResourceCacheCleanupInterval,
-		)},
-
-		{"containerkeepaliver", lockrunner.NewRunner(
-			logger.Session("container-keepaliver"),
-			containerkeepaliver.NewContainerKeepAliver(
-				logger.Session("container-keepaliver"),
-				workerClient,
-				sqlDB,
-			),
-			"container-keepaliver",
-			sqlDB,
-			clock.NewClock(),
-			30*time.Second,
-		)},
-
 		{"buildreaper", lockrunner.NewRunner(
 			logger.Session("build-reaper-runner"),
 			buildreaper.NewBuildReaper(
@@ -376,6 +432,34 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			60*time.Second,
 		)},
+
+		{"ownergc", lockrunner.NewRunner(
+			logger.Session("owner-gc-runner"),
+			ownergc.NewOwnerGarbageCollector(
+				logger.Session("owner-gc"),
+				sqlDB,
+				pipelineDBFactory,
+				cmd.FinishedBuildContainerGracePeriod,
+				cmd.OldResourceGracePeriod,
+			),
+			"owner-gc",
+			sqlDB,
+			clock.NewClock(),
+			cmd.ResourceCacheCleanupInterval,
+		)},
+
+		{"workerreconciler", lockrunner.NewRunner(
+			logger.Session("worker-reconciler-runner"),
+			ownergc.NewWorkerReconciler(
+				logger.Session("worker-reconciler"),
+				workerClient,
+				sqlDB,
+			),
+			"worker-reconciler",
+			sqlDB,
+			clock.NewClock(),
+			cmd.WorkerReconcileInterval,
+		)},
 	}
 
 	if cmd.Worker.GardenURL.URL() != nil {
@@ -400,6 +484,10 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		)})
 	}
 
+	if replicatedConn != nil {
+		members = append(members, grouper.Member{"replica-monitor", replicatedConn})
+	}
+
 	members = append(members, grouper.Member{"web", http_server.New(
 		cmd.nonTLSBindAddr(),
 		httpHandler,
@@ -449,7 +537,7 @@ func (cmd *ATCCommand) oauthBaseURL() string {
 }
 
 func (cmd *ATCCommand) authConfigured() bool {
-	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured()
+	return cmd.BasicAuth.IsConfigured() || cmd.GitHubAuth.IsConfigured() || cmd.UAAAuth.IsConfigured() || cmd.GenericOAuth.IsConfigured() || cmd.OIDCAuth.IsConfigured()
 }
 
 func (cmd *ATCCommand) validate() error {
@@ -483,6 +571,13 @@ func (cmd *ATCCommand) validate() error {
 		}
 	}
 
+	if cmd.OIDCAuth.IsConfigured() {
+		err := cmd.OIDCAuth.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
 	if cmd.BasicAuth.IsConfigured() {
 		err := cmd.BasicAuth.Validate()
 		if err != nil {
@@ -585,10 +680,9 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
 }
 
 func (cmd *ATCCommand) constructDBConn(logger lager.Logger) (db.Conn, error) {
-	driverName := "connection-counting"
-	metric.SetupConnectionCountingDriver("postgres", cmd.PostgresDataSource, driverName)
+	metric.SetupConnectionCountingDriver("postgres", cmd.PostgresDataSource, connectionCountingDriverName)
 
-	dbConn, err := migrations.LockDBAndMigrate(logger.Session("db.migrations"), driverName, cmd.PostgresDataSource)
+	dbConn, err := migrations.LockDBAndMigrate(logger.Session("db.migrations"), connectionCountingDriverName, cmd.PostgresDataSource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -598,6 +692,23 @@ func (cmd *ATCCommand) constructDBConn(logger lager.Logger) (db.Conn, error) {
 	return metric.CountQueries(dbConn), nil
 }
 
+// the replica is never migrated; it follows the primary's schema
+func (cmd *ATCCommand) constructReplicaConn(logger lager.Logger) (db.Conn, error) {
+	replicaConn, err := sql.Open(connectionCountingDriverName, cmd.PostgresReplicaDataSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read replica: %s", err)
+	}
+
+	replicaConn.SetMaxOpenConns(32)
+
+	conn := metric.CountQueries(db.Wrap(replicaConn))
+	if cmd.LogDBQueries {
+		conn = db.Log(logger.Session("log-replica-conn"), conn)
+	}
+
+	return conn, nil
+}
+
 func (cmd *ATCCommand) constructLockConn() (*db.RetryableConn, error) {
 	var pgxConfig pgx.ConnConfig
 	var err error
@@ -667,6 +778,32 @@ func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
 	return signingKey, nil
 }
 
+func (cmd *ATCCommand) loadVerificationKeys(signingKey *rsa.PrivateKey) (auth.VerificationKeys, error) {
+	keys := auth.VerificationKeys{&signingKey.PublicKey}
+
+	for _, keyFile := range cmd.PreviousSessionSigningKeys {
+		rsaKeyBlob, err := ioutil.ReadFile(string(keyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous session signing key file: %s", err)
+		}
+
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
+		if err == nil {
+			keys = append(keys, &privateKey.PublicKey)
+			continue
+		}
+
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(rsaKeyBlob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse previous session signing key as RSA: %s", err)
+		}
+
+		keys = append(keys, publicKey)
+	}
+
+	return keys, nil
+}
+
 func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactory) error {
 	teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
 
@@ -754,6 +891,25 @@ func (cmd *ATCCommand) configureAuthForDefaultTeam(teamDBFactory db.TeamDBFactor
 		return err
 	}
 
+	var oidcAuth *db.OIDCAuth
+	if cmd.OIDCAuth.IsConfigured() {
+		oidcAuth = &db.OIDCAuth{
+			DisplayName:  cmd.OIDCAuth.DisplayName,
+			Issuer:       cmd.OIDCAuth.Issuer,
+			ClientID:     cmd.OIDCAuth.ClientID,
+			ClientSecret: cmd.OIDCAuth.ClientSecret,
+			Scopes:       cmd.OIDCAuth.Scopes,
+			GroupsClaim:  cmd.OIDCAuth.GroupsClaim,
+			Groups:       cmd.OIDCAuth.Groups,
+			EmailDomains: cmd.OIDCAuth.EmailDomains,
+		}
+	}
+
+	_, err = teamDB.UpdateOIDCAuth(oidcAuth)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -810,6 +966,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	teamDBFactory db.TeamDBFactory,
 	providerFactory provider.OAuthFactory,
 	signingKey *rsa.PrivateKey,
+	verificationKeys auth.VerificationKeys,
 	pipelineDBFactory db.PipelineDBFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
@@ -817,11 +974,19 @@ func (cmd *ATCCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 ) (http.Handler, error) {
-	authValidator := auth.JWTValidator{
-		PublicKey: &signingKey.PublicKey,
+	jwtValidator := auth.JWTValidator{
+		PublicKeys:        verificationKeys,
+		TokenRevocationDB: sqlDB,
 	}
 
-	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, authValidator)
+	authValidator := auth.NewAPITokenValidator(sqlDB, jwtValidator)
+
+	userContextReader := auth.NewAPITokenReader(sqlDB, auth.JWTReader{
+		PublicKeys:        verificationKeys,
+		TokenRevocationDB: sqlDB,
+	})
+
+	getTokenValidator := auth.NewTeamAuthValidator(teamDBFactory, jwtValidator)
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(
 		pipelineDBFactory,
@@ -834,10 +999,11 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPITokenScopeWrappa(),
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
-			auth.JWTReader{PublicKey: &signingKey.PublicKey},
+			userContextReader,
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
@@ -851,6 +1017,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		apiWrapper,
 
 		auth.NewTokenGenerator(signingKey),
+		verificationKeys,
 		providerFactory,
 		cmd.oauthBaseURL(),
 
@@ -860,7 +1027,9 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // teamserver.TeamDB
 		sqlDB, // workerserver.WorkerDB
 		sqlDB, // buildserver.BuildsDB
+		sqlDB, // buildqueueserver.BuildQueueDB
 		sqlDB, // containerserver.ContainerDB
+		sqlDB, // containerserver.HijackSessionDB
 		sqlDB, // volumeserver.VolumesDB
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
@@ -877,6 +1046,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		reconfigurableSink,
 
+		cmd.RecordHijackSessions,
+
 		cmd.AuthDuration,
 
 		cmd.CLIArtifactsDir.Path(),
@@ -909,6 +1080,7 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 	sqlDB *db.SQLDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
+	atcID string,
 ) *pipelines.Syncer {
 	return pipelines.NewSyncer(
 		logger,
@@ -942,6 +1114,11 @@ func (cmd *ATCCommand) constructPipelineSyncer(
 				},
 			})
 		},
+		pipelines.NewConsistentHashSharder(
+			logger.Session("sharder"),
+			atcID,
+			sqlDB,
+		),
 	)
 }
 
@@ -0,0 +1,73 @@
+package atccmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db/migrations"
+)
+
+type MigrateCommand struct {
+	PostgresDataSource string `long:"postgres-data-source" default:"postgres://127.0.0.1:5432/atc?sslmode=disable" description:"PostgreSQL connection string."`
+
+	CurrentVersion   bool `long:"current-version"   description:"Print the database's current schema version and exit."`
+	SupportedVersion bool `long:"supported-version" description:"Print the latest schema version this ATC supports and exit."`
+
+	// a pointer, so that migrating down to version 0 can be told apart from
+	// not migrating at all
+	MigrateToVersion *int `long:"migrate-to-version" description:"Migrate the database up or down to the given schema version."`
+	DryRun           bool `long:"dry-run"            description:"Print the migrations that --migrate-to-version would run, without running them."`
+}
+
+func (cmd *MigrateCommand) Execute(args []string) error {
+	logger := lager.NewLogger("atc-migrate")
+	logger.RegisterSink(lager.NewWriterSink(os.Stderr, lager.INFO))
+
+	if cmd.SupportedVersion {
+		fmt.Println(len(migrations.Migrations))
+		return nil
+	}
+
+	dbConn, err := sql.Open("postgres", cmd.PostgresDataSource)
+	if err != nil {
+		return err
+	}
+
+	defer dbConn.Close()
+
+	migrator := migrations.NewMigrator(logger, dbConn)
+
+	if cmd.CurrentVersion {
+		version, err := migrator.CurrentVersion()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(version)
+		return nil
+	}
+
+	if cmd.MigrateToVersion == nil {
+		return errors.New("one of --current-version, --supported-version or --migrate-to-version must be specified")
+	}
+
+	if cmd.DryRun {
+		steps, err := migrator.Plan(*cmd.MigrateToVersion)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			fmt.Println(step)
+		}
+
+		return nil
+	}
+
+	return migrations.LockDB(logger, "postgres", cmd.PostgresDataSource, func() error {
+		return migrator.MigrateToVersion(*cmd.MigrateToVersion)
+	})
+}
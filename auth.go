@@ -18,3 +18,9 @@ type AuthToken struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
 }
+
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
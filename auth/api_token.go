@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+// APITokenPrefix distinguishes API tokens from session JWTs when both are
+// sent as bearer tokens.
+const APITokenPrefix = "atc_"
+
+const apiTokenBytes = 32
+
+//go:generate counterfeiter . APITokenDB
+
+type APITokenDB interface {
+	FindAPITokenByHash(tokenHash string) (db.SavedAPIToken, bool, error)
+}
+
+func GenerateAPIToken() (string, error) {
+	value := make([]byte, apiTokenBytes)
+
+	_, err := rand.Read(value)
+	if err != nil {
+		return "", err
+	}
+
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(value), nil
+}
+
+func HashAPIToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiTokenLookup remembers the result of looking up a request's API token,
+// so that the token and its team are queried once per request.
+type apiTokenLookup struct {
+	done  bool
+	token db.SavedAPIToken
+	found bool
+}
+
+func getAPIToken(r *http.Request, apiTokenDB APITokenDB) (db.SavedAPIToken, bool, bool) {
+	value, isAPIToken := apiTokenValue(r)
+	if !isAPIToken {
+		return db.SavedAPIToken{}, false, false
+	}
+
+	lookup, memoized := r.Context().Value(apiTokenLookupKey).(*apiTokenLookup)
+	if !memoized {
+		lookup = &apiTokenLookup{}
+	}
+
+	if !lookup.done {
+		lookup.token, lookup.found = findAPIToken(value, apiTokenDB)
+		lookup.done = true
+	}
+
+	return lookup.token, lookup.found, true
+}
+
+func findAPIToken(value string, apiTokenDB APITokenDB) (db.SavedAPIToken, bool) {
+	token, found, err := apiTokenDB.FindAPITokenByHash(HashAPIToken(value))
+	if err != nil || !found {
+		return db.SavedAPIToken{}, false
+	}
+
+	if token.IsExpired(time.Now()) {
+		return db.SavedAPIToken{}, false
+	}
+
+	return token, true
+}
+
+func apiTokenValue(r *http.Request) (string, bool) {
+	ah := r.Header.Get("Authorization")
+	if len(ah) <= 7 || strings.ToUpper(ah[0:7]) != "BEARER " {
+		return "", false
+	}
+
+	value := ah[7:]
+	if !strings.HasPrefix(value, APITokenPrefix) {
+		return "", false
+	}
+
+	return value, true
+}
+
+type apiTokenValidator struct {
+	apiTokenDB APITokenDB
+	validator  Validator
+}
+
+// NewAPITokenValidator accepts requests bearing a valid API token, and
+// defers to the given validator for everything else.
+func NewAPITokenValidator(apiTokenDB APITokenDB, validator Validator) Validator {
+	return apiTokenValidator{
+		apiTokenDB: apiTokenDB,
+		validator:  validator,
+	}
+}
+
+func (v apiTokenValidator) IsAuthenticated(r *http.Request) bool {
+	_, found, isAPIToken := getAPIToken(r, v.apiTokenDB)
+	if isAPIToken {
+		return found
+	}
+
+	return v.validator.IsAuthenticated(r)
+}
+
+type apiTokenReader struct {
+	apiTokenDB APITokenDB
+	reader     UserContextReader
+}
+
+// NewAPITokenReader reads the team and scope of a request's API token, and
+// defers to the given reader for everything else. API tokens never grant
+// admin or system access.
+func NewAPITokenReader(apiTokenDB APITokenDB, reader UserContextReader) UserContextReader {
+	return apiTokenReader{
+		apiTokenDB: apiTokenDB,
+		reader:     reader,
+	}
+}
+
+func (cr apiTokenReader) GetTeam(r *http.Request) (string, int, bool, bool) {
+	token, found, isAPIToken := getAPIToken(r, cr.apiTokenDB)
+	if isAPIToken {
+		return token.TeamName, token.TeamID, false, found
+	}
+
+	return cr.reader.GetTeam(r)
+}
+
+func (cr apiTokenReader) GetTeams(r *http.Request) ([]string, bool) {
+	token, found, isAPIToken := getAPIToken(r, cr.apiTokenDB)
+	if isAPIToken {
+		return []string{token.TeamName}, found
+	}
+
+	return cr.reader.GetTeams(r)
+}
+
+func (cr apiTokenReader) GetSystem(r *http.Request) (bool, bool) {
+	if _, isAPIToken := apiTokenValue(r); isAPIToken {
+		return false, false
+	}
+
+	return cr.reader.GetSystem(r)
+}
+
+func (cr apiTokenReader) GetUser(r *http.Request) (atc.User, bool) {
+	token, found, isAPIToken := getAPIToken(r, cr.apiTokenDB)
+	if isAPIToken {
+		if !found {
+			return atc.User{}, false
+		}
+
+		return atc.User{
+			ID:   "api-token:" + token.TeamName + "/" + token.Name,
+			Name: token.Name,
+		}, true
+	}
+
+	return cr.reader.GetUser(r)
+}
+
+func (cr apiTokenReader) GetAPITokenScope(r *http.Request) (atc.APITokenScope, bool) {
+	token, found, isAPIToken := getAPIToken(r, cr.apiTokenDB)
+	if isAPIToken {
+		return token.Scope, found
+	}
+
+	return cr.reader.GetAPITokenScope(r)
+}
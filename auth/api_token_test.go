@@ -0,0 +1,195 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("API tokens", func() {
+	var (
+		fakeAPITokenDB        *authfakes.FakeAPITokenDB
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		validator auth.Validator
+		reader    auth.UserContextReader
+
+		tokenValue string
+		savedToken db.SavedAPIToken
+		request    *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeAPITokenDB = new(authfakes.FakeAPITokenDB)
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		validator = auth.NewAPITokenValidator(fakeAPITokenDB, fakeValidator)
+		reader = auth.NewAPITokenReader(fakeAPITokenDB, fakeUserContextReader)
+
+		var err error
+		tokenValue, err = auth.GenerateAPIToken()
+		Expect(err).NotTo(HaveOccurred())
+
+		savedToken = db.SavedAPIToken{
+			ID: 1,
+			APIToken: db.APIToken{
+				Name:  "some-bot",
+				Scope: atc.APITokenScopeTriggerBuilds,
+			},
+			TeamID:    42,
+			TeamName:  "some-team",
+			TeamAdmin: true,
+		}
+
+		request, err = http.NewRequest("GET", "/", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("GenerateAPIToken", func() {
+		It("generates distinct, prefixed values", func() {
+			otherValue, err := auth.GenerateAPIToken()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tokenValue).To(HavePrefix(auth.APITokenPrefix))
+			Expect(otherValue).To(HavePrefix(auth.APITokenPrefix))
+			Expect(tokenValue).NotTo(Equal(otherValue))
+		})
+	})
+
+	Context("when the request has an API token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer "+tokenValue)
+		})
+
+		Context("when the token is found", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(savedToken, true, nil)
+			})
+
+			It("looks it up by its hash", func() {
+				validator.IsAuthenticated(request)
+
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(1))
+				Expect(fakeAPITokenDB.FindAPITokenByHashArgsForCall(0)).To(Equal(auth.HashAPIToken(tokenValue)))
+				Expect(fakeAPITokenDB.FindAPITokenByHashArgsForCall(0)).NotTo(ContainSubstring(tokenValue))
+			})
+
+			It("is authenticated without consulting the next validator", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeTrue())
+				Expect(fakeValidator.IsAuthenticatedCallCount()).To(BeZero())
+			})
+
+			It("reads the token's team, never as an admin", func() {
+				teamName, teamID, isAdmin, found := reader.GetTeam(request)
+				Expect(found).To(BeTrue())
+				Expect(teamName).To(Equal("some-team"))
+				Expect(teamID).To(Equal(42))
+				Expect(isAdmin).To(BeFalse())
+			})
+
+			It("reads the token's scope", func() {
+				scope, found := reader.GetAPITokenScope(request)
+				Expect(found).To(BeTrue())
+				Expect(scope).To(Equal(atc.APITokenScopeTriggerBuilds))
+			})
+
+			It("identifies the user as the token", func() {
+				user, found := reader.GetUser(request)
+				Expect(found).To(BeTrue())
+				Expect(user).To(Equal(atc.User{
+					ID:   "api-token:some-team/some-bot",
+					Name: "some-bot",
+				}))
+			})
+
+			It("is never the system", func() {
+				_, found := reader.GetSystem(request)
+				Expect(found).To(BeFalse())
+				Expect(fakeUserContextReader.GetSystemCallCount()).To(BeZero())
+			})
+
+			It("looks it up once for every request wrapped by the auth handler", func() {
+				handler := auth.WrapHandler(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+					validator,
+					reader,
+				)
+
+				handler.ServeHTTP(httptest.NewRecorder(), request)
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(1))
+
+				handler.ServeHTTP(httptest.NewRecorder(), request)
+				Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(Equal(2))
+			})
+
+			Context("when the token has expired", func() {
+				BeforeEach(func() {
+					savedToken.ExpiresAt = time.Now().Add(-time.Minute)
+					fakeAPITokenDB.FindAPITokenByHashReturns(savedToken, true, nil)
+				})
+
+				It("is not authenticated", func() {
+					Expect(validator.IsAuthenticated(request)).To(BeFalse())
+				})
+
+				It("does not read a team", func() {
+					_, _, _, found := reader.GetTeam(request)
+					Expect(found).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the token is not found", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{}, false, nil)
+				fakeValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("is not authenticated, even if the next validator would allow it", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+
+		Context("when looking up the token fails", func() {
+			BeforeEach(func() {
+				fakeAPITokenDB.FindAPITokenByHashReturns(db.SavedAPIToken{}, false, errors.New("disaster"))
+			})
+
+			It("is not authenticated", func() {
+				Expect(validator.IsAuthenticated(request)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the request has some other bearer token", func() {
+		BeforeEach(func() {
+			request.Header.Set("Authorization", "Bearer some.jwt.token")
+
+			fakeValidator.IsAuthenticatedReturns(true)
+			fakeUserContextReader.GetTeamReturns("some-other-team", 7, true, true)
+		})
+
+		It("defers to the next validator and reader", func() {
+			Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+			teamName, teamID, isAdmin, found := reader.GetTeam(request)
+			Expect(found).To(BeTrue())
+			Expect(teamName).To(Equal("some-other-team"))
+			Expect(teamID).To(Equal(7))
+			Expect(isAdmin).To(BeTrue())
+
+			Expect(fakeAPITokenDB.FindAPITokenByHashCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package authfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/auth"
+)
+
+type FakeTokenRevocationDB struct {
+	RevokeTokenStub        func(tokenID string, expiresAt time.Time) error
+	revokeTokenMutex       sync.RWMutex
+	revokeTokenArgsForCall []struct {
+		tokenID   string
+		expiresAt time.Time
+	}
+	revokeTokenReturns struct {
+		result1 error
+	}
+	IsTokenRevokedStub        func(tokenID string) (bool, error)
+	isTokenRevokedMutex       sync.RWMutex
+	isTokenRevokedArgsForCall []struct {
+		tokenID string
+	}
+	isTokenRevokedReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTokenRevocationDB) RevokeToken(tokenID string, expiresAt time.Time) error {
+	fake.revokeTokenMutex.Lock()
+	fake.revokeTokenArgsForCall = append(fake.revokeTokenArgsForCall, struct {
+		tokenID   string
+		expiresAt time.Time
+	}{tokenID, expiresAt})
+	fake.recordInvocation("RevokeToken", []interface{}{tokenID, expiresAt})
+	fake.revokeTokenMutex.Unlock()
+	if fake.RevokeTokenStub != nil {
+		return fake.RevokeTokenStub(tokenID, expiresAt)
+	} else {
+		return fake.revokeTokenReturns.result1
+	}
+}
+
+func (fake *FakeTokenRevocationDB) RevokeTokenCallCount() int {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	return len(fake.revokeTokenArgsForCall)
+}
+
+func (fake *FakeTokenRevocationDB) RevokeTokenArgsForCall(i int) (string, time.Time) {
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	return fake.revokeTokenArgsForCall[i].tokenID, fake.revokeTokenArgsForCall[i].expiresAt
+}
+
+func (fake *FakeTokenRevocationDB) RevokeTokenReturns(result1 error) {
+	fake.RevokeTokenStub = nil
+	fake.revokeTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTokenRevocationDB) IsTokenRevoked(tokenID string) (bool, error) {
+	fake.isTokenRevokedMutex.Lock()
+	fake.isTokenRevokedArgsForCall = append(fake.isTokenRevokedArgsForCall, struct {
+		tokenID string
+	}{tokenID})
+	fake.recordInvocation("IsTokenRevoked", []interface{}{tokenID})
+	fake.isTokenRevokedMutex.Unlock()
+	if fake.IsTokenRevokedStub != nil {
+		return fake.IsTokenRevokedStub(tokenID)
+	} else {
+		return fake.isTokenRevokedReturns.result1, fake.isTokenRevokedReturns.result2
+	}
+}
+
+func (fake *FakeTokenRevocationDB) IsTokenRevokedCallCount() int {
+	fake.isTokenRevokedMutex.RLock()
+	defer fake.isTokenRevokedMutex.RUnlock()
+	return len(fake.isTokenRevokedArgsForCall)
+}
+
+func (fake *FakeTokenRevocationDB) IsTokenRevokedArgsForCall(i int) string {
+	fake.isTokenRevokedMutex.RLock()
+	defer fake.isTokenRevokedMutex.RUnlock()
+	return fake.isTokenRevokedArgsForCall[i].tokenID
+}
+
+func (fake *FakeTokenRevocationDB) IsTokenRevokedReturns(result1 bool, result2 error) {
+	fake.IsTokenRevokedStub = nil
+	fake.isTokenRevokedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTokenRevocationDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.revokeTokenMutex.RLock()
+	defer fake.revokeTokenMutex.RUnlock()
+	fake.isTokenRevokedMutex.RLock()
+	defer fake.isTokenRevokedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeTokenRevocationDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.TokenRevocationDB = new(FakeTokenRevocationDB)
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
@@ -20,6 +21,15 @@ type FakeUserContextReader struct {
 		result3 bool
 		result4 bool
 	}
+	GetTeamsStub        func(r *http.Request) ([]string, bool)
+	getTeamsMutex       sync.RWMutex
+	getTeamsArgsForCall []struct {
+		r *http.Request
+	}
+	getTeamsReturns struct {
+		result1 []string
+		result2 bool
+	}
 	GetSystemStub        func(r *http.Request) (bool, bool)
 	getSystemMutex       sync.RWMutex
 	getSystemArgsForCall []struct {
@@ -29,6 +39,24 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetUserStub        func(r *http.Request) (atc.User, bool)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		r *http.Request
+	}
+	getUserReturns struct {
+		result1 atc.User
+		result2 bool
+	}
+	GetAPITokenScopeStub        func(r *http.Request) (atc.APITokenScope, bool)
+	getAPITokenScopeMutex       sync.RWMutex
+	getAPITokenScopeArgsForCall []struct {
+		r *http.Request
+	}
+	getAPITokenScopeReturns struct {
+		result1 atc.APITokenScope
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -69,6 +97,40 @@ func (fake *FakeUserContextReader) GetTeamReturns(result1 string, result2 int, r
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeUserContextReader) GetTeams(r *http.Request) ([]string, bool) {
+	fake.getTeamsMutex.Lock()
+	fake.getTeamsArgsForCall = append(fake.getTeamsArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetTeams", []interface{}{r})
+	fake.getTeamsMutex.Unlock()
+	if fake.GetTeamsStub != nil {
+		return fake.GetTeamsStub(r)
+	} else {
+		return fake.getTeamsReturns.result1, fake.getTeamsReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetTeamsCallCount() int {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return len(fake.getTeamsArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetTeamsArgsForCall(i int) *http.Request {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return fake.getTeamsArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetTeamsReturns(result1 []string, result2 bool) {
+	fake.GetTeamsStub = nil
+	fake.getTeamsReturns = struct {
+		result1 []string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) GetSystem(r *http.Request) (bool, bool) {
 	fake.getSystemMutex.Lock()
 	fake.getSystemArgsForCall = append(fake.getSystemArgsForCall, struct {
@@ -103,13 +165,87 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetUser(r *http.Request) (atc.User, bool) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUser", []interface{}{r})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(r)
+	} else {
+		return fake.getUserReturns.result1, fake.getUserReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserArgsForCall(i int) *http.Request {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserReturns(result1 atc.User, result2 bool) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 atc.User
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetAPITokenScope(r *http.Request) (atc.APITokenScope, bool) {
+	fake.getAPITokenScopeMutex.Lock()
+	fake.getAPITokenScopeArgsForCall = append(fake.getAPITokenScopeArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetAPITokenScope", []interface{}{r})
+	fake.getAPITokenScopeMutex.Unlock()
+	if fake.GetAPITokenScopeStub != nil {
+		return fake.GetAPITokenScopeStub(r)
+	} else {
+		return fake.getAPITokenScopeReturns.result1, fake.getAPITokenScopeReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetAPITokenScopeCallCount() int {
+	fake.getAPITokenScopeMutex.RLock()
+	defer fake.getAPITokenScopeMutex.RUnlock()
+	return len(fake.getAPITokenScopeArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetAPITokenScopeArgsForCall(i int) *http.Request {
+	fake.getAPITokenScopeMutex.RLock()
+	defer fake.getAPITokenScopeMutex.RUnlock()
+	return fake.getAPITokenScopeArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetAPITokenScopeReturns(result1 atc.APITokenScope, result2 bool) {
+	fake.GetAPITokenScopeStub = nil
+	fake.getAPITokenScopeReturns = struct {
+		result1 atc.APITokenScope
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getTeamMutex.RLock()
 	defer fake.getTeamMutex.RUnlock()
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	fake.getAPITokenScopeMutex.RLock()
+	defer fake.getAPITokenScopeMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckAPITokenScopeHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+
+		required atc.APITokenScope
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "still nope", http.StatusForbidden)
+		}
+
+		required = atc.APITokenScopeTriggerBuilds
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(auth.WrapHandler(
+			auth.CheckAPITokenScopeHandler(
+				simpleHandler,
+				fakeRejector,
+				required,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when a request is made", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL, bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request was not made with an API token", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetAPITokenScopeReturns("", false)
+			})
+
+			It("proxies to the handler", func() {
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(responseBody)).To(Equal("simple hello"))
+			})
+		})
+
+		Context("when the API token's scope grants the required scope", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetAPITokenScopeReturns(atc.APITokenScopeSetPipelines, true)
+			})
+
+			It("proxies to the handler", func() {
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(responseBody)).To(Equal("simple hello"))
+			})
+		})
+
+		Context("when the API token's scope does not grant the required scope", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetAPITokenScopeReturns(atc.APITokenScopeReadOnly, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when no API token may be used", func() {
+			BeforeEach(func() {
+				required = ""
+				fakeUserContextReader.GetAPITokenScopeReturns(atc.APITokenScopeSetPipelines, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+})
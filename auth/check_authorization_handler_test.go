@@ -112,6 +112,16 @@ var _ = Describe("CheckAuthorizationHandler", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(string(responseBody)).To(Equal("nope\n"))
 				})
+
+				Context("when the bearer token's user is also a member of the request's team", func() {
+					BeforeEach(func() {
+						fakeUserContextReader.GetTeamsReturns([]string{"another-team", "some-team"}, true)
+					})
+
+					It("proxies to the handler", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
 			})
 		})
 
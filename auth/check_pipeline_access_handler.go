@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -51,8 +52,21 @@ func (h checkPipelineAccessHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	pipelineName := r.FormValue(":pipeline_name")
 	requestTeamName := r.FormValue(":team_name")
 
+	instanceVars, err := atc.ParseInstanceVars(r.FormValue(atc.InstanceVarsQueryParam))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	teamDB := h.teamDBFactory.GetTeamDB(requestTeamName)
-	savedPipeline, found, err := teamDB.GetPipelineByName(pipelineName)
+
+	var savedPipeline db.SavedPipeline
+	var found bool
+	if instanceVars != nil {
+		savedPipeline, found, err = teamDB.GetPipelineInstance(pipelineName, instanceVars)
+	} else {
+		savedPipeline, found, err = teamDB.GetPipelineByName(pipelineName)
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -1,11 +1,16 @@
 package genericoauth
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
@@ -90,3 +95,71 @@ func (Provider) PreTokenClient() (*http.Client, error) {
 		},
 	}, nil
 }
+
+type userClaims struct {
+	Subject           string `json:"sub"`
+	UserID            string `json:"user_id"`
+	Name              string `json:"name"`
+	UserName          string `json:"user_name"`
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+}
+
+// GetUser reads the user's identity from the access token's claims. Providers
+// issuing opaque access tokens yield an empty user rather than an error.
+func (Provider) GetUser(logger lager.Logger, httpClient *http.Client) (atc.User, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return atc.User{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) != 3 {
+		logger.Debug("access-token-is-not-a-jwt")
+		return atc.User{}, nil
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		logger.Debug("failed-to-decode-access-token", lager.Data{"error": err.Error()})
+		return atc.User{}, nil
+	}
+
+	var claims userClaims
+	err = json.Unmarshal(decodedClaims, &claims)
+	if err != nil {
+		logger.Debug("failed-to-unmarshal-access-token", lager.Data{"error": err.Error()})
+		return atc.User{}, nil
+	}
+
+	user := atc.User{
+		ID:    firstNonEmpty(claims.UserID, claims.Subject),
+		Name:  firstNonEmpty(claims.Name, claims.PreferredUsername, claims.UserName),
+		Email: claims.Email,
+	}
+
+	if user.ID == "" {
+		return atc.User{}, nil
+	}
+
+	if user.Name == "" {
+		user.Name = user.ID
+	}
+
+	return user, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -2,14 +2,17 @@ package genericoauth_test
 
 import (
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"golang.org/x/oauth2"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -89,4 +92,73 @@ var _ = Describe("Generic OAuth Provider", func() {
 		})
 
 	})
+
+	Describe("GetUser", func() {
+		var (
+			claims      jwt.MapClaims
+			accessToken string
+			user        atc.User
+			getUserErr  error
+		)
+
+		BeforeEach(func() {
+			claims = nil
+		})
+
+		JustBeforeEach(func() {
+			if claims != nil {
+				var err error
+				accessToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("some-key"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			httpClient := (&oauth2.Config{}).Client(oauth2.NoContext, &oauth2.Token{
+				AccessToken: accessToken,
+			})
+
+			user, getUserErr = goaProvider.GetUser(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		Context("when the access token is a JWT", func() {
+			BeforeEach(func() {
+				claims = jwt.MapClaims{
+					"exp":                time.Now().Add(time.Hour).Unix(),
+					"sub":                "some-subject",
+					"preferred_username": "some-user",
+					"email":              "some-user@example.com",
+				}
+			})
+
+			It("returns the user from the token's claims", func() {
+				Expect(getUserErr).NotTo(HaveOccurred())
+				Expect(user).To(Equal(atc.User{
+					ID:    "some-subject",
+					Name:  "some-user",
+					Email: "some-user@example.com",
+				}))
+			})
+
+			Context("when the token has no subject", func() {
+				BeforeEach(func() {
+					delete(claims, "sub")
+				})
+
+				It("returns an empty user", func() {
+					Expect(getUserErr).NotTo(HaveOccurred())
+					Expect(user).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the access token is opaque", func() {
+			BeforeEach(func() {
+				accessToken = "some-opaque-token"
+			})
+
+			It("returns an empty user", func() {
+				Expect(getUserErr).NotTo(HaveOccurred())
+				Expect(user).To(BeZero())
+			})
+		})
+	})
 })
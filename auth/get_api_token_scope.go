@@ -0,0 +1,12 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+func GetAPITokenScope(r *http.Request) (atc.APITokenScope, bool) {
+	scope, found := r.Context().Value(apiTokenScopeKey).(atc.APITokenScope)
+	return scope, found
+}
@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"crypto/rsa"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,21 +9,60 @@ import (
 	"github.com/dgrijalva/jwt-go"
 )
 
-func getJWT(r *http.Request, publicKey *rsa.PublicKey) (token *jwt.Token, err error) {
-	fun := func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
+const keyIDHeaderKey = "kid"
 
-		return publicKey, nil
-	}
+var ErrUnknownKeyID = errors.New("token signed by unknown key")
+var ErrTokenRevoked = errors.New("token has been revoked")
 
+func getJWT(r *http.Request, keys VerificationKeys) (token *jwt.Token, err error) {
 	if ah := r.Header.Get("Authorization"); ah != "" {
 		// Should be a bearer token
 		if len(ah) > 6 && strings.ToUpper(ah[0:6]) == "BEARER" {
-			return jwt.Parse(ah[7:], fun)
+			return parseJWT(ah[7:], keys)
 		}
 	}
 
 	return nil, errors.New("unable to parse authorization header")
 }
+
+func parseJWT(tokenString string, keys VerificationKeys) (*jwt.Token, error) {
+	candidates := keys
+
+	fun := func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		keyID, hasKeyID := token.Header[keyIDHeaderKey].(string)
+		if hasKeyID {
+			key, found := keys.Find(keyID)
+			if !found {
+				return nil, ErrUnknownKeyID
+			}
+
+			candidates = nil
+
+			return key, nil
+		}
+
+		// tokens issued before key IDs were introduced may have been signed by
+		// any of the keys
+		if len(candidates) == 0 {
+			return nil, ErrUnknownKeyID
+		}
+
+		key := candidates[0]
+		candidates = candidates[1:]
+
+		return key, nil
+	}
+
+	for {
+		remaining := len(candidates)
+
+		token, err := jwt.Parse(tokenString, fun)
+		if err == nil || len(candidates) == 0 || len(candidates) == remaining {
+			return token, err
+		}
+	}
+}
@@ -7,12 +7,14 @@ type Team interface {
 	ID() int
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Teams() []string
 }
 
 type team struct {
 	name    string
 	teamID  int
 	isAdmin bool
+	teams   []string
 }
 
 func (t *team) Name() string {
@@ -28,7 +30,19 @@ func (t *team) IsAdmin() bool {
 }
 
 func (t *team) IsAuthorized(teamName string) bool {
-	return t.name == teamName
+	for _, member := range t.teams {
+		if member == teamName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Teams returns every team the request is a member of, starting with the
+// team it was authenticated for.
+func (t *team) Teams() []string {
+	return t.teams
 }
 
 func GetTeam(r *http.Request) (Team, bool) {
@@ -40,9 +54,12 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	teams, _ := r.Context().Value(teamsKey).([]string)
+
 	return &team{
 		name:    teamName,
 		teamID:  teamID,
 		isAdmin: isAdmin,
+		teams:   teamMemberships(teamName, teams),
 	}, true
 }
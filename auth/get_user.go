@@ -0,0 +1,12 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+func GetUser(r *http.Request) (atc.User, bool) {
+	user, found := r.Context().Value(userKey).(atc.User)
+	return user, found
+}
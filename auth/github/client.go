@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/concourse/atc"
 	gogithub "github.com/google/go-github/github"
 )
 
@@ -12,6 +13,7 @@ import (
 
 type Client interface {
 	CurrentUser(*http.Client) (string, error)
+	CurrentUserInfo(*http.Client) (atc.User, error)
 	Organizations(*http.Client) ([]string, error)
 	Teams(*http.Client) (OrganizationTeams, error)
 }
@@ -40,6 +42,33 @@ func (c *client) CurrentUser(httpClient *http.Client) (string, error) {
 	return *currentUser.Login, nil
 }
 
+func (c *client) CurrentUserInfo(httpClient *http.Client) (atc.User, error) {
+	client, err := c.githubClient(httpClient)
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	currentUser, _, err := client.Users.Get("")
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	user := atc.User{
+		ID:   *currentUser.Login,
+		Name: *currentUser.Login,
+	}
+
+	if currentUser.Name != nil && *currentUser.Name != "" {
+		user.Name = *currentUser.Name
+	}
+
+	if currentUser.Email != nil {
+		user.Email = *currentUser.Email
+	}
+
+	return user, nil
+}
+
 func (c *client) Teams(httpClient *http.Client) (OrganizationTeams, error) {
 	client, err := c.githubClient(httpClient)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/github"
 )
 
@@ -68,6 +69,71 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("CurrentUserInfo", func() {
+		Context("when getting the current user succeeds", func() {
+			var ghUser gogithub.User
+
+			BeforeEach(func() {
+				ghUser = gogithub.User{
+					Login: gogithub.String("some-user"),
+					Name:  gogithub.String("Some User"),
+					Email: gogithub.String("some-user@example.com"),
+				}
+			})
+
+			JustBeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, ghUser),
+					),
+				)
+			})
+
+			It("returns the user's login, name and email", func() {
+				user, err := client.CurrentUserInfo(proxiedClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user).To(Equal(atc.User{
+					ID:    "some-user",
+					Name:  "Some User",
+					Email: "some-user@example.com",
+				}))
+			})
+
+			Context("when the user has no name or public email", func() {
+				BeforeEach(func() {
+					ghUser.Name = nil
+					ghUser.Email = nil
+				})
+
+				It("falls back to the login for the name", func() {
+					user, err := client.CurrentUserInfo(proxiedClient)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(user).To(Equal(atc.User{
+						ID:   "some-user",
+						Name: "some-user",
+					}))
+				})
+			})
+		})
+
+		Context("when getting the current user fails", func() {
+			BeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user"),
+						ghttp.RespondWith(http.StatusUnauthorized, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.CurrentUserInfo(proxiedClient)
+				Expect(err).To(BeAssignableToTypeOf(&gogithub.ErrorResponse{}))
+			})
+		})
+	})
+
 	Describe("Organizations", func() {
 		Context("when listing organization succeeds", func() {
 			BeforeEach(func() {
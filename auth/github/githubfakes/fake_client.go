@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/github"
 )
 
@@ -18,6 +19,15 @@ type FakeClient struct {
 		result1 string
 		result2 error
 	}
+	CurrentUserInfoStub        func(*http.Client) (atc.User, error)
+	currentUserInfoMutex       sync.RWMutex
+	currentUserInfoArgsForCall []struct {
+		arg1 *http.Client
+	}
+	currentUserInfoReturns struct {
+		result1 atc.User
+		result2 error
+	}
 	OrganizationsStub        func(*http.Client) ([]string, error)
 	organizationsMutex       sync.RWMutex
 	organizationsArgsForCall []struct {
@@ -74,6 +84,40 @@ func (fake *FakeClient) CurrentUserReturns(result1 string, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeClient) CurrentUserInfo(arg1 *http.Client) (atc.User, error) {
+	fake.currentUserInfoMutex.Lock()
+	fake.currentUserInfoArgsForCall = append(fake.currentUserInfoArgsForCall, struct {
+		arg1 *http.Client
+	}{arg1})
+	fake.recordInvocation("CurrentUserInfo", []interface{}{arg1})
+	fake.currentUserInfoMutex.Unlock()
+	if fake.CurrentUserInfoStub != nil {
+		return fake.CurrentUserInfoStub(arg1)
+	} else {
+		return fake.currentUserInfoReturns.result1, fake.currentUserInfoReturns.result2
+	}
+}
+
+func (fake *FakeClient) CurrentUserInfoCallCount() int {
+	fake.currentUserInfoMutex.RLock()
+	defer fake.currentUserInfoMutex.RUnlock()
+	return len(fake.currentUserInfoArgsForCall)
+}
+
+func (fake *FakeClient) CurrentUserInfoArgsForCall(i int) *http.Client {
+	fake.currentUserInfoMutex.RLock()
+	defer fake.currentUserInfoMutex.RUnlock()
+	return fake.currentUserInfoArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) CurrentUserInfoReturns(result1 atc.User, result2 error) {
+	fake.CurrentUserInfoStub = nil
+	fake.currentUserInfoReturns = struct {
+		result1 atc.User
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Organizations(arg1 *http.Client) ([]string, error) {
 	fake.organizationsMutex.Lock()
 	fake.organizationsArgsForCall = append(fake.organizationsArgsForCall, struct {
@@ -147,6 +191,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.currentUserMutex.RLock()
 	defer fake.currentUserMutex.RUnlock()
+	fake.currentUserInfoMutex.RLock()
+	defer fake.currentUserInfoMutex.RUnlock()
 	fake.organizationsMutex.RLock()
 	defer fake.organizationsMutex.RUnlock()
 	fake.teamsMutex.RLock()
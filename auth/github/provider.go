@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -22,6 +23,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	UserFetcher
 }
 
 type OAuthClient interface {
@@ -34,6 +36,10 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type UserFetcher interface {
+	GetUser(lager.Logger, *http.Client) (atc.User, error)
+}
+
 func NewProvider(
 	gitHubAuth *db.GitHubAuth,
 	redirectURL string,
@@ -59,6 +65,7 @@ func NewProvider(
 			Scopes:       Scopes,
 			RedirectURL:  redirectURL,
 		},
+		client: client,
 	}
 }
 
@@ -70,6 +77,7 @@ type gitHubProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+	client Client
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -90,3 +98,7 @@ func (gitHubProvider) PreTokenClient() (*http.Client, error) {
 		},
 	}, nil
 }
+
+func (p gitHubProvider) GetUser(logger lager.Logger, httpClient *http.Client) (atc.User, error) {
+	return p.client.CurrentUserInfo(httpClient)
+}
@@ -1,18 +1,19 @@
 package auth
 
 import (
-	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
 type JWTReader struct {
-	PublicKey *rsa.PublicKey
+	PublicKeys        VerificationKeys
+	TokenRevocationDB TokenRevocationDB
 }
 
 func (jr JWTReader) GetTeam(r *http.Request) (string, int, bool, bool) {
-	token, err := getJWT(r, jr.PublicKey)
+	token, err := jr.getJWT(r)
 	if err != nil {
 		return "", 0, false, false
 	}
@@ -33,8 +34,31 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, int, bool, bool) {
 	return teamName, teamID, isAdmin, true
 }
 
+func (jr JWTReader) GetTeams(r *http.Request) ([]string, bool) {
+	token, err := jr.getJWT(r)
+	if err != nil {
+		return nil, false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	teamsInterface, teamsOK := claims[teamsClaimKey].([]interface{})
+	if !teamsOK {
+		return nil, false
+	}
+
+	teams := []string{}
+	for _, teamInterface := range teamsInterface {
+		teamName, ok := teamInterface.(string)
+		if ok {
+			teams = append(teams, teamName)
+		}
+	}
+
+	return teams, true
+}
+
 func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
-	token, err := getJWT(r, jr.PublicKey)
+	token, err := jr.getJWT(r)
 	if err != nil {
 		return false, false
 	}
@@ -47,3 +71,44 @@ func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 
 	return isSystemInterface.(bool), true
 }
+
+func (jr JWTReader) GetUser(r *http.Request) (atc.User, bool) {
+	token, err := jr.getJWT(r)
+	if err != nil {
+		return atc.User{}, false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userID, userIDOK := claims[userIDClaimKey].(string)
+	if !userIDOK || userID == "" {
+		return atc.User{}, false
+	}
+
+	userName, _ := claims[userNameClaimKey].(string)
+	userEmail, _ := claims[userEmailClaimKey].(string)
+
+	return atc.User{
+		ID:    userID,
+		Name:  userName,
+		Email: userEmail,
+	}, true
+}
+
+func (jr JWTReader) GetAPITokenScope(r *http.Request) (atc.APITokenScope, bool) {
+	return "", false
+}
+
+// getJWT parses the request's token, treating a revoked token like a missing
+// one so that it cannot identify a team or user.
+func (jr JWTReader) getJWT(r *http.Request) (*jwt.Token, error) {
+	token, err := getJWT(r, jr.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if isRevoked(jr.TokenRevocationDB, token) {
+		return nil, ErrTokenRevoked
+	}
+
+	return token, nil
+}
@@ -0,0 +1,105 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWTReader", func() {
+	var (
+		signingKey *rsa.PrivateKey
+
+		fakeTokenRevocationDB *authfakes.FakeTokenRevocationDB
+
+		reader  auth.JWTReader
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeTokenRevocationDB = new(authfakes.FakeTokenRevocationDB)
+
+		reader = auth.JWTReader{
+			PublicKeys:        auth.VerificationKeys{&signingKey.PublicKey},
+			TokenRevocationDB: fakeTokenRevocationDB,
+		}
+
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(
+			time.Now().Add(time.Hour),
+			"some-team",
+			42,
+			true,
+			[]string{"some-team", "some-other-team"},
+			atc.User{ID: "some-user-id"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.Header.Set("Authorization", string(tokenType)+" "+string(tokenValue))
+	})
+
+	It("reads the team, memberships, and user from the token", func() {
+		teamName, teamID, isAdmin, found := reader.GetTeam(request)
+		Expect(found).To(BeTrue())
+		Expect(teamName).To(Equal("some-team"))
+		Expect(teamID).To(Equal(42))
+		Expect(isAdmin).To(BeTrue())
+
+		teams, found := reader.GetTeams(request)
+		Expect(found).To(BeTrue())
+		Expect(teams).To(Equal([]string{"some-team", "some-other-team"}))
+
+		user, found := reader.GetUser(request)
+		Expect(found).To(BeTrue())
+		Expect(user.ID).To(Equal("some-user-id"))
+	})
+
+	Context("when the token has been revoked", func() {
+		BeforeEach(func() {
+			fakeTokenRevocationDB.IsTokenRevokedReturns(true, nil)
+		})
+
+		It("reads nothing from it", func() {
+			_, _, _, found := reader.GetTeam(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetTeams(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetSystem(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetUser(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when checking for revocation fails", func() {
+		BeforeEach(func() {
+			fakeTokenRevocationDB.IsTokenRevokedReturns(false, errors.New("disaster"))
+		})
+
+		It("reads nothing from it", func() {
+			_, _, _, found := reader.GetTeam(request)
+			Expect(found).To(BeFalse())
+
+			_, found = reader.GetTeams(request)
+			Expect(found).To(BeFalse())
+		})
+	})
+})
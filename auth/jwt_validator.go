@@ -1,19 +1,21 @@
 package auth
 
-import (
-	"crypto/rsa"
-	"net/http"
-)
+import "net/http"
 
 type JWTValidator struct {
-	PublicKey *rsa.PublicKey
+	PublicKeys        VerificationKeys
+	TokenRevocationDB TokenRevocationDB
 }
 
 func (validator JWTValidator) IsAuthenticated(r *http.Request) bool {
-	token, err := getJWT(r, validator.PublicKey)
+	token, err := getJWT(r, validator.PublicKeys)
 	if err != nil {
 		return false
 	}
 
-	return token.Valid
+	if !token.Valid {
+		return false
+	}
+
+	return !isRevoked(validator.TokenRevocationDB, token)
 }
@@ -0,0 +1,169 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWTValidator", func() {
+	var (
+		currentKey  *rsa.PrivateKey
+		previousKey *rsa.PrivateKey
+
+		fakeTokenRevocationDB *authfakes.FakeTokenRevocationDB
+
+		validator auth.JWTValidator
+		request   *http.Request
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		currentKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		previousKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeTokenRevocationDB = new(authfakes.FakeTokenRevocationDB)
+
+		validator = auth.JWTValidator{
+			PublicKeys:        auth.VerificationKeys{&currentKey.PublicKey, &previousKey.PublicKey},
+			TokenRevocationDB: fakeTokenRevocationDB,
+		}
+
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	authorizeWith := func(key *rsa.PrivateKey) {
+		tokenType, tokenValue, err := auth.NewTokenGenerator(key).GenerateToken(time.Now().Add(time.Hour), "some-team", 42, false, nil, atc.User{})
+		Expect(err).NotTo(HaveOccurred())
+
+		request.Header.Set("Authorization", string(tokenType)+" "+string(tokenValue))
+	}
+
+	authorizeWithoutKeyID := func(key *rsa.PrivateKey) {
+		token := jwt.NewWithClaims(auth.SigningMethod, jwt.MapClaims{
+			"exp":      time.Now().Add(time.Hour).Unix(),
+			"teamName": "some-team",
+			"teamID":   42,
+			"isAdmin":  false,
+		})
+
+		signed, err := token.SignedString(key)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.Header.Set("Authorization", "Bearer "+signed)
+	}
+
+	It("accepts tokens signed by the current key", func() {
+		authorizeWith(currentKey)
+		Expect(validator.IsAuthenticated(request)).To(BeTrue())
+	})
+
+	It("accepts tokens signed by a previous key", func() {
+		authorizeWith(previousKey)
+		Expect(validator.IsAuthenticated(request)).To(BeTrue())
+	})
+
+	It("accepts tokens without a key ID signed by any of the keys", func() {
+		authorizeWithoutKeyID(currentKey)
+		Expect(validator.IsAuthenticated(request)).To(BeTrue())
+
+		authorizeWithoutKeyID(previousKey)
+		Expect(validator.IsAuthenticated(request)).To(BeTrue())
+	})
+
+	It("rejects tokens signed by an unknown key", func() {
+		unknownKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		authorizeWith(unknownKey)
+		Expect(validator.IsAuthenticated(request)).To(BeFalse())
+
+		authorizeWithoutKeyID(unknownKey)
+		Expect(validator.IsAuthenticated(request)).To(BeFalse())
+	})
+
+	It("rejects requests without a token", func() {
+		Expect(validator.IsAuthenticated(request)).To(BeFalse())
+	})
+
+	It("rejects malformed tokens", func() {
+		request.Header.Set("Authorization", "Bearer not-a-jwt")
+		Expect(validator.IsAuthenticated(request)).To(BeFalse())
+	})
+
+	Context("when the token has been revoked", func() {
+		BeforeEach(func() {
+			fakeTokenRevocationDB.IsTokenRevokedReturns(true, nil)
+		})
+
+		It("rejects it", func() {
+			authorizeWith(currentKey)
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+
+			Expect(fakeTokenRevocationDB.IsTokenRevokedCallCount()).To(Equal(1))
+			Expect(fakeTokenRevocationDB.IsTokenRevokedArgsForCall(0)).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when checking for revocation fails", func() {
+		BeforeEach(func() {
+			fakeTokenRevocationDB.IsTokenRevokedReturns(false, errors.New("disaster"))
+		})
+
+		It("rejects the token", func() {
+			authorizeWith(currentKey)
+			Expect(validator.IsAuthenticated(request)).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("VerificationKeys", func() {
+	var (
+		key  *rsa.PrivateKey
+		keys auth.VerificationKeys
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		keys = auth.VerificationKeys{&key.PublicKey}
+	})
+
+	Describe("JWKS", func() {
+		It("describes each key by its ID", func() {
+			jwks := keys.JWKS()
+			Expect(jwks.Keys).To(HaveLen(1))
+			Expect(jwks.Keys[0].KeyID).To(Equal(auth.KeyID(&key.PublicKey)))
+			Expect(jwks.Keys[0].KeyType).To(Equal("RSA"))
+			Expect(jwks.Keys[0].Algorithm).To(Equal("RS256"))
+			Expect(jwks.Keys[0].Exponent).To(Equal("AQAB"))
+		})
+	})
+
+	Describe("Find", func() {
+		It("finds keys by ID", func() {
+			found, ok := keys.Find(auth.KeyID(&key.PublicKey))
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(&key.PublicKey))
+
+			_, ok = keys.Find("bogus")
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
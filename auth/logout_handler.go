@@ -2,20 +2,49 @@ package auth
 
 import (
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 type LogOutHandler struct {
-	logger lager.Logger
+	logger            lager.Logger
+	publicKeys        VerificationKeys
+	tokenRevocationDB TokenRevocationDB
 }
 
-func NewLogOutHandler(logger lager.Logger) http.Handler {
-	return &LogOutHandler{logger: logger}
+func NewLogOutHandler(
+	logger lager.Logger,
+	publicKeys VerificationKeys,
+	tokenRevocationDB TokenRevocationDB,
+) http.Handler {
+	return &LogOutHandler{
+		logger:            logger,
+		publicKeys:        publicKeys,
+		tokenRevocationDB: tokenRevocationDB,
+	}
 }
 
 func (handler *LogOutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	handler.logger.Session("logout")
+	hLog := handler.logger.Session("logout")
+
+	token, err := getJWT(r, handler.publicKeys)
+	if err == nil && token.Valid {
+		claims := token.Claims.(jwt.MapClaims)
+
+		tokenID, hasTokenID := claims[tokenIDClaimKey].(string)
+		expiration, hasExpiration := claims[expClaimKey].(float64)
+
+		if hasTokenID && hasExpiration {
+			err := handler.tokenRevocationDB.RevokeToken(tokenID, time.Unix(int64(expiration), 0))
+			if err != nil {
+				hLog.Error("failed-to-revoke-token", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+	}
 
 	http.SetCookie(w, &http.Cookie{
 		Name:   CookieName,
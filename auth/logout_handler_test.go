@@ -3,14 +3,17 @@ package auth_test
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	jwt "github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db/dbfakes"
@@ -19,18 +22,20 @@ import (
 var _ = Describe("LogOutHandler", func() {
 	Describe("GET /auth/logout", func() {
 		var (
-			fakeProviderFactory *authfakes.FakeProviderFactory
-			signingKey          *rsa.PrivateKey
-			server              *httptest.Server
-			client              *http.Client
-			request             *http.Request
-			response            *http.Response
-			err                 error
-			expire              time.Duration
+			fakeProviderFactory   *authfakes.FakeProviderFactory
+			fakeTokenRevocationDB *authfakes.FakeTokenRevocationDB
+			signingKey            *rsa.PrivateKey
+			server                *httptest.Server
+			client                *http.Client
+			request               *http.Request
+			response              *http.Response
+			err                   error
+			expire                time.Duration
 		)
 
 		BeforeEach(func() {
 			fakeProviderFactory = new(authfakes.FakeProviderFactory)
+			fakeTokenRevocationDB = new(authfakes.FakeTokenRevocationDB)
 			fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
 			signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
 			Expect(err).ToNot(HaveOccurred())
@@ -40,7 +45,10 @@ var _ = Describe("LogOutHandler", func() {
 				lagertest.NewTestLogger("test"),
 				fakeProviderFactory,
 				fakeTeamDBFactory,
+				new(authfakes.FakeTeamsDB),
 				signingKey,
+				auth.VerificationKeys{&signingKey.PublicKey},
+				fakeTokenRevocationDB,
 				expire,
 			)
 			Expect(err).ToNot(HaveOccurred())
@@ -71,5 +79,67 @@ var _ = Describe("LogOutHandler", func() {
 			Expect(deletedCookie.Name).To(Equal(auth.CookieName))
 			Expect(deletedCookie.MaxAge).To(Equal(-1))
 		})
+
+		It("does not revoke anything", func() {
+			Expect(fakeTokenRevocationDB.RevokeTokenCallCount()).To(BeZero())
+		})
+
+		Context("when logged in with a session token", func() {
+			var (
+				expiration time.Time
+				tokenID    string
+			)
+
+			BeforeEach(func() {
+				expiration = time.Now().Add(time.Hour).Truncate(time.Second)
+
+				tokenType, tokenValue, err := auth.NewTokenGenerator(signingKey).GenerateToken(expiration, "some-team", 42, false, nil, atc.User{})
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := jwt.Parse(string(tokenValue), func(*jwt.Token) (interface{}, error) {
+					return &signingKey.PublicKey, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				tokenID = token.Claims.(jwt.MapClaims)["jti"].(string)
+
+				request.Header.Set("Authorization", string(tokenType)+" "+string(tokenValue))
+			})
+
+			It("revokes the token until it expires", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakeTokenRevocationDB.RevokeTokenCallCount()).To(Equal(1))
+				revokedID, revokedUntil := fakeTokenRevocationDB.RevokeTokenArgsForCall(0)
+				Expect(revokedID).To(Equal(tokenID))
+				Expect(revokedUntil).To(Equal(expiration))
+			})
+
+			Context("when revoking the token fails", func() {
+				BeforeEach(func() {
+					fakeTokenRevocationDB.RevokeTokenReturns(errors.New("disaster"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the session token was signed by some other key", func() {
+			BeforeEach(func() {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+				Expect(err).NotTo(HaveOccurred())
+
+				tokenType, tokenValue, err := auth.NewTokenGenerator(otherKey).GenerateToken(time.Now().Add(time.Hour), "some-team", 42, false, nil, atc.User{})
+				Expect(err).NotTo(HaveOccurred())
+
+				request.Header.Set("Authorization", string(tokenType)+" "+string(tokenValue))
+			})
+
+			It("does not revoke it", func() {
+				Expect(fakeTokenRevocationDB.RevokeTokenCallCount()).To(BeZero())
+			})
+		})
 	})
 })
@@ -29,7 +29,8 @@ var _ = Describe("OAuthBeginHandler", func() {
 	var (
 		fakeProvider *providerfakes.FakeProvider
 
-		fakeProviderFactory *authfakes.FakeProviderFactory
+		fakeProviderFactory   *authfakes.FakeProviderFactory
+		fakeTokenRevocationDB *authfakes.FakeTokenRevocationDB
 
 		fakeTeamDBFactory *dbfakes.FakeTeamDBFactory
 		fakeTeamDB        *dbfakes.FakeTeamDB
@@ -48,6 +49,7 @@ var _ = Describe("OAuthBeginHandler", func() {
 		fakeProvider = new(providerfakes.FakeProvider)
 
 		fakeProviderFactory = new(authfakes.FakeProviderFactory)
+		fakeTokenRevocationDB = new(authfakes.FakeTokenRevocationDB)
 
 		fakeTeamDB = new(dbfakes.FakeTeamDB)
 
@@ -62,7 +64,10 @@ var _ = Describe("OAuthBeginHandler", func() {
 			lagertest.NewTestLogger("test"),
 			fakeProviderFactory,
 			fakeTeamDBFactory,
+			new(authfakes.FakeTeamsDB),
 			signingKey,
+			auth.VerificationKeys{&signingKey.PublicKey},
+			fakeTokenRevocationDB,
 			expire,
 		)
 		Expect(err).ToNot(HaveOccurred())
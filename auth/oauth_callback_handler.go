@@ -9,6 +9,10 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/genericoauth"
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 
 	"golang.org/x/net/context"
@@ -21,6 +25,7 @@ type OAuthCallbackHandler struct {
 	privateKey      *rsa.PrivateKey
 	tokenGenerator  TokenGenerator
 	teamDBFactory   db.TeamDBFactory
+	teamsDB         TeamsDB
 	expire          time.Duration
 }
 
@@ -29,6 +34,7 @@ func NewOAuthCallbackHandler(
 	providerFactory ProviderFactory,
 	privateKey *rsa.PrivateKey,
 	teamDBFactory db.TeamDBFactory,
+	teamsDB TeamsDB,
 	expire time.Duration,
 ) http.Handler {
 	return &OAuthCallbackHandler{
@@ -37,6 +43,7 @@ func NewOAuthCallbackHandler(
 		privateKey:      privateKey,
 		tokenGenerator:  NewTokenGenerator(privateKey),
 		teamDBFactory:   teamDBFactory,
+		teamsDB:         teamsDB,
 		expire:          expire,
 	}
 }
@@ -157,9 +164,18 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	user, err := provider.GetUser(hLog.Session("get-user"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-get-user", err)
+		http.Error(w, "failed to get user", http.StatusInternalServerError)
+		return
+	}
+
+	teams, isAdmin := handler.teamMemberships(hLog.Session("team-memberships"), providerName, team, httpClient)
+
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, isAdmin, teams, user)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
@@ -189,3 +205,101 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	fmt.Fprintln(w, tokenStr)
 }
+
+// teamMemberships finds every other team whose configuration for the same
+// provider and identity provider admits the user. Failing to check a team
+// only costs the user that team's membership, not the login.
+func (handler *OAuthCallbackHandler) teamMemberships(
+	logger lager.Logger,
+	providerName string,
+	loginTeam db.SavedTeam,
+	httpClient *http.Client,
+) ([]string, bool) {
+	teams := []string{loginTeam.Name}
+	isAdmin := loginTeam.Admin
+
+	savedTeams, err := handler.teamsDB.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return teams, isAdmin
+	}
+
+	for _, savedTeam := range savedTeams {
+		if savedTeam.ID == loginTeam.ID {
+			continue
+		}
+
+		if !sameIdentityProvider(providerName, loginTeam, savedTeam) {
+			continue
+		}
+
+		provider, found, err := handler.providerFactory.GetProvider(savedTeam, providerName)
+		if err != nil {
+			logger.Error("failed-to-get-provider", err, lager.Data{
+				"teamName": savedTeam.Name,
+			})
+			continue
+		}
+
+		if !found {
+			continue
+		}
+
+		verified, err := provider.Verify(logger.Session("verify", lager.Data{
+			"teamName": savedTeam.Name,
+		}), httpClient)
+		if err != nil {
+			logger.Error("failed-to-verify-team-membership", err, lager.Data{
+				"teamName": savedTeam.Name,
+			})
+			continue
+		}
+
+		if verified {
+			teams = append(teams, savedTeam.Name)
+			isAdmin = isAdmin || savedTeam.Admin
+		}
+	}
+
+	return teams, isAdmin
+}
+
+// sameIdentityProvider returns true if the team authenticates through the
+// provider with the same identity provider and client as the login team, as
+// the user's token means nothing to any other. A generic OAuth team without a
+// scope admits every user of its identity provider, so it never matches.
+func sameIdentityProvider(providerName string, loginTeam db.SavedTeam, team db.SavedTeam) bool {
+	switch providerName {
+	case github.ProviderName:
+		login, other := loginTeam.GitHubAuth, team.GitHubAuth
+		return login != nil && other != nil &&
+			login.AuthURL == other.AuthURL &&
+			login.TokenURL == other.TokenURL &&
+			login.APIURL == other.APIURL &&
+			login.ClientID == other.ClientID
+
+	case uaa.ProviderName:
+		login, other := loginTeam.UAAAuth, team.UAAAuth
+		return login != nil && other != nil &&
+			login.AuthURL == other.AuthURL &&
+			login.TokenURL == other.TokenURL &&
+			login.CFURL == other.CFURL &&
+			login.ClientID == other.ClientID
+
+	case genericoauth.ProviderName:
+		login, other := loginTeam.GenericOAuth, team.GenericOAuth
+		return login != nil && other != nil &&
+			other.Scope != "" &&
+			login.AuthURL == other.AuthURL &&
+			login.TokenURL == other.TokenURL &&
+			login.ClientID == other.ClientID
+
+	case oidc.ProviderName:
+		login, other := loginTeam.OIDCAuth, team.OIDCAuth
+		return login != nil && other != nil &&
+			login.Issuer == other.Issuer &&
+			login.ClientID == other.ClientID
+	}
+
+	return false
+}
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
@@ -35,9 +36,11 @@ var _ = Describe("OAuthCallbackHandler", func() {
 		fakeProvider   *providerfakes.FakeProvider
 		preTokenClient *http.Client
 
-		fakeProviderFactory *authfakes.FakeProviderFactory
+		fakeProviderFactory   *authfakes.FakeProviderFactory
+		fakeTokenRevocationDB *authfakes.FakeTokenRevocationDB
 
-		fakeTeamDB *dbfakes.FakeTeamDB
+		fakeTeamDB  *dbfakes.FakeTeamDB
+		fakeTeamsDB *authfakes.FakeTeamsDB
 
 		signingKey *rsa.PrivateKey
 
@@ -53,6 +56,7 @@ var _ = Describe("OAuthCallbackHandler", func() {
 		fakeProvider = new(providerfakes.FakeProvider)
 
 		fakeProviderFactory = new(authfakes.FakeProviderFactory)
+		fakeTokenRevocationDB = new(authfakes.FakeTokenRevocationDB)
 
 		var err error
 		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
@@ -79,12 +83,16 @@ var _ = Describe("OAuthCallbackHandler", func() {
 		fakeTeamDB = new(dbfakes.FakeTeamDB)
 		fakeTeamDB.GetTeamReturns(team, true, nil)
 		fakeTeamDBFactory.GetTeamDBReturns(fakeTeamDB)
+		fakeTeamsDB = new(authfakes.FakeTeamsDB)
 
 		handler, err := auth.NewOAuthHandler(
 			lagertest.NewTestLogger("test"),
 			fakeProviderFactory,
 			fakeTeamDBFactory,
+			fakeTeamsDB,
 			signingKey,
+			auth.VerificationKeys{&signingKey.PublicKey},
+			fakeTokenRevocationDB,
 			expire,
 		)
 		Expect(err).ToNot(HaveOccurred())
@@ -194,6 +202,11 @@ var _ = Describe("OAuthCallbackHandler", func() {
 					Context("when the token is verified", func() {
 						BeforeEach(func() {
 							fakeProvider.VerifyReturns(true, nil)
+							fakeProvider.GetUserReturns(atc.User{
+								ID:    "some-user-id",
+								Name:  "Some User",
+								Email: "some-user@example.com",
+							}, nil)
 						})
 
 						It("responds OK", func() {
@@ -241,6 +254,163 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("contains the user's identity", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["userID"]).To(Equal("some-user-id"))
+								Expect(claims["userName"]).To(Equal("Some User"))
+								Expect(claims["userEmail"]).To(Equal("some-user@example.com"))
+							})
+						})
+
+						Context("when other teams admit the user", func() {
+							var rejectingProvider *providerfakes.FakeProvider
+
+							genericOAuth := func(authURL string, clientID string, scope string) *db.GenericOAuth {
+								return &db.GenericOAuth{
+									AuthURL:  authURL,
+									TokenURL: authURL + "/token",
+									ClientID: clientID,
+									Scope:    scope,
+								}
+							}
+
+							BeforeEach(func() {
+								request.URL.Path = "/auth/oauth/callback"
+
+								rejectingProvider = new(providerfakes.FakeProvider)
+								rejectingProvider.VerifyReturns(false, nil)
+
+								team.ID = 1
+								team.GenericOAuth = genericOAuth("https://idp.example.com", "some-client", "some-scope")
+								fakeTeamDB.GetTeamReturns(team, true, nil)
+
+								fakeTeamsDB.GetTeamsReturns([]db.SavedTeam{
+									team,
+									{ID: 2, Team: db.Team{Name: "member-team", Admin: true, GenericOAuth: genericOAuth("https://idp.example.com", "some-client", "member-scope")}},
+									{ID: 3, Team: db.Team{Name: "rejecting-team", GenericOAuth: genericOAuth("https://idp.example.com", "some-client", "rejecting-scope")}},
+									{ID: 4, Team: db.Team{Name: "unconfigured-team"}},
+								}, nil)
+
+								fakeProviderFactory.GetProviderStub = func(team db.SavedTeam, providerName string) (provider.Provider, bool, error) {
+									switch team.Name {
+									case "rejecting-team":
+										return rejectingProvider, true, nil
+									case "unconfigured-team":
+										return nil, false, nil
+									default:
+										return fakeProvider, true, nil
+									}
+								}
+							})
+
+							It("carries every team membership and admin-ness in the token", func() {
+								token, err := jwt.Parse(strings.Replace(response.Cookies()[0].Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["teamName"]).To(Equal("some-team"))
+								Expect(claims["teams"]).To(Equal([]interface{}{"some-team", "member-team"}))
+								Expect(claims["isAdmin"]).To(BeTrue())
+							})
+
+							It("verifies the user against the other teams with the same HTTP client", func() {
+								Expect(fakeProvider.VerifyCallCount()).To(Equal(2))
+								_, client := fakeProvider.VerifyArgsForCall(1)
+								Expect(client).To(Equal(httpClient))
+
+								Expect(rejectingProvider.VerifyCallCount()).To(Equal(1))
+							})
+
+							Context("when verifying against another team fails", func() {
+								BeforeEach(func() {
+									rejectingProvider.VerifyReturns(false, errors.New("nope"))
+								})
+
+								It("still logs in without that team", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+								})
+							})
+
+							Context("when another team admits the user through a different identity provider", func() {
+								BeforeEach(func() {
+									fakeTeamsDB.GetTeamsReturns([]db.SavedTeam{
+										team,
+										{ID: 2, Team: db.Team{Name: "other-idp-team", Admin: true, GenericOAuth: genericOAuth("https://other-idp.example.com", "some-client", "some-scope")}},
+										{ID: 3, Team: db.Team{Name: "other-client-team", Admin: true, GenericOAuth: genericOAuth("https://idp.example.com", "other-client", "some-scope")}},
+									}, nil)
+
+									fakeProviderFactory.GetProviderStub = func(team db.SavedTeam, providerName string) (provider.Provider, bool, error) {
+										return fakeProvider, true, nil
+									}
+								})
+
+								It("does not grant membership or admin-ness of those teams", func() {
+									token, err := jwt.Parse(strings.Replace(response.Cookies()[0].Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["teams"]).To(Equal([]interface{}{"some-team"}))
+									Expect(claims["isAdmin"]).To(BeFalse())
+								})
+							})
+
+							Context("when another team's config admits every user of the identity provider", func() {
+								BeforeEach(func() {
+									fakeTeamsDB.GetTeamsReturns([]db.SavedTeam{
+										team,
+										{ID: 2, Team: db.Team{Name: "scopeless-team", Admin: true, GenericOAuth: genericOAuth("https://idp.example.com", "some-client", "")}},
+									}, nil)
+								})
+
+								It("does not grant membership or admin-ness of that team", func() {
+									token, err := jwt.Parse(strings.Replace(response.Cookies()[0].Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["teams"]).To(Equal([]interface{}{"some-team"}))
+									Expect(claims["isAdmin"]).To(BeFalse())
+								})
+							})
+						})
+
+						Context("when listing the teams fails", func() {
+							BeforeEach(func() {
+								fakeTeamsDB.GetTeamsReturns(nil, errors.New("nope"))
+							})
+
+							It("logs in with only the team's membership", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+								token, err := jwt.Parse(strings.Replace(response.Cookies()[0].Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["teams"]).To(Equal([]interface{}{"some-team"}))
+							})
+						})
+
+						It("gets the user using the provider's HTTP client", func() {
+							Expect(fakeProvider.GetUserCallCount()).To(Equal(1))
+							_, client := fakeProvider.GetUserArgsForCall(0)
+							Expect(client).To(Equal(httpClient))
+						})
+
+						Context("when getting the user fails", func() {
+							BeforeEach(func() {
+								fakeProvider.GetUserReturns(atc.User{}, errors.New("nope"))
+							})
+
+							It("returns Internal Server Error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+
+							It("does not set a cookie", func() {
+								Expect(response.Cookies()).To(BeEmpty())
+							})
 						})
 
 						It("does not redirect", func() {
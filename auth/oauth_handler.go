@@ -24,7 +24,10 @@ func NewOAuthHandler(
 	logger lager.Logger,
 	providerFactory ProviderFactory,
 	teamDBFactory db.TeamDBFactory,
+	teamsDB TeamsDB,
 	signingKey *rsa.PrivateKey,
+	publicKeys VerificationKeys,
+	tokenRevocationDB TokenRevocationDB,
 	expire time.Duration,
 ) (http.Handler, error) {
 	return rata.NewRouter(
@@ -42,10 +45,13 @@ func NewOAuthHandler(
 				providerFactory,
 				signingKey,
 				teamDBFactory,
+				teamsDB,
 				expire,
 			),
 			LogOut: NewLogOutHandler(
 				logger.Session("logout"),
+				publicKeys,
+				tokenRevocationDB,
 			),
 		},
 	)
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+type Discovery struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+func Discover(client *http.Client, issuer string) (Discovery, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	resp, err := client.Get(issuer + discoveryPath)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Discovery{}, fmt.Errorf("unexpected response from discovery endpoint: %s", resp.Status)
+	}
+
+	var discovery Discovery
+	err = json.NewDecoder(resp.Body).Decode(&discovery)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return Discovery{}, fmt.Errorf("discovered issuer %q does not match configured issuer %q", discovery.Issuer, issuer)
+	}
+
+	if discovery.AuthURL == "" || discovery.TokenURL == "" || discovery.JWKSURL == "" {
+		return Discovery{}, fmt.Errorf("discovery document for %q is missing required endpoints", issuer)
+	}
+
+	return discovery, nil
+}
+
+// DiscoveryCache remembers each issuer's discovery document for the given
+// TTL, so that logging in does not discover the provider every time.
+type DiscoveryCache struct {
+	client *http.Client
+	ttl    time.Duration
+	clock  clock.Clock
+
+	lock        *sync.Mutex
+	discoveries map[string]cachedDiscovery
+}
+
+type cachedDiscovery struct {
+	discovery    Discovery
+	discoveredAt time.Time
+}
+
+func NewDiscoveryCache(client *http.Client, ttl time.Duration, clock clock.Clock) *DiscoveryCache {
+	return &DiscoveryCache{
+		client: client,
+		ttl:    ttl,
+		clock:  clock,
+
+		lock:        &sync.Mutex{},
+		discoveries: map[string]cachedDiscovery{},
+	}
+}
+
+func (cache *DiscoveryCache) Discover(issuer string) (Discovery, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	cache.lock.Lock()
+	cached, found := cache.discoveries[issuer]
+	cache.lock.Unlock()
+
+	if found && cache.clock.Since(cached.discoveredAt) < cache.ttl {
+		return cached.discovery, nil
+	}
+
+	discovery, err := Discover(cache.client, issuer)
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	cache.lock.Lock()
+	cache.discoveries[issuer] = cachedDiscovery{
+		discovery:    discovery,
+		discoveredAt: cache.clock.Now(),
+	}
+	cache.lock.Unlock()
+
+	return discovery, nil
+}
@@ -0,0 +1,156 @@
+package oidc_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/concourse/atc/auth/oidc"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Discover", func() {
+	var (
+		issuerServer *ghttp.Server
+		issuer       string
+		discovered   Discovery
+		discoverErr  error
+	)
+
+	BeforeEach(func() {
+		issuerServer = ghttp.NewServer()
+		issuer = issuerServer.URL()
+	})
+
+	AfterEach(func() {
+		issuerServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		discovered, discoverErr = Discover(http.DefaultClient, issuer+"/")
+	})
+
+	Context("when the provider serves a valid discovery document", func() {
+		BeforeEach(func() {
+			issuerServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/.well-known/openid-configuration"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+						"issuer":                 issuer,
+						"authorization_endpoint": issuer + "/auth",
+						"token_endpoint":         issuer + "/token",
+						"jwks_uri":               issuer + "/keys",
+					}),
+				),
+			)
+		})
+
+		It("returns the endpoints", func() {
+			Expect(discoverErr).NotTo(HaveOccurred())
+			Expect(discovered).To(Equal(Discovery{
+				Issuer:   issuer,
+				AuthURL:  issuer + "/auth",
+				TokenURL: issuer + "/token",
+				JWKSURL:  issuer + "/keys",
+			}))
+		})
+	})
+
+	Context("when the discovered issuer does not match", func() {
+		BeforeEach(func() {
+			issuerServer.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+					"issuer":                 "https://evil.example.com",
+					"authorization_endpoint": issuer + "/auth",
+					"token_endpoint":         issuer + "/token",
+					"jwks_uri":               issuer + "/keys",
+				}),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(discoverErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when the discovery endpoint fails", func() {
+		BeforeEach(func() {
+			issuerServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+			)
+		})
+
+		It("returns an error", func() {
+			Expect(discoverErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("DiscoveryCache", func() {
+	var (
+		issuerServer *ghttp.Server
+		issuer       string
+		fakeClock    *fakeclock.FakeClock
+		cache        *DiscoveryCache
+	)
+
+	BeforeEach(func() {
+		issuerServer = ghttp.NewServer()
+		issuer = issuerServer.URL()
+
+		issuerServer.RouteToHandler("GET", "/.well-known/openid-configuration",
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+				"issuer":                 issuer,
+				"authorization_endpoint": issuer + "/auth",
+				"token_endpoint":         issuer + "/token",
+				"jwks_uri":               issuer + "/keys",
+			}),
+		)
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		cache = NewDiscoveryCache(http.DefaultClient, time.Hour, fakeClock)
+	})
+
+	AfterEach(func() {
+		issuerServer.Close()
+	})
+
+	It("discovers each issuer once until the TTL passes", func() {
+		discovered, err := cache.Discover(issuer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(discovered.JWKSURL).To(Equal(issuer + "/keys"))
+
+		fakeClock.Increment(59 * time.Minute)
+
+		_, err = cache.Discover(issuer + "/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issuerServer.ReceivedRequests()).To(HaveLen(1))
+
+		fakeClock.Increment(time.Minute)
+
+		_, err = cache.Discover(issuer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issuerServer.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	Context("when discovery fails", func() {
+		BeforeEach(func() {
+			issuerServer.RouteToHandler("GET", "/.well-known/openid-configuration",
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+			)
+		})
+
+		It("does not cache the failure", func() {
+			_, err := cache.Discover(issuer)
+			Expect(err).To(HaveOccurred())
+
+			_, err = cache.Discover(issuer)
+			Expect(err).To(HaveOccurred())
+			Expect(issuerServer.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+})
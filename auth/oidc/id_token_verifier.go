@@ -0,0 +1,166 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+const DefaultGroupsClaim = "groups"
+
+type IDTokenVerifier struct {
+	issuer       string
+	clientID     string
+	keySet       KeySet
+	groupsClaim  string
+	groups       []string
+	emailDomains []string
+}
+
+func NewIDTokenVerifier(
+	issuer string,
+	clientID string,
+	keySet KeySet,
+	groupsClaim string,
+	groups []string,
+	emailDomains []string,
+) verifier.Verifier {
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	return IDTokenVerifier{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		keySet:       keySet,
+		groupsClaim:  groupsClaim,
+		groups:       groups,
+		emailDomains: emailDomains,
+	}
+}
+
+func (verifier IDTokenVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return false, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return false, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return false, errors.New("token response does not contain an id_token")
+	}
+
+	idToken, err := jwt.Parse(rawIDToken, verifier.signingKey)
+	if err != nil {
+		logger.Info("invalid-id-token", lager.Data{"error": err.Error()})
+		return false, nil
+	}
+
+	claims, ok := idToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return false, errors.New("id token claims are malformed")
+	}
+
+	if issuer, _ := claims["iss"].(string); strings.TrimSuffix(issuer, "/") != verifier.issuer {
+		logger.Info("issuer-mismatch", lager.Data{
+			"have": claims["iss"],
+			"want": verifier.issuer,
+		})
+		return false, nil
+	}
+
+	if !containsAudience(claims["aud"], verifier.clientID) {
+		logger.Info("audience-mismatch", lager.Data{
+			"have": claims["aud"],
+			"want": verifier.clientID,
+		})
+		return false, nil
+	}
+
+	if _, hasExpiry := claims["exp"]; !hasExpiry {
+		logger.Info("id-token-has-no-expiry")
+		return false, nil
+	}
+
+	userGroups := stringsClaim(claims[verifier.groupsClaim])
+	for _, group := range verifier.groups {
+		for _, userGroup := range userGroups {
+			if userGroup == group {
+				return true, nil
+			}
+		}
+	}
+
+	email, _ := claims["email"].(string)
+	if emailVerified, found := claims["email_verified"].(bool); found && !emailVerified {
+		email = ""
+	}
+
+	if at := strings.LastIndex(email, "@"); at != -1 {
+		emailDomain := strings.ToLower(email[at+1:])
+		for _, domain := range verifier.emailDomains {
+			if strings.ToLower(domain) == emailDomain {
+				return true, nil
+			}
+		}
+	}
+
+	logger.Info("not-in-groups-or-email-domains", lager.Data{
+		"have-groups":        userGroups,
+		"want-groups":        verifier.groups,
+		"have-email":         email,
+		"want-email-domains": verifier.emailDomains,
+	})
+
+	return false, nil
+}
+
+func (verifier IDTokenVerifier) signingKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	return verifier.keySet.Key(kid)
+}
+
+func containsAudience(aud interface{}, clientID string) bool {
+	for _, audience := range stringsClaim(aud) {
+		if audience == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringsClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		values := []string{}
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/auth/oidc"
+	"github.com/concourse/atc/auth/oidc/oidcfakes"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/dgrijalva/jwt-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IDTokenVerifier", func() {
+	var (
+		signingKey  *rsa.PrivateKey
+		fakeKeySet  *oidcfakes.FakeKeySet
+		groupsClaim string
+		groups      []string
+		domains     []string
+		claims      jwt.MapClaims
+
+		verifier  verifier.Verifier
+		verified  bool
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeKeySet = new(oidcfakes.FakeKeySet)
+		fakeKeySet.KeyReturns(&signingKey.PublicKey, nil)
+
+		groupsClaim = ""
+		groups = []string{"venture-industries"}
+		domains = []string{"osi.example.com"}
+
+		claims = jwt.MapClaims{
+			"iss":    "https://issuer.example.com",
+			"aud":    "client-id",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"guild", "venture-industries"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		verifier = NewIDTokenVerifier(
+			"https://issuer.example.com/",
+			"client-id",
+			fakeKeySet,
+			groupsClaim,
+			groups,
+			domains,
+		)
+
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		idToken.Header["kid"] = "some-key-id"
+
+		signedIDToken, err := idToken.SignedString(signingKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		oauthToken := (&oauth2.Token{
+			AccessToken: "some-access-token",
+		}).WithExtra(map[string]interface{}{
+			"id_token": signedIDToken,
+		})
+
+		c := &oauth2.Config{}
+		httpClient := c.Client(oauth2.NoContext, oauthToken)
+
+		verified, verifyErr = verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+	})
+
+	Context("when the user is in one of the groups", func() {
+		It("returns true", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+
+		It("looks up the signing key by the token's key id", func() {
+			Expect(fakeKeySet.KeyCallCount()).To(Equal(1))
+			Expect(fakeKeySet.KeyArgsForCall(0)).To(Equal("some-key-id"))
+		})
+	})
+
+	Context("when a custom groups claim is configured", func() {
+		BeforeEach(func() {
+			groupsClaim = "roles"
+			claims["roles"] = "venture-industries"
+			delete(claims, "groups")
+		})
+
+		It("reads groups from that claim", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+	})
+
+	Context("when the user is not in any of the groups", func() {
+		BeforeEach(func() {
+			claims["groups"] = []string{"guild"}
+		})
+
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+
+		Context("but has a verified email in one of the domains", func() {
+			BeforeEach(func() {
+				claims["email"] = "brock@OSI.example.com"
+				claims["email_verified"] = true
+			})
+
+			It("returns true", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+		})
+
+		Context("but has an unverified email in one of the domains", func() {
+			BeforeEach(func() {
+				claims["email"] = "brock@osi.example.com"
+				claims["email_verified"] = false
+			})
+
+			It("returns false", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the issuer does not match", func() {
+		BeforeEach(func() {
+			claims["iss"] = "https://evil.example.com"
+		})
+
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the audience does not include the client id", func() {
+		BeforeEach(func() {
+			claims["aud"] = []string{"some-other-client"}
+		})
+
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the audience is a list including the client id", func() {
+		BeforeEach(func() {
+			claims["aud"] = []string{"some-other-client", "client-id"}
+		})
+
+		It("returns true", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+	})
+
+	Context("when the token has expired", func() {
+		BeforeEach(func() {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		})
+
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the token is signed by a different key", func() {
+		BeforeEach(func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeKeySet.KeyReturns(&otherKey.PublicKey, nil)
+		})
+
+		It("returns false", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+
+	Context("when the token response does not contain an id token", func() {
+		It("returns an error", func() {
+			c := &oauth2.Config{}
+			httpClient := c.Client(oauth2.NoContext, &oauth2.Token{AccessToken: "some-access-token"})
+
+			verified, err := verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+			Expect(err).To(HaveOccurred())
+			Expect(verified).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+//go:generate counterfeiter . KeySet
+
+type KeySet interface {
+	Key(kid string) (*rsa.PublicKey, error)
+}
+
+var ErrKeyNotFound = errors.New("signing key not found in key set")
+
+// a key id that is not in the cached keys refreshes them, as the provider may
+// have rotated its keys, but no more often than this, so that tokens with
+// made up key ids cannot hammer the provider
+const minKeyRefreshInterval = time.Minute
+
+// remoteKeySet caches the provider's keys for the given TTL.
+type remoteKeySet struct {
+	client  *http.Client
+	jwksURL string
+	ttl     time.Duration
+	clock   clock.Clock
+
+	lock      *sync.Mutex
+	keys      []jsonWebKey
+	fetchedAt time.Time
+}
+
+func NewRemoteKeySet(client *http.Client, jwksURL string, ttl time.Duration, clock clock.Clock) KeySet {
+	return &remoteKeySet{
+		client:  client,
+		jwksURL: jwksURL,
+		ttl:     ttl,
+		clock:   clock,
+
+		lock: &sync.Mutex{},
+	}
+}
+
+// RemoteKeySets shares one key set between every provider with the same JWKS
+// URL, so that their keys stay cached between logins.
+type RemoteKeySets struct {
+	client *http.Client
+	ttl    time.Duration
+	clock  clock.Clock
+
+	lock    *sync.Mutex
+	keySets map[string]KeySet
+}
+
+func NewRemoteKeySets(client *http.Client, ttl time.Duration, clock clock.Clock) *RemoteKeySets {
+	return &RemoteKeySets{
+		client: client,
+		ttl:    ttl,
+		clock:  clock,
+
+		lock:    &sync.Mutex{},
+		keySets: map[string]KeySet{},
+	}
+}
+
+func (sets *RemoteKeySets) KeySet(jwksURL string) KeySet {
+	sets.lock.Lock()
+	defer sets.lock.Unlock()
+
+	keySet, found := sets.keySets[jwksURL]
+	if !found {
+		keySet = NewRemoteKeySet(sets.client, jwksURL, sets.ttl, sets.clock)
+		sets.keySets[jwksURL] = keySet
+	}
+
+	return keySet
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+	Algorithm string `json:"alg"`
+}
+
+func (ks *remoteKeySet) Key(kid string) (*rsa.PublicKey, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	age := ks.clock.Since(ks.fetchedAt)
+
+	key, found := ks.findKey(kid)
+
+	stale := ks.fetchedAt.IsZero() || age >= ks.ttl
+	if stale || (!found && age >= minKeyRefreshInterval) {
+		err := ks.refresh()
+		if err != nil {
+			return nil, err
+		}
+
+		key, found = ks.findKey(kid)
+	}
+
+	if !found {
+		return nil, ErrKeyNotFound
+	}
+
+	return key.rsaPublicKey()
+}
+
+func (ks *remoteKeySet) findKey(kid string) (jsonWebKey, bool) {
+	for _, key := range ks.keys {
+		if key.KeyType != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		if kid != "" && key.KeyID != kid {
+			continue
+		}
+
+		return key, true
+	}
+
+	return jsonWebKey{}, false
+}
+
+func (ks *remoteKeySet) refresh() error {
+	resp, err := ks.client.Get(ks.jwksURL)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from jwks endpoint: %s", resp.Status)
+	}
+
+	var keySet jsonWebKeySet
+	err = json.NewDecoder(resp.Body).Decode(&keySet)
+	if err != nil {
+		return err
+	}
+
+	ks.keys = keySet.Keys
+	ks.fetchedAt = ks.clock.Now()
+
+	return nil
+}
+
+func (key jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(key.Modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(key.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
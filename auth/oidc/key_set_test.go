@@ -0,0 +1,115 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/concourse/atc/auth/oidc"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteKeySet", func() {
+	var (
+		jwksServer *ghttp.Server
+		fakeClock  *fakeclock.FakeClock
+		keys       map[string]*rsa.PrivateKey
+		keySet     KeySet
+	)
+
+	jsonWebKeys := func() map[string]interface{} {
+		jwks := []map[string]string{}
+		for kid, key := range keys {
+			jwks = append(jwks, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			})
+		}
+
+		return map[string]interface{}{"keys": jwks}
+	}
+
+	BeforeEach(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		keys = map[string]*rsa.PrivateKey{"some-key-id": key}
+
+		jwksServer = ghttp.NewServer()
+		jwksServer.RouteToHandler("GET", "/keys", func(w http.ResponseWriter, r *http.Request) {
+			ghttp.RespondWithJSONEncoded(http.StatusOK, jsonWebKeys())(w, r)
+		})
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		keySet = NewRemoteKeySet(http.DefaultClient, jwksServer.URL()+"/keys", time.Hour, fakeClock)
+	})
+
+	AfterEach(func() {
+		jwksServer.Close()
+	})
+
+	It("fetches the keys once until the TTL passes", func() {
+		key, err := keySet.Key("some-key-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal(&keys["some-key-id"].PublicKey))
+
+		fakeClock.Increment(59 * time.Minute)
+
+		_, err = keySet.Key("some-key-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwksServer.ReceivedRequests()).To(HaveLen(1))
+
+		fakeClock.Increment(time.Minute)
+
+		_, err = keySet.Key("some-key-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jwksServer.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	Context("when the provider rotates its keys", func() {
+		BeforeEach(func() {
+			_, err := keySet.Key("some-key-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			newKey, err := rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			keys["some-new-key-id"] = newKey
+		})
+
+		It("refreshes the keys for an unknown key id", func() {
+			fakeClock.Increment(time.Minute)
+
+			key, err := keySet.Key("some-new-key-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(&keys["some-new-key-id"].PublicKey))
+			Expect(jwksServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("does not refresh them again right away", func() {
+			_, err := keySet.Key("some-new-key-id")
+			Expect(err).To(Equal(ErrKeyNotFound))
+			Expect(jwksServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
+
+var _ = Describe("RemoteKeySets", func() {
+	It("shares a key set between providers with the same JWKS URL", func() {
+		keySets := NewRemoteKeySets(http.DefaultClient, time.Hour, fakeclock.NewFakeClock(time.Unix(123, 456)))
+
+		Expect(keySets.KeySet("https://issuer.example.com/keys")).To(BeIdenticalTo(keySets.KeySet("https://issuer.example.com/keys")))
+		Expect(keySets.KeySet("https://issuer.example.com/keys")).NotTo(BeIdenticalTo(keySets.KeySet("https://other.example.com/keys")))
+	})
+})
@@ -1,4 +1,4 @@
-package lostandfound_test
+package oidc_test
 
 import (
 	. "github.com/onsi/ginkgo"
@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-func TestLostandfound(t *testing.T) {
+func TestOIDC(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Lost and Found Suite")
+	RunSpecs(t, "OIDC Suite")
 }
@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package oidcfakes
+
+import (
+	"crypto/rsa"
+	"sync"
+
+	"github.com/concourse/atc/auth/oidc"
+)
+
+type FakeKeySet struct {
+	KeyStub        func(kid string) (*rsa.PublicKey, error)
+	keyMutex       sync.RWMutex
+	keyArgsForCall []struct {
+		kid string
+	}
+	keyReturns struct {
+		result1 *rsa.PublicKey
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeKeySet) Key(kid string) (*rsa.PublicKey, error) {
+	fake.keyMutex.Lock()
+	fake.keyArgsForCall = append(fake.keyArgsForCall, struct {
+		kid string
+	}{kid})
+	fake.recordInvocation("Key", []interface{}{kid})
+	fake.keyMutex.Unlock()
+	if fake.KeyStub != nil {
+		return fake.KeyStub(kid)
+	} else {
+		return fake.keyReturns.result1, fake.keyReturns.result2
+	}
+}
+
+func (fake *FakeKeySet) KeyCallCount() int {
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	return len(fake.keyArgsForCall)
+}
+
+func (fake *FakeKeySet) KeyArgsForCall(i int) string {
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	return fake.keyArgsForCall[i].kid
+}
+
+func (fake *FakeKeySet) KeyReturns(result1 *rsa.PublicKey, result2 error) {
+	fake.KeyStub = nil
+	fake.keyReturns = struct {
+		result1 *rsa.PublicKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeySet) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.keyMutex.RLock()
+	defer fake.keyMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeKeySet) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ oidc.KeySet = new(FakeKeySet)
@@ -0,0 +1,109 @@
+package oidc
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/verifier"
+	"github.com/concourse/atc/db"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "oidc"
+
+var Scopes = []string{"openid", "profile", "email"}
+
+type Provider interface {
+	PreTokenClient() (*http.Client, error)
+
+	OAuthClient
+	Verifier
+	UserFetcher
+}
+
+type OAuthClient interface {
+	AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	Exchange(context.Context, string) (*oauth2.Token, error)
+	Client(context.Context, *oauth2.Token) *http.Client
+}
+
+type Verifier interface {
+	Verify(lager.Logger, *http.Client) (bool, error)
+}
+
+type UserFetcher interface {
+	GetUser(lager.Logger, *http.Client) (atc.User, error)
+}
+
+var discoveryClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// how long discovery documents and signing keys are cached for; a provider is
+// built for every login, so they must outlive it
+const discoveryTTL = time.Hour
+
+var (
+	discoveries = NewDiscoveryCache(discoveryClient, discoveryTTL, clock.NewClock())
+	keySets     = NewRemoteKeySets(discoveryClient, discoveryTTL, clock.NewClock())
+)
+
+func NewProvider(
+	oidcAuth *db.OIDCAuth,
+	redirectURL string,
+) (Provider, error) {
+	discovery, err := discoveries.Discover(oidcAuth.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := append([]string{}, Scopes...)
+	scopes = append(scopes, oidcAuth.Scopes...)
+
+	return oidcProvider{
+		Verifier: NewIDTokenVerifier(
+			discovery.Issuer,
+			oidcAuth.ClientID,
+			keySets.KeySet(discovery.JWKSURL),
+			oidcAuth.GroupsClaim,
+			oidcAuth.Groups,
+			oidcAuth.EmailDomains,
+		),
+		Config: &oauth2.Config{
+			ClientID:     oidcAuth.ClientID,
+			ClientSecret: oidcAuth.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthURL,
+				TokenURL: discovery.TokenURL,
+			},
+			Scopes:      scopes,
+			RedirectURL: redirectURL,
+		},
+	}, nil
+}
+
+type oidcProvider struct {
+	*oauth2.Config
+	// oauth2.Config implements the required Provider methods:
+	// AuthCodeURL(string, ...oauth2.AuthCodeOption) string
+	// Exchange(context.Context, string) (*oauth2.Token, error)
+	// Client(context.Context, *oauth2.Token) *http.Client
+
+	verifier.Verifier
+}
+
+func (oidcProvider) PreTokenClient() (*http.Client, error) {
+	return &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+	}, nil
+}
+
+func (oidcProvider) GetUser(logger lager.Logger, httpClient *http.Client) (atc.User, error) {
+	return UserFromIDToken(httpClient)
+}
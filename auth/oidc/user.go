@@ -0,0 +1,75 @@
+package oidc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+type idTokenUserClaims struct {
+	Subject           string `json:"sub"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+}
+
+// UserFromIDToken reads the user's identity from the ID token returned
+// alongside the access token. It does not validate the token; that is the
+// job of the IDTokenVerifier, which runs first.
+func UserFromIDToken(httpClient *http.Client) (atc.User, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return atc.User{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return atc.User{}, errors.New("token response does not contain an id_token")
+	}
+
+	tokenParts := strings.Split(rawIDToken, ".")
+	if len(tokenParts) != 3 {
+		return atc.User{}, errors.New("id token contains an invalid number of segments")
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	var claims idTokenUserClaims
+	err = json.Unmarshal(decodedClaims, &claims)
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	if claims.Subject == "" {
+		return atc.User{}, errors.New("id token has no 'sub' claim")
+	}
+
+	user := atc.User{
+		ID:    claims.Subject,
+		Name:  claims.Name,
+		Email: claims.Email,
+	}
+
+	if user.Name == "" {
+		user.Name = claims.PreferredUsername
+	}
+
+	if user.Name == "" {
+		user.Name = claims.Subject
+	}
+
+	return user, nil
+}
@@ -0,0 +1,92 @@
+package oidc_test
+
+import (
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/auth/oidc"
+	"github.com/dgrijalva/jwt-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserFromIDToken", func() {
+	var (
+		claims     jwt.MapClaims
+		extra      map[string]interface{}
+		httpClient *http.Client
+
+		user       atc.User
+		getUserErr error
+	)
+
+	BeforeEach(func() {
+		claims = jwt.MapClaims{
+			"sub":                "some-subject",
+			"name":               "Some User",
+			"preferred_username": "some-user",
+			"email":              "some-user@example.com",
+		}
+
+		extra = nil
+	})
+
+	JustBeforeEach(func() {
+		if extra == nil {
+			signedIDToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("some-key"))
+			Expect(err).NotTo(HaveOccurred())
+
+			extra = map[string]interface{}{"id_token": signedIDToken}
+		}
+
+		oauthToken := (&oauth2.Token{
+			AccessToken: "some-access-token",
+		}).WithExtra(extra)
+
+		httpClient = (&oauth2.Config{}).Client(oauth2.NoContext, oauthToken)
+
+		user, getUserErr = UserFromIDToken(httpClient)
+	})
+
+	It("returns the user from the id token's claims", func() {
+		Expect(getUserErr).NotTo(HaveOccurred())
+		Expect(user).To(Equal(atc.User{
+			ID:    "some-subject",
+			Name:  "Some User",
+			Email: "some-user@example.com",
+		}))
+	})
+
+	Context("when the id token has no name", func() {
+		BeforeEach(func() {
+			delete(claims, "name")
+		})
+
+		It("falls back to the preferred username", func() {
+			Expect(user.Name).To(Equal("some-user"))
+		})
+	})
+
+	Context("when the id token has no subject", func() {
+		BeforeEach(func() {
+			delete(claims, "sub")
+		})
+
+		It("returns an error", func() {
+			Expect(getUserErr).To(HaveOccurred())
+		})
+	})
+
+	Context("when the token response has no id token", func() {
+		BeforeEach(func() {
+			extra = map[string]interface{}{}
+		})
+
+		It("returns an error", func() {
+			Expect(getUserErr).To(HaveOccurred())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"code.cloudfoundry.org/urljoiner"
 	"github.com/concourse/atc/auth/genericoauth"
 	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/oidc"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
@@ -58,6 +59,18 @@ func (of OAuthFactory) GetProvider(team db.SavedTeam, providerName string) (Prov
 
 		return genericoauth.NewProvider(team.GenericOAuth, urljoiner.Join(of.atcExternalURL, redirectURL)), true, nil
 
+	case oidc.ProviderName:
+		if team.OIDCAuth == nil {
+			return nil, false, nil
+		}
+
+		oidcProvider, err := oidc.NewProvider(team.OIDCAuth, urljoiner.Join(of.atcExternalURL, redirectURL))
+		if err != nil {
+			of.logger.Error("failed-to-discover-oidc-provider", err, lager.Data{"issuer": team.OIDCAuth.Issuer})
+			return nil, false, err
+		}
+
+		return oidcProvider, true, nil
 	}
 
 	return nil, false, nil
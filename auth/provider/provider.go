@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -16,6 +17,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	UserFetcher
 }
 
 type OAuthClient interface {
@@ -29,3 +31,9 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+//go:generate counterfeiter . UserFetcher
+
+type UserFetcher interface {
+	GetUser(lager.Logger, *http.Client) (atc.User, error)
+}
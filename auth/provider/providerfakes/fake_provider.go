@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/provider"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -57,6 +58,16 @@ type FakeProvider struct {
 		result1 bool
 		result2 error
 	}
+	GetUserStub        func(lager.Logger, *http.Client) (atc.User, error)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	getUserReturns struct {
+		result1 atc.User
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -225,6 +236,41 @@ func (fake *FakeProvider) VerifyReturns(result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeProvider) GetUser(arg1 lager.Logger, arg2 *http.Client) (atc.User, error) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("GetUser", []interface{}{arg1, arg2})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(arg1, arg2)
+	} else {
+		return fake.getUserReturns.result1, fake.getUserReturns.result2
+	}
+}
+
+func (fake *FakeProvider) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeProvider) GetUserArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].arg1, fake.getUserArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) GetUserReturns(result1 atc.User, result2 error) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 atc.User
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -238,6 +284,8 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.clientMutex.RUnlock()
 	fake.verifyMutex.RLock()
 	defer fake.verifyMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
 	return fake.invocations
 }
 
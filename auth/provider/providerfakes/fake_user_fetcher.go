@@ -0,0 +1,83 @@
+// This file was generated by counterfeiter
+package providerfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/provider"
+)
+
+type FakeUserFetcher struct {
+	GetUserStub        func(lager.Logger, *http.Client) (atc.User, error)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	getUserReturns struct {
+		result1 atc.User
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUserFetcher) GetUser(arg1 lager.Logger, arg2 *http.Client) (atc.User, error) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("GetUser", []interface{}{arg1, arg2})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(arg1, arg2)
+	} else {
+		return fake.getUserReturns.result1, fake.getUserReturns.result2
+	}
+}
+
+func (fake *FakeUserFetcher) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserFetcher) GetUserArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].arg1, fake.getUserArgsForCall[i].arg2
+}
+
+func (fake *FakeUserFetcher) GetUserReturns(result1 atc.User, result2 error) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 atc.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUserFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUserFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ provider.UserFetcher = new(FakeUserFetcher)
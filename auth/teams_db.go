@@ -0,0 +1,9 @@
+package auth
+
+import "github.com/concourse/atc/db"
+
+//go:generate counterfeiter . TeamsDB
+
+type TeamsDB interface {
+	GetTeams() ([]db.SavedTeam, error)
+}
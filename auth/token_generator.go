@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -14,12 +17,17 @@ type TokenValue string
 
 const TokenTypeBearer = "Bearer"
 const expClaimKey = "exp"
+const tokenIDClaimKey = "jti"
 const teamNameClaimKey = "teamName"
 const teamIDClaimKey = "teamID"
 const isAdminClaimKey = "isAdmin"
+const teamsClaimKey = "teams"
+const userIDClaimKey = "userID"
+const userNameClaimKey = "userName"
+const userEmailClaimKey = "userEmail"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, teams []string, user atc.User) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -32,13 +40,29 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (TokenType, TokenValue, error) {
-	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, teams []string, user atc.User) (TokenType, TokenValue, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", "", err
+	}
+
+	claims := jwt.MapClaims{
 		expClaimKey:      expiration.Unix(),
+		tokenIDClaimKey:  tokenID,
 		teamNameClaimKey: teamName,
 		teamIDClaimKey:   teamID,
 		isAdminClaimKey:  isAdmin,
-	})
+		teamsClaimKey:    teamMemberships(teamName, teams),
+	}
+
+	if user.ID != "" {
+		claims[userIDClaimKey] = user.ID
+		claims[userNameClaimKey] = user.Name
+		claims[userEmailClaimKey] = user.Email
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethod, claims)
+	jwtToken.Header[keyIDHeaderKey] = KeyID(&generator.privateKey.PublicKey)
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
@@ -47,3 +71,27 @@ func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName st
 
 	return TokenTypeBearer, TokenValue(signed), err
 }
+
+func generateTokenID() (string, error) {
+	tokenID := make([]byte, 16)
+
+	_, err := rand.Read(tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(tokenID), nil
+}
+
+// teamMemberships always includes the team the token was issued for.
+func teamMemberships(teamName string, teams []string) []string {
+	memberships := []string{teamName}
+
+	for _, team := range teams {
+		if team != teamName {
+			memberships = append(memberships, team)
+		}
+	}
+
+	return memberships
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+//go:generate counterfeiter . TokenRevocationDB
+
+type TokenRevocationDB interface {
+	RevokeToken(tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(tokenID string) (bool, error)
+}
+
+// isRevoked returns true if the token has been revoked, or if that cannot be
+// determined. Tokens without an ID predate revocation and cannot be revoked.
+func isRevoked(revocationDB TokenRevocationDB, token *jwt.Token) bool {
+	if revocationDB == nil {
+		return false
+	}
+
+	tokenID, found := token.Claims.(jwt.MapClaims)[tokenIDClaimKey].(string)
+	if !found {
+		return false
+	}
+
+	revoked, err := revocationDB.IsTokenRevoked(tokenID)
+	if err != nil {
+		return true
+	}
+
+	return revoked
+}
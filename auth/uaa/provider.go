@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/verifier"
 	"github.com/concourse/atc/db"
 	"golang.org/x/net/context"
@@ -23,6 +24,7 @@ type Provider interface {
 
 	OAuthClient
 	Verifier
+	UserFetcher
 }
 
 type OAuthClient interface {
@@ -35,6 +37,10 @@ type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
 
+type UserFetcher interface {
+	GetUser(lager.Logger, *http.Client) (atc.User, error)
+}
+
 func NewProvider(
 	uaaAuth *db.UAAAuth,
 	redirectURL string,
@@ -93,3 +99,21 @@ func (p uaaProvider) PreTokenClient() (*http.Client, error) {
 		Transport: transport,
 	}, nil
 }
+
+func (uaaProvider) GetUser(logger lager.Logger, httpClient *http.Client) (atc.User, error) {
+	uaaToken, err := decodeUAAToken(httpClient)
+	if err != nil {
+		return atc.User{}, err
+	}
+
+	name := uaaToken.UserName
+	if name == "" {
+		name = uaaToken.UserID
+	}
+
+	return atc.User{
+		ID:    uaaToken.UserID,
+		Name:  name,
+		Email: uaaToken.Email,
+	}, nil
+}
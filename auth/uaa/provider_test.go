@@ -4,12 +4,17 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"net/http"
+	"time"
 
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/uaa"
 	"github.com/concourse/atc/db"
+	"github.com/dgrijalva/jwt-go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
 )
 
 var _ = Describe("Provider", func() {
@@ -122,4 +127,54 @@ K4iijxtW0XYe5R1Od6lWOEKZ6un9Ag==
 			})
 		})
 	})
+
+	Describe("GetUser", func() {
+		var (
+			claims     jwt.MapClaims
+			user       atc.User
+			getUserErr error
+		)
+
+		BeforeEach(func() {
+			dbUAAAuth = &db.UAAAuth{}
+			redirectURI = "some-redirect-url"
+
+			claims = jwt.MapClaims{
+				"exp":       time.Now().Add(time.Hour).Unix(),
+				"user_id":   "some-user-guid",
+				"user_name": "some-user",
+				"email":     "some-user@example.com",
+			}
+		})
+
+		JustBeforeEach(func() {
+			accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SigningString()
+			Expect(err).NotTo(HaveOccurred())
+
+			httpClient := (&oauth2.Config{}).Client(oauth2.NoContext, &oauth2.Token{
+				AccessToken: accessToken,
+			})
+
+			user, getUserErr = uaaProvider.GetUser(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		It("returns the user from the access token", func() {
+			Expect(getUserErr).NotTo(HaveOccurred())
+			Expect(user).To(Equal(atc.User{
+				ID:    "some-user-guid",
+				Name:  "some-user",
+				Email: "some-user@example.com",
+			}))
+		})
+
+		Context("when the token has no user_id", func() {
+			BeforeEach(func() {
+				delete(claims, "user_id")
+			})
+
+			It("returns an error", func() {
+				Expect(getUserErr).To(HaveOccurred())
+			})
+		})
+	})
 })
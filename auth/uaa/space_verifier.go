@@ -30,7 +30,9 @@ func NewSpaceVerifier(
 }
 
 type UAAToken struct {
-	UserID string `json:"user_id"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	Email    string `json:"email"`
 }
 
 type CFSpaceDevelopersResponse struct {
@@ -45,36 +47,11 @@ type CFUserInfo struct {
 }
 
 func (verifier SpaceVerifier) Verify(logger lager.Logger, httpClient *http.Client) (bool, error) {
-	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
-	if !ok {
-		return false, errors.New("httpClient transport must be of type oauth2.Transport")
-	}
-
-	token, err := oauth2Transport.Source.Token()
+	uaaToken, err := decodeUAAToken(httpClient)
 	if err != nil {
 		return false, err
 	}
 
-	tokenParts := strings.Split(token.AccessToken, ".")
-	if len(tokenParts) < 2 {
-		return false, errors.New("access token contains an invalid number of segments")
-	}
-
-	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
-	if err != nil {
-		return false, err
-	}
-
-	var uaaToken UAAToken
-	err = json.Unmarshal(decodedClaims, &uaaToken)
-	if err != nil {
-		return false, err
-	}
-
-	if uaaToken.UserID == "" {
-		return false, fmt.Errorf("not able to retrieve 'user_id' property from UAA access token")
-	}
-
 	for _, verifierSpaceGUID := range verifier.spaceGUIDs {
 		spaceURL := urljoiner.Join(verifier.cfAPIURL, "v2", "spaces", verifierSpaceGUID, "developers?results-per-page=100")
 
@@ -107,6 +84,40 @@ func (verifier SpaceVerifier) Verify(logger lager.Logger, httpClient *http.Clien
 	return false, nil
 }
 
+func decodeUAAToken(httpClient *http.Client) (UAAToken, error) {
+	oauth2Transport, ok := httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		return UAAToken{}, errors.New("httpClient transport must be of type oauth2.Transport")
+	}
+
+	token, err := oauth2Transport.Source.Token()
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	tokenParts := strings.Split(token.AccessToken, ".")
+	if len(tokenParts) < 2 {
+		return UAAToken{}, errors.New("access token contains an invalid number of segments")
+	}
+
+	decodedClaims, err := jwt.DecodeSegment(tokenParts[1])
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	var uaaToken UAAToken
+	err = json.Unmarshal(decodedClaims, &uaaToken)
+	if err != nil {
+		return UAAToken{}, err
+	}
+
+	if uaaToken.UserID == "" {
+		return UAAToken{}, fmt.Errorf("not able to retrieve 'user_id' property from UAA access token")
+	}
+
+	return uaaToken, nil
+}
+
 func (verifier SpaceVerifier) isSpaceDeveloper(
 	logger lager.Logger,
 	httpClient *http.Client,
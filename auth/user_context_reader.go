@@ -1,10 +1,17 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 //go:generate counterfeiter . UserContextReader
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, int, bool, bool)
+	GetTeams(r *http.Request) ([]string, bool)
 	GetSystem(r *http.Request) (bool, bool)
+	GetUser(r *http.Request) (atc.User, bool)
+	GetAPITokenScope(r *http.Request) (atc.APITokenScope, bool)
 }
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+)
+
+// VerificationKeys are the public keys that session tokens are verified
+// against. The first key is the one that new tokens are signed with; the rest
+// are kept around so that tokens signed before a key rotation remain valid
+// until they expire.
+type VerificationKeys []*rsa.PublicKey
+
+func (keys VerificationKeys) Find(keyID string) (*rsa.PublicKey, bool) {
+	for _, key := range keys {
+		if KeyID(key) == keyID {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+// KeyID identifies a key by its RFC 7638 thumbprint, so that every ATC
+// configured with the same key agrees on its ID.
+func KeyID(key *rsa.PublicKey) string {
+	thumbprintInput, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{
+		E:   encodeBigInt(big.NewInt(int64(key.E))),
+		Kty: "RSA",
+		N:   encodeBigInt(key.N),
+	})
+
+	sum := sha256.Sum256(thumbprintInput)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+func (keys VerificationKeys) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{
+		Keys: []JSONWebKey{},
+	}
+
+	for _, key := range keys {
+		set.Keys = append(set.Keys, JSONWebKey{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: SigningMethod.Alg(),
+			KeyID:     KeyID(key),
+			Modulus:   encodeBigInt(key.N),
+			Exponent:  encodeBigInt(big.NewInt(int64(key.E))),
+		})
+	}
+
+	return set
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
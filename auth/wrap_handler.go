@@ -9,7 +9,11 @@ var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
+var teamsKey = "teams"
 var isSystemKey = "system"
+var userKey = "user"
+var apiTokenScopeKey = "apiTokenScope"
+var apiTokenLookupKey = "apiTokenLookup"
 
 func WrapHandler(
 	handler http.Handler,
@@ -30,6 +34,10 @@ type authHandler struct {
 }
 
 func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the validator and every reader below may need the request's API token,
+	// so it is only looked up the first time
+	r = r.WithContext(context.WithValue(r.Context(), apiTokenLookupKey, &apiTokenLookup{}))
+
 	ctx := context.WithValue(r.Context(), authenticated, h.validator.IsAuthenticated(r))
 	teamName, teamID, isAdmin, found := h.userContextReader.GetTeam(r)
 	if found {
@@ -38,9 +46,24 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	teams, found := h.userContextReader.GetTeams(r)
+	if found {
+		ctx = context.WithValue(ctx, teamsKey, teams)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
 	}
+
+	user, found := h.userContextReader.GetUser(r)
+	if found {
+		ctx = context.WithValue(ctx, userKey, user)
+	}
+
+	apiTokenScope, found := h.userContextReader.GetAPITokenScope(r)
+	if found {
+		ctx = context.WithValue(ctx, apiTokenScopeKey, apiTokenScope)
+	}
 	h.handler.ServeHTTP(w, r.WithContext(ctx))
 }
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 )
@@ -22,10 +23,13 @@ var _ = Describe("WrapHandler", func() {
 
 		authenticated   <-chan bool
 		teamNameChan    <-chan string
+		teamsChan       <-chan []string
 		isAdminChan     <-chan bool
 		isSystemChan    <-chan bool
 		foundChan       <-chan bool
 		systemFoundChan <-chan bool
+		userChan        <-chan atc.User
+		userFoundChan   <-chan bool
 	)
 
 	BeforeEach(func() {
@@ -34,17 +38,23 @@ var _ = Describe("WrapHandler", func() {
 
 		a := make(chan bool, 1)
 		tn := make(chan string, 1)
+		ts := make(chan []string, 1)
 		ia := make(chan bool, 1)
 		is := make(chan bool, 1)
 		f := make(chan bool, 1)
 		sf := make(chan bool, 1)
+		u := make(chan atc.User, 1)
+		uf := make(chan bool, 1)
 
 		authenticated = a
 		teamNameChan = tn
+		teamsChan = ts
 		isAdminChan = ia
 		isSystemChan = is
 		foundChan = f
 		systemFoundChan = sf
+		userChan = u
+		userFoundChan = uf
 		simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			a <- auth.IsAuthenticated(r)
 			authTeam, authTeamFound := auth.GetTeam(r)
@@ -54,11 +64,18 @@ var _ = Describe("WrapHandler", func() {
 			sf <- systemFound
 			if authTeam != nil {
 				tn <- authTeam.Name()
+				ts <- authTeam.Teams()
 				ia <- authTeam.IsAdmin()
 			}
 			if systemFound {
 				is <- isSystem
 			}
+
+			user, userFound := auth.GetUser(r)
+			uf <- userFound
+			if userFound {
+				u <- user
+			}
 		})
 
 		server = httptest.NewServer(auth.WrapHandler(
@@ -115,8 +132,21 @@ var _ = Describe("WrapHandler", func() {
 			It("passes the team information along in the request object", func() {
 				Expect(<-foundChan).To(BeTrue())
 				Expect(<-teamNameChan).To(Equal("some-team"))
+				Expect(<-teamsChan).To(Equal([]string{"some-team"}))
 				Expect(<-isAdminChan).To(BeTrue())
 			})
+
+			Context("when the userContextReader finds team memberships", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetTeamsReturns([]string{"some-team", "some-other-team"}, true)
+				})
+
+				It("passes the memberships along in the request object", func() {
+					Expect(<-foundChan).To(BeTrue())
+					Expect(<-teamNameChan).To(Equal("some-team"))
+					Expect(<-teamsChan).To(Equal([]string{"some-team", "some-other-team"}))
+				})
+			})
 		})
 
 		Context("when the userContextReader does not find team information", func() {
@@ -149,5 +179,34 @@ var _ = Describe("WrapHandler", func() {
 				Expect(<-systemFoundChan).To(BeFalse())
 			})
 		})
+
+		Context("when the userContextReader finds user information", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserReturns(atc.User{
+					ID:    "some-user-id",
+					Name:  "Some User",
+					Email: "some-user@example.com",
+				}, true)
+			})
+
+			It("passes the user along in the request object", func() {
+				Expect(<-userFoundChan).To(BeTrue())
+				Expect(<-userChan).To(Equal(atc.User{
+					ID:    "some-user-id",
+					Name:  "Some User",
+					Email: "some-user@example.com",
+				}))
+			})
+		})
+
+		Context("when the userContextReader does not find user information", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserReturns(atc.User{}, false)
+			})
+
+			It("does not pass the user along in the request object", func() {
+				Expect(<-userFoundChan).To(BeFalse())
+			})
+		})
 	})
 })
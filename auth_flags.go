@@ -109,6 +109,54 @@ func (auth *GenericOAuthFlag) Validate() error {
 	return errs.ErrorOrNil()
 }
 
+type OIDCAuthFlag struct {
+	DisplayName  string   `long:"display-name"  description:"Name for this auth method on the web UI."`
+	Issuer       string   `long:"issuer"        description:"OpenID Connect issuer URL, used to discover the provider's endpoints and signing keys."`
+	ClientID     string   `long:"client-id"     description:"Application client ID for enabling OpenID Connect."`
+	ClientSecret string   `long:"client-secret" description:"Application client secret for enabling OpenID Connect."`
+	Scopes       []string `long:"scope"         description:"Additional scope to request from the provider. Can be specified multiple times." value-name:"SCOPE"`
+	GroupsClaim  string   `long:"groups-claim"  description:"Name of the ID token claim listing the user's groups." default:"groups"`
+	Groups       []string `long:"group"         description:"Group whose members will have access. Can be specified multiple times." value-name:"GROUP"`
+	EmailDomains []string `long:"email-domain"  description:"Email domain whose verified users will have access. Can be specified multiple times." value-name:"DOMAIN"`
+}
+
+func (auth *OIDCAuthFlag) IsConfigured() bool {
+	return auth.Issuer != "" ||
+		auth.ClientID != "" ||
+		auth.ClientSecret != "" ||
+		len(auth.Groups) > 0 ||
+		len(auth.EmailDomains) > 0
+}
+
+func (auth *OIDCAuthFlag) Validate() error {
+	var errs *multierror.Error
+	if auth.ClientID == "" || auth.ClientSecret == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-client-id and --oidc-auth-client-secret to use OpenID Connect."),
+		)
+	}
+	if auth.Issuer == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-issuer to use OpenID Connect."),
+		)
+	}
+	if auth.DisplayName == "" {
+		errs = multierror.Append(
+			errs,
+			errors.New("must specify --oidc-auth-display-name to use OpenID Connect."),
+		)
+	}
+	if len(auth.Groups) == 0 && len(auth.EmailDomains) == 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("at least one of the following is required for oidc-auth: groups, email-domains."),
+		)
+	}
+	return errs.ErrorOrNil()
+}
+
 type UAAAuthFlag struct {
 	ClientID     string   `long:"client-id"     description:"Application client ID for enabling UAA OAuth."`
 	ClientSecret string   `long:"client-secret" description:"Application client secret for enabling UAA OAuth."`
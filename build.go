@@ -12,17 +12,34 @@ const (
 )
 
 type Build struct {
-	ID           int    `json:"id"`
-	TeamName     string `json:"team_name"`
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	JobName      string `json:"job_name,omitempty"`
-	URL          string `json:"url"`
-	APIURL       string `json:"api_url"`
-	PipelineName string `json:"pipeline_name,omitempty"`
-	StartTime    int64  `json:"start_time,omitempty"`
-	EndTime      int64  `json:"end_time,omitempty"`
-	ReapTime     int64  `json:"reap_time,omitempty"`
+	ID                   int          `json:"id"`
+	TeamName             string       `json:"team_name"`
+	Name                 string       `json:"name"`
+	Status               string       `json:"status"`
+	JobName              string       `json:"job_name,omitempty"`
+	URL                  string       `json:"url"`
+	APIURL               string       `json:"api_url"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	StartTime            int64        `json:"start_time,omitempty"`
+	EndTime              int64        `json:"end_time,omitempty"`
+	ReapTime             int64        `json:"reap_time,omitempty"`
+
+	TriggerReason string `json:"trigger_reason,omitempty"`
+
+	Metadata []MetadataField `json:"metadata,omitempty"`
+	Links    []BuildLink     `json:"links,omitempty"`
+}
+
+// BuildAnnotations are the metadata and links that tasks attach to a build.
+type BuildAnnotations struct {
+	Metadata []MetadataField `json:"metadata,omitempty"`
+	Links    []BuildLink     `json:"links,omitempty"`
+}
+
+type BuildLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 func (b Build) IsRunning() bool {
@@ -57,6 +74,10 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	TeamQuota           BuildPreparationStatus            `json:"team_quota"`
+	BuildQueue          BuildPreparationStatus            `json:"build_queue"`
+	QueuePosition       int                               `json:"queue_position,omitempty"`
+	QueueWaitInSeconds  int64                             `json:"queue_wait_in_seconds,omitempty"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
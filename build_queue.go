@@ -0,0 +1,12 @@
+package atc
+
+type QueuedBuild struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	JobName       string `json:"job_name"`
+	PipelineName  string `json:"pipeline_name"`
+	TeamName      string `json:"team_name"`
+	Priority      int    `json:"priority,omitempty"`
+	Position      int    `json:"position"`
+	WaitInSeconds int64  `json:"wait_in_seconds"`
+}
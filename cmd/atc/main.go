@@ -14,11 +14,32 @@ func main() {
 	parser := flags.NewParser(cmd, flags.Default)
 	parser.NamespaceDelimiter = "-"
 
+	// running the ATC itself does not need a command, so that existing
+	// deployments keep working
+	parser.SubcommandsOptional = true
+
+	// migrating runs without configuring, or starting, the rest of the ATC;
+	// go-flags executes it when it is given
+	_, err := parser.AddCommand(
+		"migrate",
+		"Migrate the database.",
+		"Inspect the database's schema version, or migrate it up or down to a given version, without starting the ATC.",
+		&atccmd.MigrateCommand{},
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	args, err := parser.Parse()
 	if err != nil {
 		os.Exit(1)
 	}
 
+	if parser.Active != nil {
+		return
+	}
+
 	err = cmd.Execute(args)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
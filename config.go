@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -26,6 +29,45 @@ type Config struct {
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
 }
 
+// LoadConfig loads a pipeline config from YAML (or JSON). It does not
+// validate it; see config.ValidateConfig.
+func LoadConfig(configBytes []byte) (Config, error) {
+	var untypedInput map[string]interface{}
+
+	if err := yaml.Unmarshal(configBytes, &untypedInput); err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	var metadata mapstructure.Metadata
+
+	msConfig := &mapstructure.DecoderConfig{
+		Metadata:         &metadata,
+		Result:           &config,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			SanitizeDecodeHook,
+			VersionConfigDecodeHook,
+		),
+	}
+
+	decoder, err := mapstructure.NewDecoder(msConfig)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := decoder.Decode(untypedInput); err != nil {
+		return Config{}, err
+	}
+
+	if len(metadata.Unused) > 0 {
+		keys := strings.Join(metadata.Unused, ", ")
+		return Config{}, fmt.Errorf("extra keys in the pipeline configuration: %s", keys)
+	}
+
+	return config, nil
+}
+
 type RawConfig string
 
 func (r RawConfig) String() string {
@@ -96,10 +138,20 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             int      `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
+
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 }
 
+// ScheduleConfig triggers a job on a cron expression, evaluated in the given
+// IANA time zone (UTC if empty).
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Location string `yaml:"location,omitempty" json:"location,omitempty" mapstructure:"location"`
+}
+
 func (config JobConfig) MaxInFlight() int {
 	if config.Serial || len(config.SerialGroups) > 0 {
 		return 1
@@ -261,11 +313,15 @@ type PlanConfig struct {
 	Task string `yaml:"task,omitempty" json:"task,omitempty" mapstructure:"task"`
 	// run task privileged
 	Privileged bool `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
-	// task config path, e.g. foo/build.yml
+	// task config path, e.g. foo/build.yml, or pipeline config path for set_pipeline
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan
+	// name of the pipeline to set from the config at `file`
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -323,6 +379,10 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
 	return ""
 }
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/cron"
 )
 
 func formatErr(groupName string, err error) string {
@@ -218,6 +219,16 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
+		if job.Schedule != nil {
+			_, err := cron.Parse(job.Schedule.Cron, job.Schedule.Location)
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					identifier+" has an invalid schedule: "+err.Error(),
+				)
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -285,6 +296,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("try")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
 	if valid, message := foundTypes.IsValid(); !valid {
 		return []Warning{}, []string{message}
 	}
@@ -440,6 +455,18 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			plan, identifier)...,
 		)
 
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify a config file")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -424,6 +424,50 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a valid schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Cron:     "0 2 * * mon-fri",
+					Location: "America/Toronto",
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job has an invalid cron expression", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Cron: "0 25 * * *",
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an invalid schedule: invalid value in hour field '25'"))
+			})
+		})
+
+		Context("when a job's schedule has an unknown location", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{
+					Cron:     "@daily",
+					Location: "Nowhere/Special",
+				}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an invalid schedule: invalid location 'Nowhere/Special'"))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -534,6 +578,39 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline: "lol",
+						Passed:      []string{"hi"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.lol does not specify a config file"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.lol has invalid fields specified (passed)"))
+				})
+			})
+
+			Context("when a set_pipeline plan has a file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						SetPipeline:    "lol",
+						TaskConfigPath: "some-resource/pipeline.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
 			Context("when a task plan has config path and config specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
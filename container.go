@@ -17,3 +17,17 @@ type Container struct {
 	Attempts             []int    `json:"attempt,omitempty"`
 	User                 string   `json:"user,omitempty"`
 }
+
+// Owner is what keeps a container or volume around. Once it is gone, the
+// container or volume is garbage collected.
+type Owner struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+}
+
+type ContainerDetails struct {
+	Container
+
+	TeamName string `json:"team_name"`
+	Owner    *Owner `json:"owner,omitempty"`
+}
@@ -0,0 +1,308 @@
+// Package cron parses standard five-field cron expressions (minute, hour,
+// day of month, month, day of week) and computes when they next fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// as with cron(8), when both day fields are restricted a day matches if
+	// either of them does
+	domRestricted bool
+	dowRestricted bool
+
+	location *time.Location
+}
+
+type field struct {
+	name  string
+	min   uint
+	max   uint
+	names map[string]uint
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// searchLimit bounds how far ahead Next looks before giving up on
+// expressions that can never match, e.g. the 30th of February.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// Parse parses a cron expression evaluated in the given IANA time zone. An
+// empty location means UTC.
+func Parse(expr string, location string) (*Schedule, error) {
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location '%s': %s", location, err)
+	}
+
+	expr = strings.TrimSpace(expr)
+	if descriptor, found := descriptors[strings.ToLower(expr)]; found {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression '%s', got %d", expr, len(fields))
+	}
+
+	schedule := &Schedule{location: loc}
+
+	if schedule.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+
+	if schedule.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+
+	if schedule.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+
+	if schedule.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+
+	if schedule.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// 7 is an alias for sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+
+	schedule.domRestricted = fields[2] != "*"
+	schedule.dowRestricted = fields[4] != "*"
+
+	if schedule.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, loc)).IsZero() {
+		return nil, fmt.Errorf("cron expression '%s' never matches", expr)
+	}
+
+	return schedule, nil
+}
+
+// Next returns the first time after the given time at which the schedule
+// fires, or the zero time if it never does.
+func (schedule *Schedule) Next(after time.Time) time.Time {
+	t := after.In(schedule.location).Add(time.Minute)
+	t = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if !schedule.matches(schedule.month, uint(t.Month())) || !schedule.dayMatches(t) {
+			t = schedule.startOfNextDay(t)
+			continue
+		}
+
+		if schedule.firesInGap(t) {
+			return t
+		}
+
+		// within a day, advance by absolute durations rather than with
+		// time.Date, which may normalize backwards across a daylight saving
+		// transition
+		if !schedule.matches(schedule.hour, uint(t.Hour())) {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+
+		if !schedule.matches(schedule.minute, uint(t.Minute())) || isRepeated(t) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// firesInGap returns whether t directly follows a daylight saving gap in
+// which the schedule would have fired, e.g. at 2:30 on a day when the clocks
+// go from 2:00 straight to 3:00. It then fires at t instead.
+func (schedule *Schedule) firesInGap(t time.Time) bool {
+	_, offset := t.Zone()
+	_, previousOffset := t.Add(-time.Minute).Zone()
+
+	gap := (offset - previousOffset) / 60
+	if gap <= 0 {
+		return false
+	}
+
+	minuteOfDay := t.Hour()*60 + t.Minute()
+	for skipped := minuteOfDay - gap; skipped < minuteOfDay; skipped++ {
+		if skipped < 0 {
+			continue
+		}
+
+		if schedule.matches(schedule.hour, uint(skipped/60)) && schedule.matches(schedule.minute, uint(skipped%60)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRepeated returns whether the local time of t already occurred earlier
+// that day, as it does in the hour after the clocks go back, so that the
+// schedule only fires the first time round.
+func isRepeated(t time.Time) bool {
+	_, offset := t.Zone()
+
+	// the clocks go back at most once a day, so half a day earlier is before
+	// any transition that t is repeating
+	_, earlierOffset := t.Add(-12 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+
+	first := t.Add(-time.Duration(earlierOffset-offset) * time.Second)
+
+	return first.Day() == t.Day() && first.Hour() == t.Hour() && first.Minute() == t.Minute()
+}
+
+// startOfNextDay returns midnight of the day after t, as days are not always
+// 24 hours long.
+func (schedule *Schedule) startOfNextDay(t time.Time) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, schedule.location)
+
+	// when midnight falls in a daylight saving gap, time.Date may normalize it
+	// back into the previous day; the day then starts an hour later
+	if next.Day() == t.Day() {
+		next = next.Add(time.Hour)
+	}
+
+	return next
+}
+
+func (schedule *Schedule) dayMatches(t time.Time) bool {
+	domMatches := schedule.matches(schedule.dom, uint(t.Day()))
+	dowMatches := schedule.matches(schedule.dow, uint(t.Weekday()))
+
+	if schedule.domRestricted && schedule.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
+
+func (schedule *Schedule) matches(set uint64, value uint) bool {
+	return set&(1<<value) != 0
+}
+
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		bits, err := f.parseRange(part)
+		if err != nil {
+			return 0, err
+		}
+
+		set |= bits
+	}
+
+	return set, nil
+}
+
+func (f field) parseRange(expr string) (uint64, error) {
+	rangeExpr := expr
+	step := uint(1)
+
+	if slash := strings.Index(expr, "/"); slash != -1 {
+		parsedStep, err := strconv.ParseUint(expr[slash+1:], 10, 8)
+		if err != nil || parsedStep == 0 {
+			return 0, fmt.Errorf("invalid step in %s field '%s'", f.name, expr)
+		}
+
+		rangeExpr = expr[:slash]
+		step = uint(parsedStep)
+	}
+
+	var start, end uint
+
+	switch {
+	case rangeExpr == "*":
+		start, end = f.min, f.max
+
+	case strings.Contains(rangeExpr, "-"):
+		bounds := strings.SplitN(rangeExpr, "-", 2)
+
+		var err error
+		if start, err = f.parseValue(bounds[0]); err != nil {
+			return 0, err
+		}
+
+		if end, err = f.parseValue(bounds[1]); err != nil {
+			return 0, err
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %s field '%s'", f.name, expr)
+		}
+
+	default:
+		value, err := f.parseValue(rangeExpr)
+		if err != nil {
+			return 0, err
+		}
+
+		start, end = value, value
+		if step > 1 {
+			end = f.max
+		}
+	}
+
+	var set uint64
+	for value := start; value <= end; value += step {
+		set |= 1 << value
+	}
+
+	return set, nil
+}
+
+func (f field) parseValue(expr string) (uint, error) {
+	if value, found := f.names[strings.ToLower(expr)]; found {
+		return value, nil
+	}
+
+	value, err := strconv.ParseUint(expr, 10, 8)
+	if err != nil || uint(value) < f.min || uint(value) > f.max {
+		return 0, fmt.Errorf("invalid value in %s field '%s'", f.name, expr)
+	}
+
+	return uint(value), nil
+}
@@ -0,0 +1,167 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/cron"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schedule", func() {
+	var after time.Time
+
+	BeforeEach(func() {
+		after = time.Date(2017, 3, 11, 23, 59, 30, 0, time.UTC)
+	})
+
+	nextN := func(schedule *cron.Schedule, n int) []time.Time {
+		times := []time.Time{}
+
+		t := after
+		for i := 0; i < n; i++ {
+			t = schedule.Next(t)
+			times = append(times, t)
+		}
+
+		return times
+	}
+
+	It("fires on steps", func() {
+		schedule, err := cron.Parse("*/15 * * * *", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextN(schedule, 3)).To(Equal([]time.Time{
+			time.Date(2017, 3, 12, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 3, 12, 0, 15, 0, 0, time.UTC),
+			time.Date(2017, 3, 12, 0, 30, 0, 0, time.UTC),
+		}))
+	})
+
+	It("fires on steps starting from a value", func() {
+		schedule, err := cron.Parse("5/20 * * * *", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextN(schedule, 3)).To(Equal([]time.Time{
+			time.Date(2017, 3, 12, 0, 5, 0, 0, time.UTC),
+			time.Date(2017, 3, 12, 0, 25, 0, 0, time.UTC),
+			time.Date(2017, 3, 12, 0, 45, 0, 0, time.UTC),
+		}))
+	})
+
+	It("supports named days and ranges", func() {
+		schedule, err := cron.Parse("30 9 * * mon-fri", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextN(schedule, 2)).To(Equal([]time.Time{
+			time.Date(2017, 3, 13, 9, 30, 0, 0, time.UTC),
+			time.Date(2017, 3, 14, 9, 30, 0, 0, time.UTC),
+		}))
+	})
+
+	It("supports descriptors", func() {
+		schedule, err := cron.Parse("@weekly", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextN(schedule, 2)).To(Equal([]time.Time{
+			time.Date(2017, 3, 12, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 3, 19, 0, 0, 0, 0, time.UTC),
+		}))
+	})
+
+	It("matches either day field when both are restricted", func() {
+		schedule, err := cron.Parse("0 0 1,15 * 5", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextN(schedule, 3)).To(Equal([]time.Time{
+			time.Date(2017, 3, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 3, 17, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 3, 24, 0, 0, 0, 0, time.UTC),
+		}))
+	})
+
+	Context("with a location", func() {
+		It("evaluates the expression in that time zone", func() {
+			schedule, err := cron.Parse("30 9 * * *", "Asia/Kolkata")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(schedule.Next(after).UTC()).To(Equal(time.Date(2017, 3, 12, 4, 0, 0, 0, time.UTC)))
+		})
+
+		Context("when the clocks go forward past the time it fires", func() {
+			It("fires at the first instant after the gap", func() {
+				schedule, err := cron.Parse("0 2 * * *", "America/New_York")
+				Expect(err).NotTo(HaveOccurred())
+
+				first := schedule.Next(after)
+				Expect(first).To(BeTemporally("==", time.Date(2017, 3, 12, 7, 0, 0, 0, time.UTC)))
+
+				Expect(schedule.Next(first)).To(BeTemporally("==", time.Date(2017, 3, 13, 6, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		Context("when the clocks go back over the time it fires", func() {
+			It("fires only the first time round", func() {
+				schedule, err := cron.Parse("30 1 * * *", "America/New_York")
+				Expect(err).NotTo(HaveOccurred())
+
+				first := schedule.Next(time.Date(2017, 11, 5, 0, 0, 0, 0, time.UTC))
+				Expect(first).To(BeTemporally("==", time.Date(2017, 11, 5, 5, 30, 0, 0, time.UTC)))
+
+				Expect(schedule.Next(first)).To(BeTemporally("==", time.Date(2017, 11, 6, 6, 30, 0, 0, time.UTC)))
+			})
+		})
+
+		Context("on a day that is shortened by daylight saving time", func() {
+			It("fires at midnight on the next day", func() {
+				london, err := time.LoadLocation("Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				schedule, err := cron.Parse("0 0 * * 1", "Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(schedule.Next(time.Date(2026, 3, 29, 0, 1, 0, 0, london))).To(BeTemporally("==", time.Date(2026, 3, 30, 0, 0, 0, 0, london)))
+			})
+		})
+
+		Context("on a day that is lengthened by daylight saving time", func() {
+			It("fires at midnight on the next day", func() {
+				london, err := time.LoadLocation("Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				schedule, err := cron.Parse("0 0 * * 1", "Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(schedule.Next(time.Date(2026, 10, 25, 0, 1, 0, 0, london))).To(BeTemporally("==", time.Date(2026, 10, 26, 0, 0, 0, 0, london)))
+			})
+
+			It("fires every day at the same local time across the transition", func() {
+				london, err := time.LoadLocation("Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				schedule, err := cron.Parse("30 23 * * *", "Europe/London")
+				Expect(err).NotTo(HaveOccurred())
+
+				first := schedule.Next(time.Date(2026, 10, 24, 23, 31, 0, 0, london))
+				Expect(first).To(BeTemporally("==", time.Date(2026, 10, 25, 23, 30, 0, 0, london)))
+
+				Expect(schedule.Next(first)).To(BeTemporally("==", time.Date(2026, 10, 26, 23, 30, 0, 0, london)))
+			})
+		})
+	})
+
+	DescribeTable("invalid expressions",
+		func(expr string, location string, message string) {
+			_, err := cron.Parse(expr, location)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("too few fields", "* * *", "", "expected 5 fields"),
+		Entry("out of range", "61 * * * *", "", "invalid value in minute field '61'"),
+		Entry("unknown name", "0 0 * * funday", "", "invalid value in day of week field 'funday'"),
+		Entry("backwards range", "0 5-1 * * *", "", "invalid range in hour field '5-1'"),
+		Entry("zero step", "*/0 * * * *", "", "invalid step in minute field '*/0'"),
+		Entry("impossible date", "0 0 30 2 *", "", "never matches"),
+		Entry("unknown location", "0 0 * * *", "Mars/Olympus_Mons", "invalid location 'Mars/Olympus_Mons'"),
+	)
+})
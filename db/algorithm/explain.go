@@ -0,0 +1,134 @@
+package algorithm
+
+import "sort"
+
+type CandidateStatus string
+
+const (
+	// the version was chosen for the next build
+	CandidateChosen CandidateStatus = "chosen"
+
+	// the version could be used, but another version was chosen
+	CandidateSatisfiable CandidateStatus = "satisfiable"
+
+	// the input only uses the latest version
+	CandidateNotLatest CandidateStatus = "not_latest"
+
+	// the input is pinned to another version
+	CandidateNotPinned CandidateStatus = "not_pinned"
+
+	// the version has not made it through one of the passed jobs
+	CandidateNotPassed CandidateStatus = "not_passed"
+
+	// no combination of the other inputs' versions can be used with the
+	// version, e.g. because no single build of a shared passed job used them
+	CandidateUnsatisfiable CandidateStatus = "unsatisfiable"
+
+	// the version is not known to the versions DB, e.g. because it has been
+	// disabled
+	CandidateUnavailable CandidateStatus = "unavailable"
+)
+
+type InputExplanation struct {
+	Name              string
+	ResolvedVersionID int
+	Candidates        []CandidateExplanation
+}
+
+type CandidateExplanation struct {
+	VersionID int
+	Status    CandidateStatus
+
+	// set to the job that eliminated the version when the status is
+	// CandidateNotPassed
+	JobID int
+}
+
+// Explain resolves the inputs without saving anything, and reports for each
+// of the given versions of each input why it was or was not chosen.
+func (configs InputConfigs) Explain(db *VersionsDB, versionIDs map[string][]int) ([]InputExplanation, bool) {
+	mapping, resolved := configs.Resolve(db)
+
+	explanations := []InputExplanation{}
+	for i, inputConfig := range configs {
+		explanation := InputExplanation{
+			Name:       inputConfig.Name,
+			Candidates: []CandidateExplanation{},
+		}
+
+		if resolved {
+			explanation.ResolvedVersionID = mapping[inputConfig.Name].VersionID
+		}
+
+		for _, versionID := range versionIDs[inputConfig.Name] {
+			explanation.Candidates = append(explanation.Candidates, configs.explainCandidate(db, i, versionID, explanation.ResolvedVersionID))
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations, resolved
+}
+
+func (configs InputConfigs) explainCandidate(db *VersionsDB, input int, versionID int, resolvedVersionID int) CandidateExplanation {
+	inputConfig := configs[input]
+
+	candidate := CandidateExplanation{VersionID: versionID}
+
+	if versionID == resolvedVersionID {
+		candidate.Status = CandidateChosen
+		return candidate
+	}
+
+	_, found := db.FindVersionOfResource(inputConfig.ResourceID, versionID)
+	if !found {
+		candidate.Status = CandidateUnavailable
+		return candidate
+	}
+
+	// a pin rules out every other version, whatever the input's other
+	// constraints
+	if inputConfig.PinnedVersionID != 0 && versionID != inputConfig.PinnedVersionID {
+		candidate.Status = CandidateNotPinned
+		return candidate
+	}
+
+	if len(inputConfig.Passed) == 0 && !inputConfig.UseEveryVersion && inputConfig.PinnedVersionID == 0 {
+		latest, _ := db.LatestVersionOfResource(inputConfig.ResourceID)
+		if versionID != latest.VersionID {
+			candidate.Status = CandidateNotLatest
+			return candidate
+		}
+	}
+
+	jobIDs := []int{}
+	for jobID := range inputConfig.Passed {
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	sort.Ints(jobIDs)
+
+	for _, jobID := range jobIDs {
+		passed := db.VersionsOfResourcePassedJobs(inputConfig.ResourceID, JobSet{jobID: struct{}{}})
+		if passed.ForVersion(versionID).IsEmpty() {
+			candidate.Status = CandidateNotPassed
+			candidate.JobID = jobID
+			return candidate
+		}
+	}
+
+	pinnedConfigs := make(InputConfigs, len(configs))
+	copy(pinnedConfigs, configs)
+
+	pinnedConfigs[input].PinnedVersionID = versionID
+	pinnedConfigs[input].UseEveryVersion = false
+
+	_, ok := pinnedConfigs.Resolve(db)
+	if ok {
+		candidate.Status = CandidateSatisfiable
+	} else {
+		candidate.Status = CandidateUnsatisfiable
+	}
+
+	return candidate
+}
@@ -0,0 +1,183 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		versionIDs   map[string][]int
+
+		explanations []algorithm.InputExplanation
+		resolved     bool
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 22, CheckOrder: 1},
+				{VersionID: 5, ResourceID: 22, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 22, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         32,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 5, ResourceID: 22, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+
+		inputConfigs = algorithm.InputConfigs{
+			{
+				Name:       "a",
+				JobName:    "j2",
+				Passed:     algorithm.JobSet{11: struct{}{}},
+				ResourceID: 21,
+				JobID:      12,
+			},
+			{
+				Name:       "b",
+				JobName:    "j2",
+				Passed:     algorithm.JobSet{11: struct{}{}},
+				ResourceID: 22,
+				JobID:      12,
+			},
+			{
+				Name:       "c",
+				JobName:    "j2",
+				Passed:     algorithm.JobSet{},
+				ResourceID: 21,
+				JobID:      12,
+			},
+		}
+
+		versionIDs = map[string][]int{
+			"a": {3, 2, 1},
+			"b": {5, 4},
+			"c": {3, 2, 99},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanations, resolved = inputConfigs.Explain(versionsDB, versionIDs)
+	})
+
+	It("explains why each candidate was or was not chosen", func() {
+		Expect(resolved).To(BeTrue())
+		Expect(explanations).To(Equal([]algorithm.InputExplanation{
+			{
+				Name:              "a",
+				ResolvedVersionID: 1,
+				Candidates: []algorithm.CandidateExplanation{
+					{VersionID: 3, Status: algorithm.CandidateNotPassed, JobID: 11},
+					{VersionID: 2, Status: algorithm.CandidateUnsatisfiable},
+					{VersionID: 1, Status: algorithm.CandidateChosen},
+				},
+			},
+			{
+				Name:              "b",
+				ResolvedVersionID: 4,
+				Candidates: []algorithm.CandidateExplanation{
+					{VersionID: 5, Status: algorithm.CandidateUnsatisfiable},
+					{VersionID: 4, Status: algorithm.CandidateChosen},
+				},
+			},
+			{
+				Name:              "c",
+				ResolvedVersionID: 3,
+				Candidates: []algorithm.CandidateExplanation{
+					{VersionID: 3, Status: algorithm.CandidateChosen},
+					{VersionID: 2, Status: algorithm.CandidateNotLatest},
+					{VersionID: 99, Status: algorithm.CandidateUnavailable},
+				},
+			},
+		}))
+	})
+
+	Context("when an input is pinned", func() {
+		BeforeEach(func() {
+			inputConfigs[2].PinnedVersionID = 2
+		})
+
+		It("eliminates the other versions", func() {
+			Expect(explanations[2].ResolvedVersionID).To(Equal(2))
+			Expect(explanations[2].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 3, Status: algorithm.CandidateNotPinned},
+				{VersionID: 2, Status: algorithm.CandidateChosen},
+				{VersionID: 99, Status: algorithm.CandidateUnavailable},
+			}))
+		})
+	})
+
+	Context("when an input with passed constraints is pinned", func() {
+		BeforeEach(func() {
+			inputConfigs[0].PinnedVersionID = 1
+		})
+
+		It("eliminates the other versions before checking the constraints", func() {
+			Expect(explanations[0].ResolvedVersionID).To(Equal(1))
+			Expect(explanations[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 3, Status: algorithm.CandidateNotPinned},
+				{VersionID: 2, Status: algorithm.CandidateNotPinned},
+				{VersionID: 1, Status: algorithm.CandidateChosen},
+			}))
+		})
+	})
+
+	Context("when an input uses every version", func() {
+		BeforeEach(func() {
+			inputConfigs[2].UseEveryVersion = true
+		})
+
+		It("considers the versions that were not chosen satisfiable", func() {
+			Expect(explanations[2].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 3, Status: algorithm.CandidateChosen},
+				{VersionID: 2, Status: algorithm.CandidateSatisfiable},
+				{VersionID: 99, Status: algorithm.CandidateUnavailable},
+			}))
+		})
+	})
+
+	Context("when the inputs cannot be resolved", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{}
+		})
+
+		It("explains every candidate without choosing any", func() {
+			Expect(resolved).To(BeFalse())
+			Expect(explanations[0]).To(Equal(algorithm.InputExplanation{
+				Name: "a",
+				Candidates: []algorithm.CandidateExplanation{
+					{VersionID: 3, Status: algorithm.CandidateNotPassed, JobID: 11},
+					{VersionID: 2, Status: algorithm.CandidateNotPassed, JobID: 11},
+					{VersionID: 1, Status: algorithm.CandidateNotPassed, JobID: 11},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// APIToken is a long-lived credential for automation. Only a hash of the
+// token's value is ever stored.
+type APIToken struct {
+	Name      string
+	Scope     atc.APITokenScope
+	ExpiresAt time.Time
+}
+
+type SavedAPIToken struct {
+	ID int
+	APIToken
+
+	TeamID    int
+	TeamName  string
+	TeamAdmin bool
+	CreatedAt time.Time
+}
+
+func (token SavedAPIToken) IsExpired(now time.Time) bool {
+	return !token.ExpiresAt.IsZero() && !now.Before(token.ExpiresAt)
+}
@@ -23,8 +23,10 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const BuildTriggerReasonSchedule = "schedule"
+
+const buildColumns = "id, name, job_id, team_id, status, scheduled, engine, engine_metadata, start_time, end_time, reap_time, trigger_reason, annotations"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.trigger_reason, b.annotations, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name, p.instance_vars as pipeline_instance_vars"
 
 //go:generate counterfeiter . Build
 
@@ -33,6 +35,7 @@ type Build interface {
 	Name() string
 	JobName() string
 	PipelineName() string
+	PipelineInstanceVars() atc.InstanceVars
 	TeamName() string
 	TeamID() int
 	Engine() string
@@ -41,6 +44,8 @@ type Build interface {
 	StartTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
+	TriggerReason() string
+	Annotations() atc.BuildAnnotations
 	IsOneOff() bool
 	IsScheduled() bool
 	IsRunning() bool
@@ -65,6 +70,11 @@ type Build interface {
 
 	SaveEngineMetadata(engineMetadata string) error
 
+	SaveAnnotations(annotations atc.BuildAnnotations) error
+
+	SaveTestReport(report atc.TestReport) error
+	GetTestReports() ([]atc.TestReport, error)
+
 	SaveInput(input BuildInput) (SavedVersionedResource, error)
 	SaveOutput(vr VersionedResource, explicit bool) (SavedVersionedResource, error)
 
@@ -82,11 +92,12 @@ type build struct {
 	status    Status
 	scheduled bool
 
-	jobName      string
-	pipelineName string
-	pipelineID   int
-	teamName     string
-	teamID       int
+	jobName              string
+	pipelineName         string
+	pipelineInstanceVars atc.InstanceVars
+	pipelineID           int
+	teamName             string
+	teamID               int
 
 	engine         string
 	engineMetadata string
@@ -95,6 +106,10 @@ type build struct {
 	endTime   time.Time
 	reapTime  time.Time
 
+	triggerReason string
+
+	annotations atc.BuildAnnotations
+
 	conn Conn
 	bus  *notificationsBus
 
@@ -117,6 +132,10 @@ func (b *build) PipelineName() string {
 	return b.pipelineName
 }
 
+func (b *build) PipelineInstanceVars() atc.InstanceVars {
+	return b.pipelineInstanceVars
+}
+
 func (b *build) TeamName() string {
 	return b.teamName
 }
@@ -145,6 +164,14 @@ func (b *build) ReapTime() time.Time {
 	return b.reapTime
 }
 
+func (b *build) TriggerReason() string {
+	return b.triggerReason
+}
+
+func (b *build) Annotations() atc.BuildAnnotations {
+	return b.annotations
+}
+
 func (b *build) Status() Status {
 	return b.status
 }
@@ -193,10 +220,13 @@ func (b *build) Reload() (bool, error) {
 	b.startTime = newBuild.StartTime()
 	b.endTime = newBuild.EndTime()
 	b.reapTime = newBuild.ReapTime()
+	b.triggerReason = newBuild.TriggerReason()
+	b.annotations = newBuild.Annotations()
 	b.teamName = newBuild.TeamName()
 	b.teamID = newBuild.TeamID()
 	b.jobName = newBuild.JobName()
 	b.pipelineName = newBuild.PipelineName()
+	b.pipelineInstanceVars = newBuild.PipelineInstanceVars()
 
 	return found, err
 }
@@ -217,12 +247,44 @@ func (b *build) Events(from uint) (EventSource, error) {
 	return newSQLDBBuildEventSource(
 		b.id,
 		table,
-		b.conn,
+		b.eventsConn(),
 		notifier,
 		from,
 	), nil
 }
 
+// the events of a finished build never change, so they can be replayed from
+// the read replica once it has caught up with the build finishing
+func (b *build) eventsConn() Conn {
+	if b.IsRunning() {
+		return b.conn
+	}
+
+	conn := reader(b.conn)
+	if conn == b.conn {
+		return b.conn
+	}
+
+	// QueryRow would go to the primary, which says nothing about the replica
+	rows, err := conn.Query(`
+		SELECT completed
+		FROM builds
+		WHERE id = $1
+	`, b.id)
+	if err != nil {
+		return b.conn
+	}
+
+	defer rows.Close()
+
+	var completed bool
+	if !rows.Next() || rows.Scan(&completed) != nil || !completed {
+		return b.conn
+	}
+
+	return conn
+}
+
 func (b *build) Start(engine, metadata string) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -562,6 +624,8 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			TeamQuota:           BuildPreparationStatusNotBlocking,
+			BuildQueue:          BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -572,12 +636,14 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		pausedPipeline         bool
 		pausedJob              bool
 		maxInFlightReached     bool
+		teamQuotaReached       bool
+		buildQueueBlocked      bool
 		pipelineID             int
 		resourceCheckIsRunning bool
 		jobName                string
 	)
 	err := b.conn.QueryRow(`
-			SELECT p.paused, j.paused, j.max_in_flight_reached, j.pipeline_id, j.name,
+			SELECT p.paused, j.paused, j.max_in_flight_reached, j.team_quota_reached, j.build_queue_blocked, j.pipeline_id, j.name,
 				j.resource_checking = true AND j.resource_check_waiver_end < $1
 			FROM builds b
 			JOIN jobs j
@@ -585,7 +651,7 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 			JOIN pipelines p
 				ON j.pipeline_id = p.id
 			WHERE b.id = $1
-		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &pipelineID, &jobName, &resourceCheckIsRunning)
+		`, b.id).Scan(&pausedPipeline, &pausedJob, &maxInFlightReached, &teamQuotaReached, &buildQueueBlocked, &pipelineID, &jobName, &resourceCheckIsRunning)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return BuildPreparation{}, false, nil
@@ -608,27 +674,53 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	teamQuotaReachedStatus := BuildPreparationStatusNotBlocking
+	if teamQuotaReached {
+		teamQuotaReachedStatus = BuildPreparationStatusBlocking
+	}
+
+	buildQueueStatus := BuildPreparationStatusNotBlocking
+	var queuePosition int
+	var queueWaitTime time.Duration
+	if buildQueueBlocked {
+		buildQueueStatus = BuildPreparationStatusBlocking
+
+		queue, err := getBuildQueue(b.conn)
+		if err != nil {
+			return BuildPreparation{}, false, err
+		}
+
+		position, found := queue.Position(b.id)
+		if found {
+			queuePosition = position
+			queueWaitTime = time.Since(queue.Builds[position-1].QueuedAt)
+		}
+	}
+
 	if resourceCheckIsRunning {
 		return BuildPreparation{
 			BuildID:             b.id,
 			PausedPipeline:      pausedPipelineStatus,
 			PausedJob:           pausedJobStatus,
 			MaxRunningBuilds:    maxInFlightReachedStatus,
+			TeamQuota:           teamQuotaReachedStatus,
+			BuildQueue:          buildQueueStatus,
+			QueuePosition:       queuePosition,
+			QueueWaitTime:       queueWaitTime,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusUnknown,
 			MissingInputReasons: MissingInputReasons{},
 		}, true, nil
 	}
 
-	tdbf := NewTeamDBFactory(b.conn, b.bus, b.lockFactory)
-	tdb := tdbf.GetTeamDB(b.teamName)
-	savedPipeline, found, err := tdb.GetPipelineByName(b.pipelineName)
+	// by ID, as instances of a pipeline share its name
+	savedPipeline, err := b.GetPipeline()
 	if err != nil {
-		return BuildPreparation{}, false, err
-	}
+		if err == sql.ErrNoRows {
+			return BuildPreparation{}, false, nil
+		}
 
-	if !found {
-		return BuildPreparation{}, false, nil
+		return BuildPreparation{}, false, err
 	}
 
 	pdbf := NewPipelineDBFactory(b.conn, b.bus, b.lockFactory)
@@ -714,6 +806,10 @@ func (b *build) GetPreparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		TeamQuota:           teamQuotaReachedStatus,
+		BuildQueue:          buildQueueStatus,
+		QueuePosition:       queuePosition,
+		QueueWaitTime:       queueWaitTime,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -773,6 +869,145 @@ func (b *build) SaveEngineMetadata(engineMetadata string) error {
 	return nil
 }
 
+// SaveAnnotations merges the given annotations into the build's. Metadata
+// replaces any existing metadata with the same name; links are appended.
+func (b *build) SaveAnnotations(annotations atc.BuildAnnotations) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var existingBlob sql.NullString
+	err = tx.QueryRow(`
+		SELECT annotations
+		FROM builds
+		WHERE id = $1
+		FOR UPDATE
+	`, b.id).Scan(&existingBlob)
+	if err != nil {
+		return err
+	}
+
+	var merged atc.BuildAnnotations
+	if existingBlob.Valid {
+		err = json.Unmarshal([]byte(existingBlob.String), &merged)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, field := range annotations.Metadata {
+		replaced := false
+		for i, existing := range merged.Metadata {
+			if existing.Name == field.Name {
+				merged.Metadata[i] = field
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			merged.Metadata = append(merged.Metadata, field)
+		}
+	}
+
+	merged.Links = append(merged.Links, annotations.Links...)
+
+	mergedBlob, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET annotations = $2
+		WHERE id = $1
+	`, b.id, string(mergedBlob))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.annotations = merged
+
+	return nil
+}
+
+func (b *build) SaveTestReport(report atc.TestReport) error {
+	failedCases := report.FailedCases
+	if failedCases == nil {
+		failedCases = []atc.TestCaseFailure{}
+	}
+
+	failedCasesBlob, err := json.Marshal(failedCases)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.conn.Exec(`
+		INSERT INTO build_test_reports (build_id, step_name, plan_id, format, path, tests, failures, errors, skipped, duration, failed_cases)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, b.id, report.StepName, string(report.PlanID), report.Format, report.Path, report.Tests, report.Failures, report.Errors, report.Skipped, report.Duration, string(failedCasesBlob))
+
+	return err
+}
+
+func (b *build) GetTestReports() ([]atc.TestReport, error) {
+	rows, err := b.conn.Query(`
+		SELECT step_name, plan_id, format, path, tests, failures, errors, skipped, duration, failed_cases
+		FROM build_test_reports
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reports := []atc.TestReport{}
+	for rows.Next() {
+		report, err := scanTestReport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func scanTestReport(row scannable) (atc.TestReport, error) {
+	var report atc.TestReport
+	var planID string
+	var failedCasesBlob string
+
+	err := row.Scan(&report.StepName, &planID, &report.Format, &report.Path, &report.Tests, &report.Failures, &report.Errors, &report.Skipped, &report.Duration, &failedCasesBlob)
+	if err != nil {
+		return atc.TestReport{}, err
+	}
+
+	report.PlanID = atc.PlanID(planID)
+
+	err = json.Unmarshal([]byte(failedCasesBlob), &report.FailedCases)
+	if err != nil {
+		return atc.TestReport{}, err
+	}
+
+	if len(report.FailedCases) == 0 {
+		report.FailedCases = nil
+	}
+
+	return report, nil
+}
+
 func (b *build) SaveImageResourceVersion(planID atc.PlanID, identifier ResourceCacheIdentifier) error {
 	version, err := json.Marshal(identifier.ResourceVersion)
 	if err != nil {
@@ -803,6 +1038,21 @@ func (b *build) SaveImageResourceVersion(planID atc.PlanID, identifier ResourceC
 		}
 	}
 
+	_, err = b.conn.Exec(`
+		UPDATE volumes
+		SET owner_type = $1
+		WHERE owner_type = $2
+		AND owner_id IN (
+			SELECT id
+			FROM resource_caches
+			WHERE resource_hash = $3
+			AND resource_version = $4
+		)
+	`, string(OwnerImageCache), string(OwnerResourceCache), identifier.ResourceHash, string(version))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
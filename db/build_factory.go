@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/lib/pq"
 )
@@ -27,13 +28,13 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var jobID, pipelineID, teamID sql.NullInt64
 	var status string
 	var scheduled bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, triggerReason, annotations, jobName, pipelineName, pipelineInstanceVars sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
 	var teamName string
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &triggerReason, &annotations, &jobName, &pipelineID, &pipelineName, &teamName, &pipelineInstanceVars)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -59,15 +60,31 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		endTime:   endTime.Time,
 		reapTime:  reapTime.Time,
 
+		triggerReason: triggerReason.String,
+
 		teamName: teamName,
 	}
 
+	if annotations.Valid {
+		err := json.Unmarshal([]byte(annotations.String), &build.annotations)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	if jobID.Valid {
 		build.jobName = jobName.String
 		build.pipelineName = pipelineName.String
 		build.pipelineID = int(pipelineID.Int64)
 	}
 
+	if pipelineInstanceVars.Valid {
+		err := json.Unmarshal([]byte(pipelineInstanceVars.String), &build.pipelineInstanceVars)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	if teamID.Valid {
 		build.teamID = int(teamID.Int64)
 	}
@@ -1,6 +1,9 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type BuildPreparationStatus string
 
@@ -35,6 +38,10 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus
 	PausedJob           BuildPreparationStatus
 	MaxRunningBuilds    BuildPreparationStatus
+	TeamQuota           BuildPreparationStatus
+	BuildQueue          BuildPreparationStatus
+	QueuePosition       int
+	QueueWaitTime       time.Duration
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
@@ -0,0 +1,225 @@
+package db
+
+import (
+	"sort"
+	"time"
+)
+
+type QueuedBuild struct {
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	TeamID       int
+	TeamName     string
+	Priority     int
+	QueuedAt     time.Time
+}
+
+// BuildQueue is every pending build that is only waiting for worker capacity
+// to be scheduled, in the order in which they will leave the queue. Builds
+// which are held back by anything else, e.g. their job's max-in-flight or
+// serial groups, their team's quota or their inputs not being determined yet,
+// are left out so that they do not take up the slots of builds that could
+// start.
+type BuildQueue struct {
+	Builds        []QueuedBuild
+	RunningBuilds int
+	Workers       int
+}
+
+// Position returns the 1-based position of the build in the queue.
+func (queue BuildQueue) Position(buildID int) (int, bool) {
+	for i, build := range queue.Builds {
+		if build.BuildID == buildID {
+			return i + 1, true
+		}
+	}
+
+	return 0, false
+}
+
+type teamShare struct {
+	weight  int
+	running int
+}
+
+// orderBuildQueue interleaves the teams' builds so that each team's share of
+// running and dequeued builds stays proportional to its weight. Within a
+// team, builds of higher priority jobs go first, followed by the oldest.
+func orderBuildQueue(builds []QueuedBuild, shares map[int]teamShare) []QueuedBuild {
+	teamBuilds := map[int][]QueuedBuild{}
+	teamIDs := []int{}
+	for _, build := range builds {
+		if _, found := teamBuilds[build.TeamID]; !found {
+			teamIDs = append(teamIDs, build.TeamID)
+		}
+
+		teamBuilds[build.TeamID] = append(teamBuilds[build.TeamID], build)
+	}
+
+	sort.Ints(teamIDs)
+
+	for _, teamID := range teamIDs {
+		sort.Sort(byPriorityAndAge(teamBuilds[teamID]))
+	}
+
+	taken := map[int]int{}
+	for teamID, share := range shares {
+		taken[teamID] = share.running
+	}
+
+	ordered := make([]QueuedBuild, 0, len(builds))
+	for len(ordered) < len(builds) {
+		next := -1
+		var nextLoad float64
+
+		for _, teamID := range teamIDs {
+			if len(teamBuilds[teamID]) == 0 {
+				continue
+			}
+
+			weight := shares[teamID].weight
+			if weight <= 0 {
+				weight = 1
+			}
+
+			load := float64(taken[teamID]) / float64(weight)
+			if next == -1 || load < nextLoad {
+				next = teamID
+				nextLoad = load
+			}
+		}
+
+		ordered = append(ordered, teamBuilds[next][0])
+		teamBuilds[next] = teamBuilds[next][1:]
+		taken[next]++
+	}
+
+	return ordered
+}
+
+type byPriorityAndAge []QueuedBuild
+
+func (builds byPriorityAndAge) Len() int      { return len(builds) }
+func (builds byPriorityAndAge) Swap(i, j int) { builds[i], builds[j] = builds[j], builds[i] }
+func (builds byPriorityAndAge) Less(i, j int) bool {
+	if builds[i].Priority != builds[j].Priority {
+		return builds[i].Priority > builds[j].Priority
+	}
+
+	return builds[i].BuildID < builds[j].BuildID
+}
+
+func getBuildQueue(conn Conn) (BuildQueue, error) {
+	var queue BuildQueue
+
+	err := conn.QueryRow(`
+		SELECT
+			(
+				SELECT COUNT(*)
+				FROM builds
+				WHERE status = 'started'
+				OR (scheduled = true AND status = 'pending')
+			),
+			(
+				SELECT COUNT(*)
+				FROM workers
+				WHERE expires IS NULL OR expires > NOW()
+			)
+	`).Scan(&queue.RunningBuilds, &queue.Workers)
+	if err != nil {
+		return BuildQueue{}, err
+	}
+
+	shares := map[int]teamShare{}
+
+	rows, err := conn.Query(`
+		SELECT t.id, t.build_queue_weight, COUNT(b.id)
+		FROM teams t
+		LEFT JOIN builds b
+			ON b.team_id = t.id
+			AND (
+				b.status = 'started'
+				OR
+				(b.scheduled = true AND b.status = 'pending')
+			)
+		GROUP BY t.id
+	`)
+	if err != nil {
+		return BuildQueue{}, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int
+		var share teamShare
+
+		err := rows.Scan(&teamID, &share.weight, &share.running)
+		if err != nil {
+			return BuildQueue{}, err
+		}
+
+		shares[teamID] = share
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return BuildQueue{}, err
+	}
+
+	builds := []QueuedBuild{}
+
+	rows, err = conn.Query(`
+		SELECT b.id, b.name, j.name, p.name, t.id, t.name,
+			COALESCE((j.config->>'priority')::integer, 0), b.create_time
+		FROM builds b
+		JOIN jobs j ON b.job_id = j.id
+		JOIN pipelines p ON j.pipeline_id = p.id
+		JOIN teams t ON b.team_id = t.id
+		WHERE b.status = 'pending'
+		AND b.scheduled = false
+		AND j.active = true
+		AND j.paused = false
+		AND j.inputs_determined = true
+		AND j.max_in_flight_reached = false
+		AND j.team_quota_reached = false
+		AND p.paused = false
+		ORDER BY b.id
+	`)
+	if err != nil {
+		return BuildQueue{}, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var build QueuedBuild
+
+		err := rows.Scan(
+			&build.BuildID,
+			&build.BuildName,
+			&build.JobName,
+			&build.PipelineName,
+			&build.TeamID,
+			&build.TeamName,
+			&build.Priority,
+			&build.QueuedAt,
+		)
+		if err != nil {
+			return BuildQueue{}, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return BuildQueue{}, err
+	}
+
+	queue.Builds = orderBuildQueue(builds, shares)
+
+	return queue, nil
+}
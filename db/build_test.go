@@ -117,6 +117,47 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveAnnotations", func() {
+		It("merges the annotations with any previously saved", func() {
+			build, err := teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Annotations()).To(BeZero())
+
+			err = build.SaveAnnotations(atc.BuildAnnotations{
+				Metadata: []atc.MetadataField{
+					{Name: "coverage", Value: "80%"},
+					{Name: "commit", Value: "abc"},
+				},
+				Links: []atc.BuildLink{{Name: "report", URL: "https://example.com/1"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveAnnotations(atc.BuildAnnotations{
+				Metadata: []atc.MetadataField{{Name: "coverage", Value: "84%"}},
+				Links:    []atc.BuildLink{{Name: "report", URL: "https://example.com/2"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedAnnotations := atc.BuildAnnotations{
+				Metadata: []atc.MetadataField{
+					{Name: "coverage", Value: "84%"},
+					{Name: "commit", Value: "abc"},
+				},
+				Links: []atc.BuildLink{
+					{Name: "report", URL: "https://example.com/1"},
+					{Name: "report", URL: "https://example.com/2"},
+				},
+			}
+
+			Expect(build.Annotations()).To(Equal(expectedAnnotations))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Annotations()).To(Equal(expectedAnnotations))
+		})
+	})
+
 	Describe("SaveEvent", func() {
 		It("saves and propagates events correctly", func() {
 			build, err := teamDB.CreateOneOffBuild()
@@ -474,6 +515,8 @@ var _ = Describe("Build", func() {
 				PausedPipeline:      db.BuildPreparationStatusNotBlocking,
 				PausedJob:           db.BuildPreparationStatusNotBlocking,
 				MaxRunningBuilds:    db.BuildPreparationStatusNotBlocking,
+				TeamQuota:           db.BuildPreparationStatusNotBlocking,
+				BuildQueue:          db.BuildPreparationStatusNotBlocking,
 				Inputs:              map[string]db.BuildPreparationStatus{},
 				InputsSatisfied:     db.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: db.MissingInputReasons{},
@@ -619,6 +662,44 @@ var _ = Describe("Build", func() {
 					})
 				})
 
+				Context("when the team quota is reached", func() {
+					BeforeEach(func() {
+						err := pipelineDB.SetTeamQuotaReached("some-job", true)
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.TeamQuota = db.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with team quota reached", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+
+				Context("when the build is blocked in the build queue", func() {
+					BeforeEach(func() {
+						err := pipelineDB.SetBuildQueueBlocked("some-job", true)
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.BuildQueue = db.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with the build's place in the queue", func() {
+						buildPrep, found, err := build.GetPreparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+
+						Expect(buildPrep.QueuePosition).To(BeNumerically(">=", 1))
+						Expect(buildPrep.QueueWaitTime).To(BeNumerically(">=", 0))
+
+						buildPrep.QueuePosition = 0
+						buildPrep.QueueWaitTime = 0
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
+
 				Context("when max running builds is de-reached", func() {
 					BeforeEach(func() {
 						err := pipelineDB.SetMaxInFlightReached("some-job", true)
@@ -34,6 +34,12 @@ const (
 	ContainerStageCheck = "check"
 	ContainerStageGet   = "get"
 	ContainerStageRun   = "run"
+
+	// ContainerStagePrefetch is the stage of the containers which fetch a
+	// resource's new versions onto workers ahead of the builds that need them.
+	// They are identified by the resource like its check containers, but must
+	// never be used for checking.
+	ContainerStagePrefetch = "prefetch"
 )
 
 type ContainerMetadata struct {
@@ -76,13 +76,26 @@ type DB interface {
 	DeleteTeamByName(teamName string) error
 
 	GetAllStartedBuilds() ([]Build, error)
+	GetBuildQueue() (BuildQueue, error)
 	GetPublicBuilds(page Page) ([]Build, Pagination, error)
 
 	FindJobIDForBuild(buildID int) (int, bool, error)
 
+	FindAPITokenByHash(tokenHash string) (SavedAPIToken, bool, error)
+
+	RevokeToken(tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(tokenID string) (bool, error)
+
 	CreatePipe(pipeGUID string, url string, teamID int) error
 	GetPipe(pipeGUID string) (Pipe, error)
 
+	CreateHijackSession(session HijackSession) (SavedHijackSession, error)
+	SaveHijackSessionEvent(sessionID int, event HijackSessionEvent) error
+	FinishHijackSession(sessionID int, exitStatus *int) error
+	GetHijackSessions() ([]SavedHijackSession, error)
+	GetHijackSession(sessionID int) (SavedHijackSession, bool, error)
+	GetHijackSessionEvents(sessionID int) ([]HijackSessionEvent, error)
+
 	GetTaskLock(logger lager.Logger, taskName string) (Lock, bool, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
@@ -106,6 +119,7 @@ type DB interface {
 	GetVolumesByIdentifier(VolumeIdentifier) ([]SavedVolume, error)
 	ReapVolume(string) error
 	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
+	SetVolumeSizeInBytes(string, int64) error
 	SetVolumeTTL(string, time.Duration) error
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
 	GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
@@ -159,10 +173,22 @@ type WorkerInfo struct {
 	NoProxy         string
 
 	ActiveContainers int
+	ActiveVolumes    int
+	CPUPressure      float64
+	MemoryPressure   float64
+	DiskPressure     float64
 	ResourceTypes    []atc.WorkerResourceType
 	Platform         string
 	Tags             []string
 	TeamID           int
 	Name             string
 	StartTime        int64
+	State            WorkerState
 }
+
+type WorkerState string
+
+const (
+	WorkerStateRunning WorkerState = atc.WorkerStateRunning
+	WorkerStateStalled WorkerState = atc.WorkerStateStalled
+)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/event"
 )
@@ -31,6 +32,7 @@ var _ = Describe("Builds", func() {
 		pipelineDB        db.PipelineDB
 		pipelineDBFactory db.PipelineDBFactory
 		pipeline          db.SavedPipeline
+		teamDBFactory     db.TeamDBFactory
 		teamDB            db.TeamDB
 		config            atc.Config
 	)
@@ -53,7 +55,7 @@ var _ = Describe("Builds", func() {
 		_, err := database.CreateTeam(db.Team{Name: "some-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
 		teamDB = teamDBFactory.GetTeamDB("some-team")
 
 		config = atc.Config{
@@ -227,6 +229,175 @@ var _ = Describe("Builds", func() {
 		})
 	})
 
+	Describe("GetBuildQueue", func() {
+		var (
+			someJobBuild1     db.Build
+			someJobBuild2     db.Build
+			priorityJobBuild  db.Build
+			otherTeamBuild1   db.Build
+			otherTeamBuild2   db.Build
+			otherTeamPipeline db.PipelineDB
+		)
+
+		BeforeEach(func() {
+			config.Jobs[1].Priority = 10
+
+			updatedPipeline, _, err := teamDB.SaveConfig("some-pipeline", config, pipeline.Version, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+			pipelineDB = pipelineDBFactory.Build(updatedPipeline)
+
+			_, err = database.CreateTeam(db.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamDB := teamDBFactory.GetTeamDB("other-team")
+			otherPipeline, _, err := otherTeamDB.SaveConfig("other-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{{Name: "other-job"}},
+			}, db.ConfigVersion(1), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+			otherTeamPipeline = pipelineDBFactory.Build(otherPipeline)
+
+			someJobBuild1, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			someJobBuild2, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			priorityJobBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamBuild1, err = otherTeamPipeline.CreateJobBuild("other-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamBuild2, err = otherTeamPipeline.CreateJobBuild("other-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, jobName := range []string{"some-job", "some-other-job"} {
+				err = pipelineDB.SaveNextInputMapping(algorithm.InputMapping{}, jobName)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = otherTeamPipeline.SaveNextInputMapping(algorithm.InputMapping{}, "other-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		queuedBuildIDs := func(queue db.BuildQueue) []int {
+			ids := []int{}
+			for _, build := range queue.Builds {
+				ids = append(ids, build.BuildID)
+			}
+			return ids
+		}
+
+		It("orders builds fairly between teams and by priority within a team", func() {
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queuedBuildIDs(queue)).To(Equal([]int{
+				priorityJobBuild.ID(),
+				otherTeamBuild1.ID(),
+				someJobBuild1.ID(),
+				otherTeamBuild2.ID(),
+				someJobBuild2.ID(),
+			}))
+
+			Expect(queue.Builds[0].Priority).To(Equal(10))
+			Expect(queue.Builds[0].TeamName).To(Equal("some-team"))
+			Expect(queue.Builds[1].TeamName).To(Equal("other-team"))
+			Expect(queue.Builds[1].PipelineName).To(Equal("other-pipeline"))
+			Expect(queue.Builds[1].JobName).To(Equal("other-job"))
+		})
+
+		It("counts a team's running builds against its share", func() {
+			started, err := priorityJobBuild.Start("some-engine", "so-meta")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.RunningBuilds).To(Equal(1))
+			Expect(queuedBuildIDs(queue)).To(Equal([]int{
+				otherTeamBuild1.ID(),
+				someJobBuild1.ID(),
+				otherTeamBuild2.ID(),
+				someJobBuild2.ID(),
+			}))
+		})
+
+		Context("when a team has a larger weight", func() {
+			BeforeEach(func() {
+				_, err := teamDB.UpdateQuota(db.TeamQuota{BuildQueueWeight: 3})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("gives the team a proportionally larger share of the queue", func() {
+				queue, err := database.GetBuildQueue()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(queuedBuildIDs(queue)).To(Equal([]int{
+					priorityJobBuild.ID(),
+					otherTeamBuild1.ID(),
+					someJobBuild1.ID(),
+					someJobBuild2.ID(),
+					otherTeamBuild2.ID(),
+				}))
+			})
+		})
+
+		It("does not include builds of paused pipelines", func() {
+			err := otherTeamPipeline.Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queuedBuildIDs(queue)).NotTo(ContainElement(otherTeamBuild1.ID()))
+		})
+
+		It("does not include builds of jobs that have reached their max in flight", func() {
+			err := pipelineDB.SetMaxInFlightReached("some-other-job", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queuedBuildIDs(queue)).To(Equal([]int{
+				someJobBuild1.ID(),
+				otherTeamBuild1.ID(),
+				someJobBuild2.ID(),
+				otherTeamBuild2.ID(),
+			}))
+		})
+
+		It("does not include builds of teams that have reached their quota", func() {
+			err := otherTeamPipeline.SetTeamQuotaReached("other-job", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queuedBuildIDs(queue)).To(Equal([]int{
+				priorityJobBuild.ID(),
+				someJobBuild1.ID(),
+				someJobBuild2.ID(),
+			}))
+		})
+
+		It("does not include builds of jobs whose inputs are not determined", func() {
+			err := pipelineDB.DeleteNextInputMapping("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			queue, err := database.GetBuildQueue()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queuedBuildIDs(queue)).To(Equal([]int{
+				priorityJobBuild.ID(),
+				otherTeamBuild1.ID(),
+				otherTeamBuild2.ID(),
+			}))
+		})
+	})
+
 	Describe("DeleteBuildEventsByBuildIDs", func() {
 		It("deletes all build logs corresponding to the given build ids", func() {
 			build1DB, err := teamDB.CreateOneOffBuild()
@@ -997,6 +997,30 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(found).To(BeTrue())
 		Expect(foundOldSourceContainer.Handle).To(Equal(containerToCreate.Handle))
 
+		By("ignoring prefetch containers of the same resource")
+		prefetchIdentifier := containerToCreate.ContainerIdentifier
+		prefetchIdentifier.Stage = db.ContainerStagePrefetch
+
+		prefetchContainerToCreate := db.Container{
+			ContainerIdentifier: prefetchIdentifier,
+			ContainerMetadata: db.ContainerMetadata{
+				Handle:       "prefetch-handle",
+				PipelineID:   savedPipeline.ID,
+				ResourceName: "some-resource",
+				WorkerName:   "some-worker",
+				Type:         db.ContainerTypeGet,
+				TeamID:       teamID,
+			},
+		}
+
+		_, err = database.CreateContainer(prefetchContainerToCreate, time.Minute, time.Duration(0), []string{})
+		Expect(err).NotTo(HaveOccurred())
+
+		foundCheckContainer, found, err := database.FindContainerByIdentifier(containerToCreate.ContainerIdentifier)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(foundCheckContainer.Handle).To(Equal(containerToCreate.Handle))
+
 		By("differentiating check containers based on their check type")
 		newCheckTypeContainerToCreate := db.Container{
 			ContainerIdentifier: db.ContainerIdentifier{
@@ -0,0 +1,136 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("Hijack sessions", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+	var database db.DB
+	var savedTeam db.SavedTeam
+	var err error
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		database = db.NewSQL(dbConn, bus, lockFactory)
+
+		savedTeam, err = database.CreateTeam(db.Team{Name: "team-name"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateHijackSession", func() {
+		var session db.SavedHijackSession
+
+		BeforeEach(func() {
+			session, err = database.CreateHijackSession(db.HijackSession{
+				TeamID:          savedTeam.ID,
+				HijackedBy:      "main",
+				ContainerHandle: "some-handle",
+				Process: atc.HijackProcessSpec{
+					Path: "bash",
+					Args: []string{"-l"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves an unfinished session", func() {
+			Expect(session.ID).NotTo(BeZero())
+			Expect(session.TeamName).To(Equal("team-name"))
+			Expect(session.HijackedBy).To(Equal("main"))
+			Expect(session.ContainerHandle).To(Equal("some-handle"))
+			Expect(session.Process.Path).To(Equal("bash"))
+			Expect(session.Process.Args).To(Equal([]string{"-l"}))
+			Expect(session.StartTime).NotTo(BeZero())
+			Expect(session.EndTime).To(BeZero())
+			Expect(session.ExitStatus).To(BeNil())
+		})
+
+		It("records events in order", func() {
+			err := database.SaveHijackSessionEvent(session.ID, db.HijackSessionEvent{
+				Elapsed: time.Second,
+				Type:    db.HijackSessionEventOutput,
+				Data:    []byte("$ "),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = database.SaveHijackSessionEvent(session.ID, db.HijackSessionEvent{
+				Elapsed: 2 * time.Second,
+				Type:    db.HijackSessionEventInput,
+				Data:    []byte("ls\r"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := database.GetHijackSessionEvents(session.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]db.HijackSessionEvent{
+				{Elapsed: time.Second, Type: "o", Data: []byte("$ ")},
+				{Elapsed: 2 * time.Second, Type: "i", Data: []byte("ls\r")},
+			}))
+		})
+
+		It("can be finished with an exit status", func() {
+			exitStatus := 3
+			err := database.FinishHijackSession(session.ID, &exitStatus)
+			Expect(err).NotTo(HaveOccurred())
+
+			finishedSession, found, err := database.GetHijackSession(session.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(finishedSession.EndTime).NotTo(BeZero())
+			Expect(finishedSession.ExitStatus).To(Equal(&exitStatus))
+		})
+
+		It("is listed with the newest session first", func() {
+			newerSession, err := database.CreateHijackSession(db.HijackSession{
+				TeamID:          savedTeam.ID,
+				HijackedBy:      "main",
+				ContainerHandle: "other-handle",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			sessions, err := database.GetHijackSessions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions).To(HaveLen(2))
+			Expect(sessions[0].ID).To(Equal(newerSession.ID))
+			Expect(sessions[1].ID).To(Equal(session.ID))
+		})
+	})
+
+	Describe("GetHijackSession", func() {
+		It("returns false when the session does not exist", func() {
+			_, found, err := database.GetHijackSession(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("Revoked tokens", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+	var database db.DB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		database = db.NewSQL(dbConn, bus, lockFactory)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports tokens as revoked once they have been revoked", func() {
+		revoked, err := database.IsTokenRevoked("some-token-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).To(BeFalse())
+
+		err = database.RevokeToken("some-token-id", time.Now().Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		revoked, err = database.IsTokenRevoked("some-token-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).To(BeTrue())
+
+		revoked, err = database.IsTokenRevoked("some-other-token-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).To(BeFalse())
+	})
+
+	It("allows a token to be revoked more than once", func() {
+		err := database.RevokeToken("some-token-id", time.Now().Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.RevokeToken("some-token-id", time.Now().Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("forgets revocations of tokens that have since expired", func() {
+		err := database.RevokeToken("some-expired-token-id", time.Now().Add(-time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = database.RevokeToken("some-token-id", time.Now().Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+
+		revoked, err := database.IsTokenRevoked("some-expired-token-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked).To(BeFalse())
+	})
+})
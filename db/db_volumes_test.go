@@ -252,6 +252,38 @@ var _ = Describe("Keeping track of volumes", func() {
 			})
 		})
 
+		Describe("SetVolumeSizeInBytes", func() {
+			var identifier db.VolumeIdentifier
+
+			BeforeEach(func() {
+				identifier = db.VolumeIdentifier{
+					COW: &db.COWIdentifier{
+						ParentVolumeHandle: "parent-volume-handle",
+					},
+				}
+
+				err := database.InsertVolume(db.Volume{
+					Handle:      "volume-1-handle",
+					WorkerName:  "some-worker-name",
+					TTL:         5 * time.Minute,
+					Identifier:  identifier,
+					SizeInBytes: int64(1),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("sets the volume's size without touching its TTL", func() {
+				err := database.SetVolumeSizeInBytes("volume-1-handle", int64(5000000000))
+				Expect(err).NotTo(HaveOccurred())
+
+				volumes, err := database.GetVolumesByIdentifier(identifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(HaveLen(1))
+				Expect(volumes[0].SizeInBytes).To(Equal(int64(5000000000)))
+				Expect(volumes[0].TTL).To(Equal(5 * time.Minute))
+			})
+		})
+
 		Describe("cow volumes", func() {
 			var cowIdentifier db.VolumeIdentifier
 
@@ -61,6 +61,7 @@ var _ = Describe("Keeping track of workers", func() {
 			Platform:  "webos",
 			Tags:      []string{"palm", "was", "great"},
 			StartTime: 1461864115,
+			State:     db.WorkerStateRunning,
 		}
 
 		infoB := db.WorkerInfo{
@@ -73,6 +74,7 @@ var _ = Describe("Keeping track of workers", func() {
 			Platform:  "plan9",
 			Tags:      []string{"russ", "cox", "was", "here"},
 			StartTime: 1461864110,
+			State:     db.WorkerStateRunning,
 		}
 		expectedSavedWorkerA := db.SavedWorker{
 			WorkerInfo: infoA,
@@ -252,6 +254,108 @@ var _ = Describe("Keeping track of workers", func() {
 	})
 })
 
+var _ = Describe("Worker heartbeats", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var sqlDB *db.SQLDB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+
+		_, err := sqlDB.SaveWorker(db.WorkerInfo{
+			Name:       "some-worker",
+			GardenAddr: "1.2.3.4:7777",
+			Platform:   "linux",
+		}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	getWorker := func() db.SavedWorker {
+		savedWorker, found, err := sqlDB.GetWorker("some-worker")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return savedWorker
+	}
+
+	It("records the reported load", func() {
+		found, err := sqlDB.HeartbeatWorker("some-worker", "some-stream", atc.WorkerLoad{
+			ActiveContainers: 3,
+			ActiveVolumes:    7,
+			CPUPressure:      0.5,
+			MemoryPressure:   0.25,
+			DiskPressure:     0.75,
+		}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		savedWorker := getWorker()
+		Expect(savedWorker.ActiveContainers).To(Equal(3))
+		Expect(savedWorker.ActiveVolumes).To(Equal(7))
+		Expect(savedWorker.CPUPressure).To(Equal(0.5))
+		Expect(savedWorker.MemoryPressure).To(Equal(0.25))
+		Expect(savedWorker.DiskPressure).To(Equal(0.75))
+		Expect(savedWorker.State).To(Equal(db.WorkerStateRunning))
+		Expect(savedWorker.ExpiresIn).To(BeNumerically(">", time.Minute))
+	})
+
+	It("returns false if the worker is not registered", func() {
+		found, err := sqlDB.HeartbeatWorker("bogus-worker", "some-stream", atc.WorkerLoad{}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("stalls the worker when its current stream drops", func() {
+		_, err := sqlDB.HeartbeatWorker("some-worker", "old-stream", atc.WorkerLoad{}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = sqlDB.HeartbeatWorker("some-worker", "new-stream", atc.WorkerLoad{}, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("ignoring streams that have been replaced")
+		err = sqlDB.StallWorker("some-worker", "old-stream")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getWorker().State).To(Equal(db.WorkerStateRunning))
+
+		err = sqlDB.StallWorker("some-worker", "new-stream")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getWorker().State).To(Equal(db.WorkerStateStalled))
+
+		By("running again once the worker registers again")
+		_, err = sqlDB.SaveWorker(db.WorkerInfo{
+			Name:       "some-worker",
+			GardenAddr: "1.2.3.4:7777",
+			Platform:   "linux",
+		}, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getWorker().State).To(Equal(db.WorkerStateRunning))
+	})
+})
+
 func getWorkerInfos(savedWorkers []db.SavedWorker, err error) []db.WorkerInfo {
 	Expect(err).NotTo(HaveOccurred())
 	var workerInfos []db.WorkerInfo
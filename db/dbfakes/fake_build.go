@@ -83,6 +83,12 @@ type FakeBuild struct {
 	reapTimeReturns     struct {
 		result1 time.Time
 	}
+	TriggerReasonStub        func() string
+	triggerReasonMutex       sync.RWMutex
+	triggerReasonArgsForCall []struct{}
+	triggerReasonReturns     struct {
+		result1 string
+	}
 	IsOneOffStub        func() bool
 	isOneOffMutex       sync.RWMutex
 	isOneOffArgsForCall []struct{}
@@ -256,6 +262,41 @@ type FakeBuild struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
+	AnnotationsStub        func() atc.BuildAnnotations
+	annotationsMutex       sync.RWMutex
+	annotationsArgsForCall []struct{}
+	annotationsReturns     struct {
+		result1 atc.BuildAnnotations
+	}
+	SaveAnnotationsStub        func(annotations atc.BuildAnnotations) error
+	saveAnnotationsMutex       sync.RWMutex
+	saveAnnotationsArgsForCall []struct {
+		annotations atc.BuildAnnotations
+	}
+	saveAnnotationsReturns struct {
+		result1 error
+	}
+	SaveTestReportStub        func(report atc.TestReport) error
+	saveTestReportMutex       sync.RWMutex
+	saveTestReportArgsForCall []struct {
+		report atc.TestReport
+	}
+	saveTestReportReturns struct {
+		result1 error
+	}
+	GetTestReportsStub        func() ([]atc.TestReport, error)
+	getTestReportsMutex       sync.RWMutex
+	getTestReportsArgsForCall []struct{}
+	getTestReportsReturns     struct {
+		result1 []atc.TestReport
+		result2 error
+	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct{}
+	pipelineInstanceVarsReturns     struct {
+		result1 atc.InstanceVars
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -560,6 +601,31 @@ func (fake *FakeBuild) ReapTimeReturns(result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TriggerReason() string {
+	fake.triggerReasonMutex.Lock()
+	fake.triggerReasonArgsForCall = append(fake.triggerReasonArgsForCall, struct{}{})
+	fake.recordInvocation("TriggerReason", []interface{}{})
+	fake.triggerReasonMutex.Unlock()
+	if fake.TriggerReasonStub != nil {
+		return fake.TriggerReasonStub()
+	} else {
+		return fake.triggerReasonReturns.result1
+	}
+}
+
+func (fake *FakeBuild) TriggerReasonCallCount() int {
+	fake.triggerReasonMutex.RLock()
+	defer fake.triggerReasonMutex.RUnlock()
+	return len(fake.triggerReasonArgsForCall)
+}
+
+func (fake *FakeBuild) TriggerReasonReturns(result1 string) {
+	fake.TriggerReasonStub = nil
+	fake.triggerReasonReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeBuild) IsOneOff() bool {
 	fake.isOneOffMutex.Lock()
 	fake.isOneOffArgsForCall = append(fake.isOneOffArgsForCall, struct{}{})
@@ -1211,6 +1277,148 @@ func (fake *FakeBuild) GetPipelineReturns(result1 db.SavedPipeline, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Annotations() atc.BuildAnnotations {
+	fake.annotationsMutex.Lock()
+	fake.annotationsArgsForCall = append(fake.annotationsArgsForCall, struct{}{})
+	fake.recordInvocation("Annotations", []interface{}{})
+	fake.annotationsMutex.Unlock()
+	if fake.AnnotationsStub != nil {
+		return fake.AnnotationsStub()
+	} else {
+		return fake.annotationsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) AnnotationsCallCount() int {
+	fake.annotationsMutex.RLock()
+	defer fake.annotationsMutex.RUnlock()
+	return len(fake.annotationsArgsForCall)
+}
+
+func (fake *FakeBuild) AnnotationsReturns(result1 atc.BuildAnnotations) {
+	fake.AnnotationsStub = nil
+	fake.annotationsReturns = struct {
+		result1 atc.BuildAnnotations
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveAnnotations(annotations atc.BuildAnnotations) error {
+	fake.saveAnnotationsMutex.Lock()
+	fake.saveAnnotationsArgsForCall = append(fake.saveAnnotationsArgsForCall, struct {
+		annotations atc.BuildAnnotations
+	}{annotations})
+	fake.recordInvocation("SaveAnnotations", []interface{}{annotations})
+	fake.saveAnnotationsMutex.Unlock()
+	if fake.SaveAnnotationsStub != nil {
+		return fake.SaveAnnotationsStub(annotations)
+	} else {
+		return fake.saveAnnotationsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveAnnotationsCallCount() int {
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	return len(fake.saveAnnotationsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveAnnotationsArgsForCall(i int) atc.BuildAnnotations {
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	return fake.saveAnnotationsArgsForCall[i].annotations
+}
+
+func (fake *FakeBuild) SaveAnnotationsReturns(result1 error) {
+	fake.SaveAnnotationsStub = nil
+	fake.saveAnnotationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestReport(report atc.TestReport) error {
+	fake.saveTestReportMutex.Lock()
+	fake.saveTestReportArgsForCall = append(fake.saveTestReportArgsForCall, struct {
+		report atc.TestReport
+	}{report})
+	fake.recordInvocation("SaveTestReport", []interface{}{report})
+	fake.saveTestReportMutex.Unlock()
+	if fake.SaveTestReportStub != nil {
+		return fake.SaveTestReportStub(report)
+	} else {
+		return fake.saveTestReportReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveTestReportCallCount() int {
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	return len(fake.saveTestReportArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestReportArgsForCall(i int) atc.TestReport {
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	return fake.saveTestReportArgsForCall[i].report
+}
+
+func (fake *FakeBuild) SaveTestReportReturns(result1 error) {
+	fake.SaveTestReportStub = nil
+	fake.saveTestReportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetTestReports() ([]atc.TestReport, error) {
+	fake.getTestReportsMutex.Lock()
+	fake.getTestReportsArgsForCall = append(fake.getTestReportsArgsForCall, struct{}{})
+	fake.recordInvocation("GetTestReports", []interface{}{})
+	fake.getTestReportsMutex.Unlock()
+	if fake.GetTestReportsStub != nil {
+		return fake.GetTestReportsStub()
+	} else {
+		return fake.getTestReportsReturns.result1, fake.getTestReportsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetTestReportsCallCount() int {
+	fake.getTestReportsMutex.RLock()
+	defer fake.getTestReportsMutex.RUnlock()
+	return len(fake.getTestReportsArgsForCall)
+}
+
+func (fake *FakeBuild) GetTestReportsReturns(result1 []atc.TestReport, result2 error) {
+	fake.GetTestReportsStub = nil
+	fake.getTestReportsReturns = struct {
+		result1 []atc.TestReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct{}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	} else {
+		return fake.pipelineInstanceVarsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1238,6 +1446,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.endTimeMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
+	fake.triggerReasonMutex.RLock()
+	defer fake.triggerReasonMutex.RUnlock()
 	fake.isOneOffMutex.RLock()
 	defer fake.isOneOffMutex.RUnlock()
 	fake.isScheduledMutex.RLock()
@@ -1282,6 +1492,16 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.getPipelineMutex.RLock()
 	defer fake.getPipelineMutex.RUnlock()
+	fake.annotationsMutex.RLock()
+	defer fake.annotationsMutex.RUnlock()
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	fake.saveTestReportMutex.RLock()
+	defer fake.saveTestReportMutex.RUnlock()
+	fake.getTestReportsMutex.RLock()
+	defer fake.getTestReportsMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	return fake.invocations
 }
 
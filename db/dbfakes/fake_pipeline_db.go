@@ -300,6 +300,39 @@ type FakePipelineDB struct {
 	setMaxInFlightReachedReturns struct {
 		result1 error
 	}
+	SetTeamQuotaReachedStub        func(string, bool) error
+	setTeamQuotaReachedMutex       sync.RWMutex
+	setTeamQuotaReachedArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	setTeamQuotaReachedReturns struct {
+		result1 error
+	}
+	GetTeamQuotaAndUsageStub        func() (db.TeamQuota, db.TeamQuotaUsage, error)
+	getTeamQuotaAndUsageMutex       sync.RWMutex
+	getTeamQuotaAndUsageArgsForCall []struct{}
+	getTeamQuotaAndUsageReturns     struct {
+		result1 db.TeamQuota
+		result2 db.TeamQuotaUsage
+		result3 error
+	}
+	SetBuildQueueBlockedStub        func(string, bool) error
+	setBuildQueueBlockedMutex       sync.RWMutex
+	setBuildQueueBlockedArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	setBuildQueueBlockedReturns struct {
+		result1 error
+	}
+	GetBuildQueueStub        func() (db.BuildQueue, error)
+	getBuildQueueMutex       sync.RWMutex
+	getBuildQueueArgsForCall []struct{}
+	getBuildQueueReturns     struct {
+		result1 db.BuildQueue
+		result2 error
+	}
 	UpdateFirstLoggedBuildIDStub        func(job string, newFirstLoggedBuildID int) error
 	updateFirstLoggedBuildIDMutex       sync.RWMutex
 	updateFirstLoggedBuildIDArgsForCall []struct {
@@ -359,6 +392,35 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateScheduledJobBuildStub        func(job string, scheduledAt time.Time) (db.Build, error)
+	createScheduledJobBuildMutex       sync.RWMutex
+	createScheduledJobBuildArgsForCall []struct {
+		job         string
+		scheduledAt time.Time
+	}
+	createScheduledJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	GetJobLastScheduledStub        func(job string) (time.Time, bool, error)
+	getJobLastScheduledMutex       sync.RWMutex
+	getJobLastScheduledArgsForCall []struct {
+		job string
+	}
+	getJobLastScheduledReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	SetJobLastScheduledStub        func(job string, lastScheduled time.Time) error
+	setJobLastScheduledMutex       sync.RWMutex
+	setJobLastScheduledArgsForCall []struct {
+		job           string
+		lastScheduled time.Time
+	}
+	setJobLastScheduledReturns struct {
+		result1 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -555,6 +617,35 @@ type FakePipelineDB struct {
 	hideReturns     struct {
 		result1 error
 	}
+	GetJobTestStatisticsStub        func(job string, builds int) (atc.JobTestStatistics, error)
+	getJobTestStatisticsMutex       sync.RWMutex
+	getJobTestStatisticsArgsForCall []struct {
+		job    string
+		builds int
+	}
+	getJobTestStatisticsReturns struct {
+		result1 atc.JobTestStatistics
+		result2 error
+	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct{}
+	archiveReturns     struct {
+		result1 error
+	}
+	IsArchivedStub        func() bool
+	isArchivedMutex       sync.RWMutex
+	isArchivedArgsForCall []struct{}
+	isArchivedReturns     struct {
+		result1 bool
+	}
+	GetVersionsDBStub        func() (*algorithm.VersionsDB, error)
+	getVersionsDBMutex       sync.RWMutex
+	getVersionsDBArgsForCall []struct{}
+	getVersionsDBReturns     struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1643,6 +1734,127 @@ func (fake *FakePipelineDB) SetMaxInFlightReachedReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SetTeamQuotaReached(arg1 string, arg2 bool) error {
+	fake.setTeamQuotaReachedMutex.Lock()
+	fake.setTeamQuotaReachedArgsForCall = append(fake.setTeamQuotaReachedArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("SetTeamQuotaReached", []interface{}{arg1, arg2})
+	fake.setTeamQuotaReachedMutex.Unlock()
+	if fake.SetTeamQuotaReachedStub != nil {
+		return fake.SetTeamQuotaReachedStub(arg1, arg2)
+	} else {
+		return fake.setTeamQuotaReachedReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetTeamQuotaReachedCallCount() int {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return len(fake.setTeamQuotaReachedArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetTeamQuotaReachedArgsForCall(i int) (string, bool) {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return fake.setTeamQuotaReachedArgsForCall[i].arg1, fake.setTeamQuotaReachedArgsForCall[i].arg2
+}
+
+func (fake *FakePipelineDB) SetTeamQuotaReachedReturns(result1 error) {
+	fake.SetTeamQuotaReachedStub = nil
+	fake.setTeamQuotaReachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetTeamQuotaAndUsage() (db.TeamQuota, db.TeamQuotaUsage, error) {
+	fake.getTeamQuotaAndUsageMutex.Lock()
+	fake.getTeamQuotaAndUsageArgsForCall = append(fake.getTeamQuotaAndUsageArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamQuotaAndUsage", []interface{}{})
+	fake.getTeamQuotaAndUsageMutex.Unlock()
+	if fake.GetTeamQuotaAndUsageStub != nil {
+		return fake.GetTeamQuotaAndUsageStub()
+	} else {
+		return fake.getTeamQuotaAndUsageReturns.result1, fake.getTeamQuotaAndUsageReturns.result2, fake.getTeamQuotaAndUsageReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetTeamQuotaAndUsageCallCount() int {
+	fake.getTeamQuotaAndUsageMutex.RLock()
+	defer fake.getTeamQuotaAndUsageMutex.RUnlock()
+	return len(fake.getTeamQuotaAndUsageArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetTeamQuotaAndUsageReturns(result1 db.TeamQuota, result2 db.TeamQuotaUsage, result3 error) {
+	fake.GetTeamQuotaAndUsageStub = nil
+	fake.getTeamQuotaAndUsageReturns = struct {
+		result1 db.TeamQuota
+		result2 db.TeamQuotaUsage
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) SetBuildQueueBlocked(arg1 string, arg2 bool) error {
+	fake.setBuildQueueBlockedMutex.Lock()
+	fake.setBuildQueueBlockedArgsForCall = append(fake.setBuildQueueBlockedArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("SetBuildQueueBlocked", []interface{}{arg1, arg2})
+	fake.setBuildQueueBlockedMutex.Unlock()
+	if fake.SetBuildQueueBlockedStub != nil {
+		return fake.SetBuildQueueBlockedStub(arg1, arg2)
+	} else {
+		return fake.setBuildQueueBlockedReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetBuildQueueBlockedCallCount() int {
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	return len(fake.setBuildQueueBlockedArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetBuildQueueBlockedArgsForCall(i int) (string, bool) {
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	return fake.setBuildQueueBlockedArgsForCall[i].arg1, fake.setBuildQueueBlockedArgsForCall[i].arg2
+}
+
+func (fake *FakePipelineDB) SetBuildQueueBlockedReturns(result1 error) {
+	fake.SetBuildQueueBlockedStub = nil
+	fake.setBuildQueueBlockedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetBuildQueue() (db.BuildQueue, error) {
+	fake.getBuildQueueMutex.Lock()
+	fake.getBuildQueueArgsForCall = append(fake.getBuildQueueArgsForCall, struct{}{})
+	fake.recordInvocation("GetBuildQueue", []interface{}{})
+	fake.getBuildQueueMutex.Unlock()
+	if fake.GetBuildQueueStub != nil {
+		return fake.GetBuildQueueStub()
+	} else {
+		return fake.getBuildQueueReturns.result1, fake.getBuildQueueReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetBuildQueueCallCount() int {
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
+	return len(fake.getBuildQueueArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetBuildQueueReturns(result1 db.BuildQueue, result2 error) {
+	fake.GetBuildQueueStub = nil
+	fake.getBuildQueueReturns = struct {
+		result1 db.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	fake.updateFirstLoggedBuildIDMutex.Lock()
 	fake.updateFirstLoggedBuildIDArgsForCall = append(fake.updateFirstLoggedBuildIDArgsForCall, struct {
@@ -1852,6 +2064,110 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateScheduledJobBuild(job string, scheduledAt time.Time) (db.Build, error) {
+	fake.createScheduledJobBuildMutex.Lock()
+	fake.createScheduledJobBuildArgsForCall = append(fake.createScheduledJobBuildArgsForCall, struct {
+		job         string
+		scheduledAt time.Time
+	}{job, scheduledAt})
+	fake.recordInvocation("CreateScheduledJobBuild", []interface{}{job, scheduledAt})
+	fake.createScheduledJobBuildMutex.Unlock()
+	if fake.CreateScheduledJobBuildStub != nil {
+		return fake.CreateScheduledJobBuildStub(job, scheduledAt)
+	} else {
+		return fake.createScheduledJobBuildReturns.result1, fake.createScheduledJobBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateScheduledJobBuildCallCount() int {
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	return len(fake.createScheduledJobBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateScheduledJobBuildArgsForCall(i int) (string, time.Time) {
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	return fake.createScheduledJobBuildArgsForCall[i].job, fake.createScheduledJobBuildArgsForCall[i].scheduledAt
+}
+
+func (fake *FakePipelineDB) CreateScheduledJobBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateScheduledJobBuildStub = nil
+	fake.createScheduledJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduled(job string) (time.Time, bool, error) {
+	fake.getJobLastScheduledMutex.Lock()
+	fake.getJobLastScheduledArgsForCall = append(fake.getJobLastScheduledArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("GetJobLastScheduled", []interface{}{job})
+	fake.getJobLastScheduledMutex.Unlock()
+	if fake.GetJobLastScheduledStub != nil {
+		return fake.GetJobLastScheduledStub(job)
+	} else {
+		return fake.getJobLastScheduledReturns.result1, fake.getJobLastScheduledReturns.result2, fake.getJobLastScheduledReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledCallCount() int {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return len(fake.getJobLastScheduledArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledArgsForCall(i int) string {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return fake.getJobLastScheduledArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobLastScheduledStub = nil
+	fake.getJobLastScheduledReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) SetJobLastScheduled(job string, lastScheduled time.Time) error {
+	fake.setJobLastScheduledMutex.Lock()
+	fake.setJobLastScheduledArgsForCall = append(fake.setJobLastScheduledArgsForCall, struct {
+		job           string
+		lastScheduled time.Time
+	}{job, lastScheduled})
+	fake.recordInvocation("SetJobLastScheduled", []interface{}{job, lastScheduled})
+	fake.setJobLastScheduledMutex.Unlock()
+	if fake.SetJobLastScheduledStub != nil {
+		return fake.SetJobLastScheduledStub(job, lastScheduled)
+	} else {
+		return fake.setJobLastScheduledReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetJobLastScheduledCallCount() int {
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
+	return len(fake.setJobLastScheduledArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetJobLastScheduledArgsForCall(i int) (string, time.Time) {
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
+	return fake.setJobLastScheduledArgsForCall[i].job, fake.setJobLastScheduledArgsForCall[i].lastScheduled
+}
+
+func (fake *FakePipelineDB) SetJobLastScheduledReturns(result1 error) {
+	fake.SetJobLastScheduledStub = nil
+	fake.setJobLastScheduledReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
@@ -2583,6 +2899,117 @@ func (fake *FakePipelineDB) HideReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetJobTestStatistics(job string, builds int) (atc.JobTestStatistics, error) {
+	fake.getJobTestStatisticsMutex.Lock()
+	fake.getJobTestStatisticsArgsForCall = append(fake.getJobTestStatisticsArgsForCall, struct {
+		job    string
+		builds int
+	}{job, builds})
+	fake.recordInvocation("GetJobTestStatistics", []interface{}{job, builds})
+	fake.getJobTestStatisticsMutex.Unlock()
+	if fake.GetJobTestStatisticsStub != nil {
+		return fake.GetJobTestStatisticsStub(job, builds)
+	} else {
+		return fake.getJobTestStatisticsReturns.result1, fake.getJobTestStatisticsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetJobTestStatisticsCallCount() int {
+	fake.getJobTestStatisticsMutex.RLock()
+	defer fake.getJobTestStatisticsMutex.RUnlock()
+	return len(fake.getJobTestStatisticsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobTestStatisticsArgsForCall(i int) (string, int) {
+	fake.getJobTestStatisticsMutex.RLock()
+	defer fake.getJobTestStatisticsMutex.RUnlock()
+	return fake.getJobTestStatisticsArgsForCall[i].job, fake.getJobTestStatisticsArgsForCall[i].builds
+}
+
+func (fake *FakePipelineDB) GetJobTestStatisticsReturns(result1 atc.JobTestStatistics, result2 error) {
+	fake.GetJobTestStatisticsStub = nil
+	fake.getJobTestStatisticsReturns = struct {
+		result1 atc.JobTestStatistics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) Archive() error {
+	fake.archiveMutex.Lock()
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct{}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	} else {
+		return fake.archiveReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipelineDB) ArchiveReturns(result1 error) {
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) IsArchived() bool {
+	fake.isArchivedMutex.Lock()
+	fake.isArchivedArgsForCall = append(fake.isArchivedArgsForCall, struct{}{})
+	fake.recordInvocation("IsArchived", []interface{}{})
+	fake.isArchivedMutex.Unlock()
+	if fake.IsArchivedStub != nil {
+		return fake.IsArchivedStub()
+	} else {
+		return fake.isArchivedReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) IsArchivedCallCount() int {
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	return len(fake.isArchivedArgsForCall)
+}
+
+func (fake *FakePipelineDB) IsArchivedReturns(result1 bool) {
+	fake.IsArchivedStub = nil
+	fake.isArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetVersionsDB() (*algorithm.VersionsDB, error) {
+	fake.getVersionsDBMutex.Lock()
+	fake.getVersionsDBArgsForCall = append(fake.getVersionsDBArgsForCall, struct{}{})
+	fake.recordInvocation("GetVersionsDB", []interface{}{})
+	fake.getVersionsDBMutex.Unlock()
+	if fake.GetVersionsDBStub != nil {
+		return fake.GetVersionsDBStub()
+	} else {
+		return fake.getVersionsDBReturns.result1, fake.getVersionsDBReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetVersionsDBCallCount() int {
+	fake.getVersionsDBMutex.RLock()
+	defer fake.getVersionsDBMutex.RUnlock()
+	return len(fake.getVersionsDBArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetVersionsDBReturns(result1 *algorithm.VersionsDB, result2 error) {
+	fake.GetVersionsDBStub = nil
+	fake.getVersionsDBReturns = struct {
+		result1 *algorithm.VersionsDB
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2656,6 +3083,14 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	fake.getTeamQuotaAndUsageMutex.RLock()
+	defer fake.getTeamQuotaAndUsageMutex.RUnlock()
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
 	defer fake.updateFirstLoggedBuildIDMutex.RUnlock()
 	fake.getJobFinishedAndNextBuildMutex.RLock()
@@ -2668,6 +3103,12 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.getJobBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.getPendingBuildsForJobMutex.RLock()
@@ -2712,6 +3153,14 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.getJobTestStatisticsMutex.RLock()
+	defer fake.getJobTestStatisticsMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.isArchivedMutex.RLock()
+	defer fake.isArchivedMutex.RUnlock()
+	fake.getVersionsDBMutex.RLock()
+	defer fake.getVersionsDBMutex.RUnlock()
 	return fake.invocations
 }
 
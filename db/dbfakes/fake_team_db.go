@@ -2,6 +2,7 @@
 package dbfakes
 
 import (
+	"io"
 	"sync"
 
 	"github.com/concourse/atc"
@@ -92,6 +93,33 @@ type FakeTeamDB struct {
 		result1 db.SavedTeam
 		result2 error
 	}
+	UpdateOIDCAuthStub        func(oidcAuth *db.OIDCAuth) (db.SavedTeam, error)
+	updateOIDCAuthMutex       sync.RWMutex
+	updateOIDCAuthArgsForCall []struct {
+		oidcAuth *db.OIDCAuth
+	}
+	updateOIDCAuthReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateQuotaStub        func(quota db.TeamQuota) (db.SavedTeam, error)
+	updateQuotaMutex       sync.RWMutex
+	updateQuotaArgsForCall []struct {
+		quota db.TeamQuota
+	}
+	updateQuotaReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	UpdateRequireHijackRecordingStub        func(required bool) (db.SavedTeam, error)
+	updateRequireHijackRecordingMutex       sync.RWMutex
+	updateRequireHijackRecordingArgsForCall []struct {
+		required bool
+	}
+	updateRequireHijackRecordingReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
 	GetConfigStub        func(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct {
@@ -133,6 +161,32 @@ type FakeTeamDB struct {
 		result2 db.Pagination
 		result3 error
 	}
+	GetBuildProvenanceStub        func(buildID int, direction db.ProvenanceDirection, maxBuilds int) (db.Provenance, bool, error)
+	getBuildProvenanceMutex       sync.RWMutex
+	getBuildProvenanceArgsForCall []struct {
+		buildID   int
+		direction db.ProvenanceDirection
+		maxBuilds int
+	}
+	getBuildProvenanceReturns struct {
+		result1 db.Provenance
+		result2 bool
+		result3 error
+	}
+	GetVersionProvenanceStub        func(pipelineName string, resourceName string, versionedResourceID int, direction db.ProvenanceDirection, maxBuilds int) (db.Provenance, bool, error)
+	getVersionProvenanceMutex       sync.RWMutex
+	getVersionProvenanceArgsForCall []struct {
+		pipelineName        string
+		resourceName        string
+		versionedResourceID int
+		direction           db.ProvenanceDirection
+		maxBuilds           int
+	}
+	getVersionProvenanceReturns struct {
+		result1 db.Provenance
+		result2 bool
+		result3 error
+	}
 	WorkersStub        func() ([]db.SavedWorker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct{}
@@ -166,6 +220,87 @@ type FakeTeamDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	CreateAPITokenStub        func(token db.APIToken, tokenHash string) (db.SavedAPIToken, error)
+	createAPITokenMutex       sync.RWMutex
+	createAPITokenArgsForCall []struct {
+		token     db.APIToken
+		tokenHash string
+	}
+	createAPITokenReturns struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}
+	GetAPITokensStub        func() ([]db.SavedAPIToken, error)
+	getAPITokensMutex       sync.RWMutex
+	getAPITokensArgsForCall []struct{}
+	getAPITokensReturns     struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}
+	DeleteAPITokenStub        func(name string) (bool, error)
+	deleteAPITokenMutex       sync.RWMutex
+	deleteAPITokenArgsForCall []struct {
+		name string
+	}
+	deleteAPITokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetPipelineInstanceStub        func(pipelineName string, instanceVars atc.InstanceVars) (db.SavedPipeline, bool, error)
+	getPipelineInstanceMutex       sync.RWMutex
+	getPipelineInstanceArgsForCall []struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	getPipelineInstanceReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	SaveInstanceConfigStub        func(string, atc.InstanceVars, atc.Config, db.ConfigVersion, db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveInstanceConfigMutex       sync.RWMutex
+	saveInstanceConfigArgsForCall []struct {
+		arg1 string
+		arg2 atc.InstanceVars
+		arg3 atc.Config
+		arg4 db.ConfigVersion
+		arg5 db.PipelinePausedState
+	}
+	saveInstanceConfigReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetInstanceConfigStub        func(pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, db.ConfigVersion, error)
+	getInstanceConfigMutex       sync.RWMutex
+	getInstanceConfigArgsForCall []struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}
+	getInstanceConfigReturns struct {
+		result1 atc.Config
+		result2 atc.RawConfig
+		result3 db.ConfigVersion
+		result4 error
+	}
+	ExportTeamStub        func(w io.Writer, includeEvents bool) error
+	exportTeamMutex       sync.RWMutex
+	exportTeamArgsForCall []struct {
+		w             io.Writer
+		includeEvents bool
+	}
+	exportTeamReturns struct {
+		result1 error
+	}
+	ImportTeamStub        func(archive io.Reader) (atc.TeamImport, error)
+	importTeamMutex       sync.RWMutex
+	importTeamArgsForCall []struct {
+		archive io.Reader
+	}
+	importTeamReturns struct {
+		result1 atc.TeamImport
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -484,6 +619,108 @@ func (fake *FakeTeamDB) UpdateGenericOAuthReturns(result1 db.SavedTeam, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) UpdateOIDCAuth(oidcAuth *db.OIDCAuth) (db.SavedTeam, error) {
+	fake.updateOIDCAuthMutex.Lock()
+	fake.updateOIDCAuthArgsForCall = append(fake.updateOIDCAuthArgsForCall, struct {
+		oidcAuth *db.OIDCAuth
+	}{oidcAuth})
+	fake.recordInvocation("UpdateOIDCAuth", []interface{}{oidcAuth})
+	fake.updateOIDCAuthMutex.Unlock()
+	if fake.UpdateOIDCAuthStub != nil {
+		return fake.UpdateOIDCAuthStub(oidcAuth)
+	} else {
+		return fake.updateOIDCAuthReturns.result1, fake.updateOIDCAuthReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthCallCount() int {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return len(fake.updateOIDCAuthArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthArgsForCall(i int) *db.OIDCAuth {
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	return fake.updateOIDCAuthArgsForCall[i].oidcAuth
+}
+
+func (fake *FakeTeamDB) UpdateOIDCAuthReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateOIDCAuthStub = nil
+	fake.updateOIDCAuthReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateQuota(quota db.TeamQuota) (db.SavedTeam, error) {
+	fake.updateQuotaMutex.Lock()
+	fake.updateQuotaArgsForCall = append(fake.updateQuotaArgsForCall, struct {
+		quota db.TeamQuota
+	}{quota})
+	fake.recordInvocation("UpdateQuota", []interface{}{quota})
+	fake.updateQuotaMutex.Unlock()
+	if fake.UpdateQuotaStub != nil {
+		return fake.UpdateQuotaStub(quota)
+	} else {
+		return fake.updateQuotaReturns.result1, fake.updateQuotaReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateQuotaCallCount() int {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	return len(fake.updateQuotaArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateQuotaArgsForCall(i int) db.TeamQuota {
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	return fake.updateQuotaArgsForCall[i].quota
+}
+
+func (fake *FakeTeamDB) UpdateQuotaReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateQuotaStub = nil
+	fake.updateQuotaReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) UpdateRequireHijackRecording(required bool) (db.SavedTeam, error) {
+	fake.updateRequireHijackRecordingMutex.Lock()
+	fake.updateRequireHijackRecordingArgsForCall = append(fake.updateRequireHijackRecordingArgsForCall, struct {
+		required bool
+	}{required})
+	fake.recordInvocation("UpdateRequireHijackRecording", []interface{}{required})
+	fake.updateRequireHijackRecordingMutex.Unlock()
+	if fake.UpdateRequireHijackRecordingStub != nil {
+		return fake.UpdateRequireHijackRecordingStub(required)
+	} else {
+		return fake.updateRequireHijackRecordingReturns.result1, fake.updateRequireHijackRecordingReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateRequireHijackRecordingCallCount() int {
+	fake.updateRequireHijackRecordingMutex.RLock()
+	defer fake.updateRequireHijackRecordingMutex.RUnlock()
+	return len(fake.updateRequireHijackRecordingArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateRequireHijackRecordingArgsForCall(i int) bool {
+	fake.updateRequireHijackRecordingMutex.RLock()
+	defer fake.updateRequireHijackRecordingMutex.RUnlock()
+	return fake.updateRequireHijackRecordingArgsForCall[i].required
+}
+
+func (fake *FakeTeamDB) UpdateRequireHijackRecordingReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateRequireHijackRecordingStub = nil
+	fake.updateRequireHijackRecordingReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct {
@@ -619,6 +856,82 @@ func (fake *FakeTeamDB) GetPrivateAndPublicBuildsReturns(result1 []db.Build, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeamDB) GetBuildProvenance(buildID int, direction db.ProvenanceDirection, maxBuilds int) (db.Provenance, bool, error) {
+	fake.getBuildProvenanceMutex.Lock()
+	fake.getBuildProvenanceArgsForCall = append(fake.getBuildProvenanceArgsForCall, struct {
+		buildID   int
+		direction db.ProvenanceDirection
+		maxBuilds int
+	}{buildID, direction, maxBuilds})
+	fake.recordInvocation("GetBuildProvenance", []interface{}{buildID, direction, maxBuilds})
+	fake.getBuildProvenanceMutex.Unlock()
+	if fake.GetBuildProvenanceStub != nil {
+		return fake.GetBuildProvenanceStub(buildID, direction, maxBuilds)
+	} else {
+		return fake.getBuildProvenanceReturns.result1, fake.getBuildProvenanceReturns.result2, fake.getBuildProvenanceReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetBuildProvenanceCallCount() int {
+	fake.getBuildProvenanceMutex.RLock()
+	defer fake.getBuildProvenanceMutex.RUnlock()
+	return len(fake.getBuildProvenanceArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetBuildProvenanceArgsForCall(i int) (int, db.ProvenanceDirection, int) {
+	fake.getBuildProvenanceMutex.RLock()
+	defer fake.getBuildProvenanceMutex.RUnlock()
+	return fake.getBuildProvenanceArgsForCall[i].buildID, fake.getBuildProvenanceArgsForCall[i].direction, fake.getBuildProvenanceArgsForCall[i].maxBuilds
+}
+
+func (fake *FakeTeamDB) GetBuildProvenanceReturns(result1 db.Provenance, result2 bool, result3 error) {
+	fake.GetBuildProvenanceStub = nil
+	fake.getBuildProvenanceReturns = struct {
+		result1 db.Provenance
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetVersionProvenance(pipelineName string, resourceName string, versionedResourceID int, direction db.ProvenanceDirection, maxBuilds int) (db.Provenance, bool, error) {
+	fake.getVersionProvenanceMutex.Lock()
+	fake.getVersionProvenanceArgsForCall = append(fake.getVersionProvenanceArgsForCall, struct {
+		pipelineName        string
+		resourceName        string
+		versionedResourceID int
+		direction           db.ProvenanceDirection
+		maxBuilds           int
+	}{pipelineName, resourceName, versionedResourceID, direction, maxBuilds})
+	fake.recordInvocation("GetVersionProvenance", []interface{}{pipelineName, resourceName, versionedResourceID, direction, maxBuilds})
+	fake.getVersionProvenanceMutex.Unlock()
+	if fake.GetVersionProvenanceStub != nil {
+		return fake.GetVersionProvenanceStub(pipelineName, resourceName, versionedResourceID, direction, maxBuilds)
+	} else {
+		return fake.getVersionProvenanceReturns.result1, fake.getVersionProvenanceReturns.result2, fake.getVersionProvenanceReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetVersionProvenanceCallCount() int {
+	fake.getVersionProvenanceMutex.RLock()
+	defer fake.getVersionProvenanceMutex.RUnlock()
+	return len(fake.getVersionProvenanceArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetVersionProvenanceArgsForCall(i int) (string, string, int, db.ProvenanceDirection, int) {
+	fake.getVersionProvenanceMutex.RLock()
+	defer fake.getVersionProvenanceMutex.RUnlock()
+	return fake.getVersionProvenanceArgsForCall[i].pipelineName, fake.getVersionProvenanceArgsForCall[i].resourceName, fake.getVersionProvenanceArgsForCall[i].versionedResourceID, fake.getVersionProvenanceArgsForCall[i].direction, fake.getVersionProvenanceArgsForCall[i].maxBuilds
+}
+
+func (fake *FakeTeamDB) GetVersionProvenanceReturns(result1 db.Provenance, result2 bool, result3 error) {
+	fake.GetVersionProvenanceStub = nil
+	fake.getVersionProvenanceReturns = struct {
+		result1 db.Provenance
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeamDB) Workers() ([]db.SavedWorker, error) {
 	fake.workersMutex.Lock()
 	fake.workersArgsForCall = append(fake.workersArgsForCall, struct{}{})
@@ -740,6 +1053,281 @@ func (fake *FakeTeamDB) GetVolumesReturns(result1 []db.SavedVolume, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) CreateAPIToken(token db.APIToken, tokenHash string) (db.SavedAPIToken, error) {
+	fake.createAPITokenMutex.Lock()
+	fake.createAPITokenArgsForCall = append(fake.createAPITokenArgsForCall, struct {
+		token     db.APIToken
+		tokenHash string
+	}{token, tokenHash})
+	fake.recordInvocation("CreateAPIToken", []interface{}{token, tokenHash})
+	fake.createAPITokenMutex.Unlock()
+	if fake.CreateAPITokenStub != nil {
+		return fake.CreateAPITokenStub(token, tokenHash)
+	} else {
+		return fake.createAPITokenReturns.result1, fake.createAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) CreateAPITokenCallCount() int {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return len(fake.createAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) CreateAPITokenArgsForCall(i int) (db.APIToken, string) {
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	return fake.createAPITokenArgsForCall[i].token, fake.createAPITokenArgsForCall[i].tokenHash
+}
+
+func (fake *FakeTeamDB) CreateAPITokenReturns(result1 db.SavedAPIToken, result2 error) {
+	fake.CreateAPITokenStub = nil
+	fake.createAPITokenReturns = struct {
+		result1 db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAPITokens() ([]db.SavedAPIToken, error) {
+	fake.getAPITokensMutex.Lock()
+	fake.getAPITokensArgsForCall = append(fake.getAPITokensArgsForCall, struct{}{})
+	fake.recordInvocation("GetAPITokens", []interface{}{})
+	fake.getAPITokensMutex.Unlock()
+	if fake.GetAPITokensStub != nil {
+		return fake.GetAPITokensStub()
+	} else {
+		return fake.getAPITokensReturns.result1, fake.getAPITokensReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetAPITokensCallCount() int {
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	return len(fake.getAPITokensArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAPITokensReturns(result1 []db.SavedAPIToken, result2 error) {
+	fake.GetAPITokensStub = nil
+	fake.getAPITokensReturns = struct {
+		result1 []db.SavedAPIToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) DeleteAPIToken(name string) (bool, error) {
+	fake.deleteAPITokenMutex.Lock()
+	fake.deleteAPITokenArgsForCall = append(fake.deleteAPITokenArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteAPIToken", []interface{}{name})
+	fake.deleteAPITokenMutex.Unlock()
+	if fake.DeleteAPITokenStub != nil {
+		return fake.DeleteAPITokenStub(name)
+	} else {
+		return fake.deleteAPITokenReturns.result1, fake.deleteAPITokenReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenCallCount() int {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return len(fake.deleteAPITokenArgsForCall)
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenArgsForCall(i int) string {
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	return fake.deleteAPITokenArgsForCall[i].name
+}
+
+func (fake *FakeTeamDB) DeleteAPITokenReturns(result1 bool, result2 error) {
+	fake.DeleteAPITokenStub = nil
+	fake.deleteAPITokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetPipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (db.SavedPipeline, bool, error) {
+	fake.getPipelineInstanceMutex.Lock()
+	fake.getPipelineInstanceArgsForCall = append(fake.getPipelineInstanceArgsForCall, struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{pipelineName, instanceVars})
+	fake.recordInvocation("GetPipelineInstance", []interface{}{pipelineName, instanceVars})
+	fake.getPipelineInstanceMutex.Unlock()
+	if fake.GetPipelineInstanceStub != nil {
+		return fake.GetPipelineInstanceStub(pipelineName, instanceVars)
+	} else {
+		return fake.getPipelineInstanceReturns.result1, fake.getPipelineInstanceReturns.result2, fake.getPipelineInstanceReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetPipelineInstanceCallCount() int {
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	return len(fake.getPipelineInstanceArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetPipelineInstanceArgsForCall(i int) (string, atc.InstanceVars) {
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	return fake.getPipelineInstanceArgsForCall[i].pipelineName, fake.getPipelineInstanceArgsForCall[i].instanceVars
+}
+
+func (fake *FakeTeamDB) GetPipelineInstanceReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.GetPipelineInstanceStub = nil
+	fake.getPipelineInstanceReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SaveInstanceConfig(arg1 string, arg2 atc.InstanceVars, arg3 atc.Config, arg4 db.ConfigVersion, arg5 db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveInstanceConfigMutex.Lock()
+	fake.saveInstanceConfigArgsForCall = append(fake.saveInstanceConfigArgsForCall, struct {
+		arg1 string
+		arg2 atc.InstanceVars
+		arg3 atc.Config
+		arg4 db.ConfigVersion
+		arg5 db.PipelinePausedState
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SaveInstanceConfig", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.saveInstanceConfigMutex.Unlock()
+	if fake.SaveInstanceConfigStub != nil {
+		return fake.SaveInstanceConfigStub(arg1, arg2, arg3, arg4, arg5)
+	} else {
+		return fake.saveInstanceConfigReturns.result1, fake.saveInstanceConfigReturns.result2, fake.saveInstanceConfigReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveInstanceConfigCallCount() int {
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	return len(fake.saveInstanceConfigArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveInstanceConfigArgsForCall(i int) (string, atc.InstanceVars, atc.Config, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	return fake.saveInstanceConfigArgsForCall[i].arg1, fake.saveInstanceConfigArgsForCall[i].arg2, fake.saveInstanceConfigArgsForCall[i].arg3, fake.saveInstanceConfigArgsForCall[i].arg4, fake.saveInstanceConfigArgsForCall[i].arg5
+}
+
+func (fake *FakeTeamDB) SaveInstanceConfigReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveInstanceConfigStub = nil
+	fake.saveInstanceConfigReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetInstanceConfig(pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, db.ConfigVersion, error) {
+	fake.getInstanceConfigMutex.Lock()
+	fake.getInstanceConfigArgsForCall = append(fake.getInstanceConfigArgsForCall, struct {
+		pipelineName string
+		instanceVars atc.InstanceVars
+	}{pipelineName, instanceVars})
+	fake.recordInvocation("GetInstanceConfig", []interface{}{pipelineName, instanceVars})
+	fake.getInstanceConfigMutex.Unlock()
+	if fake.GetInstanceConfigStub != nil {
+		return fake.GetInstanceConfigStub(pipelineName, instanceVars)
+	} else {
+		return fake.getInstanceConfigReturns.result1, fake.getInstanceConfigReturns.result2, fake.getInstanceConfigReturns.result3, fake.getInstanceConfigReturns.result4
+	}
+}
+
+func (fake *FakeTeamDB) GetInstanceConfigCallCount() int {
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	return len(fake.getInstanceConfigArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetInstanceConfigArgsForCall(i int) (string, atc.InstanceVars) {
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	return fake.getInstanceConfigArgsForCall[i].pipelineName, fake.getInstanceConfigArgsForCall[i].instanceVars
+}
+
+func (fake *FakeTeamDB) GetInstanceConfigReturns(result1 atc.Config, result2 atc.RawConfig, result3 db.ConfigVersion, result4 error) {
+	fake.GetInstanceConfigStub = nil
+	fake.getInstanceConfigReturns = struct {
+		result1 atc.Config
+		result2 atc.RawConfig
+		result3 db.ConfigVersion
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeTeamDB) ExportTeam(w io.Writer, includeEvents bool) error {
+	fake.exportTeamMutex.Lock()
+	fake.exportTeamArgsForCall = append(fake.exportTeamArgsForCall, struct {
+		w             io.Writer
+		includeEvents bool
+	}{w, includeEvents})
+	fake.recordInvocation("ExportTeam", []interface{}{w, includeEvents})
+	fake.exportTeamMutex.Unlock()
+	if fake.ExportTeamStub != nil {
+		return fake.ExportTeamStub(w, includeEvents)
+	} else {
+		return fake.exportTeamReturns.result1
+	}
+}
+
+func (fake *FakeTeamDB) ExportTeamCallCount() int {
+	fake.exportTeamMutex.RLock()
+	defer fake.exportTeamMutex.RUnlock()
+	return len(fake.exportTeamArgsForCall)
+}
+
+func (fake *FakeTeamDB) ExportTeamArgsForCall(i int) (io.Writer, bool) {
+	fake.exportTeamMutex.RLock()
+	defer fake.exportTeamMutex.RUnlock()
+	return fake.exportTeamArgsForCall[i].w, fake.exportTeamArgsForCall[i].includeEvents
+}
+
+func (fake *FakeTeamDB) ExportTeamReturns(result1 error) {
+	fake.ExportTeamStub = nil
+	fake.exportTeamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeamDB) ImportTeam(archive io.Reader) (atc.TeamImport, error) {
+	fake.importTeamMutex.Lock()
+	fake.importTeamArgsForCall = append(fake.importTeamArgsForCall, struct {
+		archive io.Reader
+	}{archive})
+	fake.recordInvocation("ImportTeam", []interface{}{archive})
+	fake.importTeamMutex.Unlock()
+	if fake.ImportTeamStub != nil {
+		return fake.ImportTeamStub(archive)
+	} else {
+		return fake.importTeamReturns.result1, fake.importTeamReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) ImportTeamCallCount() int {
+	fake.importTeamMutex.RLock()
+	defer fake.importTeamMutex.RUnlock()
+	return len(fake.importTeamArgsForCall)
+}
+
+func (fake *FakeTeamDB) ImportTeamArgsForCall(i int) io.Reader {
+	fake.importTeamMutex.RLock()
+	defer fake.importTeamMutex.RUnlock()
+	return fake.importTeamArgsForCall[i].archive
+}
+
+func (fake *FakeTeamDB) ImportTeamReturns(result1 atc.TeamImport, result2 error) {
+	fake.ImportTeamStub = nil
+	fake.importTeamReturns = struct {
+		result1 atc.TeamImport
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -763,6 +1351,12 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.updateUAAAuthMutex.RUnlock()
 	fake.updateGenericOAuthMutex.RLock()
 	defer fake.updateGenericOAuthMutex.RUnlock()
+	fake.updateOIDCAuthMutex.RLock()
+	defer fake.updateOIDCAuthMutex.RUnlock()
+	fake.updateQuotaMutex.RLock()
+	defer fake.updateQuotaMutex.RUnlock()
+	fake.updateRequireHijackRecordingMutex.RLock()
+	defer fake.updateRequireHijackRecordingMutex.RUnlock()
 	fake.getConfigMutex.RLock()
 	defer fake.getConfigMutex.RUnlock()
 	fake.saveConfigMutex.RLock()
@@ -771,6 +1365,10 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.getPrivateAndPublicBuildsMutex.RLock()
 	defer fake.getPrivateAndPublicBuildsMutex.RUnlock()
+	fake.getBuildProvenanceMutex.RLock()
+	defer fake.getBuildProvenanceMutex.RUnlock()
+	fake.getVersionProvenanceMutex.RLock()
+	defer fake.getVersionProvenanceMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	fake.getContainerMutex.RLock()
@@ -779,6 +1377,22 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByDescriptorsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.createAPITokenMutex.RLock()
+	defer fake.createAPITokenMutex.RUnlock()
+	fake.getAPITokensMutex.RLock()
+	defer fake.getAPITokensMutex.RUnlock()
+	fake.deleteAPITokenMutex.RLock()
+	defer fake.deleteAPITokenMutex.RUnlock()
+	fake.getPipelineInstanceMutex.RLock()
+	defer fake.getPipelineInstanceMutex.RUnlock()
+	fake.saveInstanceConfigMutex.RLock()
+	defer fake.saveInstanceConfigMutex.RUnlock()
+	fake.getInstanceConfigMutex.RLock()
+	defer fake.getInstanceConfigMutex.RUnlock()
+	fake.exportTeamMutex.RLock()
+	defer fake.exportTeamMutex.RUnlock()
+	fake.importTeamMutex.RLock()
+	defer fake.importTeamMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -3,3 +3,5 @@ package db
 import "errors"
 
 var ErrMultipleContainersFound = errors.New("multiple containers found for given identifier")
+
+var ErrAPITokenNameTaken = errors.New("an api token with that name already exists")
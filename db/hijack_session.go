@@ -0,0 +1,40 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// HijackSession records who ran what inside a container. The I/O of the
+// session is stored separately as a stream of HijackSessionEvents.
+type HijackSession struct {
+	TeamID          int
+	HijackedBy      string
+	ContainerHandle string
+	Process         atc.HijackProcessSpec
+}
+
+type SavedHijackSession struct {
+	ID int
+	HijackSession
+
+	TeamName   string
+	StartTime  time.Time
+	EndTime    time.Time
+	ExitStatus *int
+}
+
+const (
+	HijackSessionEventInput  = "i"
+	HijackSessionEventOutput = "o"
+	HijackSessionEventResize = "r"
+)
+
+// HijackSessionEvent is a single chunk of a session's I/O, following the
+// event types of the asciicast v2 format.
+type HijackSessionEvent struct {
+	Elapsed time.Duration
+	Type    string
+	Data    []byte
+}
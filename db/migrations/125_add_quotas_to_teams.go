@@ -0,0 +1,39 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddQuotasToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN max_concurrent_builds integer NOT NULL DEFAULT 0,
+		ADD COLUMN max_containers integer NOT NULL DEFAULT 0,
+		ADD COLUMN max_volume_bytes bigint NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN team_quota_reached bool NOT NULL DEFAULT false
+	`)
+	return err
+}
+
+func UndoAddQuotasToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		DROP COLUMN team_quota_reached
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE teams
+		DROP COLUMN max_concurrent_builds,
+		DROP COLUMN max_containers,
+		DROP COLUMN max_volume_bytes
+	`)
+	return err
+}
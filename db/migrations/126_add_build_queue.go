@@ -0,0 +1,51 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildQueue(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN build_queue_weight integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN create_time timestamp with time zone NOT NULL DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN build_queue_blocked bool NOT NULL DEFAULT false
+	`)
+	return err
+}
+
+func UndoAddBuildQueue(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		DROP COLUMN build_queue_blocked
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		DROP COLUMN create_time
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE teams
+		DROP COLUMN build_queue_weight
+	`)
+	return err
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddHijackSessions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE hijack_sessions (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL,
+			CONSTRAINT hijack_sessions_team_id_fkey
+				FOREIGN KEY (team_id)
+				REFERENCES teams (id)
+				ON DELETE CASCADE,
+			hijacked_by text NOT NULL,
+			container_handle text NOT NULL,
+			process json NOT NULL,
+			start_time timestamp with time zone NOT NULL DEFAULT now(),
+			end_time timestamp with time zone,
+			exit_status integer
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE hijack_session_events (
+			id serial PRIMARY KEY,
+			hijack_session_id integer NOT NULL,
+			CONSTRAINT hijack_session_events_hijack_session_id_fkey
+				FOREIGN KEY (hijack_session_id)
+				REFERENCES hijack_sessions (id)
+				ON DELETE CASCADE,
+			elapsed bigint NOT NULL,
+			type text NOT NULL,
+			data bytea NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX hijack_session_events_hijack_session_id ON hijack_session_events (hijack_session_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN require_hijack_recording bool NOT NULL DEFAULT false
+	`)
+	return err
+}
+
+func UndoAddHijackSessions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		DROP COLUMN require_hijack_recording
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP TABLE hijack_session_events
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP TABLE hijack_sessions
+	`)
+	return err
+}
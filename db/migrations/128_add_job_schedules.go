@@ -0,0 +1,35 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddJobSchedules(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN last_scheduled timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN trigger_reason text
+	`)
+	return err
+}
+
+func UndoAddJobSchedules(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		DROP COLUMN trigger_reason
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE jobs
+		DROP COLUMN last_scheduled
+	`)
+	return err
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE api_tokens (
+			id serial PRIMARY KEY,
+			team_id integer NOT NULL,
+			CONSTRAINT api_tokens_team_id_fkey
+				FOREIGN KEY (team_id)
+				REFERENCES teams (id)
+				ON DELETE CASCADE,
+			name text NOT NULL,
+			token_hash text NOT NULL,
+			scope text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			expires_at timestamp with time zone,
+			CONSTRAINT api_tokens_team_id_name_key UNIQUE (team_id, name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX api_tokens_token_hash_key ON api_tokens (token_hash)
+	`)
+	return err
+}
+
+func UndoAddAPITokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP TABLE api_tokens
+	`)
+	return err
+}
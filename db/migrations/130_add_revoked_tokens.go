@@ -0,0 +1,20 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddRevokedTokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE revoked_tokens (
+			token_id text PRIMARY KEY,
+			expires_at timestamp with time zone NOT NULL
+		)
+	`)
+	return err
+}
+
+func UndoAddRevokedTokens(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP TABLE revoked_tokens
+	`)
+	return err
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAnnotationsToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN annotations text
+	`)
+	return err
+}
+
+func UndoAddAnnotationsToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		DROP COLUMN annotations
+	`)
+	return err
+}
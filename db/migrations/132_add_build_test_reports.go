@@ -0,0 +1,41 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddBuildTestReports(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_test_reports (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL,
+			CONSTRAINT build_test_reports_build_id_fkey
+				FOREIGN KEY (build_id)
+				REFERENCES builds (id)
+				ON DELETE CASCADE,
+			step_name text NOT NULL,
+			plan_id text NOT NULL,
+			format text NOT NULL,
+			path text NOT NULL,
+			tests integer NOT NULL DEFAULT 0,
+			failures integer NOT NULL DEFAULT 0,
+			errors integer NOT NULL DEFAULT 0,
+			skipped integer NOT NULL DEFAULT 0,
+			duration double precision NOT NULL DEFAULT 0,
+			failed_cases text NOT NULL DEFAULT '[]'
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_test_reports_build_id ON build_test_reports (build_id)
+	`)
+	return err
+}
+
+func UndoAddBuildTestReports(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP TABLE build_test_reports
+	`)
+	return err
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddOwnersToContainersAndVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE resource_caches (
+			id serial PRIMARY KEY,
+			resource_hash text NOT NULL,
+			resource_version text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			CONSTRAINT resource_caches_resource_hash_resource_version_key UNIQUE (resource_hash, resource_version)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE containers
+		ADD COLUMN owner_type text,
+		ADD COLUMN owner_id integer
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN owner_type text,
+		ADD COLUMN owner_id integer
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX containers_owner ON containers (owner_type, owner_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX volumes_owner ON volumes (owner_type, owner_id)
+	`)
+	return err
+}
+
+func UndoAddOwnersToContainersAndVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volumes
+		DROP COLUMN owner_type,
+		DROP COLUMN owner_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE containers
+		DROP COLUMN owner_type,
+		DROP COLUMN owner_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP TABLE resource_caches
+	`)
+	return err
+}
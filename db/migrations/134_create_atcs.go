@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateATCs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE atcs (
+			id text PRIMARY KEY,
+			peer_url text NOT NULL,
+			start_time timestamp with time zone NOT NULL DEFAULT NOW(),
+			expires timestamp with time zone NOT NULL
+		)
+	`)
+	return err
+}
+
+func UndoCreateATCs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP TABLE atcs
+	`)
+	return err
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddLoadAndStateToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN active_volumes integer NOT NULL DEFAULT 0,
+		ADD COLUMN cpu_pressure double precision NOT NULL DEFAULT 0,
+		ADD COLUMN memory_pressure double precision NOT NULL DEFAULT 0,
+		ADD COLUMN disk_pressure double precision NOT NULL DEFAULT 0,
+		ADD COLUMN state text NOT NULL DEFAULT 'running',
+		ADD COLUMN stream_id text
+	`)
+	return err
+}
+
+func UndoAddLoadAndStateToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		DROP COLUMN active_volumes,
+		DROP COLUMN cpu_pressure,
+		DROP COLUMN memory_pressure,
+		DROP COLUMN disk_pressure,
+		DROP COLUMN state,
+		DROP COLUMN stream_id
+	`)
+	return err
+}
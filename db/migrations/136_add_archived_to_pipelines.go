@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddArchivedToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN archived bool NOT NULL DEFAULT false
+	`)
+	return err
+}
+
+func UndoAddArchivedToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		DROP COLUMN archived
+	`)
+	return err
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"errors"
+
+	"github.com/BurntSushi/migration"
+)
+
+func AddInstanceVarsToPipelines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN instance_vars text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		DROP CONSTRAINT pipelines_name_team_id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE UNIQUE INDEX pipelines_name_team_id_instance_vars
+		ON pipelines (name, team_id, COALESCE(instance_vars, ''))
+	`)
+	return err
+}
+
+func UndoAddInstanceVarsToPipelines(tx migration.LimitedTx) error {
+	var instances int
+	err := tx.QueryRow(`
+		SELECT COUNT(1)
+		FROM pipelines
+		WHERE instance_vars IS NOT NULL
+	`).Scan(&instances)
+	if err != nil {
+		return err
+	}
+
+	if instances > 0 {
+		return errors.New("pipeline instances must be deleted before migrating down")
+	}
+
+	_, err = tx.Exec(`
+		DROP INDEX pipelines_name_team_id_instance_vars
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		ADD CONSTRAINT pipelines_name_team_id UNIQUE (name, team_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		DROP COLUMN instance_vars
+	`)
+	return err
+}
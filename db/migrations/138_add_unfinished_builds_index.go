@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddUnfinishedBuildsIndex(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE INDEX builds_unfinished_team_id_idx
+		ON builds (team_id)
+		WHERE status = 'pending' OR status = 'started'
+	`)
+	return err
+}
+
+func UndoAddUnfinishedBuildsIndex(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP INDEX builds_unfinished_team_id_idx
+	`)
+	return err
+}
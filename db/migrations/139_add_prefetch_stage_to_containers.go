@@ -0,0 +1,51 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+// values cannot be added to an enum within a transaction, so the type is
+// replaced instead
+func AddPrefetchStageToContainers(tx migration.LimitedTx) error {
+	return replaceContainerStages(tx, `'check', 'get', 'run', 'prefetch'`)
+}
+
+func UndoAddPrefetchStageToContainers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DELETE FROM containers WHERE stage = 'prefetch'
+	`)
+	if err != nil {
+		return err
+	}
+
+	return replaceContainerStages(tx, `'check', 'get', 'run'`)
+}
+
+func replaceContainerStages(tx migration.LimitedTx, stages string) error {
+	_, err := tx.Exec(`
+		ALTER TYPE container_stage RENAME TO container_stage_old
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TYPE container_stage AS ENUM (` + stages + `)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE containers
+		ALTER COLUMN stage DROP DEFAULT,
+		ALTER COLUMN stage TYPE container_stage USING stage::text::container_stage,
+		ALTER COLUMN stage SET DEFAULT 'run'
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DROP TYPE container_stage_old
+	`)
+	return err
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+// versions can be too large to index directly, so their md5 is indexed
+func AddTypeVersionIndexToVersionedResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE INDEX versioned_resources_type_version_md5_idx
+		ON versioned_resources (type, md5(version))
+	`)
+	return err
+}
+
+func UndoAddTypeVersionIndexToVersionedResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		DROP INDEX versioned_resources_type_version_md5_idx
+	`)
+	return err
+}
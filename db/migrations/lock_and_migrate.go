@@ -12,10 +12,38 @@ import (
 	"github.com/BurntSushi/migration"
 )
 
+// LockDBAndMigrate brings the database up to the latest schema version this
+// ATC supports, refusing to if it has already been migrated further.
 func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource string) (db.Conn, error) {
+	var dbConn *sql.DB
+
+	err := LockDB(logger, sqlDriver, sqlDataSource, func() error {
+		var err error
+		dbConn, err = sql.Open(sqlDriver, sqlDataSource)
+		if err != nil {
+			return err
+		}
+
+		err = NewMigrator(logger, dbConn).Migrate()
+		if err != nil {
+			dbConn.Close()
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Wrap(dbConn), nil
+}
+
+// LockDB runs fn while holding the migration lock, so that it does not race
+// with other ATCs migrating the same database.
+func LockDB(logger lager.Logger, sqlDriver string, sqlDataSource string, fn func() error) error {
 	var err error
 	var dbLockConn db.Conn
-	var dbConn db.Conn
 
 	for {
 		dbLockConn, err = db.WrapWithError(sql.Open(sqlDriver, sqlDataSource))
@@ -25,12 +53,14 @@ func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource strin
 				time.Sleep(5 * time.Second)
 				continue
 			}
-			return nil, err
+			return err
 		}
 
 		break
 	}
 
+	defer dbLockConn.Close()
+
 	lockName := crc32.ChecksumIEEE([]byte(sqlDriver + sqlDataSource))
 
 	for {
@@ -41,73 +71,22 @@ func LockDBAndMigrate(logger lager.Logger, sqlDriver string, sqlDataSource strin
 			continue
 		}
 
-		logger.Info("migration-lock-acquired")
-
-		migrations := Translogrifier(logger, Migrations)
-		dbConn, err = db.WrapWithError(migration.OpenWith(sqlDriver, sqlDataSource, migrations, safeGetVersion, safeSetVersion))
-		if err != nil {
-			logger.Fatal("failed-to-run-migrations", err)
-		}
-
-		_, err = dbLockConn.Exec(`select pg_advisory_unlock($1)`, lockName)
-		if err != nil {
-			logger.Error("failed-to-release-lock", err)
-		}
-
-		dbLockConn.Close()
 		break
 	}
 
-	return dbConn, nil
-}
+	logger.Info("migration-lock-acquired")
 
-func safeGetVersion(tx migration.LimitedTx) (int, error) {
-	v, err := getVersion(tx)
-	if err != nil {
-		if err := createVersionTable(tx); err != nil {
-			return 0, err
-		}
-		return getVersion(tx)
-	}
-	return v, nil
-}
+	fnErr := fn()
 
-func safeSetVersion(tx migration.LimitedTx, version int) error {
-	if err := setVersion(tx, version); err != nil {
-		if err := createVersionTable(tx); err != nil {
-			return err
-		}
-		return setVersion(tx, version)
+	_, err = dbLockConn.Exec(`select pg_advisory_unlock($1)`, lockName)
+	if err != nil {
+		logger.Error("failed-to-release-lock", err)
 	}
-	return nil
-}
 
-func getVersion(tx migration.LimitedTx) (int, error) {
-	var version int
-	r := tx.QueryRow("SELECT version FROM migration_version")
-	if err := r.Scan(&version); err != nil {
-		return 0, err
-	}
-	return version, nil
+	return fnErr
 }
 
 func setVersion(tx migration.LimitedTx, version int) error {
 	_, err := tx.Exec("UPDATE migration_version SET version = $1", version)
 	return err
 }
-
-func createVersionTable(tx migration.LimitedTx) error {
-	_, err := tx.Exec(`
-		CREATE TABLE migration_version (
-			version INTEGER
-		)
-	`)
-	if err != nil {
-		return nil
-	}
-
-	_, err = tx.Exec(`
-		INSERT INTO migration_version (version) VALUES (0)
-	`)
-	return err
-}
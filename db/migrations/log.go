@@ -22,16 +22,8 @@ type LimitedTx interface {
 }
 
 func WithLogger(logger lager.Logger, mig migration.Migrator) migration.Migrator {
-	fullName := runtime.FuncForPC(reflect.ValueOf(mig).Pointer()).Name()
-	i := strings.LastIndex(fullName, ".")
-
-	name := "unknown migration"
-	if i >= 0 {
-		name = fullName[i+1:]
-	}
-
 	logger = logger.Session("migrating", lager.Data{
-		"migration": name,
+		"migration": migrationName(mig),
 	})
 
 	return func(tx migration.LimitedTx) error {
@@ -55,3 +47,13 @@ func Translogrifier(logger lager.Logger, migrations []migration.Migrator) []migr
 
 	return loggingMigrations
 }
+
+func migrationName(mig migration.Migrator) string {
+	fullName := runtime.FuncForPC(reflect.ValueOf(mig).Pointer()).Name()
+	i := strings.LastIndex(fullName, ".")
+	if i < 0 {
+		return "unknown migration"
+	}
+
+	return fullName[i+1:]
+}
@@ -124,4 +124,45 @@ var Migrations = []migration.Migrator{
 	MigrateFromLeasesToLocks,
 	AddTeamNameToPipe,
 	AddConfigToJobsResources,
+	AddOIDCAuthToTeams,
+	AddQuotasToTeams,
+	AddBuildQueue,
+	AddHijackSessions,
+	AddJobSchedules,
+	AddAPITokens,
+	AddRevokedTokens,
+	AddAnnotationsToBuilds,
+	AddBuildTestReports,
+	AddOwnersToContainersAndVolumes,
+	CreateATCs,
+	AddLoadAndStateToWorkers,
+	AddArchivedToPipelines,
+	AddInstanceVarsToPipelines,
+	AddUnfinishedBuildsIndex,
+	AddPrefetchStageToContainers,
+	AddTypeVersionIndexToVersionedResources,
+}
+
+// DownMigrations undo the migration of the same version, i.e. DownMigrations[n]
+// undoes Migrations[n-1], taking the schema from version n back to n-1.
+// Versions are positions in Migrations, which do not always match the file
+// names. The schema cannot be migrated down past a migration without one.
+var DownMigrations = map[int]migration.Migrator{
+	122: UndoAddOIDCAuthToTeams,
+	123: UndoAddQuotasToTeams,
+	124: UndoAddBuildQueue,
+	125: UndoAddHijackSessions,
+	126: UndoAddJobSchedules,
+	127: UndoAddAPITokens,
+	128: UndoAddRevokedTokens,
+	129: UndoAddAnnotationsToBuilds,
+	130: UndoAddBuildTestReports,
+	131: UndoAddOwnersToContainersAndVolumes,
+	132: UndoCreateATCs,
+	133: UndoAddLoadAndStateToWorkers,
+	134: UndoAddArchivedToPipelines,
+	135: UndoAddInstanceVarsToPipelines,
+	136: UndoAddUnfinishedBuildsIndex,
+	137: UndoAddPrefetchStageToContainers,
+	138: UndoAddTypeVersionIndexToVersionedResources,
 }
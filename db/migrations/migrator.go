@@ -0,0 +1,258 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/BurntSushi/migration"
+)
+
+// ErrSchemaTooNew is returned when the database has been migrated by a newer
+// ATC. Running against it could corrupt data, and its migrations cannot be
+// undone as this ATC does not know them.
+type ErrSchemaTooNew struct {
+	CurrentVersion   int
+	SupportedVersion int
+}
+
+func (err ErrSchemaTooNew) Error() string {
+	return fmt.Sprintf(
+		"database schema version %d is newer than the latest version this ATC supports (%d); upgrade the ATC or migrate down with a newer ATC",
+		err.CurrentVersion,
+		err.SupportedVersion,
+	)
+}
+
+type ErrIrreversibleMigration struct {
+	Version int
+	Name    string
+}
+
+func (err ErrIrreversibleMigration) Error() string {
+	return fmt.Sprintf("migration %d (%s) cannot be undone", err.Version, err.Name)
+}
+
+type ErrUnknownVersion struct {
+	Version int
+}
+
+func (err ErrUnknownVersion) Error() string {
+	return fmt.Sprintf("unknown schema version: %d", err.Version)
+}
+
+// Step is a single migration to apply, or to undo if Down is set. Version is
+// the migration's version, i.e. the schema version after applying it.
+type Step struct {
+	Version int
+	Name    string
+	Down    bool
+}
+
+func (step Step) String() string {
+	if step.Down {
+		return fmt.Sprintf("down %d: %s", step.Version, step.Name)
+	}
+
+	return fmt.Sprintf("up %d: %s", step.Version, step.Name)
+}
+
+// Migrator moves the schema between versions. Up[n-1] takes the schema from
+// version n-1 to n, and Down[n] takes it back from n to n-1.
+type Migrator struct {
+	Logger lager.Logger
+	DB     *sql.DB
+
+	Up   []migration.Migrator
+	Down map[int]migration.Migrator
+}
+
+func NewMigrator(logger lager.Logger, sqlDB *sql.DB) *Migrator {
+	return &Migrator{
+		Logger: logger,
+		DB:     sqlDB,
+
+		Up:   Migrations,
+		Down: DownMigrations,
+	}
+}
+
+func (m *Migrator) SupportedVersion() int {
+	return len(m.Up)
+}
+
+// CurrentVersion is 0 for a database that has never been migrated. Checking
+// it does not modify the database.
+func (m *Migrator) CurrentVersion() (int, error) {
+	var exists bool
+	err := m.DB.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM information_schema.tables
+			WHERE table_schema = current_schema()
+			AND table_name = 'migration_version'
+		)
+	`).Scan(&exists)
+	if err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	err = m.DB.QueryRow(`SELECT version FROM migration_version`).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// Plan returns the steps that MigrateToVersion would run, in order.
+func (m *Migrator) Plan(version int) ([]Step, error) {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return m.PlanFrom(current, version)
+}
+
+// PlanFrom is Plan for a database at the given version.
+func (m *Migrator) PlanFrom(current int, version int) ([]Step, error) {
+	supported := m.SupportedVersion()
+
+	if current > supported {
+		return nil, ErrSchemaTooNew{
+			CurrentVersion:   current,
+			SupportedVersion: supported,
+		}
+	}
+
+	if version < 0 || version > supported {
+		return nil, ErrUnknownVersion{Version: version}
+	}
+
+	steps := []Step{}
+
+	for v := current + 1; v <= version; v++ {
+		steps = append(steps, Step{
+			Version: v,
+			Name:    migrationName(m.Up[v-1]),
+		})
+	}
+
+	for v := current; v > version; v-- {
+		down, found := m.Down[v]
+		if !found {
+			return nil, ErrIrreversibleMigration{
+				Version: v,
+				Name:    migrationName(m.Up[v-1]),
+			}
+		}
+
+		steps = append(steps, Step{
+			Version: v,
+			Name:    migrationName(down),
+			Down:    true,
+		})
+	}
+
+	return steps, nil
+}
+
+// MigrateToVersion runs each step in its own transaction, so a failed step
+// leaves the schema at the version before it. Nothing is run if any step
+// would be irreversible.
+func (m *Migrator) MigrateToVersion(version int) error {
+	steps, err := m.Plan(version)
+	if err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	err = m.ensureVersionTable()
+	if err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		err := m.run(step)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) Migrate() error {
+	return m.MigrateToVersion(m.SupportedVersion())
+}
+
+func (m *Migrator) run(step Step) error {
+	mig := m.Up[step.Version-1]
+	newVersion := step.Version
+
+	if step.Down {
+		mig = m.Down[step.Version]
+		newVersion = step.Version - 1
+	}
+
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = WithLogger(m.Logger, mig)(tx)
+	if err != nil {
+		return fmt.Errorf("%s: %s", step, err)
+	}
+
+	err = setVersion(tx, newVersion)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) ensureVersionTable() error {
+	tx, err := m.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS migration_version (
+			version INTEGER
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO migration_version (version)
+		SELECT 0
+		WHERE NOT EXISTS (SELECT 1 FROM migration_version)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
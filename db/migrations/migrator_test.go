@@ -0,0 +1,261 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/BurntSushi/migration"
+	"github.com/concourse/atc/db/migrations"
+	"github.com/concourse/atc/postgresrunner"
+	_ "github.com/lib/pq"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func CreateWidgets(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`CREATE TABLE widgets (id serial PRIMARY KEY)`)
+	return err
+}
+
+func UndoCreateWidgets(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`DROP TABLE widgets`)
+	return err
+}
+
+func AddNameToWidgets(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE widgets ADD COLUMN name text`)
+	return err
+}
+
+func UndoAddNameToWidgets(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE widgets DROP COLUMN name`)
+	return err
+}
+
+func AddBrokenColumnToWidgets(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE widgets ADD COLUMN size bogus_type`)
+	return err
+}
+
+var _ = Describe("DownMigrations", func() {
+	It("undo the migration of the same version", func() {
+		for version, down := range migrations.DownMigrations {
+			Expect(version).To(BeNumerically(">", 0))
+			Expect(version).To(BeNumerically("<=", len(migrations.Migrations)))
+
+			migrator := &migrations.Migrator{
+				Up:   migrations.Migrations,
+				Down: map[int]migration.Migrator{version: down},
+			}
+
+			steps, err := migrator.PlanFrom(version, version-1)
+			Expect(err).NotTo(HaveOccurred())
+
+			upSteps, err := migrator.PlanFrom(version-1, version)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(steps[0].Name).To(Equal("Undo"+upSteps[0].Name), "down migration is registered under the wrong version")
+		}
+	})
+})
+
+var _ = Describe("Migrator", func() {
+	var postgresRunner postgresrunner.Runner
+	var dbProcess ifrit.Process
+	var dbConn *sql.DB
+
+	var migrator *migrations.Migrator
+
+	BeforeEach(func() {
+		var err error
+
+		postgresRunner = postgresrunner.Runner{
+			Port: 5433 + GinkgoParallelNode(),
+		}
+
+		dbProcess = ifrit.Invoke(postgresRunner)
+
+		postgresRunner.CreateTestDB()
+
+		dbConn, err = sql.Open("postgres", postgresRunner.DataSourceName())
+		Expect(err).NotTo(HaveOccurred())
+
+		migrator = &migrations.Migrator{
+			Logger: lagertest.NewTestLogger("test"),
+			DB:     dbConn,
+
+			Up: []migration.Migrator{
+				CreateWidgets,
+				AddNameToWidgets,
+			},
+			Down: map[int]migration.Migrator{
+				1: UndoCreateWidgets,
+				2: UndoAddNameToWidgets,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		postgresRunner.DropTestDB()
+
+		dbProcess.Signal(os.Interrupt)
+		Eventually(dbProcess.Wait(), 10*time.Second).Should(Receive())
+	})
+
+	widgetColumns := func() []string {
+		rows, err := dbConn.Query(`
+			SELECT column_name
+			FROM information_schema.columns
+			WHERE table_name = 'widgets'
+			ORDER BY ordinal_position
+		`)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer rows.Close()
+
+		columns := []string{}
+		for rows.Next() {
+			var column string
+			Expect(rows.Scan(&column)).To(Succeed())
+			columns = append(columns, column)
+		}
+
+		return columns
+	}
+
+	It("reports a fresh database as version 0 without modifying it", func() {
+		Expect(migrator.CurrentVersion()).To(Equal(0))
+
+		var exists bool
+		err := dbConn.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'migration_version')
+		`).Scan(&exists)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("supports as many versions as there are migrations", func() {
+		Expect(migrator.SupportedVersion()).To(Equal(2))
+	})
+
+	It("migrates up to the latest version", func() {
+		err := migrator.Migrate()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(migrator.CurrentVersion()).To(Equal(2))
+		Expect(widgetColumns()).To(Equal([]string{"id", "name"}))
+	})
+
+	Context("when the database has been migrated", func() {
+		BeforeEach(func() {
+			err := migrator.Migrate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("migrates down", func() {
+			err := migrator.MigrateToVersion(1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrator.CurrentVersion()).To(Equal(1))
+			Expect(widgetColumns()).To(Equal([]string{"id"}))
+
+			err = migrator.MigrateToVersion(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrator.CurrentVersion()).To(Equal(0))
+			Expect(widgetColumns()).To(BeEmpty())
+		})
+
+		It("plans the steps without running them", func() {
+			steps, err := migrator.Plan(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(Equal([]migrations.Step{
+				{Version: 2, Name: "UndoAddNameToWidgets", Down: true},
+				{Version: 1, Name: "UndoCreateWidgets", Down: true},
+			}))
+
+			Expect(migrator.CurrentVersion()).To(Equal(2))
+		})
+
+		Context("when a migration cannot be undone", func() {
+			BeforeEach(func() {
+				delete(migrator.Down, 1)
+			})
+
+			It("does not migrate down at all", func() {
+				err := migrator.MigrateToVersion(0)
+				Expect(err).To(Equal(migrations.ErrIrreversibleMigration{
+					Version: 1,
+					Name:    "CreateWidgets",
+				}))
+
+				Expect(migrator.CurrentVersion()).To(Equal(2))
+			})
+		})
+
+		Context("when the schema is newer than the migrator supports", func() {
+			BeforeEach(func() {
+				migrator.Up = migrator.Up[:1]
+			})
+
+			It("refuses to migrate", func() {
+				err := migrator.Migrate()
+				Expect(err).To(Equal(migrations.ErrSchemaTooNew{
+					CurrentVersion:   2,
+					SupportedVersion: 1,
+				}))
+
+				Expect(migrator.CurrentVersion()).To(Equal(2))
+			})
+		})
+
+		Context("when a migration fails", func() {
+			BeforeEach(func() {
+				migrator.Up = append(migrator.Up, AddBrokenColumnToWidgets, CreateWidgets)
+			})
+
+			It("stays at the version before it", func() {
+				err := migrator.Migrate()
+				Expect(err).To(HaveOccurred())
+
+				Expect(migrator.CurrentVersion()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("the real migrations", func() {
+		BeforeEach(func() {
+			migrator.Up = migrations.Migrations
+			migrator.Down = migrations.DownMigrations
+		})
+
+		It("can be undone and reapplied", func() {
+			err := migrator.Migrate()
+			Expect(err).NotTo(HaveOccurred())
+
+			lowest := migrator.SupportedVersion()
+			for lowest > 0 {
+				if _, found := migrations.DownMigrations[lowest]; !found {
+					break
+				}
+
+				lowest--
+			}
+
+			err = migrator.MigrateToVersion(lowest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrator.CurrentVersion()).To(Equal(lowest))
+
+			err = migrator.Migrate()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrator.CurrentVersion()).To(Equal(migrator.SupportedVersion()))
+		})
+	})
+})
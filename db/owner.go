@@ -0,0 +1,47 @@
+package db
+
+// OwnerType is the kind of thing that a container or volume belongs to. Once
+// its owner is gone, the container or volume is garbage collected.
+type OwnerType string
+
+const (
+	OwnerBuild         OwnerType = "build"
+	OwnerResourceCheck OwnerType = "resource-check"
+	OwnerResourceCache OwnerType = "resource-cache"
+	OwnerImageCache    OwnerType = "image-cache"
+)
+
+type Owner struct {
+	Type OwnerType
+	ID   int
+}
+
+// ContainerOwner determines the owner of a container from its identifier.
+// Containers without an owner are still expired by their TTL.
+func ContainerOwner(id ContainerIdentifier) (Owner, bool) {
+	switch {
+	case id.BuildID != 0:
+		return Owner{Type: OwnerBuild, ID: id.BuildID}, true
+	case id.ResourceID != 0:
+		return Owner{Type: OwnerResourceCheck, ID: id.ResourceID}, true
+	default:
+		return Owner{}, false
+	}
+}
+
+// VolumeOwnerType determines the type of owner a volume has when it is
+// created. Volumes without an owner inherit the owner of the container they
+// are attached to, if any.
+func VolumeOwnerType(id VolumeIdentifier) (OwnerType, bool) {
+	if id.ResourceCache != nil {
+		return OwnerResourceCache, true
+	}
+
+	return "", false
+}
+
+type SavedResourceCache struct {
+	ID int
+
+	ResourceCacheIdentifier
+}
@@ -9,11 +9,13 @@ type Pipeline struct {
 }
 
 type SavedPipeline struct {
-	ID       int
-	Paused   bool
-	Public   bool
-	TeamID   int
-	TeamName string
+	ID           int
+	Paused       bool
+	Public       bool
+	Archived     bool
+	InstanceVars atc.InstanceVars
+	TeamID       int
+	TeamName     string
 
 	Pipeline
 }
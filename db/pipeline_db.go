@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -30,6 +31,8 @@ type PipelineDB interface {
 	Unpause() error
 	IsPaused() (bool, error)
 	IsPublic() bool
+	Archive() error
+	IsArchived() bool
 	UpdateName(string) error
 	Destroy() error
 
@@ -58,6 +61,10 @@ type PipelineDB interface {
 	PauseJob(job string) error
 	UnpauseJob(job string) error
 	SetMaxInFlightReached(string, bool) error
+	SetTeamQuotaReached(string, bool) error
+	GetTeamQuotaAndUsage() (TeamQuota, TeamQuotaUsage, error)
+	SetBuildQueueBlocked(string, bool) error
+	GetBuildQueue() (BuildQueue, error)
 	UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error
 
 	GetJobFinishedAndNextBuild(job string) (Build, Build, error)
@@ -65,8 +72,13 @@ type PipelineDB interface {
 	GetJobBuilds(job string, page Page) ([]Build, Pagination, error)
 	GetAllJobBuilds(job string) ([]Build, error)
 
+	GetJobTestStatistics(job string, builds int) (atc.JobTestStatistics, error)
+
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateScheduledJobBuild(job string, scheduledAt time.Time) (Build, error)
+	GetJobLastScheduled(job string) (time.Time, bool, error)
+	SetJobLastScheduled(job string, lastScheduled time.Time) error
 	EnsurePendingBuildExists(jobName string) error
 	GetPendingBuildsForJob(jobName string) ([]Build, error)
 	GetAllPendingBuilds() (map[string][]Build, error)
@@ -74,6 +86,7 @@ type PipelineDB interface {
 	AcquireResourceCheckingForJobLock(logger lager.Logger, jobName string) (Lock, bool, error)
 
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
+	GetVersionsDB() (*algorithm.VersionsDB, error)
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
 	SaveIndependentInputMapping(inputMapping algorithm.InputMapping, jobName string) error
 	GetIndependentBuildInputs(jobName string) ([]BuildInput, error)
@@ -184,15 +197,90 @@ func (pdb *pipelineDB) Pause() error {
 	return err
 }
 
+// UpdateName renames the pipeline along with all of the instances sharing its
+// name, as they are addressed by the name.
 func (pdb *pipelineDB) UpdateName(newName string) error {
 	_, err := pdb.conn.Exec(`
 		UPDATE pipelines
 		SET name = $1
-		WHERE id = $2
-	`, newName, pdb.ID)
+		WHERE team_id = $2
+		AND name = (
+			SELECT name FROM pipelines WHERE id = $3
+		)
+	`, newName, pdb.TeamID, pdb.ID)
 	return err
 }
 
+// Archive stops the pipeline for good without losing its history. It is also
+// paused, so that it stays stopped if it is brought back by setting its
+// config again, and its running and pending builds are aborted.
+func (pdb *pipelineDB) Archive() error {
+	_, err := pdb.conn.Exec(`
+		UPDATE pipelines
+		SET archived = true, paused = true
+		WHERE id = $1
+	`, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	builds, err := pdb.getIncompleteBuilds()
+	if err != nil {
+		return err
+	}
+
+	for _, build := range builds {
+		// notifies whoever is tracking the build, and keeps it from starting
+		err := build.Abort()
+		if err != nil {
+			return err
+		}
+
+		// builds that never started have no one to finish them
+		if build.Engine() == "" {
+			err := build.Finish(StatusAborted)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) getIncompleteBuilds() ([]Build, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN teams t ON b.team_id = t.id
+		WHERE p.id = $1
+		AND b.completed = false
+		ORDER BY b.id
+	`, pdb.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	builds := []Build{}
+	for rows.Next() {
+		build, _, err := pdb.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, rows.Err()
+}
+
+func (pdb *pipelineDB) IsArchived() bool {
+	return pdb.Archived
+}
+
 func (pdb *pipelineDB) Destroy() error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -532,6 +620,8 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 		return []SavedVersionedResource{}, Pagination{}, false, nil
 	}
 
+	conn := reader(pdb.conn)
+
 	query := `
 		SELECT v.id, v.enabled, v.type, v.version, v.metadata, r.name, v.check_order
 		FROM versioned_resources v
@@ -541,7 +631,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 	var rows *sql.Rows
 	if page.Until != 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -555,7 +645,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			return nil, Pagination{}, false, err
 		}
 	} else if page.Since != 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order < (SELECT check_order FROM versioned_resources WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -565,7 +655,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			return nil, Pagination{}, false, err
 		}
 	} else if page.To != 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
@@ -579,7 +669,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			return nil, Pagination{}, false, err
 		}
 	} else if page.From != 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			%s
 				AND v.check_order <= (SELECT check_order FROM versioned_resources WHERE id = $2)
 			ORDER BY v.check_order DESC
@@ -589,7 +679,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			return nil, Pagination{}, false, err
 		}
 	} else {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			%s
 			ORDER BY v.check_order DESC
 			LIMIT $2
@@ -642,7 +732,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 	var minCheckOrder int
 	var maxCheckOrder int
 
-	err = pdb.conn.QueryRow(`
+	err = conn.QueryRow(`
 		SELECT COALESCE(MAX(v.check_order), 0) as maxCheckOrder,
 			COALESCE(MIN(v.check_order), 0) as minCheckOrder
 		FROM versioned_resources v
@@ -1228,6 +1318,48 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 
 	defer tx.Rollback()
 
+	build, err := pdb.createJobBuild(tx, jobName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateScheduledJobBuild creates a build triggered by the job's schedule
+// and records when it was scheduled, so the next run is computed from it.
+func (pdb *pipelineDB) CreateScheduledJobBuild(jobName string, scheduledAt time.Time) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	build, err := pdb.createJobBuild(tx, jobName, BuildTriggerReasonSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setJobLastScheduled(tx, jobName, pdb.ID, scheduledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (pdb *pipelineDB) createJobBuild(tx Tx, jobName string, triggerReason string) (Build, error) {
 	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, pdb.ID)
 	if err != nil {
 		return nil, err
@@ -1236,14 +1368,15 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	// We had to resort to sub-selects here because you can't paramaterize a
 	// RETURNING statement in lib/pq... sorry
 	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, job_id, team_id, status)
-		VALUES ($1, $2, $3, 'pending')
+		INSERT INTO builds (name, job_id, team_id, status, trigger_reason)
+		VALUES ($1, $2, $3, 'pending', NULLIF($5, ''))
 		RETURNING `+buildColumns+`,
 			(SELECT name FROM jobs WHERE id = $2),
 			(SELECT id FROM pipelines WHERE id = $4),
 			(SELECT name FROM pipelines WHERE id = $4),
-			(SELECT name FROM teams WHERE id = $3)
-	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID))
+			(SELECT name FROM teams WHERE id = $3),
+			(SELECT instance_vars FROM pipelines WHERE id = $4)
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, triggerReason))
 	if err != nil {
 		return nil, err
 	}
@@ -1253,12 +1386,59 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	return build, nil
+}
+
+func (pdb *pipelineDB) GetJobLastScheduled(jobName string) (time.Time, bool, error) {
+	var lastScheduled pq.NullTime
+	err := pdb.conn.QueryRow(`
+		SELECT last_scheduled
+		FROM jobs
+		WHERE name = $1 AND pipeline_id = $2
+	`, jobName, pdb.ID).Scan(&lastScheduled)
 	if err != nil {
-		return nil, err
+		return time.Time{}, false, err
 	}
 
-	return build, nil
+	return lastScheduled.Time, lastScheduled.Valid, nil
+}
+
+func (pdb *pipelineDB) SetJobLastScheduled(jobName string, lastScheduled time.Time) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = setJobLastScheduled(tx, jobName, pdb.ID, lastScheduled)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setJobLastScheduled(tx Tx, jobName string, pipelineID int, lastScheduled time.Time) error {
+	result, err := tx.Exec(`
+		UPDATE jobs
+		SET last_scheduled = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, lastScheduled, jobName, pipelineID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
 }
 
 func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
@@ -1547,7 +1727,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.trigger_reason, b.annotations, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name, p.instance_vars as pipeline_instance_vars
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1706,8 +1886,56 @@ func (pdb *pipelineDB) IsPaused() (bool, error) {
 	return paused, nil
 }
 
+// UpdateBuildToScheduled schedules the build unless its team already has as
+// many builds running as its quota allows. The team is locked while its
+// running builds are counted, so that schedulers racing to start the team's
+// builds cannot both take its last slot.
 func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
-	result, err := pdb.conn.Exec(`
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	var teamID, maxConcurrentBuilds int
+	err = tx.QueryRow(`
+		SELECT t.id, t.max_concurrent_builds
+		FROM teams t
+		JOIN builds b ON b.team_id = t.id
+		WHERE b.id = $1
+		FOR UPDATE OF t
+	`, buildID).Scan(&teamID, &maxConcurrentBuilds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if maxConcurrentBuilds > 0 {
+		var runningBuilds int
+		err = tx.QueryRow(`
+			SELECT COUNT(*)
+			FROM builds
+			WHERE team_id = $1
+			AND (
+				status = 'started'
+				OR
+				(scheduled = true AND status = 'pending')
+			)
+		`, teamID).Scan(&runningBuilds)
+		if err != nil {
+			return false, err
+		}
+
+		if runningBuilds >= maxConcurrentBuilds {
+			return false, nil
+		}
+	}
+
+	result, err := tx.Exec(`
 			UPDATE builds
 			SET scheduled = true
 			WHERE id = $1
@@ -1721,13 +1949,24 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 		return false, err
 	}
 
-	return rows == 1, nil
+	if rows != 1 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+// getLatestModifiedTime uses Query rather than QueryRow so that it can be
+// sent to the read replica
+func (pdb *pipelineDB) getLatestModifiedTime(conn Conn) (time.Time, error) {
 	var max_modified_time time.Time
 
-	err := pdb.conn.QueryRow(`
+	rows, err := conn.Query(`
 	SELECT
 		CASE
 			WHEN bo_max > vr_max AND bo_max > bi_max THEN bo_max
@@ -1755,13 +1994,28 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
 		) vr
-	`, pdb.ID).Scan(&max_modified_time)
+	`, pdb.ID)
+	if err != nil {
+		return max_modified_time, err
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return max_modified_time, err
+		}
+
+		return max_modified_time, sql.ErrNoRows
+	}
+
+	err = rows.Scan(&max_modified_time)
 
 	return max_modified_time, err
 }
 
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+	latestModifiedTime, err := pdb.getLatestModifiedTime(pdb.conn)
 	if err != nil {
 		return nil, err
 	}
@@ -1770,6 +2024,30 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		return pdb.versionsDB, nil
 	}
 
+	db, err := pdb.loadVersionsDB(pdb.conn, latestModifiedTime)
+	if err != nil {
+		return nil, err
+	}
+
+	pdb.versionsDB = db
+
+	return db, nil
+}
+
+// GetVersionsDB loads the versions for inspecting them rather than scheduling,
+// so it reads from the read replica and does not touch the scheduler's cache.
+func (pdb *pipelineDB) GetVersionsDB() (*algorithm.VersionsDB, error) {
+	conn := reader(pdb.conn)
+
+	latestModifiedTime, err := pdb.getLatestModifiedTime(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdb.loadVersionsDB(conn, latestModifiedTime)
+}
+
+func (pdb *pipelineDB) loadVersionsDB(conn Conn, latestModifiedTime time.Time) (*algorithm.VersionsDB, error) {
 	db := &algorithm.VersionsDB{
 		BuildOutputs:     []algorithm.BuildOutput{},
 		BuildInputs:      []algorithm.BuildInput{},
@@ -1779,7 +2057,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		CachedAt:         latestModifiedTime,
 	}
 
-	rows, err := pdb.conn.Query(`
+	rows, err := conn.Query(`
     SELECT v.id, v.check_order, r.id, o.build_id, j.id
     FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
     WHERE v.id = o.versioned_resource_id
@@ -1806,7 +2084,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildOutputs = append(db.BuildOutputs, output)
 	}
 
-	rows, err = pdb.conn.Query(`
+	rows, err = conn.Query(`
     SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
     FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
     WHERE v.id = i.versioned_resource_id
@@ -1832,7 +2110,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.BuildInputs = append(db.BuildInputs, input)
 	}
 
-	rows, err = pdb.conn.Query(`
+	rows, err = conn.Query(`
     SELECT v.id, v.check_order, r.id
     FROM versioned_resources v, resources r
     WHERE r.id = v.resource_id
@@ -1853,7 +2131,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceVersions = append(db.ResourceVersions, output)
 	}
 
-	rows, err = pdb.conn.Query(`
+	rows, err = conn.Query(`
     SELECT j.name, j.id
     FROM jobs j
     WHERE j.pipeline_id = $1
@@ -1873,7 +2151,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.JobIDs[name] = id
 	}
 
-	rows, err = pdb.conn.Query(`
+	rows, err = conn.Query(`
     SELECT r.name, r.id
     FROM resources r
     WHERE r.pipeline_id = $1
@@ -1893,8 +2171,6 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		db.ResourceIDs[name] = id
 	}
 
-	pdb.versionsDB = db
-
 	return db, nil
 }
 
@@ -2169,6 +2445,58 @@ func (pdb *pipelineDB) SetMaxInFlightReached(jobName string, reached bool) error
 	return nil
 }
 
+func (pdb *pipelineDB) SetTeamQuotaReached(jobName string, reached bool) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET team_quota_reached = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, reached, jobName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) GetTeamQuotaAndUsage() (TeamQuota, TeamQuotaUsage, error) {
+	return getTeamQuotaAndUsage(pdb.conn, pdb.SavedPipeline.TeamID)
+}
+
+func (pdb *pipelineDB) SetBuildQueueBlocked(jobName string, blocked bool) error {
+	result, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET build_queue_blocked = $1
+		WHERE name = $2 AND pipeline_id = $3
+	`, blocked, jobName, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (pdb *pipelineDB) GetBuildQueue() (BuildQueue, error) {
+	return getBuildQueue(pdb.conn)
+}
+
 func (pdb *pipelineDB) UpdateFirstLoggedBuildID(job string, newFirstLoggedBuildID int) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -2257,6 +2585,8 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 		rows *sql.Rows
 	)
 
+	conn := reader(pdb.conn)
+
 	query := fmt.Sprintf(`
 		SELECT ` + qualifiedBuildColumns + `
 		FROM builds b
@@ -2268,7 +2598,7 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 	`)
 
 	if page.Since == 0 && page.Until == 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			%s
 			ORDER BY b.id DESC
 			LIMIT $3
@@ -2277,7 +2607,7 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 			return nil, Pagination{}, err
 		}
 	} else if page.Until != 0 {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 			FROM (%s
 					AND b.id > $3
@@ -2290,7 +2620,7 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 			return nil, Pagination{}, err
 		}
 	} else {
-		rows, err = pdb.conn.Query(fmt.Sprintf(`
+		rows, err = conn.Query(fmt.Sprintf(`
 				%s
 				AND b.id < $3
 			ORDER BY b.id DESC
@@ -2318,7 +2648,7 @@ func (pdb *pipelineDB) GetJobBuilds(jobName string, page Page) ([]Build, Paginat
 		return []Build{}, Pagination{}, nil
 	}
 
-	err = pdb.conn.QueryRow(`
+	err = conn.QueryRow(`
 		SELECT COALESCE(MAX(b.id), 0) as maxID,
 			COALESCE(MIN(b.id), 0) as minID
 		FROM builds b
@@ -2418,28 +2748,30 @@ func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (Build, Build, err
 }
 
 func (pdb *pipelineDB) GetJobs() ([]SavedJob, error) {
-	return pdb.getJobs()
+	return pdb.getJobs(pdb.conn)
 }
 
 func (pdb *pipelineDB) GetDashboard() (Dashboard, atc.GroupConfigs, error) {
 	dashboard := Dashboard{}
 
-	savedJobs, err := pdb.getJobs()
+	conn := reader(pdb.conn)
+
+	savedJobs, err := pdb.getJobs(conn)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	startedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status = 'started'")
+	startedBuilds, err := pdb.getLastJobBuildsSatisfying(conn, "b.status = 'started'")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pendingBuilds, err := pdb.getLastJobBuildsSatisfying("b.status = 'pending'")
+	pendingBuilds, err := pdb.getLastJobBuildsSatisfying(conn, "b.status = 'pending'")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	finishedBuilds, err := pdb.getLastJobBuildsSatisfying("b.status NOT IN ('pending', 'started')")
+	finishedBuilds, err := pdb.getLastJobBuildsSatisfying(conn, "b.status NOT IN ('pending', 'started')")
 	if err != nil {
 		return nil, nil, err
 	}
@@ -2483,8 +2815,8 @@ func (pdb *pipelineDB) Hide() error {
 	return err
 }
 
-func (pdb *pipelineDB) getJobs() ([]SavedJob, error) {
-	rows, err := pdb.conn.Query(`
+func (pdb *pipelineDB) getJobs(conn Conn) ([]SavedJob, error) {
+	rows, err := conn.Query(`
 		SELECT j.id, j.name, j.config, j.paused, j.first_logged_build_id, p.team_id
 		FROM jobs j, pipelines p
 		WHERE j.pipeline_id = p.id
@@ -2512,8 +2844,8 @@ func (pdb *pipelineDB) getJobs() ([]SavedJob, error) {
 	return savedJobs, nil
 }
 
-func (pdb *pipelineDB) getLastJobBuildsSatisfying(bRequirement string) (map[string]Build, error) {
-	rows, err := pdb.conn.Query(`
+func (pdb *pipelineDB) getLastJobBuildsSatisfying(conn Conn, bRequirement string) (map[string]Build, error) {
+	rows, err := conn.Query(`
 		 SELECT `+qualifiedBuildColumns+`
 		 FROM builds b, jobs j, pipelines p, teams t,
 			 (
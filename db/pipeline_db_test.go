@@ -412,6 +412,86 @@ var _ = Describe("PipelineDB", func() {
 		})
 	})
 
+	Describe("archiving a pipeline", func() {
+		JustBeforeEach(func() {
+			err := pipelineDB.Archive()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("archives and pauses only that pipeline", func() {
+			archivedPipeline, found, err := teamDB.GetPipelineByName("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(archivedPipeline.Archived).To(BeTrue())
+			Expect(archivedPipeline.Paused).To(BeTrue())
+
+			otherPipeline, found, err := teamDB.GetPipelineByName("other-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(otherPipeline.Archived).To(BeFalse())
+		})
+
+		It("keeps its jobs and builds", func() {
+			_, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, _, err := pipelineDB.GetJobBuilds("some-job", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+		})
+
+		It("is brought back, still paused, by setting its config again", func() {
+			restoredPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", pipelineConfig, savedPipeline.Version, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restoredPipeline.Archived).To(BeFalse())
+			Expect(restoredPipeline.Paused).To(BeTrue())
+		})
+
+		Context("when it has running and pending builds", func() {
+			var startedBuild db.Build
+			var pendingBuild db.Build
+			var otherPipelineBuild db.Build
+
+			BeforeEach(func() {
+				var err error
+				startedBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := startedBuild.Start("some-engine", "some-metadata")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				pendingBuild, err = pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				otherPipelineBuild, err = otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("aborts the running builds for their trackers to finish", func() {
+				found, err := startedBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(startedBuild.Status()).To(Equal(db.StatusAborted))
+			})
+
+			It("aborts and finishes the pending builds", func() {
+				found, err := pendingBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pendingBuild.Status()).To(Equal(db.StatusAborted))
+				Expect(pendingBuild.EndTime()).NotTo(BeZero())
+			})
+
+			It("leaves the builds of other pipelines alone", func() {
+				found, err := otherPipelineBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(otherPipelineBuild.Status()).To(Equal(db.StatusPending))
+			})
+		})
+	})
+
 	Describe("UpdateName", func() {
 		var teamDB db.TeamDB
 
@@ -430,6 +510,27 @@ var _ = Describe("PipelineDB", func() {
 			Expect(pipeline.Name).To(Equal("some-other-weird-name"))
 		})
 
+		Context("when the pipeline has instances", func() {
+			BeforeEach(func() {
+				_, _, err := teamDB.SaveInstanceConfig("a-pipeline-name", atc.InstanceVars{"branch": "foo"}, pipelineConfig, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("renames the instances along with it", func() {
+				err := pipelineDB.UpdateName("some-other-weird-name")
+				Expect(err).NotTo(HaveOccurred())
+
+				instance, found, err := teamDB.GetPipelineInstance("some-other-weird-name", atc.InstanceVars{"branch": "foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(instance.Name).To(Equal("some-other-weird-name"))
+
+				_, found, err = teamDB.GetPipelineInstance("a-pipeline-name", atc.InstanceVars{"branch": "foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		Context("when there is a pipeline with the same name in another team", func() {
 			var team2 db.SavedTeam
 			var team2DB db.TeamDB
@@ -2092,6 +2193,55 @@ var _ = Describe("PipelineDB", func() {
 				Expect(build.Status()).To(Equal(db.StatusPending))
 				Expect(build.IsScheduled()).To(BeFalse())
 				Expect(build.TeamName()).To(Equal("some-team"))
+				Expect(build.TriggerReason()).To(BeEmpty())
+			})
+		})
+
+		Describe("CreateScheduledJobBuild", func() {
+			var build db.Build
+			var scheduledAt time.Time
+
+			BeforeEach(func() {
+				scheduledAt = time.Now().Truncate(time.Second)
+
+				var err error
+				build, err = pipelineDB.CreateScheduledJobBuild("some-job", scheduledAt)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a pending build triggered by the schedule", func() {
+				Expect(build.JobName()).To(Equal("some-job"))
+				Expect(build.Status()).To(Equal(db.StatusPending))
+				Expect(build.TriggerReason()).To(Equal(db.BuildTriggerReasonSchedule))
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.TriggerReason()).To(Equal(db.BuildTriggerReasonSchedule))
+			})
+
+			It("records when the job was last scheduled", func() {
+				lastScheduled, found, err := pipelineDB.GetJobLastScheduled("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(lastScheduled).To(BeTemporally("==", scheduledAt))
+			})
+		})
+
+		Describe("SetJobLastScheduled", func() {
+			It("is not found until it has been set", func() {
+				_, found, err := pipelineDB.GetJobLastScheduled("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				lastScheduled := time.Now().Add(-time.Hour).Truncate(time.Second)
+				err = pipelineDB.SetJobLastScheduled("some-job", lastScheduled)
+				Expect(err).NotTo(HaveOccurred())
+
+				savedLastScheduled, found, err := pipelineDB.GetJobLastScheduled("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(savedLastScheduled).To(BeTemporally("==", lastScheduled))
 			})
 		})
 
@@ -2495,6 +2645,37 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("UpdateBuildToScheduled", func() {
+			Context("when the team has a concurrent build quota", func() {
+				BeforeEach(func() {
+					_, err := teamDB.UpdateQuota(db.TeamQuota{MaxConcurrentBuilds: 1})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not schedule builds beyond it", func() {
+					firstBuild, err := pipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					secondBuild, err := otherPipelineDB.CreateJobBuild("some-other-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					scheduled, err := pipelineDB.UpdateBuildToScheduled(firstBuild.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+
+					scheduled, err = otherPipelineDB.UpdateBuildToScheduled(secondBuild.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeFalse())
+
+					Expect(firstBuild.Finish(db.StatusSucceeded)).To(Succeed())
+
+					scheduled, err = otherPipelineDB.UpdateBuildToScheduled(secondBuild.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+				})
+			})
+		})
+
 		Describe("GetRunningBuildsBySerialGroup", func() {
 			Describe("same job", func() {
 				var startedBuild, scheduledBuild db.Build
@@ -0,0 +1,229 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// reportedBuilds selects the ids of the job's most recent builds that have a
+// test report.
+const reportedBuilds = `
+	SELECT DISTINCT rb.build_id
+	FROM build_test_reports rb
+		INNER JOIN builds bb ON rb.build_id = bb.id
+		INNER JOIN jobs j ON bb.job_id = j.id
+	WHERE j.name = $1
+		AND j.pipeline_id = $2
+	ORDER BY rb.build_id DESC
+	LIMIT $3
+`
+
+type testCaseKey struct {
+	stepName  string
+	suite     string
+	classname string
+	name      string
+}
+
+// GetJobTestStatistics summarizes the test reports of the job's most recent
+// builds that have any. Only test cases that failed in at least one of the
+// builds are included, as passing test cases are not stored.
+func (pdb *pipelineDB) GetJobTestStatistics(jobName string, builds int) (atc.JobTestStatistics, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT b.id, b.name, r.step_name, r.failures, r.errors, r.failed_cases
+		FROM build_test_reports r
+			INNER JOIN builds b ON r.build_id = b.id
+		WHERE r.build_id IN (`+reportedBuilds+`)
+		ORDER BY b.id DESC, r.id ASC
+	`, jobName, pdb.ID, builds)
+	if err != nil {
+		return atc.JobTestStatistics{}, err
+	}
+
+	defer rows.Close()
+
+	buildIDs := map[int]bool{}
+	stepRuns := map[string]map[int]bool{}
+	truncatedRuns := map[string]map[int]bool{}
+	failures := map[testCaseKey]map[int]bool{}
+	statistics := map[testCaseKey]*atc.TestCaseStatistics{}
+
+	for rows.Next() {
+		var buildID, reportFailures, reportErrors int
+		var buildName, stepName, failedCasesBlob string
+
+		err := rows.Scan(&buildID, &buildName, &stepName, &reportFailures, &reportErrors, &failedCasesBlob)
+		if err != nil {
+			return atc.JobTestStatistics{}, err
+		}
+
+		var failedCases []atc.TestCaseFailure
+		err = json.Unmarshal([]byte(failedCasesBlob), &failedCases)
+		if err != nil {
+			return atc.JobTestStatistics{}, err
+		}
+
+		buildIDs[buildID] = true
+
+		if stepRuns[stepName] == nil {
+			stepRuns[stepName] = map[int]bool{}
+		}
+
+		stepRuns[stepName][buildID] = true
+
+		// reports only record so many failed cases, so a case missing from a
+		// truncated report may have failed too
+		if len(failedCases) < reportFailures+reportErrors {
+			if truncatedRuns[stepName] == nil {
+				truncatedRuns[stepName] = map[int]bool{}
+			}
+
+			truncatedRuns[stepName][buildID] = true
+		}
+
+		for _, failedCase := range failedCases {
+			key := testCaseKey{
+				stepName:  stepName,
+				suite:     failedCase.Suite,
+				classname: failedCase.Classname,
+				name:      failedCase.Name,
+			}
+
+			if failures[key] == nil {
+				failures[key] = map[int]bool{}
+			}
+
+			failures[key][buildID] = true
+
+			// builds are scanned newest first
+			if _, found := statistics[key]; !found {
+				statistics[key] = &atc.TestCaseStatistics{
+					StepName:        stepName,
+					Suite:           failedCase.Suite,
+					Classname:       failedCase.Classname,
+					Name:            failedCase.Name,
+					LastFailedBuild: buildName,
+				}
+			}
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return atc.JobTestStatistics{}, err
+	}
+
+	inputs, err := pdb.getTestReportBuildInputs(jobName, builds)
+	if err != nil {
+		return atc.JobTestStatistics{}, err
+	}
+
+	tests := []atc.TestCaseStatistics{}
+	for key, stats := range statistics {
+		failedInputs := map[string]bool{}
+		for buildID := range failures[key] {
+			failedInputs[inputs[buildID]] = true
+		}
+
+		for buildID := range stepRuns[key.stepName] {
+			if failures[key][buildID] {
+				stats.Runs++
+				continue
+			}
+
+			// a case missing from a truncated report may have failed too, so
+			// the run neither counts nor shows the case passing
+			if truncatedRuns[key.stepName][buildID] {
+				continue
+			}
+
+			stats.Runs++
+
+			if failedInputs[inputs[buildID]] {
+				stats.Flaky = true
+			}
+		}
+
+		stats.Failures = len(failures[key])
+
+		tests = append(tests, *stats)
+	}
+
+	sort.Sort(byFailures(tests))
+
+	return atc.JobTestStatistics{
+		Builds: len(buildIDs),
+		Tests:  tests,
+	}, nil
+}
+
+// getTestReportBuildInputs returns a key identifying the set of inputs each of
+// the builds considered by GetJobTestStatistics ran with, so that builds that
+// ran with the same inputs have the same key.
+func (pdb *pipelineDB) getTestReportBuildInputs(jobName string, builds int) (map[int]string, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT i.build_id, i.name, i.versioned_resource_id
+		FROM build_inputs i
+		WHERE i.build_id IN (`+reportedBuilds+`)
+		ORDER BY i.build_id, i.name, i.versioned_resource_id
+	`, jobName, pdb.ID, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	buildInputs := map[int][]string{}
+	for rows.Next() {
+		var buildID, versionedResourceID int
+		var name string
+
+		err := rows.Scan(&buildID, &name, &versionedResourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildInputs[buildID] = append(buildInputs[buildID], fmt.Sprintf("%s:%d", name, versionedResourceID))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// builds without inputs are left out, and so all share the empty key
+	inputs := map[int]string{}
+	for buildID, names := range buildInputs {
+		inputs[buildID] = strings.Join(names, ",")
+	}
+
+	return inputs, nil
+}
+
+type byFailures []atc.TestCaseStatistics
+
+func (s byFailures) Len() int      { return len(s) }
+func (s byFailures) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byFailures) Less(i, j int) bool {
+	if s[i].Failures != s[j].Failures {
+		return s[i].Failures > s[j].Failures
+	}
+
+	if s[i].StepName != s[j].StepName {
+		return s[i].StepName < s[j].StepName
+	}
+
+	if s[i].Suite != s[j].Suite {
+		return s[i].Suite < s[j].Suite
+	}
+
+	if s[i].Classname != s[j].Classname {
+		return s[i].Classname < s[j].Classname
+	}
+
+	return s[i].Name < s[j].Name
+}
@@ -0,0 +1,296 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job test statistics", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var pipelineDB db.PipelineDB
+
+	var firstBuild, secondBuild, thirdBuild db.Build
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB := db.NewSQL(dbConn, bus, lockFactory)
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+
+		_, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		config := atc.Config{
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "some-type"},
+			},
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "some-other-job"},
+			},
+		}
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDB := teamDBFactory.GetTeamDB("some-team")
+		savedPipeline, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+		saveReport := func(build db.Build, stepName string, failedNames ...string) {
+			failedCases := []atc.TestCaseFailure{}
+			for _, name := range failedNames {
+				failedCases = append(failedCases, atc.TestCaseFailure{
+					Suite:     "some-suite",
+					Classname: "SomeTest",
+					Name:      name,
+					Message:   "nope",
+				})
+			}
+
+			err := build.SaveTestReport(atc.TestReport{
+				StepName:    stepName,
+				PlanID:      atc.PlanID(stepName + "-plan"),
+				Format:      "junit",
+				Path:        "junit.xml",
+				Tests:       10,
+				Failures:    len(failedCases),
+				FailedCases: failedCases,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		firstBuild, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+		saveReport(firstBuild, "unit", "test_a")
+
+		secondBuild, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+		saveReport(secondBuild, "unit")
+
+		otherJobBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+		Expect(err).NotTo(HaveOccurred())
+		saveReport(otherJobBuild, "unit", "test_a", "test_c")
+
+		_, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		thirdBuild, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+		saveReport(thirdBuild, "unit", "test_a", "test_b")
+		saveReport(thirdBuild, "lint", "test_l")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("saves and returns each build's reports", func() {
+		reports, err := thirdBuild.GetTestReports()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(2))
+		Expect(reports[0].StepName).To(Equal("unit"))
+		Expect(reports[0].PlanID).To(Equal(atc.PlanID("unit-plan")))
+		Expect(reports[0].Failures).To(Equal(2))
+		Expect(reports[0].FailedCases).To(HaveLen(2))
+		Expect(reports[1].StepName).To(Equal("lint"))
+
+		reports, err = secondBuild.GetTestReports()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].FailedCases).To(BeNil())
+	})
+
+	It("summarizes the failures of the job's builds with reports", func() {
+		statistics, err := pipelineDB.GetJobTestStatistics("some-job", 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(statistics).To(Equal(atc.JobTestStatistics{
+			Builds: 3,
+			Tests: []atc.TestCaseStatistics{
+				{
+					StepName:        "unit",
+					Suite:           "some-suite",
+					Classname:       "SomeTest",
+					Name:            "test_a",
+					Runs:            3,
+					Failures:        2,
+					Flaky:           true,
+					LastFailedBuild: thirdBuild.Name(),
+				},
+				{
+					StepName:        "lint",
+					Suite:           "some-suite",
+					Classname:       "SomeTest",
+					Name:            "test_l",
+					Runs:            1,
+					Failures:        1,
+					Flaky:           false,
+					LastFailedBuild: thirdBuild.Name(),
+				},
+				{
+					StepName:        "unit",
+					Suite:           "some-suite",
+					Classname:       "SomeTest",
+					Name:            "test_b",
+					Runs:            3,
+					Failures:        1,
+					Flaky:           true,
+					LastFailedBuild: thirdBuild.Name(),
+				},
+			},
+		}))
+	})
+
+	It("only covers the given number of most recent builds with reports", func() {
+		statistics, err := pipelineDB.GetJobTestStatistics("some-job", 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(statistics.Builds).To(Equal(2))
+		Expect(statistics.Tests).To(HaveLen(3))
+		Expect(statistics.Tests[0].Name).To(Equal("test_l"))
+		Expect(statistics.Tests[1].Name).To(Equal("test_a"))
+		Expect(statistics.Tests[1].Runs).To(Equal(2))
+		Expect(statistics.Tests[1].Failures).To(Equal(1))
+	})
+
+	Context("when a report left out some of its failed cases", func() {
+		BeforeEach(func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveTestReport(atc.TestReport{
+				StepName: "unit",
+				PlanID:   "unit-plan",
+				Format:   "junit",
+				Path:     "junit.xml",
+				Tests:    200,
+				Failures: 150,
+				FailedCases: []atc.TestCaseFailure{
+					{Suite: "some-suite", Classname: "SomeTest", Name: "test_b"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not count the build as a pass for the cases it left out", func() {
+			statistics, err := pipelineDB.GetJobTestStatistics("some-job", 10)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statistics.Builds).To(Equal(4))
+			Expect(statistics.Tests).To(HaveLen(3))
+
+			Expect(statistics.Tests[0].Name).To(Equal("test_a"))
+			Expect(statistics.Tests[0].Runs).To(Equal(3))
+			Expect(statistics.Tests[0].Failures).To(Equal(2))
+
+			Expect(statistics.Tests[1].Name).To(Equal("test_b"))
+			Expect(statistics.Tests[1].Runs).To(Equal(4))
+			Expect(statistics.Tests[1].Failures).To(Equal(2))
+		})
+	})
+
+	Context("when the builds ran with different inputs", func() {
+		var failingBuild, passingBuild db.Build
+
+		saveInput := func(build db.Build, version string) {
+			_, err := pipelineDB.SaveInput(build.ID(), db.BuildInput{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource:   "some-resource",
+					Type:       "some-type",
+					Version:    db.Version{"version": version},
+					PipelineID: pipelineDB.GetPipelineID(),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		saveReport := func(build db.Build, failedCases ...atc.TestCaseFailure) {
+			err := build.SaveTestReport(atc.TestReport{
+				StepName:    "unit",
+				PlanID:      "unit-plan",
+				Format:      "junit",
+				Path:        "junit.xml",
+				Tests:       10,
+				Failures:    len(failedCases),
+				FailedCases: failedCases,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			failingBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			saveInput(failingBuild, "v1")
+			saveReport(failingBuild, atc.TestCaseFailure{Suite: "some-suite", Classname: "SomeTest", Name: "test_d"})
+
+			passingBuild, err = pipelineDB.CreateJobBuild("some-other-job")
+			Expect(err).NotTo(HaveOccurred())
+			saveInput(passingBuild, "v2")
+			saveReport(passingBuild)
+		})
+
+		It("does not call a case that failed with some inputs and passed with others flaky", func() {
+			statistics, err := pipelineDB.GetJobTestStatistics("some-other-job", 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statistics.Tests).To(HaveLen(1))
+			Expect(statistics.Tests[0].Name).To(Equal("test_d"))
+			Expect(statistics.Tests[0].Runs).To(Equal(2))
+			Expect(statistics.Tests[0].Failures).To(Equal(1))
+			Expect(statistics.Tests[0].Flaky).To(BeFalse())
+		})
+
+		Context("when the case passes in a rerun with the same inputs", func() {
+			BeforeEach(func() {
+				rerunBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+				Expect(err).NotTo(HaveOccurred())
+				saveInput(rerunBuild, "v1")
+				saveReport(rerunBuild)
+			})
+
+			It("calls it flaky", func() {
+				statistics, err := pipelineDB.GetJobTestStatistics("some-other-job", 3)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(statistics.Tests).To(HaveLen(1))
+				Expect(statistics.Tests[0].Runs).To(Equal(3))
+				Expect(statistics.Tests[0].Flaky).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when the job has no reports", func() {
+		It("returns empty statistics", func() {
+			statistics, err := pipelineDB.GetJobTestStatistics("some-other-job-without-reports", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statistics).To(Equal(atc.JobTestStatistics{Tests: []atc.TestCaseStatistics{}}))
+		})
+	})
+})
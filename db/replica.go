@@ -0,0 +1,200 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/lib/pq"
+)
+
+// ReplicatedConn is a connection to the primary database which also knows
+// about a streaming read replica. It behaves exactly like the primary; only
+// reads that explicitly tolerate being slightly behind go to the replica, via
+// reader.
+//
+// The replica is only used while its replication lag is known to be within
+// maxLag, which is checked every checkInterval by running it as an
+// ifrit.Runner. Until the first check, and whenever the replica is down or
+// lagging, reads go to the primary.
+type ReplicatedConn struct {
+	Conn
+
+	logger        lager.Logger
+	replica       Conn
+	maxLag        time.Duration
+	checkInterval time.Duration
+	clock         clock.Clock
+
+	usable  bool
+	usableL sync.RWMutex
+}
+
+func NewReplicatedConn(
+	logger lager.Logger,
+	primary Conn,
+	replica Conn,
+	maxLag time.Duration,
+	checkInterval time.Duration,
+	clock clock.Clock,
+) *ReplicatedConn {
+	return &ReplicatedConn{
+		Conn: primary,
+
+		logger:        logger,
+		replica:       replica,
+		maxLag:        maxLag,
+		checkInterval: checkInterval,
+		clock:         clock,
+	}
+}
+
+func (conn *ReplicatedConn) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	conn.checkReplica()
+
+	ticker := conn.clock.NewTicker(conn.checkInterval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	for {
+		select {
+		case <-ticker.C():
+			conn.checkReplica()
+
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+// Reader returns the replica if it is usable, and the primary otherwise.
+// Queries that fail on the replica are retried against the primary.
+func (conn *ReplicatedConn) Reader() Conn {
+	conn.usableL.RLock()
+	usable := conn.usable
+	conn.usableL.RUnlock()
+
+	if !usable {
+		return conn
+	}
+
+	return &replicaReader{
+		Conn:    conn.replica,
+		primary: conn.Conn,
+		failed:  conn.replicaFailed,
+	}
+}
+
+func (conn *ReplicatedConn) Close() error {
+	replicaErr := conn.replica.Close()
+
+	err := conn.Conn.Close()
+	if err != nil {
+		return err
+	}
+
+	return replicaErr
+}
+
+// a replica which has replayed everything it has received is caught up, no
+// matter how long ago the last transaction was; only otherwise is the time
+// since the last replayed transaction how far behind it is, as an idle
+// primary would make it look like it is falling behind
+func (conn *ReplicatedConn) checkReplica() {
+	var lagSeconds float64
+	err := conn.replica.QueryRow(`
+		SELECT CASE
+			WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(epoch FROM NOW() - pg_last_xact_replay_timestamp()), 0)
+		END
+	`).Scan(&lagSeconds)
+	if err != nil {
+		conn.replicaFailed(err)
+		return
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	if lag > conn.maxLag {
+		conn.setUsable(false, lager.Data{"lag": lag.String()})
+		return
+	}
+
+	conn.setUsable(true, lager.Data{"lag": lag.String()})
+}
+
+func (conn *ReplicatedConn) replicaFailed(err error) {
+	conn.setUsable(false, lager.Data{"error": err.Error()})
+}
+
+func (conn *ReplicatedConn) setUsable(usable bool, data lager.Data) {
+	conn.usableL.Lock()
+	defer conn.usableL.Unlock()
+
+	if conn.usable == usable {
+		return
+	}
+
+	conn.usable = usable
+
+	if usable {
+		conn.logger.Info("replica-usable", data)
+	} else {
+		conn.logger.Info("replica-unusable", data)
+	}
+}
+
+// replicaReader only sends Query to the replica, as it is the only method
+// that can fall back to the primary; a *sql.Row does not report errors until
+// it is scanned. Reads that should go to the replica must use Query, and
+// everything else, including all writes, goes to the primary.
+type replicaReader struct {
+	Conn
+
+	primary Conn
+	failed  func(error)
+}
+
+func (reader *replicaReader) Begin() (Tx, error) {
+	return reader.primary.Begin()
+}
+
+func (reader *replicaReader) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return reader.primary.Exec(query, args...)
+}
+
+func (reader *replicaReader) Prepare(query string) (*sql.Stmt, error) {
+	return reader.primary.Prepare(query)
+}
+
+func (reader *replicaReader) QueryRow(query string, args ...interface{}) *sql.Row {
+	return reader.primary.QueryRow(query, args...)
+}
+
+func (reader *replicaReader) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := reader.Conn.Query(query, args...)
+	if err == nil {
+		return rows, nil
+	}
+
+	// errors from postgres itself, e.g. queries cancelled due to conflicts
+	// with recovery, do not mean that the replica is down
+	if _, ok := err.(*pq.Error); !ok {
+		reader.failed(err)
+	}
+
+	return reader.primary.Query(query, args...)
+}
+
+// reader returns the connection to use for reads that can tolerate being a
+// little behind, i.e. the read replica, if there is one and it is usable.
+func reader(conn Conn) Conn {
+	if replicated, ok := conn.(*ReplicatedConn); ok {
+		return replicated.Reader()
+	}
+
+	return conn
+}
@@ -0,0 +1,131 @@
+package db_test
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("ReplicatedConn", func() {
+	var (
+		primaryConn db.Conn
+		replicaConn db.Conn
+		fakeClock   *fakeclock.FakeClock
+		maxLag      time.Duration
+
+		replicatedConn *db.ReplicatedConn
+		process        ifrit.Process
+	)
+
+	BeforeEach(func() {
+		// the test database is not in recovery, so it looks like a replica
+		// that is perfectly caught up
+		primaryConn = db.Wrap(postgresRunner.Open())
+		replicaConn = db.Wrap(postgresRunner.Open())
+
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+		maxLag = 5 * time.Second
+	})
+
+	JustBeforeEach(func() {
+		replicatedConn = db.NewReplicatedConn(
+			lagertest.NewTestLogger("test"),
+			primaryConn,
+			replicaConn,
+			maxLag,
+			time.Second,
+			fakeClock,
+		)
+	})
+
+	AfterEach(func() {
+		if process != nil {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+			process = nil
+		}
+
+		replicatedConn.Close()
+	})
+
+	It("reads from the primary until the replica has been checked", func() {
+		Expect(replicatedConn.Reader()).To(Equal(replicatedConn))
+	})
+
+	Context("once the replica has been checked", func() {
+		JustBeforeEach(func() {
+			process = ifrit.Invoke(replicatedConn)
+		})
+
+		It("reads from the replica", func() {
+			reader := replicatedConn.Reader()
+			Expect(reader).NotTo(Equal(replicatedConn))
+
+			rows, err := reader.Query("SELECT 1")
+			Expect(err).NotTo(HaveOccurred())
+			rows.Close()
+		})
+
+		Context("when the replica is lagging too far behind", func() {
+			BeforeEach(func() {
+				maxLag = -time.Second
+			})
+
+			It("reads from the primary", func() {
+				Expect(replicatedConn.Reader()).To(Equal(replicatedConn))
+			})
+		})
+
+		Context("when the replica goes away", func() {
+			var reader db.Conn
+
+			JustBeforeEach(func() {
+				reader = replicatedConn.Reader()
+
+				err := replicaConn.Close()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("falls back to the primary for queries already routed to it", func() {
+				rows, err := reader.Query("SELECT 1")
+				Expect(err).NotTo(HaveOccurred())
+				rows.Close()
+			})
+
+			It("always sends single-row queries, writes, and transactions to the primary", func() {
+				var one int
+				err := reader.QueryRow("SELECT 1").Scan(&one)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(one).To(Equal(1))
+
+				_, err = reader.Exec("SELECT 1")
+				Expect(err).NotTo(HaveOccurred())
+
+				tx, err := reader.Begin()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tx.Rollback()).To(Succeed())
+			})
+
+			It("reads from the primary once it fails a query", func() {
+				rows, err := reader.Query("SELECT 1")
+				Expect(err).NotTo(HaveOccurred())
+				rows.Close()
+
+				Expect(replicatedConn.Reader()).To(Equal(replicatedConn))
+			})
+
+			It("reads from the primary after the next check", func() {
+				fakeClock.WaitForWatcherAndIncrement(time.Second)
+
+				Eventually(replicatedConn.Reader).Should(Equal(replicatedConn))
+			})
+		})
+	})
+})
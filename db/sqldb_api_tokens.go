@@ -0,0 +1,56 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+const apiTokenColumns = "a.id, a.name, a.scope, a.created_at, a.expires_at, t.id, t.name, t.admin"
+
+func (db *SQLDB) FindAPITokenByHash(tokenHash string) (SavedAPIToken, bool, error) {
+	token, err := scanAPIToken(db.conn.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.token_hash = $1
+	`, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedAPIToken{}, false, nil
+		}
+
+		return SavedAPIToken{}, false, err
+	}
+
+	return token, true, nil
+}
+
+func scanAPIToken(row scannable) (SavedAPIToken, error) {
+	var token SavedAPIToken
+	var scope string
+	var expiresAt pq.NullTime
+
+	err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&scope,
+		&token.CreatedAt,
+		&expiresAt,
+		&token.TeamID,
+		&token.TeamName,
+		&token.TeamAdmin,
+	)
+	if err != nil {
+		return SavedAPIToken{}, err
+	}
+
+	token.Scope = atc.APITokenScope(scope)
+
+	if expiresAt.Valid {
+		token.ExpiresAt = expiresAt.Time
+	}
+
+	return token, nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// SavedATC is an ATC in the cluster that is heartbeating to the database.
+type SavedATC struct {
+	ID      string
+	PeerURL string
+
+	StartTime time.Time
+	Expires   time.Time
+}
+
+func (db *SQLDB) HeartbeatATC(id string, peerURL string, ttl time.Duration) error {
+	expires := fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
+
+	result, err := db.conn.Exec(`
+		UPDATE atcs
+		SET peer_url = $2, expires = `+expires+`
+		WHERE id = $1
+	`, id, peerURL)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO atcs (id, peer_url, expires)
+		VALUES ($1, $2, `+expires+`)
+	`, id, peerURL)
+	return err
+}
+
+// GetActiveATCs returns the ATCs whose heartbeats have not expired, ordered
+// by ID.
+func (db *SQLDB) GetActiveATCs() ([]SavedATC, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, peer_url, start_time, expires
+		FROM atcs
+		WHERE expires > NOW()
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	atcs := []SavedATC{}
+	for rows.Next() {
+		var savedATC SavedATC
+		err := rows.Scan(&savedATC.ID, &savedATC.PeerURL, &savedATC.StartTime, &savedATC.Expires)
+		if err != nil {
+			return nil, err
+		}
+
+		atcs = append(atcs, savedATC)
+	}
+
+	return atcs, nil
+}
+
+func (db *SQLDB) DeleteATC(id string) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM atcs
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+func (db *SQLDB) ReapExpiredATCs() error {
+	_, err := db.conn.Exec(`
+		DELETE FROM atcs
+		WHERE expires < NOW()
+	`)
+	return err
+}
@@ -0,0 +1,98 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("ATCs", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB *db.SQLDB
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	getActiveIDs := func() []string {
+		atcs, err := sqlDB.GetActiveATCs()
+		Expect(err).NotTo(HaveOccurred())
+
+		ids := []string{}
+		for _, savedATC := range atcs {
+			ids = append(ids, savedATC.ID)
+		}
+
+		return ids
+	}
+
+	It("registers ATCs until they leave or their heartbeats expire", func() {
+		err := sqlDB.HeartbeatATC("atc-b", "http://atc-b", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.HeartbeatATC("atc-a", "http://atc-a", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getActiveIDs()).To(Equal([]string{"atc-a", "atc-b"}))
+
+		By("updating the registration on subsequent heartbeats")
+		err = sqlDB.HeartbeatATC("atc-a", "http://new-atc-a", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		atcs, err := sqlDB.GetActiveATCs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atcs).To(HaveLen(2))
+		Expect(atcs[0].PeerURL).To(Equal("http://new-atc-a"))
+
+		By("removing ATCs that leave")
+		err = sqlDB.DeleteATC("atc-b")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getActiveIDs()).To(Equal([]string{"atc-a"}))
+
+		By("ignoring and then reaping ATCs whose heartbeats have expired")
+		err = sqlDB.HeartbeatATC("atc-a", "http://atc-a", -time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getActiveIDs()).To(BeEmpty())
+
+		err = sqlDB.ReapExpiredATCs()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.HeartbeatATC("atc-a", "http://atc-a", time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(getActiveIDs()).To(Equal([]string{"atc-a"}))
+	})
+})
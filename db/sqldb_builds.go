@@ -45,7 +45,7 @@ func (db *SQLDB) GetPublicBuilds(page Page) ([]Build, Pagination, error) {
 		LeftJoin("teams t ON b.team_id = t.id").
 		Where(sq.Eq{"p.public": true})
 
-	return getBuildsWithPagination(buildsQuery, page, db.conn, db.buildFactory)
+	return getBuildsWithPagination(buildsQuery, page, reader(db.conn), db.buildFactory)
 }
 
 func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
@@ -203,7 +203,20 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, dbConn Con
 		return nil, Pagination{}, err
 	}
 
-	err = dbConn.QueryRow(maxMinBuildIDQuery).Scan(&maxID, &minID)
+	// not QueryRow, so that it goes to the same place as the builds when dbConn
+	// is the read replica
+	maxMinRows, err := dbConn.Query(maxMinBuildIDQuery)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer maxMinRows.Close()
+
+	if !maxMinRows.Next() {
+		return nil, Pagination{}, maxMinRows.Err()
+	}
+
+	err = maxMinRows.Scan(&maxID, &minID)
 	if err != nil {
 		return nil, Pagination{}, err
 	}
@@ -229,3 +242,7 @@ func getBuildsWithPagination(buildsQuery sq.SelectBuilder, page Page, dbConn Con
 
 	return builds, pagination, nil
 }
+
+func (db *SQLDB) GetBuildQueue() (BuildQueue, error) {
+	return getBuildQueue(db.conn)
+}
@@ -179,7 +179,13 @@ func (db *SQLDB) CreateContainer(
 
 	user := container.User
 
-	interval := fmt.Sprintf("%d second", int(ttl.Seconds()))
+	// a TTL of 0 means the container never expires, i.e. it is kept until its
+	// owner is gone
+	var interval sql.NullString
+	if ttl != 0 {
+		interval.String = fmt.Sprintf("%d second", int(ttl.Seconds()))
+		interval.Valid = true
+	}
 
 	if container.PipelineName != "" && container.PipelineID == 0 {
 		// containers that belong to some pipeline must be identified by pipeline ID not name
@@ -239,6 +245,15 @@ func (db *SQLDB) CreateContainer(
 		imageResourceType.Valid = true
 	}
 
+	var ownerType sql.NullString
+	var ownerID sql.NullInt64
+	if owner, found := ContainerOwner(container.ContainerIdentifier); found {
+		ownerType.String = string(owner.Type)
+		ownerType.Valid = true
+		ownerID.Int64 = int64(owner.ID)
+		ownerID.Valid = true
+	}
+
 	maxLifetimeValue := "NULL"
 	if maxLifetime > 0 {
 		maxLifetimeValue = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(maxLifetime.Seconds()))
@@ -248,8 +263,8 @@ func (db *SQLDB) CreateContainer(
 		INSERT INTO containers (handle, resource_id, step_name, pipeline_id, build_id, type, worker_name,
 			expires_at, ttl, best_if_used_by, check_type, check_source, plan_id, working_directory,
 			env_variables, attempts, stage, image_resource_type, image_resource_source,
-			process_user, resource_type_version, team_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+			process_user, resource_type_version, team_id, owner_type, owner_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8::INTERVAL, $9,`+maxLifetimeValue+`, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 		RETURNING id`,
 		container.Handle,
 		resourceID,
@@ -272,6 +287,8 @@ func (db *SQLDB) CreateContainer(
 		user,
 		resourceTypeVersion,
 		container.TeamID,
+		ownerType,
+		ownerID,
 	).Scan(&id)
 	if err != nil {
 		return SavedContainer{}, err
@@ -286,12 +303,17 @@ func (db *SQLDB) CreateContainer(
 		return SavedContainer{}, err
 	}
 
+	// volumes without an owner of their own, e.g. outputs and copy-on-write
+	// volumes, belong to whatever owns the container, and no longer expire
 	for _, volumeHandle := range volumeHandles {
 		_, err = tx.Exec(`
 			UPDATE volumes
-			SET container_id = $1
+			SET container_id = $1,
+				owner_type = COALESCE(owner_type, $3),
+				owner_id = CASE WHEN owner_type IS NULL THEN $4 ELSE owner_id END,
+				expires_at = CASE WHEN $3::text IS NULL THEN expires_at ELSE NULL END
 			WHERE handle = $2
-		`, id, volumeHandle)
+		`, id, volumeHandle, ownerType, ownerID)
 		if err != nil {
 			return SavedContainer{}, err
 		}
@@ -394,7 +416,7 @@ func isValidCheckID(id ContainerIdentifier) bool {
 	switch id.Stage {
 	case ContainerStageCheck, ContainerStageGet:
 		return id.ImageResourceType != "" && id.ImageResourceSource != nil
-	case ContainerStageRun:
+	case ContainerStageRun, ContainerStagePrefetch:
 		return id.ImageResourceType == "" && id.ImageResourceSource == nil
 	default:
 		return false
@@ -551,16 +573,3 @@ func scanContainer(row scannable) (SavedContainer, error) {
 
 	return container, nil
 }
-
-func (db *SQLDB) ReapExpiredContainers() error {
-	_, err := db.conn.Exec(`
-		DELETE FROM containers
-		WHERE expires_at IS NOT NULL
-		AND expires_at < NOW()
-	`)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const hijackSessionColumns = "s.id, s.team_id, t.name, s.hijacked_by, s.container_handle, s.process, s.start_time, s.end_time, s.exit_status"
+
+func (db *SQLDB) CreateHijackSession(session HijackSession) (SavedHijackSession, error) {
+	process, err := json.Marshal(session.Process)
+	if err != nil {
+		return SavedHijackSession{}, err
+	}
+
+	var id int
+	err = db.conn.QueryRow(`
+		INSERT INTO hijack_sessions (team_id, hijacked_by, container_handle, process)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, session.TeamID, session.HijackedBy, session.ContainerHandle, string(process)).Scan(&id)
+	if err != nil {
+		return SavedHijackSession{}, err
+	}
+
+	savedSession, _, err := db.GetHijackSession(id)
+	return savedSession, err
+}
+
+func (db *SQLDB) SaveHijackSessionEvent(sessionID int, event HijackSessionEvent) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO hijack_session_events (hijack_session_id, elapsed, type, data)
+		VALUES ($1, $2, $3, $4)
+	`, sessionID, int64(event.Elapsed), event.Type, event.Data)
+	return err
+}
+
+func (db *SQLDB) FinishHijackSession(sessionID int, exitStatus *int) error {
+	result, err := db.conn.Exec(`
+		UPDATE hijack_sessions
+		SET end_time = now(), exit_status = $2
+		WHERE id = $1
+	`, sessionID, exitStatus)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return nil
+}
+
+func (db *SQLDB) GetHijackSessions() ([]SavedHijackSession, error) {
+	rows, err := db.conn.Query(`
+		SELECT ` + hijackSessionColumns + `
+		FROM hijack_sessions s
+		JOIN teams t ON t.id = s.team_id
+		ORDER BY s.id DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	sessions := []SavedHijackSession{}
+	for rows.Next() {
+		session, err := scanHijackSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (db *SQLDB) GetHijackSession(sessionID int) (SavedHijackSession, bool, error) {
+	session, err := scanHijackSession(db.conn.QueryRow(`
+		SELECT `+hijackSessionColumns+`
+		FROM hijack_sessions s
+		JOIN teams t ON t.id = s.team_id
+		WHERE s.id = $1
+	`, sessionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedHijackSession{}, false, nil
+		}
+
+		return SavedHijackSession{}, false, err
+	}
+
+	return session, true, nil
+}
+
+func (db *SQLDB) GetHijackSessionEvents(sessionID int) ([]HijackSessionEvent, error) {
+	rows, err := db.conn.Query(`
+		SELECT elapsed, type, data
+		FROM hijack_session_events
+		WHERE hijack_session_id = $1
+		ORDER BY id ASC
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []HijackSessionEvent{}
+	for rows.Next() {
+		var elapsed int64
+		var event HijackSessionEvent
+
+		err := rows.Scan(&elapsed, &event.Type, &event.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Elapsed = time.Duration(elapsed)
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func scanHijackSession(row scannable) (SavedHijackSession, error) {
+	var session SavedHijackSession
+	var process []byte
+	var endTime pq.NullTime
+	var exitStatus sql.NullInt64
+
+	err := row.Scan(
+		&session.ID,
+		&session.TeamID,
+		&session.TeamName,
+		&session.HijackedBy,
+		&session.ContainerHandle,
+		&process,
+		&session.StartTime,
+		&endTime,
+		&exitStatus,
+	)
+	if err != nil {
+		return SavedHijackSession{}, err
+	}
+
+	err = json.Unmarshal(process, &session.Process)
+	if err != nil {
+		return SavedHijackSession{}, err
+	}
+
+	if endTime.Valid {
+		session.EndTime = endTime.Time
+	}
+
+	if exitStatus.Valid {
+		status := int(exitStatus.Int64)
+		session.ExitStatus = &status
+	}
+
+	return session, nil
+}
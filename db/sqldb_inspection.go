@@ -0,0 +1,276 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// InspectionFilter narrows down the containers and volumes returned for
+// inspection. Empty fields match everything.
+type InspectionFilter struct {
+	Handle       string
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildID      int
+	WorkerName   string
+	Type         string
+}
+
+type InspectedContainer struct {
+	SavedContainer
+
+	TeamName string
+	Owner    *Owner
+}
+
+type InspectedVolume struct {
+	SavedVolume
+
+	TeamName        string
+	Owner           *Owner
+	ContainerHandle string
+	ChildHandles    []string
+}
+
+var volumeTypeConditions = map[string]string{
+	"cache":       "v.resource_hash IS NOT NULL",
+	"copy":        "v.original_volume_handle IS NOT NULL",
+	"output":      "v.output_name IS NOT NULL",
+	"import":      "v.path IS NOT NULL",
+	"replication": "v.replicated_from IS NOT NULL",
+}
+
+func ValidVolumeType(volumeType string) bool {
+	_, found := volumeTypeConditions[volumeType]
+	return found
+}
+
+// scanning with extra columns lets inspection reuse the regular scanners
+// for the columns they have in common
+type withExtraColumns struct {
+	row   scannable
+	extra []interface{}
+}
+
+func (r withExtraColumns) Scan(destinations ...interface{}) error {
+	return r.row.Scan(append(destinations, r.extra...)...)
+}
+
+type inspectionConditions struct {
+	conditions []string
+	params     []interface{}
+}
+
+func (c *inspectionConditions) add(condition string, param interface{}) {
+	c.params = append(c.params, param)
+	c.conditions = append(c.conditions, fmt.Sprintf(condition, fmt.Sprintf("$%d", len(c.params))))
+}
+
+func (c *inspectionConditions) where() string {
+	if len(c.conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(c.conditions, " AND ")
+}
+
+func (db *SQLDB) InspectContainers(filter InspectionFilter) ([]InspectedContainer, error) {
+	conditions := &inspectionConditions{}
+
+	if filter.Handle != "" {
+		conditions.add("c.handle = %s", filter.Handle)
+	}
+
+	if filter.TeamName != "" {
+		conditions.add("t.name = %s", filter.TeamName)
+	}
+
+	if filter.PipelineName != "" {
+		conditions.add("p.name = %s", filter.PipelineName)
+	}
+
+	if filter.JobName != "" {
+		conditions.add("j.name = %s", filter.JobName)
+	}
+
+	if filter.BuildID != 0 {
+		conditions.add("c.build_id = %s", filter.BuildID)
+	}
+
+	if filter.WorkerName != "" {
+		conditions.add("c.worker_name = %s", filter.WorkerName)
+	}
+
+	if filter.Type != "" {
+		conditions.add("c.type = %s", filter.Type)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT `+containerColumns+`, t.name, c.owner_type, c.owner_id
+		FROM containers c `+containerJoins+`
+		LEFT JOIN teams t
+			ON t.id = c.team_id
+		`+conditions.where()+`
+		ORDER BY c.id ASC
+	`, conditions.params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	containers := []InspectedContainer{}
+
+	for rows.Next() {
+		var (
+			teamName  sql.NullString
+			ownerType sql.NullString
+			ownerID   sql.NullInt64
+		)
+
+		container, err := scanContainer(withExtraColumns{
+			row:   rows,
+			extra: []interface{}{&teamName, &ownerType, &ownerID},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		containers = append(containers, InspectedContainer{
+			SavedContainer: container,
+			TeamName:       teamName.String,
+			Owner:          inspectedOwner(ownerType, ownerID),
+		})
+	}
+
+	return containers, nil
+}
+
+func (db *SQLDB) InspectVolumes(filter InspectionFilter) ([]InspectedVolume, error) {
+	conditions := &inspectionConditions{}
+
+	if filter.Handle != "" {
+		conditions.add("v.handle = %s", filter.Handle)
+	}
+
+	if filter.TeamName != "" {
+		conditions.add("t.name = %s", filter.TeamName)
+	}
+
+	if filter.PipelineName != "" {
+		conditions.add("p.name = %s", filter.PipelineName)
+	}
+
+	if filter.JobName != "" {
+		conditions.add("j.name = %s", filter.JobName)
+	}
+
+	if filter.BuildID != 0 {
+		conditions.add("(c.build_id = %[1]s OR (v.owner_type = '"+string(OwnerBuild)+"' AND v.owner_id = %[1]s))", filter.BuildID)
+	}
+
+	if filter.WorkerName != "" {
+		conditions.add("v.worker_name = %s", filter.WorkerName)
+	}
+
+	if filter.Type != "" {
+		condition, found := volumeTypeConditions[filter.Type]
+		if !found {
+			return nil, fmt.Errorf("unknown volume type: %s", filter.Type)
+		}
+
+		conditions.conditions = append(conditions.conditions, condition)
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT
+			v.worker_name,
+			v.ttl,
+			EXTRACT(epoch FROM v.expires_at - NOW()),
+			v.handle,
+			v.resource_version,
+			v.resource_hash,
+			v.id,
+			v.original_volume_handle,
+			v.output_name,
+			v.replicated_from,
+			v.path,
+			v.host_path_version,
+			v.size_in_bytes,
+			c.ttl,
+			v.team_id,
+			t.name,
+			v.owner_type,
+			v.owner_id,
+			c.handle,
+			(
+				SELECT string_agg(cv.handle, ',' ORDER BY cv.handle)
+				FROM volumes cv
+				WHERE cv.original_volume_handle = v.handle
+			)
+		FROM volumes v
+		`+volumeJoins+`
+		LEFT JOIN pipelines p
+			ON p.id = c.pipeline_id
+		LEFT JOIN builds b
+			ON b.id = c.build_id
+		LEFT JOIN jobs j
+			ON j.id = b.job_id
+		`+conditions.where()+`
+		ORDER BY v.id ASC
+	`, conditions.params...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	volumes := []InspectedVolume{}
+
+	for rows.Next() {
+		var (
+			teamName        sql.NullString
+			ownerType       sql.NullString
+			ownerID         sql.NullInt64
+			containerHandle sql.NullString
+			childHandles    sql.NullString
+		)
+
+		volume, err := scanVolume(withExtraColumns{
+			row:   rows,
+			extra: []interface{}{&teamName, &ownerType, &ownerID, &containerHandle, &childHandles},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		inspected := InspectedVolume{
+			SavedVolume:     volume,
+			TeamName:        teamName.String,
+			Owner:           inspectedOwner(ownerType, ownerID),
+			ContainerHandle: containerHandle.String,
+			ChildHandles:    []string{},
+		}
+
+		if childHandles.Valid {
+			inspected.ChildHandles = strings.Split(childHandles.String, ",")
+		}
+
+		volumes = append(volumes, inspected)
+	}
+
+	return volumes, nil
+}
+
+func inspectedOwner(ownerType sql.NullString, ownerID sql.NullInt64) *Owner {
+	if !ownerType.Valid || !ownerID.Valid {
+		return nil
+	}
+
+	return &Owner{
+		Type: OwnerType(ownerType.String),
+		ID:   int(ownerID.Int64),
+	}
+}
@@ -0,0 +1,229 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("Inspection", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB *db.SQLDB
+		build db.Build
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+
+		someTeam, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		otherTeam, err := sqlDB.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		build, err = teamDBFactory.GetTeamDB("some-team").CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.InsertVolume(db.Volume{
+			Handle:      "build-volume",
+			WorkerName:  "some-worker",
+			TeamID:      someTeam.ID,
+			TTL:         time.Hour,
+			SizeInBytes: 1024,
+			Identifier: db.VolumeIdentifier{
+				Output: &db.OutputIdentifier{Name: "some-output"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.InsertVolume(db.Volume{
+			Handle:     "child-volume",
+			WorkerName: "some-worker",
+			TeamID:     someTeam.ID,
+			TTL:        time.Hour,
+			Identifier: db.VolumeIdentifier{
+				COW: &db.COWIdentifier{ParentVolumeHandle: "build-volume"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = sqlDB.InsertVolume(db.Volume{
+			Handle:     "other-volume",
+			WorkerName: "other-worker",
+			TeamID:     otherTeam.ID,
+			TTL:        time.Hour,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = sqlDB.CreateContainer(db.Container{
+			ContainerIdentifier: db.ContainerIdentifier{
+				BuildID: build.ID(),
+				PlanID:  atc.PlanID("some-task"),
+				Stage:   db.ContainerStageRun,
+			},
+			ContainerMetadata: db.ContainerMetadata{
+				Handle:     "build-container",
+				WorkerName: "some-worker",
+				Type:       db.ContainerTypeTask,
+				TeamID:     someTeam.ID,
+			},
+		}, time.Hour, 0, []string{"build-volume"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = sqlDB.CreateContainer(db.Container{
+			ContainerMetadata: db.ContainerMetadata{
+				Handle:     "other-container",
+				WorkerName: "other-worker",
+				Type:       db.ContainerTypeCheck,
+				TeamID:     otherTeam.ID,
+			},
+		}, time.Hour, 0, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("InspectContainers", func() {
+		handles := func(containers []db.InspectedContainer) []string {
+			handles := []string{}
+			for _, container := range containers {
+				handles = append(handles, container.Handle)
+			}
+			return handles
+		}
+
+		It("returns every container with its team and owner", func() {
+			containers, err := sqlDB.InspectContainers(db.InspectionFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(HaveLen(2))
+
+			Expect(containers[0].Handle).To(Equal("build-container"))
+			Expect(containers[0].TeamName).To(Equal("some-team"))
+			Expect(containers[0].Owner).To(Equal(&db.Owner{Type: db.OwnerBuild, ID: build.ID()}))
+			Expect(containers[0].ExpiresIn).To(BeNumerically("~", time.Hour, 10*time.Second))
+
+			Expect(containers[1].Handle).To(Equal("other-container"))
+			Expect(containers[1].TeamName).To(Equal("other-team"))
+			Expect(containers[1].Owner).To(BeNil())
+		})
+
+		It("filters by team", func() {
+			containers, err := sqlDB.InspectContainers(db.InspectionFilter{TeamName: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(containers)).To(ConsistOf("other-container"))
+		})
+
+		It("filters by build", func() {
+			containers, err := sqlDB.InspectContainers(db.InspectionFilter{BuildID: build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(containers)).To(ConsistOf("build-container"))
+		})
+
+		It("filters by worker and type", func() {
+			containers, err := sqlDB.InspectContainers(db.InspectionFilter{WorkerName: "some-worker", Type: "check"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(BeEmpty())
+
+			containers, err = sqlDB.InspectContainers(db.InspectionFilter{WorkerName: "other-worker", Type: "check"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(containers)).To(ConsistOf("other-container"))
+		})
+
+		It("filters by handle", func() {
+			containers, err := sqlDB.InspectContainers(db.InspectionFilter{Handle: "build-container"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(containers)).To(ConsistOf("build-container"))
+		})
+	})
+
+	Describe("InspectVolumes", func() {
+		handles := func(volumes []db.InspectedVolume) []string {
+			handles := []string{}
+			for _, volume := range volumes {
+				handles = append(handles, volume.Handle)
+			}
+			return handles
+		}
+
+		It("returns every volume with its team, owner, container and children", func() {
+			volumes, err := sqlDB.InspectVolumes(db.InspectionFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumes).To(HaveLen(3))
+
+			Expect(volumes[0].Handle).To(Equal("build-volume"))
+			Expect(volumes[0].TeamName).To(Equal("some-team"))
+			Expect(volumes[0].Owner).To(Equal(&db.Owner{Type: db.OwnerBuild, ID: build.ID()}))
+			Expect(volumes[0].ContainerHandle).To(Equal("build-container"))
+			Expect(volumes[0].ChildHandles).To(Equal([]string{"child-volume"}))
+			Expect(volumes[0].SizeInBytes).To(Equal(int64(1024)))
+
+			Expect(volumes[1].Handle).To(Equal("child-volume"))
+			Expect(volumes[1].Volume.Identifier.COW).To(Equal(&db.COWIdentifier{ParentVolumeHandle: "build-volume"}))
+			Expect(volumes[1].ChildHandles).To(BeEmpty())
+
+			Expect(volumes[2].Handle).To(Equal("other-volume"))
+			Expect(volumes[2].TeamName).To(Equal("other-team"))
+			Expect(volumes[2].Owner).To(BeNil())
+			Expect(volumes[2].ContainerHandle).To(BeEmpty())
+		})
+
+		It("filters by team", func() {
+			volumes, err := sqlDB.InspectVolumes(db.InspectionFilter{TeamName: "some-team"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(volumes)).To(ConsistOf("build-volume", "child-volume"))
+		})
+
+		It("filters by build", func() {
+			volumes, err := sqlDB.InspectVolumes(db.InspectionFilter{BuildID: build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(volumes)).To(ConsistOf("build-volume"))
+		})
+
+		It("filters by type", func() {
+			volumes, err := sqlDB.InspectVolumes(db.InspectionFilter{Type: "copy"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(volumes)).To(ConsistOf("child-volume"))
+		})
+
+		It("filters by worker", func() {
+			volumes, err := sqlDB.InspectVolumes(db.InspectionFilter{WorkerName: "other-worker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handles(volumes)).To(ConsistOf("other-volume"))
+		})
+
+		It("rejects unknown types", func() {
+			_, err := sqlDB.InspectVolumes(db.InspectionFilter{Type: "bogus"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,267 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// a build's containers and volumes are kept while it is running, for a grace
+// period once it has finished, and for as long as it is the latest finished
+// build of its job and did not succeed, so that it can be hijacked, unless its
+// pipeline has been archived
+const buildOwnerAlive = `
+	EXISTS (
+		SELECT 1
+		FROM builds b
+		WHERE b.id = %[1]s.owner_id
+		AND (
+			b.status IN ('pending', 'started')
+			OR b.end_time > NOW() - $1::INTERVAL
+			OR (
+				b.status IN ('failed', 'errored')
+				AND b.job_id IS NOT NULL
+				AND NOT EXISTS (
+					SELECT 1
+					FROM jobs j, pipelines p
+					WHERE j.id = b.job_id
+					AND p.id = j.pipeline_id
+					AND p.archived
+				)
+				AND NOT EXISTS (
+					SELECT 1
+					FROM builds nb
+					WHERE nb.job_id = b.job_id
+					AND nb.id > b.id
+					AND nb.status NOT IN ('pending', 'started')
+				)
+			)
+		)
+	)`
+
+// a resource's check containers are kept for as long as it is configured
+// and its pipeline has not been archived
+const resourceCheckOwnerAlive = `
+	EXISTS (
+		SELECT 1
+		FROM resources r, pipelines p
+		WHERE r.id = %[1]s.owner_id
+		AND r.active
+		AND p.id = r.pipeline_id
+		AND NOT p.archived
+	)`
+
+const resourceCacheOwnerAlive = `
+	EXISTS (
+		SELECT 1
+		FROM resource_caches rc
+		WHERE rc.id = %[1]s.owner_id
+	)`
+
+func ownerAlive(alias string) string {
+	return fmt.Sprintf(`(
+		(%[1]s.owner_type = '`+string(OwnerBuild)+`' AND `+buildOwnerAlive+`)
+		OR (%[1]s.owner_type = '`+string(OwnerResourceCheck)+`' AND `+resourceCheckOwnerAlive+`)
+		OR (%[1]s.owner_type IN ('`+string(OwnerResourceCache)+`', '`+string(OwnerImageCache)+`') AND `+resourceCacheOwnerAlive+`)
+	)`, alias)
+}
+
+// containers and volumes without an owner, e.g. the volumes created for a
+// container before it exists, are kept until they expire
+const ownerlessExpired = `(
+		%[1]s.owner_type IS NULL
+		AND %[1]s.expires_at IS NOT NULL
+		AND %[1]s.expires_at < NOW()
+	)`
+
+// ReapOrphanedContainers deletes the containers whose owners are gone, or
+// that have no owner and have expired, and returns how many were deleted for
+// each type of owner.
+func (db *SQLDB) ReapOrphanedContainers(buildGracePeriod time.Duration) (map[OwnerType]int, error) {
+	rows, err := db.conn.Query(`
+		DELETE FROM containers c
+		WHERE (c.owner_type IS NOT NULL AND NOT `+ownerAlive("c")+`)
+		OR `+fmt.Sprintf(ownerlessExpired, "c")+`
+		RETURNING COALESCE(c.owner_type, '')
+	`, durationInterval(buildGracePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	return countOwnerTypes(rows)
+}
+
+// ReapOrphanedVolumes deletes the volumes whose owners are gone, or that have
+// no owner and have expired, and returns how many were deleted for each type
+// of owner.
+func (db *SQLDB) ReapOrphanedVolumes(buildGracePeriod time.Duration) (map[OwnerType]int, error) {
+	rows, err := db.conn.Query(`
+		DELETE FROM volumes v
+		WHERE (v.owner_type IS NOT NULL AND NOT `+ownerAlive("v")+`)
+		OR `+fmt.Sprintf(ownerlessExpired, "v")+`
+		RETURNING COALESCE(v.owner_type, '')
+	`, durationInterval(buildGracePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	return countOwnerTypes(rows)
+}
+
+// GetUnusedResourceCaches returns the resource caches that were created
+// before the grace period and none of whose volumes are attached to a
+// container or have copy-on-write volumes of their own.
+// resourceCacheUnused is true for a resource cache whose volumes are neither
+// attached to a container nor the parent of a copy-on-write volume
+const resourceCacheUnused = `
+	NOT EXISTS (
+		SELECT 1
+		FROM volumes v
+		WHERE v.owner_type IN ('` + string(OwnerResourceCache) + `', '` + string(OwnerImageCache) + `')
+		AND v.owner_id = rc.id
+		AND (
+			v.container_id IS NOT NULL
+			OR EXISTS (
+				SELECT 1
+				FROM volumes cv
+				WHERE cv.original_volume_handle = v.handle
+			)
+		)
+	)
+`
+
+func (db *SQLDB) GetUnusedResourceCaches(gracePeriod time.Duration) ([]SavedResourceCache, error) {
+	rows, err := db.conn.Query(`
+		SELECT rc.id, rc.resource_hash, rc.resource_version
+		FROM resource_caches rc
+		WHERE rc.created_at < NOW() - $1::INTERVAL
+		AND `+resourceCacheUnused+`
+		ORDER BY rc.id ASC
+	`, durationInterval(gracePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	caches := []SavedResourceCache{}
+	for rows.Next() {
+		var cache SavedResourceCache
+		var version string
+
+		err := rows.Scan(&cache.ID, &cache.ResourceHash, &version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(version), &cache.ResourceVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+// DeleteResourceCache deletes the resource cache unless it has come into use
+// since it was found to be unused, e.g. by a build attaching its volume.
+func (db *SQLDB) DeleteResourceCache(id int) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM resource_caches rc
+		WHERE rc.id = $1
+		AND `+resourceCacheUnused, id)
+	return err
+}
+
+func (db *SQLDB) GetContainerHandlesForWorker(workerName string) ([]string, error) {
+	return db.getHandlesForWorker("containers", workerName)
+}
+
+func (db *SQLDB) GetVolumeHandlesForWorker(workerName string) ([]string, error) {
+	return db.getHandlesForWorker("volumes", workerName)
+}
+
+func (db *SQLDB) getHandlesForWorker(table string, workerName string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT handle
+		FROM `+table+`
+		WHERE worker_name = $1
+	`, workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	handles := []string{}
+	for rows.Next() {
+		var handle string
+		err := rows.Scan(&handle)
+		if err != nil {
+			return nil, err
+		}
+
+		handles = append(handles, handle)
+	}
+
+	return handles, nil
+}
+
+func (db *SQLDB) findOrCreateResourceCache(identifier ResourceCacheIdentifier) (int, error) {
+	version, err := json.Marshal(identifier.ResourceVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO resource_caches (resource_hash, resource_version)
+		SELECT $1, $2
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM resource_caches
+			WHERE resource_hash = $1
+			AND resource_version = $2
+		)
+	`, identifier.ResourceHash, string(version))
+	err = swallowUniqueViolation(err)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = db.conn.QueryRow(`
+		SELECT id
+		FROM resource_caches
+		WHERE resource_hash = $1
+		AND resource_version = $2
+	`, identifier.ResourceHash, string(version)).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func countOwnerTypes(rows *sql.Rows) (map[OwnerType]int, error) {
+	defer rows.Close()
+
+	counts := map[OwnerType]int{}
+	for rows.Next() {
+		var ownerType string
+		err := rows.Scan(&ownerType)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[OwnerType(ownerType)]++
+	}
+
+	return counts, nil
+}
+
+func durationInterval(duration time.Duration) string {
+	return fmt.Sprintf("%d second", int(duration.Seconds()))
+}
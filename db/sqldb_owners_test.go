@@ -0,0 +1,389 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("Owners", func() {
+	var (
+		dbConn   db.Conn
+		listener *pq.Listener
+
+		sqlDB             *db.SQLDB
+		teamDB            db.TeamDB
+		teamID            int
+		pipelineDBFactory db.PipelineDBFactory
+	)
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+
+		savedTeam, err := sqlDB.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+		teamID = savedTeam.ID
+
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ReapOrphanedContainers and ReapOrphanedVolumes", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = sqlDB.InsertVolume(db.Volume{
+				Handle:     "build-volume",
+				WorkerName: "some-worker",
+				TTL:        time.Hour,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.CreateContainer(db.Container{
+				ContainerIdentifier: db.ContainerIdentifier{
+					BuildID: build.ID(),
+					PlanID:  atc.PlanID("some-task"),
+					Stage:   db.ContainerStageRun,
+				},
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     "build-container",
+					WorkerName: "some-worker",
+					Type:       db.ContainerTypeTask,
+					TeamID:     teamID,
+				},
+			}, 0, 0, []string{"build-volume"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.CreateContainer(db.Container{
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     "ownerless-container",
+					WorkerName: "some-worker",
+					Type:       db.ContainerTypeTask,
+					TeamID:     teamID,
+				},
+			}, time.Hour, 0, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the containers and volumes of running builds", func() {
+			containers, err := sqlDB.ReapOrphanedContainers(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(BeEmpty())
+
+			volumes, err := sqlDB.ReapOrphanedVolumes(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumes).To(BeEmpty())
+		})
+
+		Context("when the build has finished", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps them within the grace period", func() {
+				containers, err := sqlDB.ReapOrphanedContainers(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(BeEmpty())
+			})
+
+			It("reaps them, and the volumes attached to them, after the grace period", func() {
+				containers, err := sqlDB.ReapOrphanedContainers(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(Equal(map[db.OwnerType]int{db.OwnerBuild: 1}))
+
+				volumes, err := sqlDB.ReapOrphanedVolumes(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(Equal(map[db.OwnerType]int{db.OwnerBuild: 1}))
+
+				handles, err := sqlDB.GetContainerHandlesForWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handles).To(ConsistOf("ownerless-container"))
+
+				handles, err = sqlDB.GetVolumeHandlesForWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handles).To(BeEmpty())
+			})
+		})
+
+		Context("when containers and volumes without an owner have expired", func() {
+			BeforeEach(func() {
+				err := sqlDB.InsertVolume(db.Volume{
+					Handle:     "expired-volume",
+					WorkerName: "some-worker",
+					TTL:        -time.Minute,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = sqlDB.CreateContainer(db.Container{
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "expired-container",
+						WorkerName: "some-worker",
+						Type:       db.ContainerTypeCheck,
+						TeamID:     teamID,
+					},
+				}, -time.Minute, 0, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reaps only them", func() {
+				containers, err := sqlDB.ReapOrphanedContainers(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(Equal(map[db.OwnerType]int{"": 1}))
+
+				volumes, err := sqlDB.ReapOrphanedVolumes(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(Equal(map[db.OwnerType]int{"": 1}))
+
+				handles, err := sqlDB.GetContainerHandlesForWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handles).To(ConsistOf("build-container", "ownerless-container"))
+
+				handles, err = sqlDB.GetVolumeHandlesForWorker("some-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handles).To(ConsistOf("build-volume"))
+			})
+		})
+
+		It("does not expire the volumes attached to the containers of an owner", func() {
+			container, found, err := sqlDB.GetContainer("build-container")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(container.ExpiresIn).To(BeZero())
+
+			volumes, err := sqlDB.GetVolumes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumes).To(HaveLen(1))
+			Expect(volumes[0].ExpiresIn).To(BeZero())
+		})
+	})
+
+	Describe("team quota usage", func() {
+		var (
+			pipelineDB db.PipelineDB
+			build      db.Build
+		)
+
+		BeforeEach(func() {
+			savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sqlDB.CreateContainer(db.Container{
+				ContainerIdentifier: db.ContainerIdentifier{
+					BuildID: build.ID(),
+					PlanID:  atc.PlanID("some-task"),
+					Stage:   db.ContainerStageRun,
+				},
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     "build-container",
+					WorkerName: "some-worker",
+					Type:       db.ContainerTypeTask,
+					TeamID:     teamID,
+				},
+			}, 0, 0, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the containers of running builds", func() {
+			_, usage, err := pipelineDB.GetTeamQuotaAndUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage.Containers).To(Equal(1))
+		})
+
+		Context("when the build has failed and its containers are kept for hijacking", func() {
+			BeforeEach(func() {
+				err := build.Finish(db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not count them", func() {
+				_, usage, err := pipelineDB.GetTeamQuotaAndUsage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(usage.Containers).To(BeZero())
+			})
+		})
+	})
+
+	Describe("resource caches", func() {
+		BeforeEach(func() {
+			err := sqlDB.InsertVolume(db.Volume{
+				Handle:     "cache-volume",
+				WorkerName: "some-worker",
+				TTL:        time.Hour,
+				Identifier: db.VolumeIdentifier{
+					ResourceCache: &db.ResourceCacheIdentifier{
+						ResourceVersion: atc.Version{"some": "version"},
+						ResourceHash:    "some-hash",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("only reports caches created before the grace period", func() {
+			caches, err := sqlDB.GetUnusedResourceCaches(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(caches).To(BeEmpty())
+		})
+
+		It("reaps the cache's volumes once it is deleted", func() {
+			caches, err := sqlDB.GetUnusedResourceCaches(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(caches).To(HaveLen(1))
+			Expect(caches[0].ResourceCacheIdentifier).To(Equal(db.ResourceCacheIdentifier{
+				ResourceVersion: atc.Version{"some": "version"},
+				ResourceHash:    "some-hash",
+			}))
+
+			volumes, err := sqlDB.ReapOrphanedVolumes(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumes).To(BeEmpty())
+
+			err = sqlDB.DeleteResourceCache(caches[0].ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			volumes, err = sqlDB.ReapOrphanedVolumes(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(volumes).To(Equal(map[db.OwnerType]int{db.OwnerResourceCache: 1}))
+		})
+
+		Context("when a volume of the cache is attached to a container", func() {
+			BeforeEach(func() {
+				_, err := sqlDB.CreateContainer(db.Container{
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-container",
+						WorkerName: "some-worker",
+						Type:       db.ContainerTypeGet,
+						TeamID:     teamID,
+					},
+				}, time.Hour, 0, []string{"cache-volume"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is not reported as unused", func() {
+				caches, err := sqlDB.GetUnusedResourceCaches(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(caches).To(BeEmpty())
+			})
+		})
+
+		Context("when a volume of the cache is attached after the cache was found to be unused", func() {
+			It("is not deleted", func() {
+				caches, err := sqlDB.GetUnusedResourceCaches(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(caches).To(HaveLen(1))
+
+				_, err = sqlDB.CreateContainer(db.Container{
+					ContainerMetadata: db.ContainerMetadata{
+						Handle:     "some-container",
+						WorkerName: "some-worker",
+						Type:       db.ContainerTypeGet,
+						TeamID:     teamID,
+					},
+				}, time.Hour, 0, []string{"cache-volume"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sqlDB.DeleteResourceCache(caches[0].ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				volumes, err := sqlDB.ReapOrphanedVolumes(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(volumes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("resource check containers", func() {
+		var pipelineDB db.PipelineDB
+
+		BeforeEach(func() {
+			savedPipeline, _, err := teamDB.SaveConfig("some-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "git",
+						Source: atc.Source{"uri": "some-repo"},
+					},
+				},
+			}, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipelineDB = pipelineDBFactory.Build(savedPipeline)
+
+			resource, found, err := pipelineDB.GetResource("some-resource")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = sqlDB.CreateContainer(db.Container{
+				ContainerIdentifier: db.ContainerIdentifier{
+					ResourceID: resource.ID,
+					CheckType:  "git",
+				},
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     "check-container",
+					WorkerName: "some-worker",
+					Type:       db.ContainerTypeCheck,
+					TeamID:     teamID,
+				},
+			}, time.Hour, 0, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps them while the resource is configured", func() {
+			containers, err := sqlDB.ReapOrphanedContainers(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(BeEmpty())
+		})
+
+		Context("when the pipeline is archived", func() {
+			BeforeEach(func() {
+				err := pipelineDB.Archive()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reaps them", func() {
+				containers, err := sqlDB.ReapOrphanedContainers(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(containers).To(Equal(map[db.OwnerType]int{db.OwnerResourceCheck: 1}))
+			})
+		})
+	})
+})
@@ -6,11 +6,11 @@ type PipelinesDB interface {
 	GetAllPublicPipelines() ([]SavedPipeline, error)
 }
 
-const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, t.name as team_name"
-const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public"
+const pipelineColumns = "p.id, p.name, p.config, p.version, p.paused, p.team_id, p.public, p.archived, p.instance_vars, t.name as team_name"
+const unqualifiedPipelineColumns = "id, name, config, version, paused, team_id, public, archived, instance_vars"
 
 func (db *SQLDB) GetAllPublicPipelines() ([]SavedPipeline, error) {
-	rows, err := db.conn.Query(`
+	rows, err := reader(db.conn).Query(`
 		SELECT ` + pipelineColumns + `
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
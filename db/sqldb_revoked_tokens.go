@@ -0,0 +1,48 @@
+package db
+
+import "time"
+
+func (db *SQLDB) RevokeToken(tokenID string, expiresAt time.Time) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// the revocation only has to outlive the token itself
+	_, err = tx.Exec(`
+		DELETE FROM revoked_tokens
+		WHERE expires_at < now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO revoked_tokens (token_id, expires_at)
+		SELECT $1::text, $2::timestamptz
+		WHERE NOT EXISTS (
+			SELECT 1 FROM revoked_tokens WHERE token_id = $1
+		)
+	`, tokenID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *SQLDB) IsTokenRevoked(tokenID string) (bool, error) {
+	var revoked bool
+	err := db.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM revoked_tokens WHERE token_id = $1
+		)
+	`, tokenID).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
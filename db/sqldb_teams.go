@@ -9,7 +9,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -79,18 +79,23 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
+	jsonEncodedOIDCAuth, err := json.Marshal(team.OIDCAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
 	return scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth, genericoauth_auth
+    name, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 	) VALUES (
-		$1, $2, $3, $4, $5
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth), string(jsonEncodedOIDCAuth), team.Quota.MaxConcurrentBuilds, team.Quota.MaxContainers, team.Quota.MaxVolumeBytes, team.Quota.BuildQueueWeight, team.RequireHijackRecording))
 }
 
 func scanTeam(rows scannable) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth sql.NullString
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -101,6 +106,12 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&savedTeam.Quota.MaxConcurrentBuilds,
+		&savedTeam.Quota.MaxContainers,
+		&savedTeam.Quota.MaxVolumeBytes,
+		&savedTeam.Quota.BuildQueueWeight,
+		&savedTeam.RequireHijackRecording,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -134,6 +145,13 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
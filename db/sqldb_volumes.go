@@ -58,6 +58,15 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "resource_hash")
 		params = append(params, data.Identifier.ResourceCache.ResourceHash)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		resourceCacheID, err := db.findOrCreateResourceCache(*data.Identifier.ResourceCache)
+		if err != nil {
+			return err
+		}
+
+		columns = append(columns, "owner_type", "owner_id")
+		params = append(params, string(OwnerResourceCache), resourceCacheID)
+		values = append(values, fmt.Sprintf("$%d", len(params)-1), fmt.Sprintf("$%d", len(params)))
 	case data.Identifier.COW != nil:
 		columns = append(columns, "original_volume_handle")
 		params = append(params, data.Identifier.COW.ParentVolumeHandle)
@@ -245,6 +254,16 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 	return volumes, err
 }
 
+func (db *SQLDB) SetVolumeSizeInBytes(handle string, sizeInBytes int64) error {
+	_, err := db.conn.Exec(`
+		UPDATE volumes
+		SET size_in_bytes = $2
+		WHERE handle = $1
+	`, handle, sizeInBytes)
+
+	return err
+}
+
 func (db *SQLDB) SetVolumeTTLAndSizeInBytes(handle string, ttl time.Duration, sizeInBytes int64) error {
 	if ttl == 0 {
 		_, err := db.conn.Exec(`
@@ -309,97 +328,97 @@ func (db *SQLDB) GetVolumeTTL(handle string) (time.Duration, bool, error) {
 	return ttl, true, nil
 }
 
-func (db *SQLDB) ReapExpiredVolumes() error {
-	_, err := db.conn.Exec(`
-		DELETE FROM volumes
-		WHERE expires_at IS NOT NULL
-		AND expires_at < NOW()
-	`)
-	return err
-}
-
 func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 	defer rows.Close()
 
 	volumes := []SavedVolume{}
 
 	for rows.Next() {
-		var (
-			volume               SavedVolume
-			ttlSeconds           *float64
-			versionJSON          sql.NullString
-			resourceHash         sql.NullString
-			originalVolumeHandle sql.NullString
-			outputName           sql.NullString
-			replicationName      sql.NullString
-			path                 sql.NullString
-			hostPathVersion      sql.NullString
-			teamID               sql.NullInt64
-		)
-
-		err := rows.Scan(
-			&volume.WorkerName,
-			&volume.TTL,
-			&ttlSeconds,
-			&volume.Handle,
-			&versionJSON,
-			&resourceHash,
-			&volume.ID,
-			&originalVolumeHandle,
-			&outputName,
-			&replicationName,
-			&path,
-			&hostPathVersion,
-			&volume.SizeInBytes,
-			&volume.ContainerTTL,
-			&teamID,
-		)
+		volume, err := scanVolume(rows)
 		if err != nil {
 			return []SavedVolume{}, err
 		}
 
-		if ttlSeconds != nil {
-			volume.ExpiresIn = time.Duration(*ttlSeconds) * time.Second
-		}
+		volumes = append(volumes, volume)
+	}
 
-		if teamID.Valid {
-			volume.TeamID = int(teamID.Int64)
-		}
+	return volumes, nil
+}
+
+func scanVolume(row scannable) (SavedVolume, error) {
+	var (
+		volume               SavedVolume
+		ttlSeconds           *float64
+		versionJSON          sql.NullString
+		resourceHash         sql.NullString
+		originalVolumeHandle sql.NullString
+		outputName           sql.NullString
+		replicationName      sql.NullString
+		path                 sql.NullString
+		hostPathVersion      sql.NullString
+		teamID               sql.NullInt64
+	)
+
+	err := row.Scan(
+		&volume.WorkerName,
+		&volume.TTL,
+		&ttlSeconds,
+		&volume.Handle,
+		&versionJSON,
+		&resourceHash,
+		&volume.ID,
+		&originalVolumeHandle,
+		&outputName,
+		&replicationName,
+		&path,
+		&hostPathVersion,
+		&volume.SizeInBytes,
+		&volume.ContainerTTL,
+		&teamID,
+	)
+	if err != nil {
+		return SavedVolume{}, err
+	}
+
+	if ttlSeconds != nil {
+		volume.ExpiresIn = time.Duration(*ttlSeconds) * time.Second
+	}
+
+	if teamID.Valid {
+		volume.TeamID = int(teamID.Int64)
+	}
 
-		switch {
-		case versionJSON.Valid && resourceHash.Valid:
-			var cacheID ResourceCacheIdentifier
-
-			err = json.Unmarshal([]byte(versionJSON.String), &cacheID.ResourceVersion)
-			if err != nil {
-				return []SavedVolume{}, err
-			}
-
-			cacheID.ResourceHash = resourceHash.String
-
-			volume.Volume.Identifier.ResourceCache = &cacheID
-		case originalVolumeHandle.Valid:
-			volume.Volume.Identifier.COW = &COWIdentifier{
-				ParentVolumeHandle: originalVolumeHandle.String,
-			}
-		case outputName.Valid:
-			volume.Volume.Identifier.Output = &OutputIdentifier{
-				Name: outputName.String,
-			}
-		case replicationName.Valid:
-			volume.Volume.Identifier.Replication = &ReplicationIdentifier{
-				ReplicatedVolumeHandle: replicationName.String,
-			}
-		case path.Valid:
-			volume.Volume.Identifier.Import = &ImportIdentifier{
-				Path:       path.String,
-				WorkerName: volume.WorkerName,
-				Version:    &hostPathVersion.String,
-			}
+	switch {
+	case versionJSON.Valid && resourceHash.Valid:
+		var cacheID ResourceCacheIdentifier
+
+		err = json.Unmarshal([]byte(versionJSON.String), &cacheID.ResourceVersion)
+		if err != nil {
+			return SavedVolume{}, err
 		}
 
-		volumes = append(volumes, volume)
+		cacheID.ResourceHash = resourceHash.String
+
+		volume.Volume.Identifier.ResourceCache = &cacheID
+	case originalVolumeHandle.Valid:
+		volume.Volume.Identifier.COW = &COWIdentifier{
+			ParentVolumeHandle: originalVolumeHandle.String,
+		}
+	case outputName.Valid:
+		volume.Volume.Identifier.Output = &OutputIdentifier{
+			Name: outputName.String,
+		}
+	case replicationName.Valid:
+		volume.Volume.Identifier.Replication = &ReplicationIdentifier{
+			ReplicatedVolumeHandle: replicationName.String,
+		}
+	case path.Valid:
+		volume.Volume.Identifier.Import = &ImportIdentifier{
+			Path:       path.String,
+			WorkerName: volume.WorkerName,
+			Version:    &hostPathVersion.String,
+		}
 	}
 
-	return volumes, nil
+	return volume, nil
 }
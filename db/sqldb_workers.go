@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/concourse/atc"
 )
 
-var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, t.name as team_name, team_id"
-var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time"
+var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, active_volumes, cpu_pressure, memory_pressure, disk_pressure, state, t.name as team_name, team_id"
+var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time, active_volumes, cpu_pressure, memory_pressure, disk_pressure, state"
 
 func (db *SQLDB) Workers() ([]SavedWorker, error) {
 	rows, err := db.conn.Query(`
@@ -78,18 +80,18 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 
 	row := db.conn.QueryRow(`
   		UPDATE workers
-      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12
+      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12, active_volumes = $13, cpu_pressure = $14, memory_pressure = $15, disk_pressure = $16, state = 'running', stream_id = NULL
 			WHERE name = $10 OR addr = $1
 			RETURNING  `+actualWorkerColumns,
-		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID)
+		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID, info.ActiveVolumes, info.CPUPressure, info.MemoryPressure, info.DiskPressure)
 
 	savedWorker, err = scanWorker(row, false)
 	if err == sql.ErrNoRows {
 		row = db.conn.QueryRow(`
-			INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, name, start_time, team_id)
-			VALUES ($1, `+expires+`, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, name, start_time, team_id, active_volumes, cpu_pressure, memory_pressure, disk_pressure)
+			VALUES ($1, `+expires+`, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 			RETURNING `+actualWorkerColumns,
-			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID)
+			info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID, info.ActiveVolumes, info.CPUPressure, info.MemoryPressure, info.DiskPressure)
 		savedWorker, err = scanWorker(row, false)
 	}
 	if err != nil {
@@ -100,6 +102,39 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 	return savedWorker, nil
 }
 
+// HeartbeatWorker records the load reported on a worker's heartbeat stream
+// and extends its TTL. It returns false if the worker is no longer
+// registered.
+func (db *SQLDB) HeartbeatWorker(name string, streamID string, load atc.WorkerLoad, ttl time.Duration) (bool, error) {
+	result, err := db.conn.Exec(`
+		UPDATE workers
+		SET expires = NOW() + $3::INTERVAL, active_containers = $4, active_volumes = $5, cpu_pressure = $6, memory_pressure = $7, disk_pressure = $8, state = 'running', stream_id = $2
+		WHERE name = $1
+	`, name, streamID, durationInterval(ttl), load.ActiveContainers, load.ActiveVolumes, load.CPUPressure, load.MemoryPressure, load.DiskPressure)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// StallWorker marks the worker as stalled when its heartbeat stream drops,
+// unless the worker has since reconnected on another stream.
+func (db *SQLDB) StallWorker(name string, streamID string) error {
+	_, err := db.conn.Exec(`
+		UPDATE workers
+		SET state = 'stalled'
+		WHERE name = $1
+		AND stream_id = $2
+	`, name, streamID)
+	return err
+}
+
 func (db *SQLDB) ReapExpiredWorkers() error {
 	_, err := db.conn.Exec(`
 		DELETE FROM workers
@@ -124,9 +159,9 @@ func scanWorker(row scannable, scanTeam bool) (SavedWorker, error) {
 	var err error
 
 	if scanTeam {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &teamName, &teamID)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &info.ActiveVolumes, &info.CPUPressure, &info.MemoryPressure, &info.DiskPressure, &info.State, &teamName, &teamID)
 	} else {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &info.ActiveVolumes, &info.CPUPressure, &info.MemoryPressure, &info.DiskPressure, &info.State)
 	}
 	if err != nil {
 		return SavedWorker{}, err
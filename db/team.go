@@ -14,10 +14,15 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth"`
+
+	Quota TeamQuota `json:"quota"`
+
+	RequireHijackRecording bool `json:"require_hijack_recording"`
 }
 
 func (t Team) IsAuthConfigured() bool {
-	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
+	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil || t.OIDCAuth != nil
 }
 
 type BasicAuth struct {
@@ -82,3 +87,14 @@ type GenericOAuth struct {
 	DisplayName   string            `json:"display_name"`
 	Scope         string            `json:"scope"`
 }
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scopes       []string `json:"scopes"`
+	GroupsClaim  string   `json:"groups_claim"`
+	Groups       []string `json:"groups"`
+	EmailDomains []string `json:"email_domains"`
+}
@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 
 	"github.com/concourse/atc"
 )
@@ -19,6 +21,7 @@ type TeamDB interface {
 	GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error)
 
 	GetPipelineByName(pipelineName string) (SavedPipeline, bool, error)
+	GetPipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (SavedPipeline, bool, error)
 
 	OrderPipelines([]string) error
 
@@ -27,18 +30,33 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error)
+	UpdateQuota(quota TeamQuota) (SavedTeam, error)
+	UpdateRequireHijackRecording(required bool) (SavedTeam, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
+	GetInstanceConfig(pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, ConfigVersion, error)
 	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveInstanceConfig(string, atc.InstanceVars, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, error)
 
+	GetBuildProvenance(buildID int, direction ProvenanceDirection, maxBuilds int) (Provenance, bool, error)
+	GetVersionProvenance(pipelineName string, resourceName string, versionedResourceID int, direction ProvenanceDirection, maxBuilds int) (Provenance, bool, error)
+
 	Workers() ([]SavedWorker, error)
 	GetContainer(handle string) (SavedContainer, bool, error)
 	FindContainersByDescriptors(id Container) ([]SavedContainer, error)
 
 	GetVolumes() ([]SavedVolume, error)
+
+	CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error)
+	GetAPITokens() ([]SavedAPIToken, error)
+	DeleteAPIToken(name string) (bool, error)
+
+	ExportTeam(w io.Writer, includeEvents bool) error
+	ImportTeam(archive io.Reader) (atc.TeamImport, error)
 }
 
 type teamDB struct {
@@ -48,7 +66,18 @@ type teamDB struct {
 	buildFactory *buildFactory
 }
 
+// GetPipelineByName only finds the pipeline with the name that is not an
+// instance; see GetPipelineInstance.
 func (db *teamDB) GetPipelineByName(pipelineName string) (SavedPipeline, bool, error) {
+	return db.GetPipelineInstance(pipelineName, nil)
+}
+
+func (db *teamDB) GetPipelineInstance(pipelineName string, instanceVars atc.InstanceVars) (SavedPipeline, bool, error) {
+	varsPayload, err := instanceVarsJSON(instanceVars)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	row := db.conn.QueryRow(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
@@ -57,7 +86,8 @@ func (db *teamDB) GetPipelineByName(pipelineName string) (SavedPipeline, bool, e
 		AND p.team_id = (
 			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
 		)
-	`, pipelineName, db.teamName)
+		AND p.instance_vars IS NOT DISTINCT FROM $3
+	`, pipelineName, db.teamName, varsPayload)
 	pipeline, err := scanPipeline(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -71,7 +101,7 @@ func (db *teamDB) GetPipelineByName(pipelineName string) (SavedPipeline, bool, e
 }
 
 func (db *teamDB) GetPipelines() ([]SavedPipeline, error) {
-	rows, err := db.conn.Query(`
+	rows, err := reader(db.conn).Query(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
@@ -90,7 +120,7 @@ func (db *teamDB) GetPipelines() ([]SavedPipeline, error) {
 }
 
 func (db *teamDB) GetPublicPipelines() ([]SavedPipeline, error) {
-	rows, err := db.conn.Query(`
+	rows, err := reader(db.conn).Query(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
@@ -110,7 +140,9 @@ func (db *teamDB) GetPublicPipelines() ([]SavedPipeline, error) {
 }
 
 func (db *teamDB) GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error) {
-	rows, err := db.conn.Query(`
+	conn := reader(db.conn)
+
+	rows, err := conn.Query(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
@@ -128,7 +160,7 @@ func (db *teamDB) GetPrivateAndAllPublicPipelines() ([]SavedPipeline, error) {
 		return nil, err
 	}
 
-	otherRows, err := db.conn.Query(`
+	otherRows, err := conn.Query(`
 		SELECT `+pipelineColumns+`
 		FROM pipelines p
 		INNER JOIN teams t ON t.id = p.team_id
@@ -203,9 +235,18 @@ func (db *teamDB) OrderPipelines(pipelineNames []string) error {
 }
 
 func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error) {
+	return db.GetInstanceConfig(pipelineName, nil)
+}
+
+func (db *teamDB) GetInstanceConfig(pipelineName string, instanceVars atc.InstanceVars) (atc.Config, atc.RawConfig, ConfigVersion, error) {
+	varsPayload, err := instanceVarsJSON(instanceVars)
+	if err != nil {
+		return atc.Config{}, atc.RawConfig(""), 0, err
+	}
+
 	var configBlob []byte
 	var version int
-	err := db.conn.QueryRow(`
+	err = db.conn.QueryRow(`
 		SELECT config, version
 		FROM pipelines
 		WHERE name = $1 AND team_id = (
@@ -213,7 +254,8 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 			FROM teams
 			WHERE LOWER(name) = LOWER($2)
 		)
-	`, pipelineName, db.teamName).Scan(&configBlob, &version)
+		AND instance_vars IS NOT DISTINCT FROM $3
+	`, pipelineName, db.teamName, varsPayload).Scan(&configBlob, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, atc.RawConfig(""), 0, nil
@@ -236,11 +278,18 @@ func (db *teamDB) SaveConfig(
 	from ConfigVersion,
 	pausedState PipelinePausedState,
 ) (SavedPipeline, bool, error) {
-	payload, err := json.Marshal(config)
-	if err != nil {
-		return SavedPipeline{}, false, err
-	}
+	return db.SaveInstanceConfig(pipelineName, nil, config, from, pausedState)
+}
 
+// SaveInstanceConfig saves the config of one instance of a pipeline, which
+// should already have the instance's vars interpolated into it.
+func (db *teamDB) SaveInstanceConfig(
+	pipelineName string,
+	instanceVars atc.InstanceVars,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedPipeline{}, false, err
@@ -254,6 +303,33 @@ func (db *teamDB) SaveConfig(
 		return SavedPipeline{}, false, err
 	}
 
+	savedPipeline, created, err := db.saveConfig(tx, teamID, pipelineName, instanceVars, config, from, pausedState)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	return savedPipeline, created, tx.Commit()
+}
+
+func (db *teamDB) saveConfig(
+	tx Tx,
+	teamID int,
+	pipelineName string,
+	instanceVars atc.InstanceVars,
+	config atc.Config,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
+	varsPayload, err := instanceVarsJSON(instanceVars)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	var created bool
 	var savedPipeline SavedPipeline
 
@@ -263,7 +339,8 @@ func (db *teamDB) SaveConfig(
 		FROM pipelines
 		WHERE name = $1
 	  AND team_id = $2
-	`, pipelineName, teamID).Scan(&existingConfig)
+		AND instance_vars IS NOT DISTINCT FROM $3
+	`, pipelineName, teamID, varsPayload).Scan(&existingConfig)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
@@ -274,20 +351,21 @@ func (db *teamDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, config, version, ordering, paused, team_id, instance_vars)
 		VALUES (
 			$1,
 			$2,
 			nextval('config_version_seq'),
 			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
-			$4
+			$4,
+			$5
 		)
 		RETURNING `+unqualifiedPipelineColumns+`,
 		(
 			SELECT t.name as team_name FROM teams t WHERE t.id = $4
 		)
-		`, pipelineName, payload, pausedState.Bool(), teamID))
+		`, pipelineName, payload, pausedState.Bool(), teamID, varsPayload))
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -319,27 +397,29 @@ func (db *teamDB) SaveConfig(
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
+			SET config = $1, version = nextval('config_version_seq'), archived = false
 			WHERE name = $2
 			AND version = $3
 			AND team_id = $4
+			AND instance_vars IS NOT DISTINCT FROM $5
 			RETURNING `+unqualifiedPipelineColumns+`,
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pipelineName, from, teamID))
+			`, payload, pipelineName, from, teamID, varsPayload))
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
+			SET config = $1, version = nextval('config_version_seq'), paused = $2, archived = false
 			WHERE name = $3
 			AND version = $4
 			AND team_id = $5
+			AND instance_vars IS NOT DISTINCT FROM $6
 			RETURNING `+unqualifiedPipelineColumns+`,
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pausedState.Bool(), pipelineName, from, teamID))
+			`, payload, pausedState.Bool(), pipelineName, from, teamID, varsPayload))
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -418,7 +498,7 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
-	return savedPipeline, created, tx.Commit()
+	return savedPipeline, created, nil
 }
 
 func (db *teamDB) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
@@ -520,7 +600,7 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -538,7 +618,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 }
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
-	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var basicAuth, gitHubAuth, uaaAuth, genericOAuth, oidcAuth sql.NullString
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -555,6 +635,12 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&oidcAuth,
+		&savedTeam.Quota.MaxConcurrentBuilds,
+		&savedTeam.Quota.MaxContainers,
+		&savedTeam.Quota.MaxVolumeBytes,
+		&savedTeam.Quota.BuildQueueWeight,
+		&savedTeam.RequireHijackRecording,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -592,6 +678,13 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	if oidcAuth.Valid {
+		err = json.Unmarshal([]byte(oidcAuth.String), &savedTeam.OIDCAuth)
+		if err != nil {
+			return savedTeam, err
+		}
+	}
+
 	return savedTeam, nil
 }
 
@@ -605,7 +698,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -627,7 +720,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -643,7 +736,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -659,12 +752,50 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateOIDCAuth(oidcAuth *OIDCAuth) (SavedTeam, error) {
+	jsonEncodedOIDCAuth, err := json.Marshal(oidcAuth)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET oidc_auth = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
+	`
+	params := []interface{}{string(jsonEncodedOIDCAuth), db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateQuota(quota TeamQuota) (SavedTeam, error) {
+	query := `
+		UPDATE teams
+		SET max_concurrent_builds = $1, max_containers = $2, max_volume_bytes = $3, build_queue_weight = $4
+		WHERE LOWER(name) = LOWER($5)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
+	`
+	params := []interface{}{quota.MaxConcurrentBuilds, quota.MaxContainers, quota.MaxVolumeBytes, quota.BuildQueueWeight, db.teamName}
+	return db.queryTeam(query, params)
+}
+
+func (db *teamDB) UpdateRequireHijackRecording(required bool) (SavedTeam, error) {
+	query := `
+		UPDATE teams
+		SET require_hijack_recording = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, oidc_auth, max_concurrent_builds, max_containers, max_volume_bytes, build_queue_weight, require_hijack_recording
+	`
+	params := []interface{}{required, db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -680,7 +811,7 @@ func (db *teamDB) CreateOneOffBuild() (Build, error) {
 		RETURNING `+buildColumns+`, null, null, null,
 		(
 			SELECT name FROM teams WHERE LOWER(name) = LOWER($1)
-		)
+		), null
 	`, string(db.teamName)))
 	if err != nil {
 		return nil, err
@@ -745,7 +876,84 @@ func (db *teamDB) GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, err
 		LeftJoin("teams t ON b.team_id = t.id").
 		Where(sq.Or{sq.Eq{"p.public": true}, sq.Eq{"LOWER(t.name)": strings.ToLower(db.teamName)}})
 
-	return getBuildsWithPagination(buildsQuery, page, db.conn, db.buildFactory)
+	return getBuildsWithPagination(buildsQuery, page, reader(db.conn), db.buildFactory)
+}
+
+func (db *teamDB) CreateAPIToken(token APIToken, tokenHash string) (SavedAPIToken, error) {
+	var expiresAt pq.NullTime
+	if !token.ExpiresAt.IsZero() {
+		expiresAt = pq.NullTime{Time: token.ExpiresAt, Valid: true}
+	}
+
+	var id int
+	err := db.conn.QueryRow(`
+		INSERT INTO api_tokens (team_id, name, token_hash, scope, expires_at)
+		SELECT id, $2, $3, $4, $5
+		FROM teams
+		WHERE LOWER(name) = LOWER($1)
+		RETURNING id
+	`, db.teamName, token.Name, tokenHash, string(token.Scope), expiresAt).Scan(&id)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code.Name() == "unique_violation" {
+			return SavedAPIToken{}, ErrAPITokenNameTaken
+		}
+
+		return SavedAPIToken{}, err
+	}
+
+	return scanAPIToken(db.conn.QueryRow(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE a.id = $1
+	`, id))
+}
+
+func (db *teamDB) GetAPITokens() ([]SavedAPIToken, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens a
+		JOIN teams t ON t.id = a.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY a.name ASC
+	`, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := []SavedAPIToken{}
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+func (db *teamDB) DeleteAPIToken(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM api_tokens
+		WHERE name = $1
+		AND team_id = (
+			SELECT id FROM teams WHERE LOWER(name) = LOWER($2)
+		)
+	`, name, db.teamName)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
 }
 
 func scanPipeline(rows scannable) (SavedPipeline, error) {
@@ -755,10 +963,12 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	var version int
 	var paused bool
 	var public bool
+	var archived bool
+	var instanceVarsBlob sql.NullString
 	var teamID int
 	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &teamName)
+	err := rows.Scan(&id, &name, &configBlob, &version, &paused, &teamID, &public, &archived, &instanceVarsBlob, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
@@ -769,12 +979,22 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 		return SavedPipeline{}, err
 	}
 
+	var instanceVars atc.InstanceVars
+	if instanceVarsBlob.Valid {
+		err = json.Unmarshal([]byte(instanceVarsBlob.String), &instanceVars)
+		if err != nil {
+			return SavedPipeline{}, err
+		}
+	}
+
 	return SavedPipeline{
-		ID:       id,
-		Paused:   paused,
-		Public:   public,
-		TeamID:   teamID,
-		TeamName: teamName,
+		ID:           id,
+		Paused:       paused,
+		Public:       public,
+		Archived:     archived,
+		InstanceVars: instanceVars,
+		TeamID:       teamID,
+		TeamName:     teamName,
 		Pipeline: Pipeline{
 			Name:    name,
 			Config:  config,
@@ -783,8 +1003,16 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	}, nil
 }
 
+// instances of a pipeline are grouped together, where the first of them
+// would otherwise be
 func scanPipelines(rows *sql.Rows) ([]SavedPipeline, error) {
-	pipelines := []SavedPipeline{}
+	type instancesKey struct {
+		teamID int
+		name   string
+	}
+
+	keys := []instancesKey{}
+	instances := map[instancesKey][]SavedPipeline{}
 
 	for rows.Next() {
 		pipeline, err := scanPipeline(rows)
@@ -792,12 +1020,37 @@ func scanPipelines(rows *sql.Rows) ([]SavedPipeline, error) {
 			return nil, err
 		}
 
-		pipelines = append(pipelines, pipeline)
+		key := instancesKey{teamID: pipeline.TeamID, name: pipeline.Name}
+		if _, found := instances[key]; !found {
+			keys = append(keys, key)
+		}
+
+		instances[key] = append(instances[key], pipeline)
+	}
+
+	pipelines := []SavedPipeline{}
+	for _, key := range keys {
+		pipelines = append(pipelines, instances[key]...)
 	}
 
 	return pipelines, nil
 }
 
+// instance vars are stored as JSON, which sorts object keys, so that equal
+// vars are stored identically. Pipelines that are not instances have none.
+func instanceVarsJSON(instanceVars atc.InstanceVars) (sql.NullString, error) {
+	if len(instanceVars) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(payload), Valid: true}, nil
+}
+
 type PipelinePausedState string
 
 const (
@@ -837,6 +837,94 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 		Expect(invalidConfigVersion).NotTo(Equal(db.ConfigVersion(1)))
 	})
 
+	Context("when there are instances of a pipeline", func() {
+		var fooVars atc.InstanceVars
+		var barVars atc.InstanceVars
+
+		BeforeEach(func() {
+			fooVars = atc.InstanceVars{"branch": "foo"}
+			barVars = atc.InstanceVars{"branch": "bar"}
+
+			_, _, err := teamDB.SaveConfig("some-pipeline", config, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig("other-pipeline", otherConfig, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, created, err := teamDB.SaveInstanceConfig("some-pipeline", fooVars, otherConfig, 0, db.PipelinePaused)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			_, created, err = teamDB.SaveInstanceConfig("some-pipeline", barVars, config, 0, db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		It("looks up each instance by its vars", func() {
+			fooPipeline, found, err := teamDB.GetPipelineInstance("some-pipeline", fooVars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(fooPipeline.InstanceVars).To(Equal(fooVars))
+			Expect(fooPipeline.Config).To(Equal(otherConfig))
+			Expect(fooPipeline.Paused).To(BeTrue())
+
+			barPipeline, found, err := teamDB.GetPipelineInstance("some-pipeline", barVars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(barPipeline.InstanceVars).To(Equal(barVars))
+			Expect(barPipeline.Paused).To(BeFalse())
+			Expect(barPipeline.ID).NotTo(Equal(fooPipeline.ID))
+
+			_, found, err = teamDB.GetPipelineInstance("some-pipeline", atc.InstanceVars{"branch": "baz"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("looks up the pipeline that is not an instance by name", func() {
+			pipeline, found, err := teamDB.GetPipelineByName("some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.InstanceVars).To(BeNil())
+			Expect(pipeline.Config).To(Equal(config))
+
+			actualConfig, _, _, err := teamDB.GetConfig("some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualConfig).To(Equal(config))
+		})
+
+		It("updates only the instance being saved", func() {
+			_, _, version, err := teamDB.GetInstanceConfig("some-pipeline", fooVars)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, created, err := teamDB.SaveInstanceConfig("some-pipeline", fooVars, config, version, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			fooConfig, _, _, err := teamDB.GetInstanceConfig("some-pipeline", fooVars)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fooConfig).To(Equal(config))
+
+			By("not saving over an instance from another instance's version")
+			_, _, err = teamDB.SaveInstanceConfig("some-pipeline", barVars, otherConfig, version, db.PipelineNoChange)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("lists instances together, where the first of them is ordered", func() {
+			pipelines, err := teamDB.GetPipelines()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipelines).To(HaveLen(4))
+
+			Expect(pipelines[0].Name).To(Equal("some-pipeline"))
+			Expect(pipelines[1].Name).To(Equal("some-pipeline"))
+			Expect(pipelines[2].Name).To(Equal("some-pipeline"))
+			Expect(pipelines[3].Name).To(Equal("other-pipeline"))
+
+			Expect(pipelines[0].InstanceVars).To(BeNil())
+			Expect(pipelines[1].InstanceVars).To(Equal(fooVars))
+			Expect(pipelines[2].InstanceVars).To(Equal(barVars))
+		})
+	})
+
 	Context("when there are multiple teams", func() {
 		var otherTeamDB db.TeamDB
 
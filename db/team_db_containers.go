@@ -11,12 +11,9 @@ import (
 const teamContainerJoins = containerJoins + "\nLEFT JOIN teams t ON c.team_id = t.id"
 
 func (db *teamDB) FindContainersByDescriptors(id Container) ([]SavedContainer, error) {
-	err := db.deleteExpiredContainers()
-	if err != nil {
-		return nil, err
-	}
+	var err error
 
-	var whereCriteria []string
+	whereCriteria := []string{"(c.expires_at IS NULL OR c.expires_at > NOW())"}
 	var params []interface{}
 
 	if id.ResourceName != "" {
@@ -127,11 +124,6 @@ func (db *teamDB) FindContainersByDescriptors(id Container) ([]SavedContainer, e
 }
 
 func (db *teamDB) GetContainer(handle string) (SavedContainer, bool, error) {
-	err := db.deleteExpiredContainers()
-	if err != nil {
-		return SavedContainer{}, false, err
-	}
-
 	team, found, err := db.GetTeam()
 	if err != nil {
 		return SavedContainer{}, false, err
@@ -146,6 +138,7 @@ func (db *teamDB) GetContainer(handle string) (SavedContainer, bool, error) {
 	  FROM containers c `+teamContainerJoins+`
 		WHERE c.handle = $1
 		AND c.team_id = %d
+		AND (c.expires_at IS NULL OR c.expires_at > NOW())
 	`, team.ID), handle))
 
 	if err != nil {
@@ -157,16 +150,3 @@ func (db *teamDB) GetContainer(handle string) (SavedContainer, bool, error) {
 
 	return container, true, nil
 }
-
-func (db *teamDB) deleteExpiredContainers() error {
-	_, err := db.conn.Exec(`
-		DELETE FROM containers
-		WHERE expires_at IS NOT NULL
-		AND expires_at < NOW()
-	`)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -0,0 +1,862 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var ErrTeamNotFound = errors.New("team not found")
+var ErrUnsupportedExportVersion = errors.New("unsupported team export version")
+
+type ErrMalformedExport struct {
+	Reason string
+}
+
+func (err ErrMalformedExport) Error() string {
+	return fmt.Sprintf("malformed team export: %s", err.Reason)
+}
+
+// ExportTeam streams an archive of the team's pipelines along with their
+// resource versions and finished builds to w. Build events are only included
+// if asked for. Everything is read from one snapshot, so builds only ever
+// refer to versions that are in the archive.
+func (db *teamDB) ExportTeam(w io.Writer, includeEvents bool) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines p
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE LOWER(t.name) = LOWER($1)
+		ORDER BY ordering
+	`, db.teamName)
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := scanPipelines(rows)
+	rows.Close()
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+
+	err = enc.Encode(atc.TeamExportRecord{
+		Header: &atc.TeamExportHeader{
+			Version: atc.TeamExportVersion,
+			Team:    db.teamName,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		err := exportPipeline(tx, enc, pipeline, includeEvents)
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.Encode(atc.TeamExportRecord{End: true})
+}
+
+func exportPipeline(tx Tx, enc *json.Encoder, pipeline SavedPipeline, includeEvents bool) error {
+	err := enc.Encode(atc.TeamExportRecord{
+		Pipeline: &atc.PipelineExport{
+			Name:         pipeline.Name,
+			InstanceVars: pipeline.InstanceVars,
+			Config:       pipeline.Config,
+			Paused:       pipeline.Paused,
+			Public:       pipeline.Public,
+			Archived:     pipeline.Archived,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	resourceIDs := []int{}
+	resources := []atc.ResourceExport{}
+
+	rows, err := tx.Query(`
+		SELECT id, name, paused
+		FROM resources
+		WHERE pipeline_id = $1
+		AND active = true
+		ORDER BY id ASC
+	`, pipeline.ID)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var resourceID int
+		var resource atc.ResourceExport
+
+		err := rows.Scan(&resourceID, &resource.Name, &resource.Paused)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		resourceIDs = append(resourceIDs, resourceID)
+		resources = append(resources, resource)
+	}
+
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	for i, resourceID := range resourceIDs {
+		err := enc.Encode(atc.TeamExportRecord{Resource: &resources[i]})
+		if err != nil {
+			return err
+		}
+
+		err = exportVersions(tx, enc, resourceID)
+		if err != nil {
+			return err
+		}
+	}
+
+	jobIDs := []int{}
+	jobs := []atc.JobExport{}
+
+	rows, err = tx.Query(`
+		SELECT id, name, paused
+		FROM jobs
+		WHERE pipeline_id = $1
+		AND active = true
+		ORDER BY id ASC
+	`, pipeline.ID)
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var jobID int
+		var job atc.JobExport
+
+		err := rows.Scan(&jobID, &job.Name, &job.Paused)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		jobIDs = append(jobIDs, jobID)
+		jobs = append(jobs, job)
+	}
+
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	eventsTable := fmt.Sprintf("pipeline_build_events_%d", pipeline.ID)
+
+	for i, jobID := range jobIDs {
+		err := enc.Encode(atc.TeamExportRecord{Job: &jobs[i]})
+		if err != nil {
+			return err
+		}
+
+		err = exportBuilds(tx, enc, jobID, eventsTable, includeEvents)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func exportVersions(tx Tx, enc *json.Encoder, resourceID int) error {
+	rows, err := tx.Query(`
+		SELECT id, type, version, metadata, enabled
+		FROM versioned_resources
+		WHERE resource_id = $1
+		ORDER BY check_order ASC, id ASC
+	`, resourceID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var version atc.VersionExport
+		var versionJSON, metadataJSON string
+
+		err := rows.Scan(&version.ID, &version.Type, &versionJSON, &metadataJSON, &version.Enabled)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal([]byte(versionJSON), &version.Version)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal([]byte(metadataJSON), &version.Metadata)
+		if err != nil {
+			return err
+		}
+
+		err = enc.Encode(atc.TeamExportRecord{Version: &version})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// exportBuilds reads the job's builds along with all of their inputs and
+// outputs up front, and then streams the builds out alongside their events.
+func exportBuilds(tx Tx, enc *json.Encoder, jobID int, eventsTable string, includeEvents bool) error {
+	rows, err := tx.Query(`
+		SELECT id, name, status, start_time, end_time, trigger_reason, annotations
+		FROM builds
+		WHERE job_id = $1
+		AND completed = true
+		ORDER BY id ASC
+	`, jobID)
+	if err != nil {
+		return err
+	}
+
+	buildIDs := []int{}
+	builds := map[int]*atc.BuildExport{}
+
+	for rows.Next() {
+		var (
+			buildID       int
+			build         atc.BuildExport
+			status        string
+			startTime     pq.NullTime
+			endTime       pq.NullTime
+			triggerReason sql.NullString
+			annotations   sql.NullString
+		)
+
+		err := rows.Scan(&buildID, &build.Name, &status, &startTime, &endTime, &triggerReason, &annotations)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		build.Status = atc.BuildStatus(status)
+		build.TriggerReason = triggerReason.String
+		build.Inputs = []atc.BuildInputExport{}
+		build.Outputs = []atc.BuildOutputExport{}
+
+		if startTime.Valid {
+			build.StartTime = startTime.Time.Unix()
+		}
+
+		if endTime.Valid {
+			build.EndTime = endTime.Time.Unix()
+		}
+
+		if annotations.Valid {
+			build.Annotations = json.RawMessage(annotations.String)
+		}
+
+		buildIDs = append(buildIDs, buildID)
+		builds[buildID] = &build
+	}
+
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	err = exportBuildInputs(tx, jobID, builds)
+	if err != nil {
+		return err
+	}
+
+	err = exportBuildOutputs(tx, jobID, builds)
+	if err != nil {
+		return err
+	}
+
+	if !includeEvents {
+		for _, buildID := range buildIDs {
+			err := enc.Encode(atc.TeamExportRecord{Build: builds[buildID]})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return exportBuildsWithEvents(tx, enc, jobID, eventsTable, buildIDs, builds)
+}
+
+func exportBuildInputs(tx Tx, jobID int, builds map[int]*atc.BuildExport) error {
+	rows, err := tx.Query(`
+		SELECT i.build_id, i.name, i.versioned_resource_id
+		FROM build_inputs i
+		INNER JOIN builds b ON b.id = i.build_id
+		WHERE b.job_id = $1
+		AND b.completed = true
+		ORDER BY i.build_id ASC, i.name ASC
+	`, jobID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var input atc.BuildInputExport
+
+		err := rows.Scan(&buildID, &input.Name, &input.VersionID)
+		if err != nil {
+			return err
+		}
+
+		if build, found := builds[buildID]; found {
+			build.Inputs = append(build.Inputs, input)
+		}
+	}
+
+	return rows.Err()
+}
+
+func exportBuildOutputs(tx Tx, jobID int, builds map[int]*atc.BuildExport) error {
+	rows, err := tx.Query(`
+		SELECT o.build_id, o.versioned_resource_id, o.explicit
+		FROM build_outputs o
+		INNER JOIN builds b ON b.id = o.build_id
+		WHERE b.job_id = $1
+		AND b.completed = true
+		ORDER BY o.build_id ASC, o.versioned_resource_id ASC
+	`, jobID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var output atc.BuildOutputExport
+
+		err := rows.Scan(&buildID, &output.VersionID, &output.Explicit)
+		if err != nil {
+			return err
+		}
+
+		if build, found := builds[buildID]; found {
+			build.Outputs = append(build.Outputs, output)
+		}
+	}
+
+	return rows.Err()
+}
+
+// exportBuildsWithEvents reads the events of all of the job's builds in one
+// go, in build order, and writes each build out once its events are read, so
+// that only one build's events are held at a time.
+func exportBuildsWithEvents(tx Tx, enc *json.Encoder, jobID int, eventsTable string, buildIDs []int, builds map[int]*atc.BuildExport) error {
+	rows, err := tx.Query(`
+		SELECT e.build_id, e.type, e.version, e.payload
+		FROM `+eventsTable+` e
+		INNER JOIN builds b ON b.id = e.build_id
+		WHERE b.job_id = $1
+		AND b.completed = true
+		ORDER BY e.build_id ASC, e.event_id ASC
+	`, jobID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	next := 0
+
+	// writes out the builds before the given one, which have all had their
+	// events read
+	flushBefore := func(buildID int) error {
+		for ; next < len(buildIDs) && buildIDs[next] < buildID; next++ {
+			err := enc.Encode(atc.TeamExportRecord{Build: builds[buildIDs[next]]})
+			if err != nil {
+				return err
+			}
+
+			// the build is done with; let its events go
+			builds[buildIDs[next]].Events = nil
+		}
+
+		return nil
+	}
+
+	for rows.Next() {
+		var buildID int
+		var t, v, p string
+
+		err := rows.Scan(&buildID, &t, &v, &p)
+		if err != nil {
+			return err
+		}
+
+		err = flushBefore(buildID)
+		if err != nil {
+			return err
+		}
+
+		build, found := builds[buildID]
+		if !found {
+			continue
+		}
+
+		build.Events = append(build.Events, atc.EventExport{
+			Type:    atc.EventType(t),
+			Version: atc.EventVersion(v),
+			Payload: json.RawMessage(p),
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	return flushBefore(math.MaxInt32)
+}
+
+// ImportTeam recreates the pipelines and their history streamed from an
+// export under this team. Each pipeline is imported all or nothing, in its own
+// transaction, so that the archive never has to be held as a whole; if the
+// import fails part way, running it again skips the pipelines it already
+// recreated. Versions and builds get new IDs; build names are kept, and each
+// job carries on numbering its builds from where the export left off.
+// Pipelines that already exist are skipped rather than overwritten.
+func (db *teamDB) ImportTeam(archive io.Reader) (atc.TeamImport, error) {
+	result := atc.TeamImport{
+		Imported: []string{},
+		Skipped:  []string{},
+	}
+
+	dec := json.NewDecoder(archive)
+
+	var header atc.TeamExportRecord
+	err := dec.Decode(&header)
+	if err != nil {
+		return result, ErrMalformedExport{Reason: err.Error()}
+	}
+
+	if header.Header == nil {
+		return result, ErrMalformedExport{Reason: "missing header"}
+	}
+
+	if header.Header.Version != atc.TeamExportVersion {
+		return result, ErrUnsupportedExportVersion
+	}
+
+	var teamID int
+	err = db.conn.QueryRow(`SELECT id FROM teams WHERE LOWER(name) = LOWER($1)`, db.teamName).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return result, ErrTeamNotFound
+		}
+
+		return result, err
+	}
+
+	importer := &teamImporter{teamDB: db, teamID: teamID}
+
+	// rolls back whatever pipeline was left half way
+	defer importer.abort()
+
+	for {
+		var record atc.TeamExportRecord
+		err := dec.Decode(&record)
+		if err != nil {
+			if err == io.EOF {
+				return result, ErrMalformedExport{Reason: "export ended early"}
+			}
+
+			return result, ErrMalformedExport{Reason: err.Error()}
+		}
+
+		if record.End {
+			err := importer.finishPipeline(&result)
+			if err != nil {
+				return result, err
+			}
+
+			return result, nil
+		}
+
+		err = importer.importRecord(record, &result)
+		if err != nil {
+			return result, err
+		}
+	}
+}
+
+type teamImporter struct {
+	teamDB *teamDB
+	teamID int
+
+	// the pipeline being imported, if any; tx is nil while skipping one
+	pipeline    *atc.PipelineExport
+	tx          Tx
+	pipelineID  int
+	eventsTable string
+
+	// exported version ID -> imported version ID
+	versionIDs map[int]int
+
+	resource   *atc.ResourceExport
+	resourceID int
+	checkOrder int
+
+	job   *atc.JobExport
+	jobID int
+}
+
+func (importer *teamImporter) importRecord(record atc.TeamExportRecord, result *atc.TeamImport) error {
+	switch {
+	case record.Pipeline != nil:
+		err := importer.finishPipeline(result)
+		if err != nil {
+			return err
+		}
+
+		return importer.startPipeline(record.Pipeline, result)
+
+	case record.Resource != nil:
+		if importer.pipeline == nil || importer.job != nil {
+			return ErrMalformedExport{Reason: "resource out of place"}
+		}
+
+		importer.resource = record.Resource
+		importer.resourceID = 0
+		importer.checkOrder = 0
+
+		if importer.tx == nil {
+			return nil
+		}
+
+		return importer.importResource()
+
+	case record.Version != nil:
+		if importer.resource == nil || importer.job != nil {
+			return ErrMalformedExport{Reason: "version out of place"}
+		}
+
+		if importer.tx == nil || importer.resourceID == 0 {
+			return nil
+		}
+
+		importer.checkOrder++
+
+		return importer.importVersion(*record.Version)
+
+	case record.Job != nil:
+		if importer.pipeline == nil {
+			return ErrMalformedExport{Reason: "job out of place"}
+		}
+
+		importer.resource = nil
+		importer.job = record.Job
+		importer.jobID = 0
+
+		if importer.tx == nil {
+			return nil
+		}
+
+		return importer.importJob()
+
+	case record.Build != nil:
+		if importer.job == nil {
+			return ErrMalformedExport{Reason: "build out of place"}
+		}
+
+		if importer.tx == nil || importer.jobID == 0 {
+			return nil
+		}
+
+		return importer.importBuild(*record.Build)
+
+	default:
+		return ErrMalformedExport{Reason: "unknown record"}
+	}
+}
+
+func (importer *teamImporter) startPipeline(pipeline *atc.PipelineExport, result *atc.TeamImport) error {
+	importer.pipeline = pipeline
+	importer.resource = nil
+	importer.job = nil
+	importer.versionIDs = map[int]int{}
+
+	tx, err := importer.teamDB.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	varsPayload, err := instanceVarsJSON(pipeline.InstanceVars)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var existing int
+	err = tx.QueryRow(`
+		SELECT COUNT(1)
+		FROM pipelines
+		WHERE name = $1
+		AND team_id = $2
+		AND instance_vars IS NOT DISTINCT FROM $3
+	`, pipeline.Name, importer.teamID, varsPayload).Scan(&existing)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if existing != 0 {
+		tx.Rollback()
+		result.Skipped = append(result.Skipped, pipeline.Name)
+		return nil
+	}
+
+	pausedState := PipelineUnpaused
+	if pipeline.Paused {
+		pausedState = PipelinePaused
+	}
+
+	savedPipeline, _, err := importer.teamDB.saveConfig(tx, importer.teamID, pipeline.Name, pipeline.InstanceVars, pipeline.Config, 0, pausedState)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pipelines
+		SET public = $2, archived = $3
+		WHERE id = $1
+	`, savedPipeline.ID, pipeline.Public, pipeline.Archived)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	importer.tx = tx
+	importer.pipelineID = savedPipeline.ID
+	importer.eventsTable = fmt.Sprintf("pipeline_build_events_%d", savedPipeline.ID)
+
+	return nil
+}
+
+func (importer *teamImporter) finishPipeline(result *atc.TeamImport) error {
+	if importer.tx == nil {
+		return nil
+	}
+
+	err := importer.tx.Commit()
+	importer.tx = nil
+	if err != nil {
+		return err
+	}
+
+	result.Imported = append(result.Imported, importer.pipeline.Name)
+
+	return nil
+}
+
+func (importer *teamImporter) abort() {
+	if importer.tx != nil {
+		importer.tx.Rollback()
+		importer.tx = nil
+	}
+}
+
+func (importer *teamImporter) importResource() error {
+	err := importer.tx.QueryRow(`
+		UPDATE resources
+		SET paused = $3
+		WHERE pipeline_id = $1
+		AND name = $2
+		RETURNING id
+	`, importer.pipelineID, importer.resource.Name, importer.resource.Paused).Scan(&importer.resourceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// not in the config; nothing to attach its versions to
+			importer.resourceID = 0
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (importer *teamImporter) importVersion(version atc.VersionExport) error {
+	versionJSON, err := json.Marshal(version.Version)
+	if err != nil {
+		return err
+	}
+
+	metadata := version.Metadata
+	if metadata == nil {
+		metadata = []atc.MetadataField{}
+	}
+
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	var versionID int
+	err = importer.tx.QueryRow(`
+		INSERT INTO versioned_resources (resource_id, type, version, metadata, enabled, modified_time, check_order)
+		VALUES ($1, $2, $3, $4, $5, now(), $6)
+		RETURNING id
+	`, importer.resourceID, version.Type, string(versionJSON), string(metadataJSON), version.Enabled, importer.checkOrder).Scan(&versionID)
+	if err != nil {
+		return err
+	}
+
+	importer.versionIDs[version.ID] = versionID
+
+	return nil
+}
+
+func (importer *teamImporter) importJob() error {
+	err := importer.tx.QueryRow(`
+		UPDATE jobs
+		SET paused = $3
+		WHERE pipeline_id = $1
+		AND name = $2
+		RETURNING id
+	`, importer.pipelineID, importer.job.Name, importer.job.Paused).Scan(&importer.jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			importer.jobID = 0
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (importer *teamImporter) importBuild(build atc.BuildExport) error {
+	tx := importer.tx
+
+	var startTime, endTime pq.NullTime
+	if build.StartTime != 0 {
+		startTime = pq.NullTime{Time: time.Unix(build.StartTime, 0), Valid: true}
+	}
+
+	if build.EndTime != 0 {
+		endTime = pq.NullTime{Time: time.Unix(build.EndTime, 0), Valid: true}
+	}
+
+	var annotations sql.NullString
+	if len(build.Annotations) > 0 {
+		annotations = sql.NullString{String: string(build.Annotations), Valid: true}
+	}
+
+	var buildID int
+	err := tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, scheduled, inputs_determined, completed, start_time, end_time, trigger_reason, annotations)
+		VALUES ($1, $2, $3, $4, true, true, true, $5, $6, NULLIF($7, ''), $8)
+		RETURNING id
+	`, build.Name, importer.jobID, importer.teamID, string(build.Status), startTime, endTime, build.TriggerReason, annotations).Scan(&buildID)
+	if err != nil {
+		return err
+	}
+
+	// carry on numbering builds from the highest exported one
+	number, err := strconv.Atoi(build.Name)
+	if err == nil {
+		_, err := tx.Exec(`
+			UPDATE jobs
+			SET build_number_seq = GREATEST(build_number_seq, $2)
+			WHERE id = $1
+		`, importer.jobID, number)
+		if err != nil {
+			return err
+		}
+	}
+
+	// versions of resources that are no longer configured were not imported,
+	// so inputs and outputs referring to them are dropped
+	for _, input := range build.Inputs {
+		versionID, found := importer.versionIDs[input.VersionID]
+		if !found {
+			continue
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+			VALUES ($1, $2, $3)
+		`, buildID, versionID, input.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, output := range build.Outputs {
+		versionID, found := importer.versionIDs[output.VersionID]
+		if !found {
+			continue
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO build_outputs (build_id, versioned_resource_id, explicit)
+			VALUES ($1, $2, $3)
+		`, buildID, versionID, output.Explicit)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, event := range build.Events {
+		_, err := tx.Exec(`
+			INSERT INTO `+importer.eventsTable+` (event_id, build_id, type, version, payload)
+			VALUES ($1, $2, $3, $4, $5)
+		`, i, buildID, string(event.Type), string(event.Version), string(event.Payload))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
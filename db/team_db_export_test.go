@@ -0,0 +1,340 @@
+package db_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamDB export and import", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var teamDB db.TeamDB
+	var otherTeamDB db.TeamDB
+	var pipelineDBFactory db.PipelineDBFactory
+
+	repoConfig := atc.ResourceConfig{
+		Name:   "repo",
+		Type:   "git",
+		Source: atc.Source{"uri": "some-repo"},
+	}
+
+	imageConfig := atc.ResourceConfig{
+		Name:   "image",
+		Type:   "docker-image",
+		Source: atc.Source{"repository": "some-image"},
+	}
+
+	pipelineConfig := atc.Config{
+		Resources: atc.ResourceConfigs{repoConfig, imageConfig},
+		Jobs: atc.JobConfigs{
+			{
+				Name: "build",
+				Plan: atc.PlanSequence{
+					{Get: "repo"},
+					{Put: "image"},
+				},
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		database := db.NewSQL(dbConn, bus, lockFactory)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+
+		_, _, err = teamDB.SaveConfig("source", pipelineConfig, 0, db.PipelinePaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedPipeline, found, err := teamDB.GetPipelineByName("source")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		pipelineDB := pipelineDBFactory.Build(savedPipeline)
+
+		err = pipelineDB.SaveResourceVersions(repoConfig, []atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		build, err := pipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pipelineDB.SaveInput(build.ID(), db.BuildInput{
+			Name: "repo",
+			VersionedResource: db.VersionedResource{
+				Resource:   "repo",
+				Type:       "git",
+				Version:    db.Version{"ref": "v1"},
+				PipelineID: pipelineDB.GetPipelineID(),
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pipelineDB.SaveOutput(build.ID(), db.VersionedResource{
+			Resource:   "image",
+			Type:       "docker-image",
+			Version:    db.Version{"digest": "i1"},
+			PipelineID: pipelineDB.GetPipelineID(),
+		}, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.SaveEvent(event.Log{Payload: "hello"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.Finish(db.StatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	exportRecords := func(includeEvents bool) []atc.TeamExportRecord {
+		buf := new(bytes.Buffer)
+		err := teamDB.ExportTeam(buf, includeEvents)
+		Expect(err).NotTo(HaveOccurred())
+
+		records := []atc.TeamExportRecord{}
+
+		dec := json.NewDecoder(buf)
+		for {
+			var record atc.TeamExportRecord
+			err := dec.Decode(&record)
+			if err == io.EOF {
+				return records
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			records = append(records, record)
+		}
+	}
+
+	encodeRecords := func(records []atc.TeamExportRecord) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+
+		enc := json.NewEncoder(buf)
+		for _, record := range records {
+			err := enc.Encode(record)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		return buf
+	}
+
+	Describe("ExportTeam", func() {
+		It("streams the pipelines with their versions and finished builds", func() {
+			records := exportRecords(false)
+			Expect(records).To(HaveLen(10))
+
+			Expect(records[0].Header).To(Equal(&atc.TeamExportHeader{
+				Version: atc.TeamExportVersion,
+				Team:    "some-team",
+			}))
+
+			pipeline := records[1].Pipeline
+			Expect(pipeline).NotTo(BeNil())
+			Expect(pipeline.Name).To(Equal("source"))
+			Expect(pipeline.Config).To(Equal(pipelineConfig))
+			Expect(pipeline.Paused).To(BeTrue())
+
+			Expect(records[2].Resource).To(Equal(&atc.ResourceExport{Name: "repo"}))
+			Expect(records[3].Version.Version).To(Equal(atc.Version{"ref": "v1"}))
+			Expect(records[4].Version.Version).To(Equal(atc.Version{"ref": "v2"}))
+			Expect(records[5].Resource).To(Equal(&atc.ResourceExport{Name: "image"}))
+			Expect(records[6].Version.Version).To(Equal(atc.Version{"digest": "i1"}))
+
+			Expect(records[7].Job).To(Equal(&atc.JobExport{Name: "build"}))
+
+			build := records[8].Build
+			Expect(build).NotTo(BeNil())
+			Expect(build.Name).To(Equal("1"))
+			Expect(build.Status).To(Equal(atc.StatusSucceeded))
+			Expect(build.Inputs).To(Equal([]atc.BuildInputExport{
+				{Name: "repo", VersionID: records[3].Version.ID},
+			}))
+			Expect(build.Outputs).To(Equal([]atc.BuildOutputExport{
+				{VersionID: records[6].Version.ID, Explicit: true},
+			}))
+			Expect(build.Events).To(BeEmpty())
+
+			Expect(records[9].End).To(BeTrue())
+		})
+
+		It("includes build events when asked to", func() {
+			records := exportRecords(true)
+			Expect(records).To(HaveLen(10))
+
+			events := records[8].Build.Events
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Type).To(Equal(event.EventTypeLog))
+
+			var log event.Log
+			err := json.Unmarshal(events[0].Payload, &log)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(log.Payload).To(Equal("hello"))
+		})
+	})
+
+	Describe("ImportTeam", func() {
+		var records []atc.TeamExportRecord
+
+		BeforeEach(func() {
+			records = exportRecords(true)
+		})
+
+		It("recreates the pipelines and their history under the team", func() {
+			imported, err := otherTeamDB.ImportTeam(encodeRecords(records))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(Equal(atc.TeamImport{
+				Imported: []string{"source"},
+				Skipped:  []string{},
+			}))
+
+			savedPipeline, found, err := otherTeamDB.GetPipelineByName("source")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedPipeline.Paused).To(BeTrue())
+			Expect(savedPipeline.Config).To(Equal(pipelineConfig))
+
+			pipelineDB := pipelineDBFactory.Build(savedPipeline)
+
+			versions, _, found, err := pipelineDB.GetResourceVersions("repo", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(versions).To(HaveLen(2))
+			Expect(versions[0].Version).To(Equal(db.Version{"ref": "v2"}))
+			Expect(versions[1].Version).To(Equal(db.Version{"ref": "v1"}))
+
+			builds, _, err := pipelineDB.GetJobBuilds("build", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].Name()).To(Equal("1"))
+			Expect(builds[0].Status()).To(Equal(db.StatusSucceeded))
+			Expect(builds[0].TeamName()).To(Equal("other-team"))
+
+			inputs, outputs, err := builds[0].GetResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Name).To(Equal("repo"))
+			Expect(inputs[0].Version).To(Equal(db.Version{"ref": "v1"}))
+			Expect(outputs).To(HaveLen(1))
+			Expect(outputs[0].Version).To(Equal(db.Version{"digest": "i1"}))
+
+			events, err := builds[0].Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer events.Close()
+
+			ev, err := events.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ev.Event).To(Equal(event.EventTypeLog))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("carries on numbering builds from where the export left off", func() {
+			_, err := otherTeamDB.ImportTeam(encodeRecords(records))
+			Expect(err).NotTo(HaveOccurred())
+
+			savedPipeline, _, err := otherTeamDB.GetPipelineByName("source")
+			Expect(err).NotTo(HaveOccurred())
+
+			build, err := pipelineDBFactory.Build(savedPipeline).CreateJobBuild("build")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Name()).To(Equal("2"))
+		})
+
+		It("skips pipelines that already exist", func() {
+			imported, err := teamDB.ImportTeam(encodeRecords(records))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(imported).To(Equal(atc.TeamImport{
+				Imported: []string{},
+				Skipped:  []string{"source"},
+			}))
+
+			savedPipeline, _, err := teamDB.GetPipelineByName("source")
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, _, err := pipelineDBFactory.Build(savedPipeline).GetJobBuilds("build", db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(2))
+		})
+
+		It("rejects exports of an unsupported version", func() {
+			records[0].Header.Version = atc.TeamExportVersion + 1
+
+			_, err := otherTeamDB.ImportTeam(encodeRecords(records))
+			Expect(err).To(Equal(db.ErrUnsupportedExportVersion))
+		})
+
+		Context("when the export was cut short", func() {
+			It("does not import the pipeline it was cut short in", func() {
+				_, err := otherTeamDB.ImportTeam(encodeRecords(records[:len(records)-1]))
+				Expect(err).To(Equal(db.ErrMalformedExport{Reason: "export ended early"}))
+
+				_, found, err := otherTeamDB.GetPipelineByName("source")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the records are out of place", func() {
+			It("returns ErrMalformedExport", func() {
+				_, err := otherTeamDB.ImportTeam(encodeRecords([]atc.TeamExportRecord{
+					records[0],
+					records[8],
+				}))
+				Expect(err).To(Equal(db.ErrMalformedExport{Reason: "build out of place"}))
+			})
+		})
+
+		Context("when the team does not exist", func() {
+			It("returns ErrTeamNotFound", func() {
+				teamDBFactory := db.NewTeamDBFactory(dbConn, nil, nil)
+
+				_, err := teamDBFactory.GetTeamDB("missing-team").ImportTeam(encodeRecords(records))
+				Expect(err).To(Equal(db.ErrTeamNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,591 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/concourse/atc"
+)
+
+type ProvenanceDirection string
+
+const (
+	ProvenanceUpstream   ProvenanceDirection = "upstream"
+	ProvenanceDownstream ProvenanceDirection = "downstream"
+	ProvenanceBoth       ProvenanceDirection = "both"
+)
+
+// Provenance is the graph of builds and the versions they took as inputs or
+// produced as outputs, reachable from a build or version.
+type Provenance struct {
+	Builds   []Build
+	Versions []SavedVersionedResource
+
+	// Inputs are the versions each build took as an input, and Outputs the
+	// versions each build explicitly produced.
+	Inputs  []ProvenanceEdge
+	Outputs []ProvenanceEdge
+
+	// SameVersions links versions of resources in different pipelines (or
+	// different resources) which have the same type, source and version.
+	SameVersions []ProvenanceLink
+
+	// Truncated is set when the walk stopped because it reached the maximum
+	// number of builds, or left out some of a version's same versions.
+	Truncated bool
+}
+
+type ProvenanceEdge struct {
+	BuildID             int
+	VersionedResourceID int
+}
+
+type ProvenanceLink struct {
+	VersionedResourceID      int
+	OtherVersionedResourceID int
+}
+
+func (db *teamDB) GetBuildProvenance(buildID int, direction ProvenanceDirection, maxBuilds int) (Provenance, bool, error) {
+	walker := db.newProvenanceWalker(maxBuilds)
+
+	found, err := walker.addBuild(buildID)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	if !found {
+		return Provenance{}, false, nil
+	}
+
+	err = walker.walk(provenanceNode{build: true, id: buildID}, direction)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	return walker.provenance(), true, nil
+}
+
+func (db *teamDB) GetVersionProvenance(pipelineName string, resourceName string, versionedResourceID int, direction ProvenanceDirection, maxBuilds int) (Provenance, bool, error) {
+	walker := db.newProvenanceWalker(maxBuilds)
+
+	svr, found, err := walker.loadVersion(versionedResourceID)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	if !found || svr.Resource != resourceName || walker.versionPipelines[versionedResourceID] != pipelineName {
+		return Provenance{}, false, nil
+	}
+
+	err = walker.walk(provenanceNode{id: versionedResourceID}, direction)
+	if err != nil {
+		return Provenance{}, false, err
+	}
+
+	return walker.provenance(), true, nil
+}
+
+// maxSameVersions bounds how many versions with the same type and version are
+// considered for each version walked.
+const maxSameVersions = 100
+
+type provenanceNode struct {
+	build bool
+	id    int
+}
+
+type provenanceVersionKey struct {
+	resourceType string
+	version      string
+}
+
+type provenanceWalker struct {
+	conn         Conn
+	teamName     string
+	buildFactory *buildFactory
+	maxBuilds    int
+
+	builds           map[int]Build
+	versions         map[int]SavedVersionedResource
+	versionKeys      map[int]provenanceVersionKey
+	versionSources   map[int]atc.Source
+	versionPipelines map[int]string
+	resourceSources  map[int]atc.Source
+
+	inputs       map[ProvenanceEdge]bool
+	outputs      map[ProvenanceEdge]bool
+	sameVersions map[ProvenanceLink]bool
+
+	truncated bool
+}
+
+func (db *teamDB) newProvenanceWalker(maxBuilds int) *provenanceWalker {
+	return &provenanceWalker{
+		conn:         db.conn,
+		teamName:     db.teamName,
+		buildFactory: db.buildFactory,
+		maxBuilds:    maxBuilds,
+
+		builds:           map[int]Build{},
+		versions:         map[int]SavedVersionedResource{},
+		versionKeys:      map[int]provenanceVersionKey{},
+		versionSources:   map[int]atc.Source{},
+		versionPipelines: map[int]string{},
+		resourceSources:  map[int]atc.Source{},
+
+		inputs:       map[ProvenanceEdge]bool{},
+		outputs:      map[ProvenanceEdge]bool{},
+		sameVersions: map[ProvenanceLink]bool{},
+	}
+}
+
+// walk follows edges away from the starting node breadth-first. Upstream and
+// downstream are walked separately so that, for example, the other consumers
+// of an upstream version are not included.
+func (w *provenanceWalker) walk(start provenanceNode, direction ProvenanceDirection) error {
+	if direction != ProvenanceDownstream {
+		err := w.walkOneWay(start, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if direction != ProvenanceUpstream {
+		err := w.walkOneWay(start, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *provenanceWalker) walkOneWay(start provenanceNode, upstream bool) error {
+	visited := map[provenanceNode]bool{start: true}
+	queue := []provenanceNode{start}
+
+	for len(queue) > 0 && !w.truncated {
+		node := queue[0]
+		queue = queue[1:]
+
+		var next []provenanceNode
+		var err error
+		if node.build {
+			next, err = w.versionsOf(node.id, upstream)
+		} else {
+			next, err = w.buildsOf(node.id, upstream)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, n := range next {
+			if visited[n] {
+				continue
+			}
+
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+
+	return nil
+}
+
+func (w *provenanceWalker) versionsOf(buildID int, upstream bool) ([]provenanceNode, error) {
+	query := `
+		SELECT DISTINCT versioned_resource_id
+		FROM build_outputs
+		WHERE build_id = $1 AND explicit
+	`
+	if upstream {
+		query = `
+			SELECT DISTINCT versioned_resource_id
+			FROM build_inputs
+			WHERE build_id = $1
+		`
+	}
+
+	ids, err := w.queryIDs(query, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var next []provenanceNode
+	for _, id := range ids {
+		_, found, err := w.loadVersion(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		edge := ProvenanceEdge{BuildID: buildID, VersionedResourceID: id}
+		if upstream {
+			w.inputs[edge] = true
+		} else {
+			w.outputs[edge] = true
+		}
+
+		next = append(next, provenanceNode{id: id})
+	}
+
+	return next, nil
+}
+
+func (w *provenanceWalker) buildsOf(versionedResourceID int, upstream bool) ([]provenanceNode, error) {
+	query := `
+		SELECT DISTINCT build_id
+		FROM build_inputs
+		WHERE versioned_resource_id = $1
+	`
+	if upstream {
+		query = `
+			SELECT DISTINCT build_id
+			FROM build_outputs
+			WHERE versioned_resource_id = $1 AND explicit
+		`
+	}
+
+	buildIDs, err := w.queryIDs(query+" ORDER BY build_id", versionedResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var next []provenanceNode
+	for _, buildID := range buildIDs {
+		found, err := w.addBuild(buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.truncated {
+			return next, nil
+		}
+
+		if !found {
+			continue
+		}
+
+		edge := ProvenanceEdge{BuildID: buildID, VersionedResourceID: versionedResourceID}
+		if upstream {
+			w.outputs[edge] = true
+		} else {
+			w.inputs[edge] = true
+		}
+
+		next = append(next, provenanceNode{build: true, id: buildID})
+	}
+
+	sameVersionIDs, err := w.sameVersionsAs(versionedResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range sameVersionIDs {
+		link := ProvenanceLink{VersionedResourceID: versionedResourceID, OtherVersionedResourceID: id}
+		if id < versionedResourceID {
+			link = ProvenanceLink{VersionedResourceID: id, OtherVersionedResourceID: versionedResourceID}
+		}
+
+		w.sameVersions[link] = true
+
+		next = append(next, provenanceNode{id: id})
+	}
+
+	return next, nil
+}
+
+// sameVersionsAs looks versions up by type and the md5 of their version, which
+// is indexed, and only loads the config of each resource they belong to once.
+func (w *provenanceWalker) sameVersionsAs(versionedResourceID int) ([]int, error) {
+	key := w.versionKeys[versionedResourceID]
+
+	rows, err := w.conn.Query(`
+		SELECT v.id, v.resource_id
+		FROM versioned_resources v
+		INNER JOIN resources r ON r.id = v.resource_id
+		INNER JOIN pipelines p ON p.id = r.pipeline_id
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE v.type = $1
+			AND md5(v.version) = md5($2)
+			AND v.version = $2
+			AND v.id != $3
+			AND LOWER(t.name) = LOWER($4)
+		ORDER BY v.id
+		LIMIT $5
+	`, key.resourceType, key.version, versionedResourceID, w.teamName, maxSameVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var candidateIDs, candidateResourceIDs []int
+	for rows.Next() {
+		var id, resourceID int
+		err := rows.Scan(&id, &resourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		candidateIDs = append(candidateIDs, id)
+		candidateResourceIDs = append(candidateResourceIDs, resourceID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidateIDs) == maxSameVersions {
+		w.truncated = true
+	}
+
+	var ids []int
+	for i, id := range candidateIDs {
+		source, err := w.resourceSource(candidateResourceIDs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(source, w.versionSources[versionedResourceID]) {
+			continue
+		}
+
+		_, found, err := w.loadVersion(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (w *provenanceWalker) resourceSource(resourceID int) (atc.Source, error) {
+	if source, found := w.resourceSources[resourceID]; found {
+		return source, nil
+	}
+
+	var configBlob []byte
+	err := w.conn.QueryRow(`
+		SELECT config
+		FROM resources
+		WHERE id = $1
+	`, resourceID).Scan(&configBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var config atc.ResourceConfig
+	err = json.Unmarshal(configBlob, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	w.resourceSources[resourceID] = config.Source
+
+	return config.Source, nil
+}
+
+func (w *provenanceWalker) addBuild(buildID int) (bool, error) {
+	if _, found := w.builds[buildID]; found {
+		return true, nil
+	}
+
+	if w.maxBuilds > 0 && len(w.builds) >= w.maxBuilds {
+		w.truncated = true
+		return false, nil
+	}
+
+	build, found, err := w.buildFactory.ScanBuild(w.conn.QueryRow(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		LEFT OUTER JOIN jobs j ON b.job_id = j.id
+		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
+		LEFT OUTER JOIN teams t ON b.team_id = t.id
+		WHERE b.id = $1
+			AND LOWER(t.name) = LOWER($2)
+	`, buildID, w.teamName))
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		return false, nil
+	}
+
+	w.builds[buildID] = build
+
+	return true, nil
+}
+
+func (w *provenanceWalker) loadVersion(versionedResourceID int) (SavedVersionedResource, bool, error) {
+	if svr, found := w.versions[versionedResourceID]; found {
+		return svr, true, nil
+	}
+
+	var versionBlob, metadataBlob, pipelineName string
+	var configBlob []byte
+
+	svr := SavedVersionedResource{}
+
+	err := w.conn.QueryRow(`
+		SELECT v.id, v.enabled, v.type, v.version, v.metadata, v.modified_time, v.check_order, r.name, r.pipeline_id, r.config, p.name
+		FROM versioned_resources v
+		INNER JOIN resources r ON r.id = v.resource_id
+		INNER JOIN pipelines p ON p.id = r.pipeline_id
+		INNER JOIN teams t ON t.id = p.team_id
+		WHERE v.id = $1
+			AND LOWER(t.name) = LOWER($2)
+	`, versionedResourceID, w.teamName).Scan(
+		&svr.ID,
+		&svr.Enabled,
+		&svr.Type,
+		&versionBlob,
+		&metadataBlob,
+		&svr.ModifiedTime,
+		&svr.CheckOrder,
+		&svr.Resource,
+		&svr.PipelineID,
+		&configBlob,
+		&pipelineName,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedVersionedResource{}, false, nil
+		}
+
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(versionBlob), &svr.Version)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(metadataBlob), &svr.Metadata)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	var config atc.ResourceConfig
+	err = json.Unmarshal(configBlob, &config)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	w.versions[versionedResourceID] = svr
+	w.versionKeys[versionedResourceID] = provenanceVersionKey{
+		resourceType: svr.Type,
+		version:      versionBlob,
+	}
+	w.versionSources[versionedResourceID] = config.Source
+	w.versionPipelines[versionedResourceID] = pipelineName
+
+	return svr, true, nil
+}
+
+func (w *provenanceWalker) queryIDs(query string, args ...interface{}) ([]int, error) {
+	rows, err := w.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (w *provenanceWalker) provenance() Provenance {
+	provenance := Provenance{
+		Builds:       []Build{},
+		Versions:     []SavedVersionedResource{},
+		Inputs:       sortedProvenanceEdges(w.inputs),
+		Outputs:      sortedProvenanceEdges(w.outputs),
+		SameVersions: []ProvenanceLink{},
+		Truncated:    w.truncated,
+	}
+
+	buildIDs := []int{}
+	for id := range w.builds {
+		buildIDs = append(buildIDs, id)
+	}
+	sort.Ints(buildIDs)
+
+	for _, id := range buildIDs {
+		provenance.Builds = append(provenance.Builds, w.builds[id])
+	}
+
+	versionIDs := []int{}
+	for id := range w.versions {
+		versionIDs = append(versionIDs, id)
+	}
+	sort.Ints(versionIDs)
+
+	for _, id := range versionIDs {
+		provenance.Versions = append(provenance.Versions, w.versions[id])
+	}
+
+	for link := range w.sameVersions {
+		provenance.SameVersions = append(provenance.SameVersions, link)
+	}
+
+	sort.Sort(provenanceLinksByID(provenance.SameVersions))
+
+	return provenance
+}
+
+func sortedProvenanceEdges(edges map[ProvenanceEdge]bool) []ProvenanceEdge {
+	sorted := []ProvenanceEdge{}
+	for edge := range edges {
+		sorted = append(sorted, edge)
+	}
+
+	sort.Sort(provenanceEdgesByID(sorted))
+
+	return sorted
+}
+
+type provenanceEdgesByID []ProvenanceEdge
+
+func (edges provenanceEdgesByID) Len() int      { return len(edges) }
+func (edges provenanceEdgesByID) Swap(i, j int) { edges[i], edges[j] = edges[j], edges[i] }
+func (edges provenanceEdgesByID) Less(i, j int) bool {
+	if edges[i].BuildID == edges[j].BuildID {
+		return edges[i].VersionedResourceID < edges[j].VersionedResourceID
+	}
+
+	return edges[i].BuildID < edges[j].BuildID
+}
+
+type provenanceLinksByID []ProvenanceLink
+
+func (links provenanceLinksByID) Len() int      { return len(links) }
+func (links provenanceLinksByID) Swap(i, j int) { links[i], links[j] = links[j], links[i] }
+func (links provenanceLinksByID) Less(i, j int) bool {
+	if links[i].VersionedResourceID == links[j].VersionedResourceID {
+		return links[i].OtherVersionedResourceID < links[j].OtherVersionedResourceID
+	}
+
+	return links[i].VersionedResourceID < links[j].VersionedResourceID
+}
@@ -0,0 +1,274 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamDB Provenance", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var teamDB db.TeamDB
+	var otherTeamDB db.TeamDB
+
+	var sourcePipelineDB db.PipelineDB
+	var deployPipelineDB db.PipelineDB
+	var otherTeamPipelineDB db.PipelineDB
+
+	var repoV1 db.SavedVersionedResource
+	var sourceImage db.SavedVersionedResource
+	var deployImage db.SavedVersionedResource
+
+	var buildBuild db.Build
+	var deployBuild db.Build
+
+	repoConfig := atc.ResourceConfig{
+		Name:   "repo",
+		Type:   "git",
+		Source: atc.Source{"uri": "some-repo"},
+	}
+
+	imageConfig := atc.ResourceConfig{
+		Name:   "image",
+		Type:   "docker-image",
+		Source: atc.Source{"repository": "some-image"},
+	}
+
+	savePipeline := func(teamDB db.TeamDB, pipelineDBFactory db.PipelineDBFactory, name string, config atc.Config) db.PipelineDB {
+		_, _, err := teamDB.SaveConfig(name, config, 0, db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		savedPipeline, found, err := teamDB.GetPipelineByName(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return pipelineDBFactory.Build(savedPipeline)
+	}
+
+	saveInput := func(pipelineDB db.PipelineDB, buildID int, config atc.ResourceConfig, version db.Version) db.SavedVersionedResource {
+		svr, err := pipelineDB.SaveInput(buildID, db.BuildInput{
+			Name: config.Name,
+			VersionedResource: db.VersionedResource{
+				Resource:   config.Name,
+				Type:       config.Type,
+				Version:    version,
+				PipelineID: pipelineDB.GetPipelineID(),
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return svr
+	}
+
+	saveOutput := func(pipelineDB db.PipelineDB, buildID int, config atc.ResourceConfig, version db.Version) db.SavedVersionedResource {
+		svr, err := pipelineDB.SaveOutput(buildID, db.VersionedResource{
+			Resource:   config.Name,
+			Type:       config.Type,
+			Version:    version,
+			PipelineID: pipelineDB.GetPipelineID(),
+		}, true)
+		Expect(err).NotTo(HaveOccurred())
+		return svr
+	}
+
+	buildIDs := func(provenance db.Provenance) []int {
+		ids := []int{}
+		for _, build := range provenance.Builds {
+			ids = append(ids, build.ID())
+		}
+		return ids
+	}
+
+	versionIDs := func(provenance db.Provenance) []int {
+		ids := []int{}
+		for _, version := range provenance.Versions {
+			ids = append(ids, version.ID)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(dbfakes.FakeConnector)
+		retryableConn := &db.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := db.NewLockFactory(retryableConn)
+		database := db.NewSQL(dbConn, bus, lockFactory)
+
+		_, err := database.CreateTeam(db.Team{Name: "some-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = database.CreateTeam(db.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDB = teamDBFactory.GetTeamDB("some-team")
+		otherTeamDB = teamDBFactory.GetTeamDB("other-team")
+
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+
+		sourcePipelineDB = savePipeline(teamDB, pipelineDBFactory, "source", atc.Config{
+			Resources: atc.ResourceConfigs{repoConfig, imageConfig},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "build",
+					Plan: atc.PlanSequence{
+						{Get: "repo"},
+						{Put: "image"},
+					},
+				},
+			},
+		})
+
+		deployPipelineDB = savePipeline(teamDB, pipelineDBFactory, "deploy", atc.Config{
+			Resources: atc.ResourceConfigs{imageConfig},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "deploy",
+					Plan: atc.PlanSequence{
+						{Get: "image"},
+					},
+				},
+			},
+		})
+
+		otherTeamPipelineDB = savePipeline(otherTeamDB, pipelineDBFactory, "source", atc.Config{
+			Resources: atc.ResourceConfigs{repoConfig},
+			Jobs: atc.JobConfigs{
+				{
+					Name: "build",
+					Plan: atc.PlanSequence{
+						{Get: "repo"},
+					},
+				},
+			},
+		})
+
+		buildBuild, err = sourcePipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		repoV1 = saveInput(sourcePipelineDB, buildBuild.ID(), repoConfig, db.Version{"ref": "v1"})
+		sourceImage = saveOutput(sourcePipelineDB, buildBuild.ID(), imageConfig, db.Version{"digest": "i1"})
+
+		deployBuild, err = deployPipelineDB.CreateJobBuild("deploy")
+		Expect(err).NotTo(HaveOccurred())
+
+		deployImage = saveInput(deployPipelineDB, deployBuild.ID(), imageConfig, db.Version{"digest": "i1"})
+
+		unrelatedBuild, err := sourcePipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveInput(sourcePipelineDB, unrelatedBuild.ID(), repoConfig, db.Version{"ref": "v2"})
+
+		otherTeamBuild, err := otherTeamPipelineDB.CreateJobBuild("build")
+		Expect(err).NotTo(HaveOccurred())
+
+		saveInput(otherTeamPipelineDB, otherTeamBuild.ID(), repoConfig, db.Version{"ref": "v1"})
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("GetVersionProvenance", func() {
+		It("walks downstream through builds and equivalent versions in other pipelines", func() {
+			provenance, found, err := teamDB.GetVersionProvenance("source", "repo", repoV1.ID, db.ProvenanceDownstream, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(buildIDs(provenance)).To(Equal([]int{buildBuild.ID(), deployBuild.ID()}))
+			Expect(versionIDs(provenance)).To(Equal([]int{repoV1.ID, sourceImage.ID, deployImage.ID}))
+
+			Expect(provenance.Inputs).To(ConsistOf(
+				db.ProvenanceEdge{BuildID: buildBuild.ID(), VersionedResourceID: repoV1.ID},
+				db.ProvenanceEdge{BuildID: deployBuild.ID(), VersionedResourceID: deployImage.ID},
+			))
+			Expect(provenance.Outputs).To(ConsistOf(
+				db.ProvenanceEdge{BuildID: buildBuild.ID(), VersionedResourceID: sourceImage.ID},
+			))
+			Expect(provenance.SameVersions).To(ConsistOf(
+				db.ProvenanceLink{VersionedResourceID: sourceImage.ID, OtherVersionedResourceID: deployImage.ID},
+			))
+			Expect(provenance.Truncated).To(BeFalse())
+		})
+
+		It("does not follow upstream edges when walking downstream", func() {
+			provenance, found, err := teamDB.GetVersionProvenance("deploy", "image", deployImage.ID, db.ProvenanceDownstream, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(buildIDs(provenance)).To(Equal([]int{deployBuild.ID()}))
+		})
+
+		It("marks the graph as truncated when there are more builds than the limit", func() {
+			provenance, found, err := teamDB.GetVersionProvenance("source", "repo", repoV1.ID, db.ProvenanceDownstream, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(buildIDs(provenance)).To(Equal([]int{buildBuild.ID()}))
+			Expect(provenance.Truncated).To(BeTrue())
+		})
+
+		It("does not find the version under another resource", func() {
+			_, found, err := teamDB.GetVersionProvenance("source", "image", repoV1.ID, db.ProvenanceBoth, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find the version under another pipeline", func() {
+			_, found, err := teamDB.GetVersionProvenance("deploy", "repo", repoV1.ID, db.ProvenanceBoth, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find another team's version", func() {
+			_, found, err := otherTeamDB.GetVersionProvenance("source", "repo", repoV1.ID, db.ProvenanceBoth, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("GetBuildProvenance", func() {
+		It("walks upstream through equivalent versions to the builds that produced them", func() {
+			provenance, found, err := teamDB.GetBuildProvenance(deployBuild.ID(), db.ProvenanceUpstream, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(buildIDs(provenance)).To(Equal([]int{buildBuild.ID(), deployBuild.ID()}))
+			Expect(versionIDs(provenance)).To(Equal([]int{repoV1.ID, sourceImage.ID, deployImage.ID}))
+		})
+
+		It("does not include versions from other teams with the same source", func() {
+			provenance, found, err := teamDB.GetBuildProvenance(buildBuild.ID(), db.ProvenanceBoth, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(buildIDs(provenance)).To(Equal([]int{buildBuild.ID(), deployBuild.ID()}))
+			Expect(versionIDs(provenance)).To(Equal([]int{repoV1.ID, sourceImage.ID, deployImage.ID}))
+		})
+
+		It("does not find another team's build", func() {
+			_, found, err := otherTeamDB.GetBuildProvenance(buildBuild.ID(), db.ProvenanceBoth, 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -448,6 +448,110 @@ var _ = Describe("TeamDB", func() {
 		})
 	})
 
+	Describe("UpdateQuota", func() {
+		It("saves the quota to the existing team", func() {
+			quota := db.TeamQuota{
+				MaxConcurrentBuilds: 2,
+				MaxContainers:       10,
+				MaxVolumeBytes:      1024,
+			}
+
+			savedTeam, err := teamDB.UpdateQuota(quota)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.Quota).To(Equal(quota))
+
+			actualTeam, found, err := teamDB.GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(actualTeam.Quota).To(Equal(quota))
+		})
+	})
+
+	Describe("UpdateRequireHijackRecording", func() {
+		It("saves the setting to the existing team", func() {
+			savedTeam, err := teamDB.UpdateRequireHijackRecording(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedTeam.RequireHijackRecording).To(BeTrue())
+
+			actualTeam, found, err := teamDB.GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(actualTeam.RequireHijackRecording).To(BeTrue())
+		})
+	})
+
+	Describe("API tokens", func() {
+		var expiresAt time.Time
+
+		BeforeEach(func() {
+			expiresAt = time.Now().Add(time.Hour).Truncate(time.Second)
+		})
+
+		It("can create, list, find and delete tokens", func() {
+			created, err := teamDB.CreateAPIToken(db.APIToken{
+				Name:      "some-bot",
+				Scope:     atc.APITokenScopeTriggerBuilds,
+				ExpiresAt: expiresAt,
+			}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Name).To(Equal("some-bot"))
+			Expect(created.Scope).To(Equal(atc.APITokenScopeTriggerBuilds))
+			Expect(created.ExpiresAt.Unix()).To(Equal(expiresAt.Unix()))
+			Expect(created.TeamID).To(Equal(savedTeam.ID))
+			Expect(created.TeamName).To(Equal("TEAM-name"))
+			Expect(created.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			_, err = otherTeamDB.CreateAPIToken(db.APIToken{
+				Name:  "other-bot",
+				Scope: atc.APITokenScopeReadOnly,
+			}, "some-other-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			tokens, err := teamDB.GetAPITokens()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokens).To(Equal([]db.SavedAPIToken{created}))
+
+			found, exists, err := database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(found).To(Equal(created))
+
+			_, exists, err = database.FindAPITokenByHash("bogus-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			deleted, err := otherTeamDB.DeleteAPIToken("some-bot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			deleted, err = teamDB.DeleteAPIToken("some-bot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, exists, err = database.FindAPITokenByHash("some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("does not expire tokens created without an expiry", func() {
+			created, err := teamDB.CreateAPIToken(db.APIToken{
+				Name:  "some-bot",
+				Scope: atc.APITokenScopeReadOnly,
+			}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ExpiresAt.IsZero()).To(BeTrue())
+			Expect(created.IsExpired(time.Now().Add(24 * 365 * time.Hour))).To(BeFalse())
+		})
+
+		It("rejects a second token with the same name", func() {
+			_, err := teamDB.CreateAPIToken(db.APIToken{Name: "some-bot", Scope: atc.APITokenScopeReadOnly}, "some-hash")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = teamDB.CreateAPIToken(db.APIToken{Name: "some-bot", Scope: atc.APITokenScopeReadOnly}, "some-other-hash")
+			Expect(err).To(Equal(db.ErrAPITokenNameTaken))
+		})
+	})
+
 	Describe("GetTeam", func() {
 		It("returns the saved team", func() {
 			actualTeam, found, err := teamDB.GetTeam()
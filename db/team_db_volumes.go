@@ -3,11 +3,6 @@ package db
 import "errors"
 
 func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
-	err := db.expireVolumes()
-	if err != nil {
-		return nil, err
-	}
-
 	team, found, err := db.GetTeam()
 	if err != nil {
 		return nil, err
@@ -39,8 +34,8 @@ func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
 			ON v.container_id = c.id
 		LEFT JOIN teams t
 			ON v.team_id = t.id
-		WHERE v.team_id = $1
-		OR v.team_id is null
+		WHERE (v.team_id = $1 OR v.team_id is null)
+		AND (v.expires_at IS NULL OR v.expires_at > NOW())
 	`, team.ID)
 	if err != nil {
 		return nil, err
@@ -49,12 +44,3 @@ func (db *teamDB) GetVolumes() ([]SavedVolume, error) {
 	volumes, err := scanVolumes(rows)
 	return volumes, err
 }
-
-func (db *teamDB) expireVolumes() error {
-	_, err := db.conn.Exec(`
-		DELETE FROM volumes
-		WHERE expires_at IS NOT NULL
-		AND expires_at < NOW()
-	`)
-	return err
-}
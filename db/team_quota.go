@@ -0,0 +1,108 @@
+package db
+
+import "fmt"
+
+// TeamQuota limits the resources a team's builds may consume. A zero value
+// for any limit means the team is unlimited in that dimension.
+//
+// BuildQueueWeight is the team's share of the build queue relative to other
+// teams; zero is treated as a weight of one.
+type TeamQuota struct {
+	MaxConcurrentBuilds int   `json:"max_concurrent_builds"`
+	MaxContainers       int   `json:"max_containers"`
+	MaxVolumeBytes      int64 `json:"max_volume_bytes"`
+	BuildQueueWeight    int   `json:"build_queue_weight"`
+}
+
+type TeamQuotaUsage struct {
+	RunningBuilds int
+	Containers    int
+	VolumeBytes   int64
+}
+
+func (quota TeamQuota) IsConfigured() bool {
+	return quota.MaxConcurrentBuilds > 0 || quota.MaxContainers > 0 || quota.MaxVolumeBytes > 0 || quota.BuildQueueWeight > 0
+}
+
+// BuildsReached returns a reason if no more builds may be started by the
+// team, or an empty string if there is room for another build.
+func (quota TeamQuota) BuildsReached(usage TeamQuotaUsage) string {
+	if quota.MaxConcurrentBuilds > 0 && usage.RunningBuilds >= quota.MaxConcurrentBuilds {
+		return fmt.Sprintf("team has %d of %d concurrent builds running", usage.RunningBuilds, quota.MaxConcurrentBuilds)
+	}
+
+	return quota.ContainersReached(usage)
+}
+
+// ContainersReached returns a reason if no more containers may be created
+// for the team, or an empty string if there is room for another container.
+func (quota TeamQuota) ContainersReached(usage TeamQuotaUsage) string {
+	if quota.MaxContainers > 0 && usage.Containers >= quota.MaxContainers {
+		return fmt.Sprintf("team has %d of %d containers", usage.Containers, quota.MaxContainers)
+	}
+
+	if quota.MaxVolumeBytes > 0 && usage.VolumeBytes >= quota.MaxVolumeBytes {
+		return fmt.Sprintf("team is using %d of %d volume bytes", usage.VolumeBytes, quota.MaxVolumeBytes)
+	}
+
+	return ""
+}
+
+// containers and volumes kept around once their build has finished, e.g. so
+// that the latest failed build of a job can be hijacked, do not count towards
+// a team's usage; otherwise a team at its limit could never start the build
+// that would let them go
+const ownedByFinishedBuild = `(
+		%[1]s.owner_type = '` + string(OwnerBuild) + `'
+		AND EXISTS (
+			SELECT 1
+			FROM builds fb
+			WHERE fb.id = %[1]s.owner_id
+			AND fb.status NOT IN ('pending', 'started')
+		)
+	)`
+
+func getTeamQuotaAndUsage(conn Conn, teamID int) (TeamQuota, TeamQuotaUsage, error) {
+	var quota TeamQuota
+	var usage TeamQuotaUsage
+
+	err := conn.QueryRow(`
+		SELECT t.max_concurrent_builds, t.max_containers, t.max_volume_bytes,
+			(
+				SELECT COUNT(*)
+				FROM builds b
+				WHERE b.team_id = t.id
+				AND (
+					b.status = 'started'
+					OR
+					(b.scheduled = true AND b.status = 'pending')
+				)
+			),
+			(
+				SELECT COUNT(*)
+				FROM containers c
+				WHERE c.team_id = t.id
+				AND NOT `+fmt.Sprintf(ownedByFinishedBuild, "c")+`
+			),
+			(
+				SELECT COALESCE(SUM(v.size_in_bytes), 0)
+				FROM volumes v
+				WHERE v.team_id = t.id
+				AND NOT `+fmt.Sprintf(ownedByFinishedBuild, "v")+`
+			)
+		FROM teams t
+		WHERE t.id = $1
+	`, teamID).Scan(
+		&quota.MaxConcurrentBuilds,
+		&quota.MaxContainers,
+		&quota.MaxVolumeBytes,
+		&usage.RunningBuilds,
+		&usage.Containers,
+		&usage.VolumeBytes,
+	)
+	if err != nil {
+		return TeamQuota{}, TeamQuotaUsage{}, err
+	}
+
+	return quota, usage, nil
+}
@@ -122,8 +122,6 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Task.OutputMapping,
 		plan.Task.ImageArtifactName,
 		clock,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
 	)
 }
 
@@ -158,8 +156,6 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		plan.Get.Params,
 		plan.Get.Version,
 		plan.Get.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
 	)
 }
 
@@ -192,8 +188,6 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		build.teamID,
 		plan.Put.Params,
 		plan.Put.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
 	)
 }
 
@@ -228,8 +222,6 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 		build.teamID,
 		getPlan.Params,
 		getPlan.ResourceTypes,
-		build.containerSuccessTTL,
-		build.containerFailureTTL,
 	)
 }
 
@@ -247,3 +239,16 @@ func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.
 
 	return step
 }
+
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		build.delegate.SetPipelineDelegate(logger, *plan.SetPipeline, event.OriginID(plan.ID)),
+		build.teamDB,
+		*plan.SetPipeline,
+	)
+}
@@ -50,6 +50,16 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	SetPipelineDelegateStub        func(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -186,6 +196,41 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 lager.Logger, arg2 atc.SetPipelinePlan, arg3 event.OriginID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.SetPipelinePlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.setPipelineDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) (lager.Logger, atc.SetPipelinePlan, event.OriginID) {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return fake.setPipelineDelegateArgsForCall[i].arg1, fake.setPipelineDelegateArgsForCall[i].arg2, fake.setPipelineDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -197,6 +242,8 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.outputDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
 
 	"os"
 
@@ -21,8 +20,6 @@ type execMetadata struct {
 }
 
 const execEngineName = "exec.v2"
-const successTTL = 5 * time.Minute
-const failureTTL = 5 * time.Minute
 
 type execEngine struct {
 	factory         exec.Factory
@@ -54,6 +51,7 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
+		teamDB:       engine.teamDBFactory.GetTeamDB(build.TeamName()),
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
 		factory:  engine.factory,
@@ -63,9 +61,6 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		},
 
 		signals: make(chan os.Signal, 1),
-
-		containerSuccessTTL: successTTL,
-		containerFailureTTL: failureTTL,
 	}, nil
 }
 
@@ -86,6 +81,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		buildID:      build.ID(),
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
+		teamDB:       engine.teamDBFactory.GetTeamDB(build.TeamName()),
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
 		factory:  engine.factory,
@@ -93,9 +89,6 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		metadata: metadata,
 
 		signals: make(chan os.Signal, 1),
-
-		containerSuccessTTL: successTTL,
-		containerFailureTTL: failureTTL,
 	}, nil
 }
 
@@ -164,6 +157,7 @@ type execBuild struct {
 	stepMetadata StepMetadata
 	teamName     string
 	teamID       int
+	teamDB       db.TeamDB
 
 	factory  exec.Factory
 	delegate BuildDelegate
@@ -171,9 +165,6 @@ type execBuild struct {
 	signals chan os.Signal
 
 	metadata execMetadata
-
-	containerSuccessTTL time.Duration
-	containerFailureTTL time.Duration
 }
 
 func (build *execBuild) Metadata() string {
@@ -282,6 +273,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
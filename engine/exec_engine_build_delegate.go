@@ -25,6 +25,7 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	SetPipelineDelegate(lager.Logger, atc.SetPipelinePlan, event.OriginID) exec.SetPipelineDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -91,6 +92,16 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) SetPipelineDelegate(logger lager.Logger, plan atc.SetPipelinePlan, id event.OriginID) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -178,6 +189,31 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveAnnotations(logger lager.Logger, annotations atc.BuildAnnotations, origin event.Origin) {
+	err := delegate.build.SaveAnnotations(annotations)
+	if err != nil {
+		logger.Error("failed-to-save-annotations", err)
+		return
+	}
+
+	err = delegate.build.SaveEvent(event.Annotations{
+		Time:     time.Now().Unix(),
+		Metadata: annotations.Metadata,
+		Links:    annotations.Links,
+		Origin:   origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-annotations-event", err)
+	}
+}
+
+func (delegate *delegate) saveTestReport(logger lager.Logger, report atc.TestReport) {
+	err := delegate.build.SaveTestReport(report)
+	if err != nil {
+		logger.Error("failed-to-save-test-report", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.build.Finish(db.Status(status))
 	if err != nil {
@@ -431,6 +467,28 @@ func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 	execution.logger.Info("finished", lager.Data{"exit-status": status})
 }
 
+func (execution *executionDelegate) Annotated(annotations atc.BuildAnnotations) {
+	execution.delegate.saveAnnotations(execution.logger, annotations, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("annotated")
+}
+
+func (execution *executionDelegate) Reported(report atc.TestReport) {
+	report.StepName = execution.plan.Name
+	report.PlanID = atc.PlanID(execution.id)
+
+	execution.delegate.saveTestReport(execution.logger, report)
+
+	execution.logger.Info("reported", lager.Data{
+		"path":     report.Path,
+		"tests":    report.Tests,
+		"failures": report.Failures,
+		"errors":   report.Errors,
+	})
+}
+
 func (execution *executionDelegate) Failed(err error) {
 	execution.delegate.saveErr(execution.logger, err, event.Origin{
 		ID: execution.id,
@@ -456,6 +514,44 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type setPipelineDelegate struct {
+	logger lager.Logger
+
+	plan atc.SetPipelinePlan
+	id   event.OriginID
+
+	delegate *delegate
+}
+
+func (setPipeline *setPipelineDelegate) Finished(status exec.ExitStatus) {
+	setPipeline.delegate.saveFinish(setPipeline.logger, status, event.Origin{
+		ID: setPipeline.id,
+	})
+
+	setPipeline.logger.Info("finished", lager.Data{"exit-status": status})
+}
+
+func (setPipeline *setPipelineDelegate) Failed(err error) {
+	setPipeline.delegate.saveErr(setPipeline.logger, err, event.Origin{
+		ID: setPipeline.id,
+	})
+	setPipeline.logger.Info("errored", lager.Data{"error": err.Error()})
+}
+
+func (setPipeline *setPipelineDelegate) Stdout() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStdout,
+		ID:     setPipeline.id,
+	})
+}
+
+func (setPipeline *setPipelineDelegate) Stderr() io.Writer {
+	return setPipeline.delegate.eventWriter(event.Origin{
+		Source: event.OriginSourceStderr,
+		ID:     setPipeline.id,
+	})
+}
+
 type dbEventWriter struct {
 	build db.Build
 
@@ -694,6 +694,71 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Annotated", func() {
+			var annotations atc.BuildAnnotations
+
+			BeforeEach(func() {
+				annotations = atc.BuildAnnotations{
+					Metadata: []atc.MetadataField{{Name: "coverage", Value: "84%"}},
+					Links:    []atc.BuildLink{{Name: "report", URL: "https://example.com/report"}},
+				}
+			})
+
+			JustBeforeEach(func() {
+				executionDelegate.Annotated(annotations)
+			})
+
+			It("saves the annotations to the build", func() {
+				Expect(fakeBuild.SaveAnnotationsCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveAnnotationsArgsForCall(0)).To(Equal(annotations))
+			})
+
+			It("saves an annotations event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.Annotations{}))
+				Expect(savedEvent.(event.Annotations).Metadata).To(Equal(annotations.Metadata))
+				Expect(savedEvent.(event.Annotations).Links).To(Equal(annotations.Links))
+				Expect(savedEvent.(event.Annotations).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+
+			Context("when saving the annotations fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveAnnotationsReturns(errors.New("nope"))
+				})
+
+				It("does not save an event", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Describe("Reported", func() {
+			JustBeforeEach(func() {
+				executionDelegate.Reported(atc.TestReport{
+					Format:   "junit",
+					Path:     "out/junit.xml",
+					Tests:    3,
+					Failures: 1,
+				})
+			})
+
+			It("saves the report with the step it came from", func() {
+				Expect(fakeBuild.SaveTestReportCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveTestReportArgsForCall(0)).To(Equal(atc.TestReport{
+					StepName: taskPlan.Name,
+					PlanID:   atc.PlanID(originID),
+					Format:   "junit",
+					Path:     "out/junit.xml",
+					Tests:    3,
+					Failures: 1,
+				}))
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				executionDelegate.Failed(errors.New("nope"))
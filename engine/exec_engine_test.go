@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -191,70 +190,6 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
-			Context("when one-off build", func() {
-				BeforeEach(func() {
-					dbBuild.IsOneOffReturns(true)
-				})
-
-				It("constructs the put with finite container TTLs", func() {
-					var err error
-					build, err = execEngine.CreateBuild(logger, dbBuild, outputPlan)
-					Expect(err).NotTo(HaveOccurred())
-
-					build.Resume(logger)
-					Expect(fakeFactory.PutCallCount()).To(Equal(2))
-
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-
-				It("constructs the dependent get with finite container TTLs", func() {
-					var err error
-					build, err = execEngine.CreateBuild(logger, dbBuild, outputPlan)
-					Expect(err).NotTo(HaveOccurred())
-
-					build.Resume(logger)
-					Expect(fakeFactory.PutCallCount()).To(Equal(2))
-
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-			})
-
-			Context("when JobID is nonzero (job build)", func() {
-				BeforeEach(func() {
-					dbBuild.IsOneOffReturns(false)
-				})
-
-				It("constructs the put with infinite container TTLs", func() {
-					var err error
-					build, err = execEngine.CreateBuild(logger, dbBuild, outputPlan)
-					Expect(err).NotTo(HaveOccurred())
-
-					build.Resume(logger)
-					Expect(fakeFactory.PutCallCount()).To(Equal(2))
-
-					_, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.PutArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-
-				It("constructs the dependent get with infinite container TTLs", func() {
-					var err error
-					build, err = execEngine.CreateBuild(logger, dbBuild, outputPlan)
-					Expect(err).NotTo(HaveOccurred())
-
-					build.Resume(logger)
-					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
-
-					_, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.DependentGetArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-			})
-
 			Context("constructing outputs", func() {
 				It("constructs the put correctly", func() {
 					var err error
@@ -264,7 +199,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -287,7 +222,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"some": "params"}))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.PutArgsForCall(1)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -319,7 +254,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(2))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -347,7 +282,7 @@ var _ = Describe("ExecEngine", func() {
 					Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 					Expect(params).To(Equal(atc.Params{"another": "params"}))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ = fakeFactory.DependentGetArgsForCall(1)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ = fakeFactory.DependentGetArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -439,7 +374,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -467,7 +402,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(1)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(expectedMetadata))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -499,7 +434,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -521,7 +456,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -589,13 +524,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
@@ -618,44 +553,6 @@ var _ = Describe("ExecEngine", func() {
 					plan = planFactory.NewPlan(getPlan)
 				})
 
-				Context("when one-off build", func() {
-					BeforeEach(func() {
-						dbBuild.IsOneOffReturns(true)
-					})
-
-					It("constructs the get with container TTLs", func() {
-						var err error
-						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
-						Expect(err).NotTo(HaveOccurred())
-
-						build.Resume(logger)
-						Expect(fakeFactory.GetCallCount()).To(Equal(1))
-
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
-						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-					})
-				})
-
-				Context("when build is not one-off", func() {
-					BeforeEach(func() {
-						dbBuild.IsOneOffReturns(false)
-					})
-
-					It("constructs the get with container TTLs", func() {
-						var err error
-						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
-						Expect(err).NotTo(HaveOccurred())
-
-						build.Resume(logger)
-						Expect(fakeFactory.GetCallCount()).To(Equal(1))
-
-						_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
-						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-					})
-				})
-
 				It("constructs inputs correctly", func() {
 					var err error
 					build, err := execEngine.CreateBuild(logger, dbBuild, plan)
@@ -664,7 +561,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _, _, _ := fakeFactory.GetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, version, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -733,43 +630,11 @@ var _ = Describe("ExecEngine", func() {
 					plan = planFactory.NewPlan(taskPlan)
 				})
 
-				Context("when one-off build", func() {
-					BeforeEach(func() {
-						dbBuild.IsOneOffReturns(true)
-					})
-
-					It("constructs the task with container TTLs", func() {
-						var err error
-						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
-						Expect(err).NotTo(HaveOccurred())
-
-						build.Resume(logger)
-						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
-
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
-						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-					})
-				})
-
 				Context("when build is not one-off", func() {
 					BeforeEach(func() {
 						dbBuild.IsOneOffReturns(false)
 					})
 
-					It("constructs the task with infinite container TTLs", func() {
-						var err error
-						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
-						Expect(err).NotTo(HaveOccurred())
-
-						build.Resume(logger)
-						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
-
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
-						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-					})
-
 					It("constructs tasks correctly", func() {
 						var err error
 						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
@@ -778,7 +643,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
 						Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -820,7 +685,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
@@ -840,7 +705,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -865,7 +730,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -889,6 +754,56 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that sets a pipeline", func() {
+				var (
+					plan                    atc.Plan
+					setPipelinePlan         atc.SetPipelinePlan
+					fakeSetPipelineDelegate *execfakes.FakeSetPipelineDelegate
+
+					setPipelineStepFactory *execfakes.FakeStepFactory
+					setPipelineStep        *execfakes.FakeStep
+				)
+
+				BeforeEach(func() {
+					setPipelinePlan = atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some-input/pipeline.yml",
+					}
+
+					plan = planFactory.NewPlan(setPipelinePlan)
+
+					fakeSetPipelineDelegate = new(execfakes.FakeSetPipelineDelegate)
+					fakeDelegate.SetPipelineDelegateReturns(fakeSetPipelineDelegate)
+
+					setPipelineStepFactory = new(execfakes.FakeStepFactory)
+					setPipelineStep = new(execfakes.FakeStep)
+					setPipelineStep.ResultStub = successResult(true)
+					setPipelineStepFactory.UsingReturns(setPipelineStep)
+					fakeFactory.SetPipelineReturns(setPipelineStepFactory)
+				})
+
+				It("constructs the step for the build's team", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+					logger, delegate, teamDB, actualPlan := fakeFactory.SetPipelineArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(delegate).To(Equal(fakeSetPipelineDelegate))
+					Expect(teamDB).To(Equal(fakeTeamDB))
+					Expect(actualPlan).To(Equal(setPipelinePlan))
+
+					_, _, planID := fakeDelegate.SetPipelineDelegateArgsForCall(0)
+					Expect(planID).To(Equal(event.OriginID(plan.ID)))
+
+					Expect(setPipelineStep.RunCallCount()).To(Equal(1))
+					Expect(setPipelineStep.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					plan             atc.Plan
@@ -930,7 +845,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.PutArgsForCall(0)
+					logger, metadata, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -966,7 +881,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.DependentGetCallCount()).To(Equal(1))
 
-					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _ := fakeFactory.DependentGetArgsForCall(0)
+					logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _ := fakeFactory.DependentGetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(metadata).To(Equal(expectedMetadata))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -1115,7 +1030,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(metadata).To(Equal(engine.StepMetadata{
 					BuildID:      42,
@@ -1148,42 +1063,6 @@ var _ = Describe("ExecEngine", func() {
 				Expect(resourceConfig.Source).To(Equal(atc.Source{"some": "source"}))
 				Expect(params).To(Equal(atc.Params{"some": "params"}))
 			})
-
-			Context("when one-off build", func() {
-				BeforeEach(func() {
-					dbBuild.IsOneOffReturns(true)
-				})
-
-				It("constructs the get with success and failure ttls", func() {
-					foundBuild, err := execEngine.LookupBuild(logger, dbBuild)
-					Expect(err).NotTo(HaveOccurred())
-
-					foundBuild.Resume(logger)
-					Expect(fakeFactory.GetCallCount()).To(Equal(1))
-
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-			})
-
-			Context("when build is not one-off", func() {
-				BeforeEach(func() {
-					dbBuild.IsOneOffReturns(false)
-				})
-
-				It("constructs the get with infinite container TTLs", func() {
-					foundBuild, err := execEngine.LookupBuild(logger, dbBuild)
-					Expect(err).NotTo(HaveOccurred())
-
-					foundBuild.Resume(logger)
-					Expect(fakeFactory.GetCallCount()).To(Equal(1))
-
-					_, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.GetArgsForCall(0)
-					Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
-					Expect(containerFailureTTL).To(Equal(5 * time.Minute))
-				})
-			})
 		})
 
 		Context("when pipeline name is specified and pipeline ID is not", func() {
@@ -19,6 +19,16 @@ type FinishTask struct {
 func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
 func (FinishTask) Version() atc.EventVersion { return "4.0" }
 
+type Annotations struct {
+	Time     int64               `json:"time"`
+	Metadata []atc.MetadataField `json:"metadata,omitempty"`
+	Links    []atc.BuildLink     `json:"links,omitempty"`
+	Origin   Origin              `json:"origin"`
+}
+
+func (Annotations) EventType() atc.EventType  { return EventTypeAnnotations }
+func (Annotations) Version() atc.EventVersion { return "1.0" }
+
 type InitializeTask struct {
 	TaskConfig TaskConfig `json:"config"`
 	Origin     Origin     `json:"origin"`
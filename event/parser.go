@@ -36,6 +36,7 @@ func init() {
 	registerEvent(InitializeTask{})
 	registerEvent(StartTask{})
 	registerEvent(FinishTask{})
+	registerEvent(Annotations{})
 	registerEvent(InitializeGet{})
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
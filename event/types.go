@@ -18,6 +18,9 @@ const (
 	// task execution finished
 	EventTypeFinishTask atc.EventType = "finish-task"
 
+	// task annotated the build with metadata and links
+	EventTypeAnnotations atc.EventType = "annotations"
+
 	// get step initializing
 	EventTypeInitializeGet atc.EventType = "initialize-get"
 
@@ -1,8 +1,6 @@
 package exec
 
 import (
-	"time"
-
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/resource"
@@ -12,19 +10,17 @@ import (
 // previous step. It is used to fetch the resource version produced by a
 // PutStep.
 type DependentGetStep struct {
-	logger              lager.Logger
-	sourceName          SourceName
-	resourceConfig      atc.ResourceConfig
-	params              atc.Params
-	stepMetadata        StepMetadata
-	session             resource.Session
-	tags                atc.Tags
-	teamID              int
-	delegate            ResourceDelegate
-	resourceFetcher     resource.Fetcher
-	resourceTypes       atc.ResourceTypes
-	containerSuccessTTL time.Duration
-	containerFailureTTL time.Duration
+	logger          lager.Logger
+	sourceName      SourceName
+	resourceConfig  atc.ResourceConfig
+	params          atc.Params
+	stepMetadata    StepMetadata
+	session         resource.Session
+	tags            atc.Tags
+	teamID          int
+	delegate        ResourceDelegate
+	resourceFetcher resource.Fetcher
+	resourceTypes   atc.ResourceTypes
 }
 
 func newDependentGetStep(
@@ -39,23 +35,19 @@ func newDependentGetStep(
 	delegate ResourceDelegate,
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) DependentGetStep {
 	return DependentGetStep{
-		logger:              logger,
-		sourceName:          sourceName,
-		resourceConfig:      resourceConfig,
-		params:              params,
-		stepMetadata:        stepMetadata,
-		session:             session,
-		tags:                tags,
-		teamID:              teamID,
-		delegate:            delegate,
-		resourceFetcher:     resourceFetcher,
-		resourceTypes:       resourceTypes,
-		containerSuccessTTL: containerSuccessTTL,
-		containerFailureTTL: containerFailureTTL,
+		logger:          logger,
+		sourceName:      sourceName,
+		resourceConfig:  resourceConfig,
+		params:          params,
+		stepMetadata:    stepMetadata,
+		session:         session,
+		tags:            tags,
+		teamID:          teamID,
+		delegate:        delegate,
+		resourceFetcher: resourceFetcher,
+		resourceTypes:   resourceTypes,
 	}
 }
 
@@ -84,7 +76,5 @@ func (step DependentGetStep) Using(prev Step, repo *SourceRepository) Step {
 		step.delegate,
 		step.resourceFetcher,
 		step.resourceTypes,
-		step.containerSuccessTTL,
-		step.containerFailureTTL,
 	).Using(prev, repo)
 }
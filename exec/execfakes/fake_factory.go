@@ -3,17 +3,28 @@ package execfakes
 
 import (
 	"sync"
-	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 )
 
 type FakeFactory struct {
-	GetStub        func(lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.ResourceTypes, time.Duration, time.Duration) exec.StepFactory
+	SetPipelineStub        func(lager.Logger, exec.SetPipelineDelegate, db.TeamDB, atc.SetPipelinePlan) exec.StepFactory
+	setPipelineMutex       sync.RWMutex
+	setPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 db.TeamDB
+		arg4 atc.SetPipelinePlan
+	}
+	setPipelineReturns struct {
+		result1 exec.StepFactory
+	}
+	GetStub        func(lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.ResourceTypes) exec.StepFactory
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
 		arg1  lager.Logger
@@ -28,13 +39,11 @@ type FakeFactory struct {
 		arg10 atc.Params
 		arg11 atc.Version
 		arg12 atc.ResourceTypes
-		arg13 time.Duration
-		arg14 time.Duration
 	}
 	getReturns struct {
 		result1 exec.StepFactory
 	}
-	PutStub        func(lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes, time.Duration, time.Duration) exec.StepFactory
+	PutStub        func(lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes) exec.StepFactory
 	putMutex       sync.RWMutex
 	putArgsForCall []struct {
 		arg1  lager.Logger
@@ -47,13 +56,11 @@ type FakeFactory struct {
 		arg8  int
 		arg9  atc.Params
 		arg10 atc.ResourceTypes
-		arg11 time.Duration
-		arg12 time.Duration
 	}
 	putReturns struct {
 		result1 exec.StepFactory
 	}
-	DependentGetStub        func(lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes, time.Duration, time.Duration) exec.StepFactory
+	DependentGetStub        func(lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes) exec.StepFactory
 	dependentGetMutex       sync.RWMutex
 	dependentGetArgsForCall []struct {
 		arg1  lager.Logger
@@ -67,13 +74,11 @@ type FakeFactory struct {
 		arg9  int
 		arg10 atc.Params
 		arg11 atc.ResourceTypes
-		arg12 time.Duration
-		arg13 time.Duration
 	}
 	dependentGetReturns struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) exec.StepFactory
+	TaskStub        func(lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1  lager.Logger
@@ -90,8 +95,6 @@ type FakeFactory struct {
 		arg12 map[string]string
 		arg13 string
 		arg14 clock.Clock
-		arg15 time.Duration
-		arg16 time.Duration
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -100,7 +103,43 @@ type FakeFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.Version, arg12 atc.ResourceTypes, arg13 time.Duration, arg14 time.Duration) exec.StepFactory {
+func (fake *FakeFactory) SetPipeline(arg1 lager.Logger, arg2 exec.SetPipelineDelegate, arg3 db.TeamDB, arg4 atc.SetPipelinePlan) exec.StepFactory {
+	fake.setPipelineMutex.Lock()
+	fake.setPipelineArgsForCall = append(fake.setPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 exec.SetPipelineDelegate
+		arg3 db.TeamDB
+		arg4 atc.SetPipelinePlan
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("SetPipeline", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setPipelineMutex.Unlock()
+	if fake.SetPipelineStub != nil {
+		return fake.SetPipelineStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.setPipelineReturns.result1
+	}
+}
+
+func (fake *FakeFactory) SetPipelineCallCount() int {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return len(fake.setPipelineArgsForCall)
+}
+
+func (fake *FakeFactory) SetPipelineArgsForCall(i int) (lager.Logger, exec.SetPipelineDelegate, db.TeamDB, atc.SetPipelinePlan) {
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
+	return fake.setPipelineArgsForCall[i].arg1, fake.setPipelineArgsForCall[i].arg2, fake.setPipelineArgsForCall[i].arg3, fake.setPipelineArgsForCall[i].arg4
+}
+
+func (fake *FakeFactory) SetPipelineReturns(result1 exec.StepFactory) {
+	fake.SetPipelineStub = nil
+	fake.setPipelineReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.Version, arg12 atc.ResourceTypes) exec.StepFactory {
 	fake.getMutex.Lock()
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
 		arg1  lager.Logger
@@ -115,13 +154,11 @@ func (fake *FakeFactory) Get(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exe
 		arg10 atc.Params
 		arg11 atc.Version
 		arg12 atc.ResourceTypes
-		arg13 time.Duration
-		arg14 time.Duration
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
-	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
 	fake.getMutex.Unlock()
 	if fake.GetStub != nil {
-		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14)
+		return fake.GetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12)
 	} else {
 		return fake.getReturns.result1
 	}
@@ -133,10 +170,10 @@ func (fake *FakeFactory) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.ResourceTypes, time.Duration, time.Duration) {
+func (fake *FakeFactory) GetArgsForCall(i int) (lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.Version, atc.ResourceTypes) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
-	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6, fake.getArgsForCall[i].arg7, fake.getArgsForCall[i].arg8, fake.getArgsForCall[i].arg9, fake.getArgsForCall[i].arg10, fake.getArgsForCall[i].arg11, fake.getArgsForCall[i].arg12, fake.getArgsForCall[i].arg13, fake.getArgsForCall[i].arg14
+	return fake.getArgsForCall[i].arg1, fake.getArgsForCall[i].arg2, fake.getArgsForCall[i].arg3, fake.getArgsForCall[i].arg4, fake.getArgsForCall[i].arg5, fake.getArgsForCall[i].arg6, fake.getArgsForCall[i].arg7, fake.getArgsForCall[i].arg8, fake.getArgsForCall[i].arg9, fake.getArgsForCall[i].arg10, fake.getArgsForCall[i].arg11, fake.getArgsForCall[i].arg12
 }
 
 func (fake *FakeFactory) GetReturns(result1 exec.StepFactory) {
@@ -146,7 +183,7 @@ func (fake *FakeFactory) GetReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.PutDelegate, arg6 atc.ResourceConfig, arg7 atc.Tags, arg8 int, arg9 atc.Params, arg10 atc.ResourceTypes, arg11 time.Duration, arg12 time.Duration) exec.StepFactory {
+func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.PutDelegate, arg6 atc.ResourceConfig, arg7 atc.Tags, arg8 int, arg9 atc.Params, arg10 atc.ResourceTypes) exec.StepFactory {
 	fake.putMutex.Lock()
 	fake.putArgsForCall = append(fake.putArgsForCall, struct {
 		arg1  lager.Logger
@@ -159,13 +196,11 @@ func (fake *FakeFactory) Put(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 wor
 		arg8  int
 		arg9  atc.Params
 		arg10 atc.ResourceTypes
-		arg11 time.Duration
-		arg12 time.Duration
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
-	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12})
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10})
 	fake.putMutex.Unlock()
 	if fake.PutStub != nil {
-		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12)
+		return fake.PutStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
 	} else {
 		return fake.putReturns.result1
 	}
@@ -177,10 +212,10 @@ func (fake *FakeFactory) PutCallCount() int {
 	return len(fake.putArgsForCall)
 }
 
-func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes, time.Duration, time.Duration) {
+func (fake *FakeFactory) PutArgsForCall(i int) (lager.Logger, exec.StepMetadata, worker.Identifier, worker.Metadata, exec.PutDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes) {
 	fake.putMutex.RLock()
 	defer fake.putMutex.RUnlock()
-	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6, fake.putArgsForCall[i].arg7, fake.putArgsForCall[i].arg8, fake.putArgsForCall[i].arg9, fake.putArgsForCall[i].arg10, fake.putArgsForCall[i].arg11, fake.putArgsForCall[i].arg12
+	return fake.putArgsForCall[i].arg1, fake.putArgsForCall[i].arg2, fake.putArgsForCall[i].arg3, fake.putArgsForCall[i].arg4, fake.putArgsForCall[i].arg5, fake.putArgsForCall[i].arg6, fake.putArgsForCall[i].arg7, fake.putArgsForCall[i].arg8, fake.putArgsForCall[i].arg9, fake.putArgsForCall[i].arg10
 }
 
 func (fake *FakeFactory) PutReturns(result1 exec.StepFactory) {
@@ -190,7 +225,7 @@ func (fake *FakeFactory) PutReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.ResourceTypes, arg12 time.Duration, arg13 time.Duration) exec.StepFactory {
+func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.GetDelegate, arg7 atc.ResourceConfig, arg8 atc.Tags, arg9 int, arg10 atc.Params, arg11 atc.ResourceTypes) exec.StepFactory {
 	fake.dependentGetMutex.Lock()
 	fake.dependentGetArgsForCall = append(fake.dependentGetArgsForCall, struct {
 		arg1  lager.Logger
@@ -204,13 +239,11 @@ func (fake *FakeFactory) DependentGet(arg1 lager.Logger, arg2 exec.StepMetadata,
 		arg9  int
 		arg10 atc.Params
 		arg11 atc.ResourceTypes
-		arg12 time.Duration
-		arg13 time.Duration
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13})
-	fake.recordInvocation("DependentGet", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13})
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
+	fake.recordInvocation("DependentGet", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11})
 	fake.dependentGetMutex.Unlock()
 	if fake.DependentGetStub != nil {
-		return fake.DependentGetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13)
+		return fake.DependentGetStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
 	} else {
 		return fake.dependentGetReturns.result1
 	}
@@ -222,10 +255,10 @@ func (fake *FakeFactory) DependentGetCallCount() int {
 	return len(fake.dependentGetArgsForCall)
 }
 
-func (fake *FakeFactory) DependentGetArgsForCall(i int) (lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes, time.Duration, time.Duration) {
+func (fake *FakeFactory) DependentGetArgsForCall(i int) (lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.GetDelegate, atc.ResourceConfig, atc.Tags, int, atc.Params, atc.ResourceTypes) {
 	fake.dependentGetMutex.RLock()
 	defer fake.dependentGetMutex.RUnlock()
-	return fake.dependentGetArgsForCall[i].arg1, fake.dependentGetArgsForCall[i].arg2, fake.dependentGetArgsForCall[i].arg3, fake.dependentGetArgsForCall[i].arg4, fake.dependentGetArgsForCall[i].arg5, fake.dependentGetArgsForCall[i].arg6, fake.dependentGetArgsForCall[i].arg7, fake.dependentGetArgsForCall[i].arg8, fake.dependentGetArgsForCall[i].arg9, fake.dependentGetArgsForCall[i].arg10, fake.dependentGetArgsForCall[i].arg11, fake.dependentGetArgsForCall[i].arg12, fake.dependentGetArgsForCall[i].arg13
+	return fake.dependentGetArgsForCall[i].arg1, fake.dependentGetArgsForCall[i].arg2, fake.dependentGetArgsForCall[i].arg3, fake.dependentGetArgsForCall[i].arg4, fake.dependentGetArgsForCall[i].arg5, fake.dependentGetArgsForCall[i].arg6, fake.dependentGetArgsForCall[i].arg7, fake.dependentGetArgsForCall[i].arg8, fake.dependentGetArgsForCall[i].arg9, fake.dependentGetArgsForCall[i].arg10, fake.dependentGetArgsForCall[i].arg11
 }
 
 func (fake *FakeFactory) DependentGetReturns(result1 exec.StepFactory) {
@@ -235,7 +268,7 @@ func (fake *FakeFactory) DependentGetReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 exec.SourceName, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.TaskDelegate, arg6 exec.Privileged, arg7 atc.Tags, arg8 int, arg9 exec.TaskConfigSource, arg10 atc.ResourceTypes, arg11 map[string]string, arg12 map[string]string, arg13 string, arg14 clock.Clock, arg15 time.Duration, arg16 time.Duration) exec.StepFactory {
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 exec.SourceName, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.TaskDelegate, arg6 exec.Privileged, arg7 atc.Tags, arg8 int, arg9 exec.TaskConfigSource, arg10 atc.ResourceTypes, arg11 map[string]string, arg12 map[string]string, arg13 string, arg14 clock.Clock) exec.StepFactory {
 	fake.taskMutex.Lock()
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1  lager.Logger
@@ -252,13 +285,11 @@ func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 exec.SourceName, arg3 work
 		arg12 map[string]string
 		arg13 string
 		arg14 clock.Clock
-		arg15 time.Duration
-		arg16 time.Duration
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16})
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14)
 	} else {
 		return fake.taskReturns.result1
 	}
@@ -270,10 +301,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15, fake.taskArgsForCall[i].arg16
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
@@ -286,6 +317,8 @@ func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
 func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.setPipelineMutex.RLock()
+	defer fake.setPipelineMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	fake.putMutex.RLock()
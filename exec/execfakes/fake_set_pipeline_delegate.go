@@ -0,0 +1,162 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeSetPipelineDelegate struct {
+	FinishedStub        func(exec.ExitStatus)
+	finishedMutex       sync.RWMutex
+	finishedArgsForCall []struct {
+		arg1 exec.ExitStatus
+	}
+	FailedStub        func(error)
+	failedMutex       sync.RWMutex
+	failedArgsForCall []struct {
+		arg1 error
+	}
+	StdoutStub        func() io.Writer
+	stdoutMutex       sync.RWMutex
+	stdoutArgsForCall []struct{}
+	stdoutReturns     struct {
+		result1 io.Writer
+	}
+	StderrStub        func() io.Writer
+	stderrMutex       sync.RWMutex
+	stderrArgsForCall []struct{}
+	stderrReturns     struct {
+		result1 io.Writer
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSetPipelineDelegate) Finished(arg1 exec.ExitStatus) {
+	fake.finishedMutex.Lock()
+	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
+		arg1 exec.ExitStatus
+	}{arg1})
+	fake.recordInvocation("Finished", []interface{}{arg1})
+	fake.finishedMutex.Unlock()
+	if fake.FinishedStub != nil {
+		fake.FinishedStub(arg1)
+	}
+}
+
+func (fake *FakeSetPipelineDelegate) FinishedCallCount() int {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return len(fake.finishedArgsForCall)
+}
+
+func (fake *FakeSetPipelineDelegate) FinishedArgsForCall(i int) exec.ExitStatus {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return fake.finishedArgsForCall[i].arg1
+}
+
+func (fake *FakeSetPipelineDelegate) Failed(arg1 error) {
+	fake.failedMutex.Lock()
+	fake.failedArgsForCall = append(fake.failedArgsForCall, struct {
+		arg1 error
+	}{arg1})
+	fake.recordInvocation("Failed", []interface{}{arg1})
+	fake.failedMutex.Unlock()
+	if fake.FailedStub != nil {
+		fake.FailedStub(arg1)
+	}
+}
+
+func (fake *FakeSetPipelineDelegate) FailedCallCount() int {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return len(fake.failedArgsForCall)
+}
+
+func (fake *FakeSetPipelineDelegate) FailedArgsForCall(i int) error {
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	return fake.failedArgsForCall[i].arg1
+}
+
+func (fake *FakeSetPipelineDelegate) Stdout() io.Writer {
+	fake.stdoutMutex.Lock()
+	fake.stdoutArgsForCall = append(fake.stdoutArgsForCall, struct{}{})
+	fake.recordInvocation("Stdout", []interface{}{})
+	fake.stdoutMutex.Unlock()
+	if fake.StdoutStub != nil {
+		return fake.StdoutStub()
+	} else {
+		return fake.stdoutReturns.result1
+	}
+}
+
+func (fake *FakeSetPipelineDelegate) StdoutCallCount() int {
+	fake.stdoutMutex.RLock()
+	defer fake.stdoutMutex.RUnlock()
+	return len(fake.stdoutArgsForCall)
+}
+
+func (fake *FakeSetPipelineDelegate) StdoutReturns(result1 io.Writer) {
+	fake.StdoutStub = nil
+	fake.stdoutReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeSetPipelineDelegate) Stderr() io.Writer {
+	fake.stderrMutex.Lock()
+	fake.stderrArgsForCall = append(fake.stderrArgsForCall, struct{}{})
+	fake.recordInvocation("Stderr", []interface{}{})
+	fake.stderrMutex.Unlock()
+	if fake.StderrStub != nil {
+		return fake.StderrStub()
+	} else {
+		return fake.stderrReturns.result1
+	}
+}
+
+func (fake *FakeSetPipelineDelegate) StderrCallCount() int {
+	fake.stderrMutex.RLock()
+	defer fake.stderrMutex.RUnlock()
+	return len(fake.stderrArgsForCall)
+}
+
+func (fake *FakeSetPipelineDelegate) StderrReturns(result1 io.Writer) {
+	fake.StderrStub = nil
+	fake.stderrReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeSetPipelineDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	fake.failedMutex.RLock()
+	defer fake.failedMutex.RUnlock()
+	fake.stdoutMutex.RLock()
+	defer fake.stdoutMutex.RUnlock()
+	fake.stderrMutex.RLock()
+	defer fake.stderrMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSetPipelineDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.SetPipelineDelegate = new(FakeSetPipelineDelegate)
@@ -49,6 +49,16 @@ type FakeTaskDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
+	AnnotatedStub        func(atc.BuildAnnotations)
+	annotatedMutex       sync.RWMutex
+	annotatedArgsForCall []struct {
+		arg1 atc.BuildAnnotations
+	}
+	ReportedStub        func(atc.TestReport)
+	reportedMutex       sync.RWMutex
+	reportedArgsForCall []struct {
+		arg1 atc.TestReport
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -224,6 +234,54 @@ func (fake *FakeTaskDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) Annotated(arg1 atc.BuildAnnotations) {
+	fake.annotatedMutex.Lock()
+	fake.annotatedArgsForCall = append(fake.annotatedArgsForCall, struct {
+		arg1 atc.BuildAnnotations
+	}{arg1})
+	fake.recordInvocation("Annotated", []interface{}{arg1})
+	fake.annotatedMutex.Unlock()
+	if fake.AnnotatedStub != nil {
+		fake.AnnotatedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) AnnotatedCallCount() int {
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	return len(fake.annotatedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) AnnotatedArgsForCall(i int) atc.BuildAnnotations {
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	return fake.annotatedArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) Reported(arg1 atc.TestReport) {
+	fake.reportedMutex.Lock()
+	fake.reportedArgsForCall = append(fake.reportedArgsForCall, struct {
+		arg1 atc.TestReport
+	}{arg1})
+	fake.recordInvocation("Reported", []interface{}{arg1})
+	fake.reportedMutex.Unlock()
+	if fake.ReportedStub != nil {
+		fake.ReportedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) ReportedCallCount() int {
+	fake.reportedMutex.RLock()
+	defer fake.reportedMutex.RUnlock()
+	return len(fake.reportedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ReportedArgsForCall(i int) atc.TestReport {
+	fake.reportedMutex.RLock()
+	defer fake.reportedMutex.RUnlock()
+	return fake.reportedArgsForCall[i].arg1
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -241,6 +299,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	fake.reportedMutex.RLock()
+	defer fake.reportedMutex.RUnlock()
 	return fake.invocations
 }
 
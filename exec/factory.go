@@ -2,11 +2,11 @@ package exec
 
 import (
 	"io"
-	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
 
@@ -28,8 +28,6 @@ type Factory interface {
 		atc.Params,
 		atc.Version,
 		atc.ResourceTypes,
-		time.Duration,
-		time.Duration,
 	) StepFactory
 
 	// Put constructs a PutStep factory.
@@ -44,8 +42,6 @@ type Factory interface {
 		int,
 		atc.Params,
 		atc.ResourceTypes,
-		time.Duration,
-		time.Duration,
 	) StepFactory
 
 	// DependentGet constructs a GetStep factory whose version is determined by
@@ -62,8 +58,6 @@ type Factory interface {
 		int,
 		atc.Params,
 		atc.ResourceTypes,
-		time.Duration,
-		time.Duration,
 	) StepFactory
 
 	// Task constructs a TaskStep factory.
@@ -82,8 +76,14 @@ type Factory interface {
 		map[string]string,
 		string,
 		clock.Clock,
-		time.Duration,
-		time.Duration,
+	) StepFactory
+
+	// SetPipeline constructs a SetPipelineStep factory.
+	SetPipeline(
+		lager.Logger,
+		SetPipelineDelegate,
+		db.TeamDB,
+		atc.SetPipelinePlan,
 	) StepFactory
 }
 
@@ -104,6 +104,9 @@ type TaskDelegate interface {
 	Finished(ExitStatus)
 	Failed(error)
 
+	Annotated(atc.BuildAnnotations)
+	Reported(atc.TestReport)
+
 	ImageVersionDetermined(worker.VolumeIdentifier) error
 
 	Stdout() io.Writer
@@ -132,6 +135,18 @@ type GetDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . SetPipelineDelegate
+
+// SetPipelineDelegate is used to record events related to a SetPipelineStep's
+// runtime behavior.
+type SetPipelineDelegate interface {
+	Finished(ExitStatus)
+	Failed(error)
+
+	Stdout() io.Writer
+	Stderr() io.Writer
+}
+
 //go:generate counterfeiter . PutDelegate
 
 // PutDelegate is used to record events related to a PutStep's runtime
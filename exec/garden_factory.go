@@ -4,12 +4,12 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -50,8 +50,6 @@ func (factory *gardenFactory) DependentGet(
 	teamID int,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) StepFactory {
 	return newDependentGetStep(
 		logger,
@@ -69,8 +67,6 @@ func (factory *gardenFactory) DependentGet(
 		delegate,
 		factory.resourceFetcher,
 		resourceTypes,
-		containerSuccessTTL,
-		containerFailureTTL,
 	)
 }
 
@@ -87,8 +83,6 @@ func (factory *gardenFactory) Get(
 	params atc.Params,
 	version atc.Version,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 	return newGetStep(
@@ -114,9 +108,6 @@ func (factory *gardenFactory) Get(
 		delegate,
 		factory.resourceFetcher,
 		resourceTypes,
-
-		containerSuccessTTL,
-		containerFailureTTL,
 	)
 }
 
@@ -131,8 +122,6 @@ func (factory *gardenFactory) Put(
 	teamID int,
 	params atc.Params,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) StepFactory {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 	return newPutStep(
@@ -150,8 +139,6 @@ func (factory *gardenFactory) Put(
 		delegate,
 		factory.tracker,
 		resourceTypes,
-		containerSuccessTTL,
-		containerFailureTTL,
 	)
 }
 
@@ -170,8 +157,6 @@ func (factory *gardenFactory) Task(
 	outputMapping map[string]string,
 	imageArtifactName string,
 	clock clock.Clock,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) StepFactory {
 	workingDirectory := factory.taskWorkingDirectory(sourceName)
 	workerMetadata.WorkingDirectory = workingDirectory
@@ -191,8 +176,20 @@ func (factory *gardenFactory) Task(
 		outputMapping,
 		imageArtifactName,
 		clock,
-		containerSuccessTTL,
-		containerFailureTTL,
+	)
+}
+
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamDB db.TeamDB,
+	plan atc.SetPipelinePlan,
+) StepFactory {
+	return newSetPipelineStep(
+		logger,
+		delegate,
+		teamDB,
+		plan,
 	)
 }
 
@@ -2,12 +2,8 @@ package exec
 
 import (
 	"archive/tar"
-	"crypto/sha256"
-	"encoding/json"
-	"fmt"
 	"io"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -38,9 +34,6 @@ type GetStep struct {
 	fetchSource resource.FetchSource
 
 	succeeded bool
-
-	containerSuccessTTL time.Duration
-	containerFailureTTL time.Duration
 }
 
 func newGetStep(
@@ -57,25 +50,21 @@ func newGetStep(
 	delegate GetDelegate,
 	resourceFetcher resource.Fetcher,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) GetStep {
 	return GetStep{
-		logger:              logger,
-		sourceName:          sourceName,
-		resourceConfig:      resourceConfig,
-		version:             version,
-		params:              params,
-		cacheIdentifier:     cacheIdentifier,
-		stepMetadata:        stepMetadata,
-		session:             session,
-		tags:                tags,
-		teamID:              teamID,
-		delegate:            delegate,
-		resourceFetcher:     resourceFetcher,
-		resourceTypes:       resourceTypes,
-		containerSuccessTTL: containerSuccessTTL,
-		containerFailureTTL: containerFailureTTL,
+		logger:          logger,
+		sourceName:      sourceName,
+		resourceConfig:  resourceConfig,
+		version:         version,
+		params:          params,
+		cacheIdentifier: cacheIdentifier,
+		stepMetadata:    stepMetadata,
+		session:         session,
+		tags:            tags,
+		teamID:          teamID,
+		delegate:        delegate,
+		resourceFetcher: resourceFetcher,
+		resourceTypes:   resourceTypes,
 	}
 }
 
@@ -163,11 +152,7 @@ func (step *GetStep) Release() {
 		return
 	}
 
-	if step.succeeded {
-		step.fetchSource.Release(worker.FinalTTL(step.containerSuccessTTL))
-	} else {
-		step.fetchSource.Release(worker.FinalTTL(step.containerFailureTTL))
-	}
+	step.fetchSource.Release(nil)
 }
 
 // Result indicates Success as true if the script completed successfully (or
@@ -275,25 +260,5 @@ func (d *getStepResource) ResourceType() resource.ResourceType {
 }
 
 func (d *getStepResource) LockName(workerName string) (string, error) {
-	id := &getStepLeaseID{
-		Type:       d.resourceType,
-		Version:    d.version,
-		Source:     d.source,
-		Params:     d.params,
-		WorkerName: workerName,
-	}
-
-	taskNameJSON, err := json.Marshal(id)
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("%x", sha256.Sum256(taskNameJSON)), nil
-}
-
-type getStepLeaseID struct {
-	Type       resource.ResourceType `json:"type"`
-	Version    atc.Version           `json:"version"`
-	Source     atc.Source            `json:"source"`
-	Params     atc.Params            `json:"params"`
-	WorkerName string                `json:"worker_name"`
+	return resource.FetchLockName(d.resourceType, d.version, d.source, d.params, workerName)
 }
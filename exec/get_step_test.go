@@ -8,7 +8,6 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -54,9 +53,6 @@ var _ = Describe("Get", func() {
 		step    Step
 		process ifrit.Process
 
-		successTTL time.Duration
-		failureTTL time.Duration
-
 		identifier = worker.Identifier{
 			ResourceID: 1234,
 		}
@@ -109,9 +105,6 @@ var _ = Describe("Get", func() {
 			},
 		}
 
-		successTTL = 3 * time.Second
-		failureTTL = 7 * time.Second
-
 		fakeResourceFetcher = new(rfakes.FakeFetcher)
 		fakeFetchSource = new(rfakes.FakeFetchSource)
 		fakeResourceFetcher.FetchReturns(fakeFetchSource, nil)
@@ -135,8 +128,6 @@ var _ = Describe("Get", func() {
 			params,
 			version,
 			resourceTypes,
-			successTTL,
-			failureTTL,
 		).Using(inStep, repo)
 
 		process = ifrit.Invoke(step)
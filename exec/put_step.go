@@ -4,13 +4,11 @@ import (
 	"archive/tar"
 	"bytes"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
-	"github.com/concourse/atc/worker"
 )
 
 // PutStep produces a resource version using preconfigured params and any data
@@ -34,9 +32,6 @@ type PutStep struct {
 	versionedSource resource.VersionedSource
 
 	succeeded bool
-
-	containerSuccessTTL time.Duration
-	containerFailureTTL time.Duration
 }
 
 func newPutStep(
@@ -50,22 +45,18 @@ func newPutStep(
 	delegate PutDelegate,
 	tracker resource.Tracker,
 	resourceTypes atc.ResourceTypes,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) PutStep {
 	return PutStep{
-		logger:              logger,
-		resourceConfig:      resourceConfig,
-		params:              params,
-		stepMetadata:        stepMetadata,
-		session:             session,
-		tags:                tags,
-		teamID:              teamID,
-		delegate:            delegate,
-		tracker:             tracker,
-		resourceTypes:       resourceTypes,
-		containerSuccessTTL: containerSuccessTTL,
-		containerFailureTTL: containerFailureTTL,
+		logger:         logger,
+		resourceConfig: resourceConfig,
+		params:         params,
+		stepMetadata:   stepMetadata,
+		session:        session,
+		tags:           tags,
+		teamID:         teamID,
+		delegate:       delegate,
+		tracker:        tracker,
+		resourceTypes:  resourceTypes,
 	}
 }
 
@@ -175,11 +166,7 @@ func (step *PutStep) Release() {
 		return
 	}
 
-	if step.succeeded {
-		step.resource.Release(worker.FinalTTL(step.containerSuccessTTL))
-	} else {
-		step.resource.Release(worker.FinalTTL(step.containerFailureTTL))
-	}
+	step.resource.Release(nil)
 }
 
 // Result indicates Success as true if the script completed with exit status 0.
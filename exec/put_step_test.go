@@ -6,7 +6,6 @@ import (
 	"errors"
 	"io"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -71,9 +70,6 @@ var _ = Describe("GardenFactory", func() {
 
 			step    Step
 			process ifrit.Process
-
-			successTTL time.Duration
-			failureTTL time.Duration
 		)
 
 		BeforeEach(func() {
@@ -101,8 +97,6 @@ var _ = Describe("GardenFactory", func() {
 				},
 			}
 
-			successTTL = 3 * time.Second
-			failureTTL = 10 * time.Second
 		})
 
 		JustBeforeEach(func() {
@@ -117,8 +111,6 @@ var _ = Describe("GardenFactory", func() {
 				teamID,
 				params,
 				resourceTypes,
-				successTTL,
-				failureTTL,
 			).Using(inStep, repo)
 
 			process = ifrit.Invoke(step)
@@ -261,14 +253,14 @@ var _ = Describe("GardenFactory", func() {
 					Expect(bool(success)).To(BeTrue())
 				})
 
-				It("releases the resource with success TTL", func() {
+				It("releases the resource", func() {
 					<-process.Wait()
 
 					Expect(fakeResource.ReleaseCallCount()).To(BeZero())
 
 					step.Release()
 					Expect(fakeResource.ReleaseCallCount()).To(Equal(1))
-					Expect(fakeResource.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(successTTL)))
+					Expect(fakeResource.ReleaseArgsForCall(0)).To(BeNil())
 				})
 
 				It("completes via the delegate", func() {
@@ -348,14 +340,14 @@ var _ = Describe("GardenFactory", func() {
 							Expect(putDelegate.FailedArgsForCall(0)).To(Equal(disaster))
 						})
 
-						It("releases the resource with the failre ttl", func() {
+						It("releases the resource", func() {
 							<-process.Wait()
 
 							Expect(fakeResource.ReleaseCallCount()).To(BeZero())
 
 							step.Release()
 							Expect(fakeResource.ReleaseCallCount()).To(Equal(1))
-							Expect(fakeResource.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+							Expect(fakeResource.ReleaseArgsForCall(0)).To(BeNil())
 						})
 					})
 
@@ -377,14 +369,14 @@ var _ = Describe("GardenFactory", func() {
 							Expect(putDelegate.FailedArgsForCall(0)).To(Equal(ErrInterrupted))
 						})
 
-						It("releases the resource with the failre ttl", func() {
+						It("releases the resource", func() {
 							<-process.Wait()
 
 							Expect(fakeResource.ReleaseCallCount()).To(BeZero())
 
 							step.Release()
 							Expect(fakeResource.ReleaseCallCount()).To(Equal(1))
-							Expect(fakeResource.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+							Expect(fakeResource.ReleaseArgsForCall(0)).To(BeNil())
 						})
 					})
 
@@ -420,14 +412,14 @@ var _ = Describe("GardenFactory", func() {
 							Expect(bool(success)).To(BeFalse())
 						})
 
-						It("releases the resource with the failure ttl", func() {
+						It("releases the resource", func() {
 							<-process.Wait()
 
 							Expect(fakeResource.ReleaseCallCount()).To(BeZero())
 
 							step.Release()
 							Expect(fakeResource.ReleaseCallCount()).To(Equal(1))
-							Expect(fakeResource.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+							Expect(fakeResource.ReleaseArgsForCall(0)).To(BeNil())
 						})
 					})
 				})
@@ -0,0 +1,324 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/baggageclaim"
+	"gopkg.in/yaml.v2"
+)
+
+// SetPipelineStep saves a pipeline config read from a file in the
+// SourceRepository as a pipeline of the build's team.
+type SetPipelineStep struct {
+	logger   lager.Logger
+	delegate SetPipelineDelegate
+	teamDB   db.TeamDB
+	plan     atc.SetPipelinePlan
+	repo     *SourceRepository
+
+	succeeded bool
+}
+
+func newSetPipelineStep(
+	logger lager.Logger,
+	delegate SetPipelineDelegate,
+	teamDB db.TeamDB,
+	plan atc.SetPipelinePlan,
+) SetPipelineStep {
+	return SetPipelineStep{
+		logger:   logger,
+		delegate: delegate,
+		teamDB:   teamDB,
+		plan:     plan,
+	}
+}
+
+// Using finishes construction of the SetPipelineStep and returns a
+// *SetPipelineStep. If the *SetPipelineStep errors, its error is reported to
+// the delegate.
+func (step SetPipelineStep) Using(prev Step, repo *SourceRepository) Step {
+	step.repo = repo
+
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run reads the pipeline config from the file, which must be in the format
+// SOURCE_NAME/FILE/PATH.yml, and validates it. If it is valid, the difference
+// from the pipeline's current config is written to the delegate's stdout and
+// the config is saved. If it is invalid, the errors are written to the
+// delegate's stderr and the step fails.
+//
+// Saving the pipeline is idempotent, as a config without changes is not saved
+// again.
+func (step *SetPipelineStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	configBytes, fetched, err := step.fetchConfig(stderr)
+	if err != nil {
+		return err
+	}
+
+	if !fetched {
+		step.finish(1)
+		return nil
+	}
+
+	pipelineConfig, err := atc.LoadConfig(configBytes)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load %s: %s\n", step.plan.File, err)
+		step.finish(1)
+		return nil
+	}
+
+	warnings, errorMessages := config.ValidateConfig(pipelineConfig)
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintf(stderr, "invalid pipeline config in %s:\n", step.plan.File)
+		for _, message := range errorMessages {
+			fmt.Fprintf(stderr, "  - %s\n", message)
+		}
+
+		step.finish(1)
+		return nil
+	}
+
+	existingConfig, _, version, err := step.teamDB.GetConfig(step.plan.Name)
+	if err != nil {
+		// a malformed config can be replaced; its version is still returned
+		if _, ok := err.(atc.MalformedConfigError); !ok {
+			return err
+		}
+	}
+
+	changed, err := writeConfigDiff(stdout, existingConfig, pipelineConfig)
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		fmt.Fprintf(stdout, "no changes to pipeline '%s'\n", step.plan.Name)
+		step.finish(0)
+		return nil
+	}
+
+	_, created, err := step.teamDB.SaveConfig(step.plan.Name, pipelineConfig, version, db.PipelineNoChange)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintf(stdout, "created pipeline '%s'\n", step.plan.Name)
+	} else {
+		fmt.Fprintf(stdout, "updated pipeline '%s'\n", step.plan.Name)
+	}
+
+	step.logger.Info("saved", lager.Data{"pipeline": step.plan.Name, "created": created})
+
+	step.finish(0)
+	return nil
+}
+
+// Result indicates Success as true if the config was valid and saved.
+//
+// All other types are ignored.
+func (step *SetPipelineStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Release is a no-op.
+func (step *SetPipelineStep) Release() {}
+
+func (step *SetPipelineStep) finish(status ExitStatus) {
+	step.succeeded = status == 0
+	step.delegate.Finished(status)
+}
+
+// fetchConfig reads the config file. A file that cannot be found is the
+// build's mistake rather than an error, so it is written to stderr and the
+// config is not fetched.
+func (step *SetPipelineStep) fetchConfig(stderr io.Writer) ([]byte, bool, error) {
+	segs := strings.SplitN(step.plan.File, "/", 2)
+	if len(segs) != 2 {
+		fmt.Fprintf(stderr, "%s\n", UnspecifiedArtifactSourceError{step.plan.File})
+		return nil, false, nil
+	}
+
+	sourceName := SourceName(segs[0])
+	filePath := segs[1]
+
+	source, found := step.repo.SourceFor(sourceName)
+	if !found {
+		fmt.Fprintf(stderr, "%s\n", UnknownArtifactSourceError{sourceName})
+		return nil, false, nil
+	}
+
+	stream, err := source.StreamFile(filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			fmt.Fprintf(stderr, "pipeline config '%s/%s' not found\n", sourceName, filePath)
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer stream.Close()
+
+	configBytes, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return configBytes, true, nil
+}
+
+// writeConfigDiff writes the lines that differ between the configs as YAML,
+// prefixed with - if removed and + if added.
+func writeConfigDiff(w io.Writer, from atc.Config, to atc.Config) (bool, error) {
+	fromYAML, err := yaml.Marshal(from)
+	if err != nil {
+		return false, err
+	}
+
+	toYAML, err := yaml.Marshal(to)
+	if err != nil {
+		return false, err
+	}
+
+	a := strings.Split(strings.TrimSuffix(string(fromYAML), "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(string(toYAML), "\n"), "\n")
+
+	changed := false
+
+	diffLines(a, b, func(removed bool, line string) {
+		if removed {
+			fmt.Fprintf(w, "\x1b[31m- %s\x1b[0m\n", line)
+		} else {
+			fmt.Fprintf(w, "\x1b[32m+ %s\x1b[0m\n", line)
+		}
+
+		changed = true
+	})
+
+	return changed, nil
+}
+
+// diffLines calls emit, in order, with each line of a that is removed and
+// each line of b that is added by a shortest edit script from a to b. It uses
+// Myers' linear space algorithm, as configs can be thousands of lines long.
+func diffLines(a []string, b []string, emit func(removed bool, line string)) {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	if len(a) == 0 || len(b) == 0 {
+		for _, line := range a {
+			emit(true, line)
+		}
+
+		for _, line := range b {
+			emit(false, line)
+		}
+
+		return
+	}
+
+	// with no common prefix or suffix, the snake splits the script into two
+	// strictly shorter ones
+	x, y, u, v := middleSnake(a, b)
+
+	diffLines(a[:x], b[:y], emit)
+	diffLines(a[u:], b[v:], emit)
+}
+
+// middleSnake finds the diagonal run of matching lines, from (x, y) to
+// (u, v), in the middle of a shortest edit script from a to b, by searching
+// forwards from the start and backwards from the end until they overlap.
+func middleSnake(a []string, b []string) (int, int, int, int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	odd := delta%2 != 0
+
+	max := (n + m + 1) / 2
+	offset := max + 1
+
+	// the furthest x reached on each diagonal k = x - y, going forwards, and
+	// going backwards in coordinates counted from the end
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := furthestReaching(forward, offset, k, d)
+			y := x - k
+
+			startX, startY := x, y
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			forward[offset+k] = x
+
+			if odd && delta-k >= -(d-1) && delta-k <= d-1 && x+backward[offset+delta-k] >= n {
+				return startX, startY, x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			x := furthestReaching(backward, offset, k, d)
+			y := x - k
+
+			startX, startY := x, y
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+
+			backward[offset+k] = x
+
+			if !odd && delta-k >= -d && delta-k <= d && x+forward[offset+delta-k] >= n {
+				return n - x, m - y, n - startX, m - startY
+			}
+		}
+	}
+
+	// unreachable, as the searches always overlap by the middle
+	return 0, 0, n, m
+}
+
+func furthestReaching(furthest []int, offset int, k int, d int) int {
+	if k == -d || (k != d && furthest[offset+k-1] < furthest[offset+k+1]) {
+		return furthest[offset+k+1]
+	}
+
+	return furthest[offset+k-1] + 1
+}
@@ -0,0 +1,315 @@
+package exec_test
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/baggageclaim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+	"gopkg.in/yaml.v2"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	var (
+		factory Factory
+
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+
+		fakeDelegate       *execfakes.FakeSetPipelineDelegate
+		fakeTeamDB         *dbfakes.FakeTeamDB
+		fakeArtifactSource *execfakes.FakeArtifactSource
+
+		plan atc.SetPipelinePlan
+		repo *SourceRepository
+
+		step    Step
+		process ifrit.Process
+	)
+
+	pipelineYAML := `
+resources:
+- name: some-resource
+  type: git
+  source:
+    uri: https://example.com/some-repo.git
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+
+	BeforeEach(func() {
+		factory = NewGardenFactory(nil, nil, nil)
+
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		fakeDelegate.StdoutReturns(stdoutBuf)
+		fakeDelegate.StderrReturns(stderrBuf)
+
+		fakeTeamDB = new(dbfakes.FakeTeamDB)
+		fakeTeamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, nil)
+
+		fakeArtifactSource = new(execfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(pipelineYAML)), nil)
+
+		repo = NewSourceRepository()
+		repo.RegisterSource("some-source", fakeArtifactSource)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-source/ci/pipeline.yml",
+		}
+	})
+
+	JustBeforeEach(func() {
+		step = factory.SetPipeline(
+			lagertest.NewTestLogger("test"),
+			fakeDelegate,
+			fakeTeamDB,
+			plan,
+		).Using(nil, repo)
+
+		process = ifrit.Invoke(step)
+	})
+
+	It("reads the config from the artifact source", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(fakeArtifactSource.StreamFileCallCount()).To(Equal(1))
+		Expect(fakeArtifactSource.StreamFileArgsForCall(0)).To(Equal("ci/pipeline.yml"))
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		BeforeEach(func() {
+			fakeTeamDB.SaveConfigReturns(db.SavedPipeline{}, true, nil)
+		})
+
+		It("saves the config for the pipeline", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeTeamDB.GetConfigArgsForCall(0)).To(Equal("some-pipeline"))
+
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(Equal(1))
+			name, savedConfig, version, pausedState := fakeTeamDB.SaveConfigArgsForCall(0)
+			Expect(name).To(Equal("some-pipeline"))
+			Expect(savedConfig.Resources).To(Equal(atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "https://example.com/some-repo.git"},
+				},
+			}))
+			Expect(savedConfig.Jobs[0].Name).To(Equal("some-job"))
+			Expect(version).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+		})
+
+		It("writes the diff and that the pipeline was created", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stdoutBuf).To(gbytes.Say(`\+ - name: some-resource`))
+			Expect(stdoutBuf).To(gbytes.Say(`created pipeline 'some-pipeline'`))
+		})
+
+		It("succeeds", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeTrue())
+		})
+	})
+
+	Context("when the pipeline exists with another config", func() {
+		BeforeEach(func() {
+			existingConfig, err := atc.LoadConfig([]byte(pipelineYAML))
+			Expect(err).NotTo(HaveOccurred())
+
+			existingConfig.Resources[0].Source = atc.Source{"uri": "https://example.com/old-repo.git"}
+
+			fakeTeamDB.GetConfigReturns(existingConfig, atc.RawConfig("raw"), 42, nil)
+		})
+
+		It("updates it from its current version", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			_, _, version, _ := fakeTeamDB.SaveConfigArgsForCall(0)
+			Expect(version).To(Equal(db.ConfigVersion(42)))
+		})
+
+		It("writes only the lines that changed", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stdoutBuf).To(gbytes.Say(`- +uri: https://example.com/old-repo.git`))
+			Expect(stdoutBuf).To(gbytes.Say(`\+ +uri: https://example.com/some-repo.git`))
+			Expect(stdoutBuf.Contents()).NotTo(ContainSubstring("some-job"))
+			Expect(stdoutBuf).To(gbytes.Say(`updated pipeline 'some-pipeline'`))
+		})
+	})
+
+	Context("when the pipeline exists with the same config", func() {
+		BeforeEach(func() {
+			existingConfig, err := atc.LoadConfig([]byte(pipelineYAML))
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeTeamDB.GetConfigReturns(existingConfig, atc.RawConfig("raw"), 42, nil)
+		})
+
+		It("does not save it again", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+			Expect(stdoutBuf).To(gbytes.Say(`no changes to pipeline 'some-pipeline'`))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - get: bogus-resource
+`)), nil)
+		})
+
+		It("writes the errors and fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say(`invalid pipeline config in some-source/ci/pipeline.yml`))
+			Expect(stderrBuf).To(gbytes.Say(`bogus-resource`))
+
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeFalse())
+		})
+	})
+
+	Context("when the config file has unknown keys", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte(`bogus: true`)), nil)
+		})
+
+		It("fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say(`extra keys in the pipeline configuration: bogus`))
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+		})
+	})
+
+	Context("when the config file does not exist", func() {
+		BeforeEach(func() {
+			fakeArtifactSource.StreamFileReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say(`pipeline config 'some-source/ci/pipeline.yml' not found`))
+			Expect(fakeDelegate.FailedCallCount()).To(BeZero())
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the artifact source is not in the repository", func() {
+		BeforeEach(func() {
+			plan.File = "bogus-source/ci/pipeline.yml"
+		})
+
+		It("fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say(`unknown artifact source: bogus-source`))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the file does not say which artifact source it is in", func() {
+		BeforeEach(func() {
+			plan.File = "pipeline.yml"
+		})
+
+		It("fails without saving", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stderrBuf).To(gbytes.Say(`config path 'pipeline.yml' does not specify where the file lives`))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(1)))
+			Expect(fakeTeamDB.SaveConfigCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the config changes in the middle of a long file", func() {
+		var existingConfig atc.Config
+
+		BeforeEach(func() {
+			for i := 0; i < 500; i++ {
+				existingConfig.Jobs = append(existingConfig.Jobs, atc.JobConfig{
+					Name: fmt.Sprintf("job-%d", i),
+				})
+			}
+
+			fakeTeamDB.GetConfigReturns(existingConfig, atc.RawConfig(""), 1, nil)
+
+			changedConfig := atc.Config{
+				Jobs: append(atc.JobConfigs{}, existingConfig.Jobs...),
+			}
+			changedConfig.Jobs[250].Name = "renamed-job"
+
+			changedYAML, err := yaml.Marshal(changedConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes(changedYAML), nil)
+		})
+
+		It("writes only the changed lines", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			Expect(stdoutBuf).To(gbytes.Say(`- - name: job-250`))
+			Expect(stdoutBuf).To(gbytes.Say(`\+ - name: renamed-job`))
+			Expect(stdoutBuf.Contents()).NotTo(ContainSubstring("job-249"))
+			Expect(stdoutBuf.Contents()).NotTo(ContainSubstring("job-251"))
+		})
+	})
+
+	Context("when saving the config fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeamDB.SaveConfigReturns(db.SavedPipeline{}, false, disaster)
+		})
+
+		It("errors", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+			Expect(err).To(Equal(disaster))
+
+			Expect(fakeDelegate.FailedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FailedArgsForCall(0)).To(Equal(disaster))
+		})
+	})
+})
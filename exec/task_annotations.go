@@ -0,0 +1,104 @@
+package exec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+// AnnotationsOutputName is the reserved task output which, if declared, is
+// checked for an AnnotationsFile once the task exits.
+const AnnotationsOutputName = "build-annotations"
+
+// AnnotationsFile is the file within the AnnotationsOutputName output which
+// a task writes build annotations to, as JSON of the form of
+// atc.BuildAnnotations.
+const AnnotationsFile = "annotations.json"
+
+const maxAnnotationsSize = 64 * 1024
+
+var errAnnotationsTooLarge = fmt.Errorf("%s is larger than %d bytes", AnnotationsFile, maxAnnotationsSize)
+
+func (step *TaskStep) recordAnnotations(config atc.TaskConfig) {
+	var annotationsOutput *atc.TaskOutputConfig
+	for _, output := range config.Outputs {
+		if output.Name == AnnotationsOutputName {
+			annotationsOutput = &output
+			break
+		}
+	}
+
+	if annotationsOutput == nil {
+		return
+	}
+
+	logger := step.logger.Session("record-annotations")
+
+	source := newContainerSource(step.artifactsRoot, step.container, *annotationsOutput, logger, "")
+
+	file, err := source.StreamFile(AnnotationsFile)
+	if err != nil {
+		if _, ok := err.(FileNotFoundError); !ok {
+			logger.Error("failed-to-stream-annotations", err)
+		}
+
+		return
+	}
+
+	defer file.Close()
+
+	annotations, err := parseAnnotations(file)
+	if err != nil {
+		logger.Info("invalid-annotations", lager.Data{"error": err.Error()})
+		fmt.Fprintf(step.delegate.Stderr(), "ignoring build annotations: %s\n", err)
+		return
+	}
+
+	if len(annotations.Metadata) == 0 && len(annotations.Links) == 0 {
+		return
+	}
+
+	step.delegate.Annotated(annotations)
+}
+
+func parseAnnotations(file io.Reader) (atc.BuildAnnotations, error) {
+	payload, err := ioutil.ReadAll(io.LimitReader(file, maxAnnotationsSize+1))
+	if err != nil {
+		return atc.BuildAnnotations{}, err
+	}
+
+	if len(payload) > maxAnnotationsSize {
+		return atc.BuildAnnotations{}, errAnnotationsTooLarge
+	}
+
+	var annotations atc.BuildAnnotations
+	err = json.Unmarshal(payload, &annotations)
+	if err != nil {
+		return atc.BuildAnnotations{}, fmt.Errorf("malformed %s: %s", AnnotationsFile, err)
+	}
+
+	for _, field := range annotations.Metadata {
+		if field.Name == "" {
+			return atc.BuildAnnotations{}, errors.New("metadata must have a name")
+		}
+	}
+
+	for _, link := range annotations.Links {
+		if link.Name == "" {
+			return atc.BuildAnnotations{}, errors.New("links must have a name")
+		}
+
+		linkURL, err := url.Parse(link.URL)
+		if err != nil || (linkURL.Scheme != "http" && linkURL.Scheme != "https") {
+			return atc.BuildAnnotations{}, fmt.Errorf("link '%s' must have an http or https url", link.Name)
+		}
+	}
+
+	return annotations, nil
+}
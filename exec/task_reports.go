@@ -0,0 +1,191 @@
+package exec
+
+import (
+	"archive/tar"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+const maxReportSize = 10 * 1024 * 1024
+
+// the most failed test cases recorded for a single report; the totals still
+// account for the rest, so readers can tell when cases were left out
+const maxFailedCases = 100
+
+const maxFailureMessageLength = 4096
+
+func (step *TaskStep) recordReports(config atc.TaskConfig) {
+	if len(config.Reports) == 0 {
+		return
+	}
+
+	logger := step.logger.Session("record-reports")
+
+	for _, reportConfig := range config.Reports {
+		report, err := step.readReport(config, reportConfig)
+		if err != nil {
+			if _, ok := err.(FileNotFoundError); ok {
+				fmt.Fprintf(step.delegate.Stderr(), "no %s report found at %s\n", reportConfig.Format, reportConfig.Path)
+				continue
+			}
+
+			logger.Info("failed-to-read-report", lager.Data{"path": reportConfig.Path, "error": err.Error()})
+			fmt.Fprintf(step.delegate.Stderr(), "ignoring report %s: %s\n", reportConfig.Path, err)
+			continue
+		}
+
+		step.delegate.Reported(report)
+	}
+}
+
+func (step *TaskStep) readReport(config atc.TaskConfig, reportConfig atc.TaskReportConfig) (atc.TestReport, error) {
+	out, err := step.container.StreamOut(garden.StreamOutSpec{
+		Path: path.Join(step.artifactsRoot, config.Run.Dir, reportConfig.Path),
+	})
+	if err != nil {
+		return atc.TestReport{}, err
+	}
+
+	defer out.Close()
+
+	tarReader := tar.NewReader(out)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		return atc.TestReport{}, FileNotFoundError{Path: reportConfig.Path}
+	}
+
+	report, err := parseJUnitReport(tarReader)
+	if err != nil {
+		return atc.TestReport{}, err
+	}
+
+	report.Format = reportConfig.Format
+	report.Path = reportConfig.Path
+
+	return report, nil
+}
+
+type junitTestSuite struct {
+	XMLName xml.Name
+
+	Name   string           `xml:"name,attr"`
+	Suites []junitTestSuite `xml:"testsuite"`
+	Cases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string `xml:"name,attr"`
+	Classname string `xml:"classname,attr"`
+	Time      string `xml:"time,attr"`
+
+	Failure *junitResult `xml:"failure"`
+	Error   *junitResult `xml:"error"`
+	Skipped *struct{}    `xml:"skipped"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func parseJUnitReport(file io.Reader) (atc.TestReport, error) {
+	payload, err := ioutil.ReadAll(io.LimitReader(file, maxReportSize+1))
+	if err != nil {
+		return atc.TestReport{}, err
+	}
+
+	if len(payload) > maxReportSize {
+		return atc.TestReport{}, fmt.Errorf("report is larger than %d bytes", maxReportSize)
+	}
+
+	var root junitTestSuite
+	err = xml.Unmarshal(payload, &root)
+	if err != nil {
+		return atc.TestReport{}, fmt.Errorf("malformed junit report: %s", err)
+	}
+
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return atc.TestReport{}, fmt.Errorf("malformed junit report: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	report := atc.TestReport{}
+	summarizeJUnitSuite(&report, root)
+
+	return report, nil
+}
+
+func summarizeJUnitSuite(report *atc.TestReport, suite junitTestSuite) {
+	for _, testCase := range suite.Cases {
+		report.Tests++
+
+		duration, err := strconv.ParseFloat(testCase.Time, 64)
+		if err == nil {
+			report.Duration += duration
+		}
+
+		var result *junitResult
+		errored := false
+
+		switch {
+		case testCase.Error != nil:
+			report.Errors++
+			result = testCase.Error
+			errored = true
+		case testCase.Failure != nil:
+			report.Failures++
+			result = testCase.Failure
+		case testCase.Skipped != nil:
+			report.Skipped++
+			continue
+		default:
+			continue
+		}
+
+		if len(report.FailedCases) >= maxFailedCases {
+			continue
+		}
+
+		report.FailedCases = append(report.FailedCases, atc.TestCaseFailure{
+			Suite:     suite.Name,
+			Classname: testCase.Classname,
+			Name:      testCase.Name,
+			Errored:   errored,
+			Type:      result.Type,
+			Message:   failureMessage(*result),
+		})
+	}
+
+	for _, nested := range suite.Suites {
+		summarizeJUnitSuite(report, nested)
+	}
+}
+
+func failureMessage(result junitResult) string {
+	message := result.Message
+	if message == "" {
+		message = strings.TrimSpace(result.Body)
+	}
+
+	if len(message) > maxFailureMessageLength {
+		end := maxFailureMessageLength
+		for end > 0 && !utf8.RuneStart(message[end]) {
+			end--
+		}
+
+		message = message[:end]
+	}
+
+	return message
+}
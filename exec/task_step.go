@@ -10,7 +10,6 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
@@ -55,9 +54,7 @@ type TaskStep struct {
 	clock             clock.Clock
 	repo              *SourceRepository
 
-	container           worker.Container
-	containerSuccessTTL time.Duration
-	containerFailureTTL time.Duration
+	container worker.Container
 
 	process garden.Process
 
@@ -80,27 +77,23 @@ func newTaskStep(
 	outputMapping map[string]string,
 	imageArtifactName string,
 	clock clock.Clock,
-	containerSuccessTTL time.Duration,
-	containerFailureTTL time.Duration,
 ) TaskStep {
 	return TaskStep{
-		logger:              logger,
-		containerID:         containerID,
-		metadata:            metadata,
-		tags:                tags,
-		teamID:              teamID,
-		delegate:            delegate,
-		privileged:          privileged,
-		configSource:        configSource,
-		workerPool:          workerPool,
-		artifactsRoot:       artifactsRoot,
-		resourceTypes:       resourceTypes,
-		inputMapping:        inputMapping,
-		outputMapping:       outputMapping,
-		imageArtifactName:   imageArtifactName,
-		clock:               clock,
-		containerSuccessTTL: containerSuccessTTL,
-		containerFailureTTL: containerFailureTTL,
+		logger:            logger,
+		containerID:       containerID,
+		metadata:          metadata,
+		tags:              tags,
+		teamID:            teamID,
+		delegate:          delegate,
+		privileged:        privileged,
+		configSource:      configSource,
+		workerPool:        workerPool,
+		artifactsRoot:     artifactsRoot,
+		resourceTypes:     resourceTypes,
+		inputMapping:      inputMapping,
+		outputMapping:     outputMapping,
+		imageArtifactName: imageArtifactName,
+		clock:             clock,
 	}
 }
 
@@ -127,6 +120,10 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 // the RunStep indicates that it's ready, and any signals will be forwarded to
 // the script.
 //
+// Once the script exits, any annotations it wrote to the AnnotationsOutputName
+// output and a summary of each of the TaskConfig's test reports are reported
+// to the delegate.
+//
 // If the script exits successfully, the outputs specified in the TaskConfig
 // are registered with the SourceRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
@@ -291,6 +288,9 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		step.recordAnnotations(config)
+		step.recordReports(config)
+
 		step.delegate.Finished(ExitStatus(processStatus))
 
 		return nil
@@ -502,11 +502,7 @@ func (step *TaskStep) Release() {
 		return
 	}
 
-	if step.exitStatus == 0 {
-		step.container.Release(worker.FinalTTL(step.containerSuccessTTL))
-	} else {
-		step.container.Release(worker.FinalTTL(step.containerFailureTTL))
-	}
+	step.container.Release(nil)
 }
 
 func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
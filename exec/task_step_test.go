@@ -73,9 +73,6 @@ var _ = Describe("GardenFactory", func() {
 
 			step    Step
 			process ifrit.Process
-
-			successTTL time.Duration
-			failureTTL time.Duration
 		)
 
 		BeforeEach(func() {
@@ -104,9 +101,6 @@ var _ = Describe("GardenFactory", func() {
 			imageArtifactName = ""
 			fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 
-			successTTL = 3 * time.Microsecond
-			failureTTL = 5 * time.Second
-
 			identifier = worker.Identifier{
 				BuildID: 1234,
 				PlanID:  atc.PlanID("some-plan-id"),
@@ -135,8 +129,6 @@ var _ = Describe("GardenFactory", func() {
 				outputMapping,
 				imageArtifactName,
 				fakeClock,
-				successTTL,
-				failureTTL,
 			).Using(inStep, repo)
 
 			process = ifrit.Invoke(step)
@@ -1144,6 +1136,251 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration declares the build annotations output", func() {
+							var annotationsJSON string
+
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Outputs: []atc.TaskOutputConfig{
+										{Name: "build-annotations"},
+									},
+								}, nil)
+
+								fakeWorker.CreateVolumeReturns(new(wfakes.FakeVolume), nil)
+								fakeProcess.WaitReturns(1, nil)
+
+								annotationsJSON = `{
+									"metadata": [{"name": "coverage", "value": "84%"}],
+									"links": [{"name": "report", "url": "https://example.com/report"}]
+								}`
+
+								fakeContainer.StreamOutStub = func(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+									tarBuffer := gbytes.NewBuffer()
+									tarWriter := tar.NewWriter(tarBuffer)
+
+									if annotationsJSON != "" {
+										err := tarWriter.WriteHeader(&tar.Header{
+											Name: "annotations.json",
+											Mode: 0644,
+											Size: int64(len(annotationsJSON)),
+										})
+										Expect(err).NotTo(HaveOccurred())
+
+										_, err = tarWriter.Write([]byte(annotationsJSON))
+										Expect(err).NotTo(HaveOccurred())
+									}
+
+									err := tarWriter.Close()
+									Expect(err).NotTo(HaveOccurred())
+
+									return tarBuffer, nil
+								}
+							})
+
+							It("reads the annotations file from the output once the process exits", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
+								spec := fakeContainer.StreamOutArgsForCall(0)
+								Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/build-annotations/annotations.json"))
+							})
+
+							It("reports the annotations to the delegate, even if the task failed", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(taskDelegate.AnnotatedCallCount()).To(Equal(1))
+								Expect(taskDelegate.AnnotatedArgsForCall(0)).To(Equal(atc.BuildAnnotations{
+									Metadata: []atc.MetadataField{{Name: "coverage", Value: "84%"}},
+									Links:    []atc.BuildLink{{Name: "report", URL: "https://example.com/report"}},
+								}))
+
+								Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+							})
+
+							Context("when the task did not write the file", func() {
+								BeforeEach(func() {
+									annotationsJSON = ""
+								})
+
+								It("does not report any annotations", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.AnnotatedCallCount()).To(Equal(0))
+								})
+							})
+
+							Context("when the file is malformed", func() {
+								BeforeEach(func() {
+									annotationsJSON = "{nope"
+								})
+
+								It("ignores it and tells the user why", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.AnnotatedCallCount()).To(Equal(0))
+									Expect(stderrBuf).To(gbytes.Say("ignoring build annotations: malformed annotations.json"))
+								})
+							})
+
+							Context("when a link is not http or https", func() {
+								BeforeEach(func() {
+									annotationsJSON = `{"links": [{"name": "report", "url": "javascript:alert(1)"}]}`
+								})
+
+								It("ignores the annotations", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.AnnotatedCallCount()).To(Equal(0))
+									Expect(stderrBuf).To(gbytes.Say("link 'report' must have an http or https url"))
+								})
+							})
+						})
+
+						Context("when the configuration specifies test reports", func() {
+							var reportXML string
+
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+										Dir:  "some-dir",
+									},
+									Reports: []atc.TaskReportConfig{
+										{Format: "junit", Path: "out/junit.xml"},
+									},
+								}, nil)
+
+								fakeProcess.WaitReturns(1, nil)
+
+								reportXML = `<?xml version="1.0" encoding="UTF-8"?>
+									<testsuites>
+										<testsuite name="models" tests="4">
+											<testcase classname="models.UserTest" name="test_create" time="0.5"/>
+											<testcase classname="models.UserTest" name="test_save" time="0.25">
+												<failure message="expected true" type="AssertionError">stack trace</failure>
+											</testcase>
+											<testcase classname="models.UserTest" name="test_delete" time="0.25">
+												<error type="RuntimeError">
+													connection refused
+												</error>
+											</testcase>
+											<testcase classname="models.UserTest" name="test_update">
+												<skipped/>
+											</testcase>
+										</testsuite>
+									</testsuites>`
+
+								fakeContainer.StreamOutStub = func(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+									tarBuffer := gbytes.NewBuffer()
+									tarWriter := tar.NewWriter(tarBuffer)
+
+									if reportXML != "" {
+										err := tarWriter.WriteHeader(&tar.Header{
+											Name: "junit.xml",
+											Mode: 0644,
+											Size: int64(len(reportXML)),
+										})
+										Expect(err).NotTo(HaveOccurred())
+
+										_, err = tarWriter.Write([]byte(reportXML))
+										Expect(err).NotTo(HaveOccurred())
+									}
+
+									err := tarWriter.Close()
+									Expect(err).NotTo(HaveOccurred())
+
+									return tarBuffer, nil
+								}
+							})
+
+							It("streams the report relative to the task's working directory", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
+								spec := fakeContainer.StreamOutArgsForCall(0)
+								Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-dir/out/junit.xml"))
+							})
+
+							It("reports a summary of the report to the delegate", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(taskDelegate.ReportedCallCount()).To(Equal(1))
+								Expect(taskDelegate.ReportedArgsForCall(0)).To(Equal(atc.TestReport{
+									Format:   "junit",
+									Path:     "out/junit.xml",
+									Tests:    4,
+									Failures: 1,
+									Errors:   1,
+									Skipped:  1,
+									Duration: 1,
+									FailedCases: []atc.TestCaseFailure{
+										{
+											Suite:     "models",
+											Classname: "models.UserTest",
+											Name:      "test_save",
+											Type:      "AssertionError",
+											Message:   "expected true",
+										},
+										{
+											Suite:     "models",
+											Classname: "models.UserTest",
+											Name:      "test_delete",
+											Errored:   true,
+											Type:      "RuntimeError",
+											Message:   "connection refused",
+										},
+									},
+								}))
+							})
+
+							Context("when the task did not write the report", func() {
+								BeforeEach(func() {
+									reportXML = ""
+								})
+
+								It("tells the user and does not report anything", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.ReportedCallCount()).To(Equal(0))
+									Expect(stderrBuf).To(gbytes.Say("no junit report found at out/junit.xml"))
+								})
+							})
+
+							Context("when the report is not junit", func() {
+								BeforeEach(func() {
+									reportXML = `<html></html>`
+								})
+
+								It("ignores it and tells the user why", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.ReportedCallCount()).To(Equal(0))
+									Expect(stderrBuf).To(gbytes.Say("ignoring report out/junit.xml: malformed junit report: unexpected root element <html>"))
+								})
+							})
+
+							Context("when a failure message is too long", func() {
+								BeforeEach(func() {
+									reportXML = `<testsuite name="models">
+										<testcase name="test_save">
+											<failure message="` + strings.Repeat("a", 4095) + strings.Repeat("é", 10) + `"/>
+										</testcase>
+									</testsuite>`
+								})
+
+								It("truncates it without splitting a character", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.ReportedCallCount()).To(Equal(1))
+									report := taskDelegate.ReportedArgsForCall(0)
+									Expect(report.FailedCases).To(HaveLen(1))
+									Expect(report.FailedCases[0].Message).To(Equal(strings.Repeat("a", 4095)))
+								})
+							})
+						})
+
 						Context("when output is remapped", func() {
 							BeforeEach(func() {
 								outputMapping = map[string]string{"generic-remapped-output": "specific-remapped-output"}
@@ -1661,14 +1898,14 @@ var _ = Describe("GardenFactory", func() {
 								Expect(status).To(Equal(ExitStatus(0)))
 							})
 
-							It("releases with success ttl", func() {
+							It("releases the container", func() {
 								<-process.Wait()
 
 								Expect(fakeContainer.ReleaseCallCount()).To(BeZero())
 
 								step.Release()
 								Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
-								Expect(fakeContainer.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(successTTL)))
+								Expect(fakeContainer.ReleaseArgsForCall(0)).To(BeNil())
 							})
 
 							It("doesn't register a source", func() {
@@ -1758,14 +1995,14 @@ var _ = Describe("GardenFactory", func() {
 								Expect(status).To(Equal(ExitStatus(1)))
 							})
 
-							It("releases with failure ttl", func() {
+							It("releases the container", func() {
 								<-process.Wait()
 
 								Expect(fakeContainer.ReleaseCallCount()).To(BeZero())
 
 								step.Release()
 								Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
-								Expect(fakeContainer.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+								Expect(fakeContainer.ReleaseArgsForCall(0)).To(BeNil())
 							})
 
 							Context("when saving the exit status succeeds", func() {
@@ -2132,14 +2369,14 @@ var _ = Describe("GardenFactory", func() {
 						Expect(bool(success)).To(BeFalse())
 					})
 
-					It("releases the container with failure TTL", func() {
+					It("releases the container", func() {
 						<-process.Wait()
 
 						Expect(fakeContainer.ReleaseCallCount()).To(BeZero())
 
 						step.Release()
 						Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
-						Expect(fakeContainer.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(failureTTL)))
+						Expect(fakeContainer.ReleaseArgsForCall(0)).To(BeNil())
 					})
 
 					It("reports its exit status", func() {
@@ -2216,14 +2453,14 @@ var _ = Describe("GardenFactory", func() {
 							Expect(taskDelegate.StartedCallCount()).To(BeZero())
 						})
 
-						It("releases the container with success TTL", func() {
+						It("releases the container", func() {
 							<-process.Wait()
 
 							Expect(fakeContainer.ReleaseCallCount()).To(BeZero())
 
 							step.Release()
 							Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
-							Expect(fakeContainer.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(successTTL)))
+							Expect(fakeContainer.ReleaseArgsForCall(0)).To(BeNil())
 						})
 					})
 
@@ -1,13 +0,0 @@
-package containerkeepaliver_test
-
-import (
-	. "github.com/onsi/ginkgo"
-	. "github.com/onsi/gomega"
-
-	"testing"
-)
-
-func TestContainerkeepaliver(t *testing.T) {
-	RegisterFailHandler(Fail)
-	RunSpecs(t, "Containerkeepaliver Suite")
-}
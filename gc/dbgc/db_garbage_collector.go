@@ -7,9 +7,8 @@ import (
 //go:generate counterfeiter . ReaperDB
 
 type ReaperDB interface {
-	ReapExpiredContainers() error
-	ReapExpiredVolumes() error
 	ReapExpiredWorkers() error
+	ReapExpiredATCs() error
 }
 
 type DBGarbageCollector interface {
@@ -32,21 +31,15 @@ func NewDBGarbageCollector(
 }
 
 func (c *dbGarbageCollector) Run() error {
-	err := c.db.ReapExpiredContainers()
+	err := c.db.ReapExpiredWorkers()
 	if err != nil {
-		c.logger.Error("failed-to-reap-expired-containers", err)
-		return err
-	}
-
-	err = c.db.ReapExpiredVolumes()
-	if err != nil {
-		c.logger.Error("failed-to-reap-expired-volumes", err)
+		c.logger.Error("failed-to-reap-expired-workers", err)
 		return err
 	}
 
-	err = c.db.ReapExpiredWorkers()
+	err = c.db.ReapExpiredATCs()
 	if err != nil {
-		c.logger.Error("failed-to-reap-expired-workers", err)
+		c.logger.Error("failed-to-reap-expired-atcs", err)
 		return err
 	}
 
@@ -20,13 +20,12 @@ var _ = Describe("DbGarbageCollector", func() {
 	})
 
 	Describe("Run", func() {
-		It("reaps expired containers, workers and volumes", func() {
+		It("reaps expired workers and ATCs", func() {
 			err := dbGarbageCollector.Run()
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeDB.ReapExpiredContainersCallCount()).To(Equal(1))
-			Expect(fakeDB.ReapExpiredVolumesCallCount()).To(Equal(1))
 			Expect(fakeDB.ReapExpiredWorkersCallCount()).To(Equal(1))
+			Expect(fakeDB.ReapExpiredATCsCallCount()).To(Equal(1))
 		})
 	})
 })
@@ -8,76 +8,20 @@ import (
 )
 
 type FakeReaperDB struct {
-	ReapExpiredContainersStub        func() error
-	reapExpiredContainersMutex       sync.RWMutex
-	reapExpiredContainersArgsForCall []struct{}
-	reapExpiredContainersReturns     struct {
-		result1 error
-	}
-	ReapExpiredVolumesStub        func() error
-	reapExpiredVolumesMutex       sync.RWMutex
-	reapExpiredVolumesArgsForCall []struct{}
-	reapExpiredVolumesReturns     struct {
-		result1 error
-	}
 	ReapExpiredWorkersStub        func() error
 	reapExpiredWorkersMutex       sync.RWMutex
 	reapExpiredWorkersArgsForCall []struct{}
 	reapExpiredWorkersReturns     struct {
 		result1 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakeReaperDB) ReapExpiredContainers() error {
-	fake.reapExpiredContainersMutex.Lock()
-	fake.reapExpiredContainersArgsForCall = append(fake.reapExpiredContainersArgsForCall, struct{}{})
-	fake.recordInvocation("ReapExpiredContainers", []interface{}{})
-	fake.reapExpiredContainersMutex.Unlock()
-	if fake.ReapExpiredContainersStub != nil {
-		return fake.ReapExpiredContainersStub()
-	} else {
-		return fake.reapExpiredContainersReturns.result1
-	}
-}
-
-func (fake *FakeReaperDB) ReapExpiredContainersCallCount() int {
-	fake.reapExpiredContainersMutex.RLock()
-	defer fake.reapExpiredContainersMutex.RUnlock()
-	return len(fake.reapExpiredContainersArgsForCall)
-}
-
-func (fake *FakeReaperDB) ReapExpiredContainersReturns(result1 error) {
-	fake.ReapExpiredContainersStub = nil
-	fake.reapExpiredContainersReturns = struct {
+	ReapExpiredATCsStub        func() error
+	reapExpiredATCsMutex       sync.RWMutex
+	reapExpiredATCsArgsForCall []struct{}
+	reapExpiredATCsReturns     struct {
 		result1 error
-	}{result1}
-}
-
-func (fake *FakeReaperDB) ReapExpiredVolumes() error {
-	fake.reapExpiredVolumesMutex.Lock()
-	fake.reapExpiredVolumesArgsForCall = append(fake.reapExpiredVolumesArgsForCall, struct{}{})
-	fake.recordInvocation("ReapExpiredVolumes", []interface{}{})
-	fake.reapExpiredVolumesMutex.Unlock()
-	if fake.ReapExpiredVolumesStub != nil {
-		return fake.ReapExpiredVolumesStub()
-	} else {
-		return fake.reapExpiredVolumesReturns.result1
 	}
-}
-
-func (fake *FakeReaperDB) ReapExpiredVolumesCallCount() int {
-	fake.reapExpiredVolumesMutex.RLock()
-	defer fake.reapExpiredVolumesMutex.RUnlock()
-	return len(fake.reapExpiredVolumesArgsForCall)
-}
-
-func (fake *FakeReaperDB) ReapExpiredVolumesReturns(result1 error) {
-	fake.ReapExpiredVolumesStub = nil
-	fake.reapExpiredVolumesReturns = struct {
-		result1 error
-	}{result1}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeReaperDB) ReapExpiredWorkers() error {
@@ -105,15 +49,38 @@ func (fake *FakeReaperDB) ReapExpiredWorkersReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReaperDB) ReapExpiredATCs() error {
+	fake.reapExpiredATCsMutex.Lock()
+	fake.reapExpiredATCsArgsForCall = append(fake.reapExpiredATCsArgsForCall, struct{}{})
+	fake.recordInvocation("ReapExpiredATCs", []interface{}{})
+	fake.reapExpiredATCsMutex.Unlock()
+	if fake.ReapExpiredATCsStub != nil {
+		return fake.ReapExpiredATCsStub()
+	} else {
+		return fake.reapExpiredATCsReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) ReapExpiredATCsCallCount() int {
+	fake.reapExpiredATCsMutex.RLock()
+	defer fake.reapExpiredATCsMutex.RUnlock()
+	return len(fake.reapExpiredATCsArgsForCall)
+}
+
+func (fake *FakeReaperDB) ReapExpiredATCsReturns(result1 error) {
+	fake.ReapExpiredATCsStub = nil
+	fake.reapExpiredATCsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.reapExpiredContainersMutex.RLock()
-	defer fake.reapExpiredContainersMutex.RUnlock()
-	fake.reapExpiredVolumesMutex.RLock()
-	defer fake.reapExpiredVolumesMutex.RUnlock()
 	fake.reapExpiredWorkersMutex.RLock()
 	defer fake.reapExpiredWorkersMutex.RUnlock()
+	fake.reapExpiredATCsMutex.RLock()
+	defer fake.reapExpiredATCsMutex.RUnlock()
 	return fake.invocations
 }
 
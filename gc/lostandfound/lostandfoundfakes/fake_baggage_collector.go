@@ -1,66 +0,0 @@
-// This file was generated by counterfeiter
-package lostandfoundfakes
-
-import (
-	"sync"
-
-	"github.com/concourse/atc/gc/lostandfound"
-)
-
-type FakeBaggageCollector struct {
-	RunStub        func() error
-	runMutex       sync.RWMutex
-	runArgsForCall []struct{}
-	runReturns     struct {
-		result1 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
-}
-
-func (fake *FakeBaggageCollector) Run() error {
-	fake.runMutex.Lock()
-	fake.runArgsForCall = append(fake.runArgsForCall, struct{}{})
-	fake.recordInvocation("Run", []interface{}{})
-	fake.runMutex.Unlock()
-	if fake.RunStub != nil {
-		return fake.RunStub()
-	} else {
-		return fake.runReturns.result1
-	}
-}
-
-func (fake *FakeBaggageCollector) RunCallCount() int {
-	fake.runMutex.RLock()
-	defer fake.runMutex.RUnlock()
-	return len(fake.runArgsForCall)
-}
-
-func (fake *FakeBaggageCollector) RunReturns(result1 error) {
-	fake.RunStub = nil
-	fake.runReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeBaggageCollector) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
-	fake.runMutex.RLock()
-	defer fake.runMutex.RUnlock()
-	return fake.invocations
-}
-
-func (fake *FakeBaggageCollector) recordInvocation(key string, args []interface{}) {
-	fake.invocationsMutex.Lock()
-	defer fake.invocationsMutex.Unlock()
-	if fake.invocations == nil {
-		fake.invocations = map[string][][]interface{}{}
-	}
-	if fake.invocations[key] == nil {
-		fake.invocations[key] = [][]interface{}{}
-	}
-	fake.invocations[key] = append(fake.invocations[key], args)
-}
-
-var _ lostandfound.BaggageCollector = new(FakeBaggageCollector)
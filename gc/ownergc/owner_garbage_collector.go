@@ -0,0 +1,199 @@
+package ownergc
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/resource"
+)
+
+//go:generate counterfeiter . OwnerGarbageCollectorDB
+
+type OwnerGarbageCollectorDB interface {
+	GetAllPipelines() ([]db.SavedPipeline, error)
+	GetUnusedResourceCaches(gracePeriod time.Duration) ([]db.SavedResourceCache, error)
+	DeleteResourceCache(id int) error
+	ReapOrphanedContainers(buildGracePeriod time.Duration) (map[db.OwnerType]int, error)
+	ReapOrphanedVolumes(buildGracePeriod time.Duration) (map[db.OwnerType]int, error)
+}
+
+// OwnerGarbageCollector deletes the containers and volumes whose owners are
+// gone from the database. The WorkerReconciler then destroys them on the
+// workers.
+type OwnerGarbageCollector interface {
+	Run() error
+}
+
+type ownerGarbageCollector struct {
+	logger            lager.Logger
+	db                OwnerGarbageCollectorDB
+	pipelineDBFactory db.PipelineDBFactory
+	buildGracePeriod  time.Duration
+	cacheGracePeriod  time.Duration
+}
+
+func NewOwnerGarbageCollector(
+	logger lager.Logger,
+	db OwnerGarbageCollectorDB,
+	pipelineDBFactory db.PipelineDBFactory,
+	buildGracePeriod time.Duration,
+	cacheGracePeriod time.Duration,
+) OwnerGarbageCollector {
+	return &ownerGarbageCollector{
+		logger:            logger,
+		db:                db,
+		pipelineDBFactory: pipelineDBFactory,
+		buildGracePeriod:  buildGracePeriod,
+		cacheGracePeriod:  cacheGracePeriod,
+	}
+}
+
+func (c *ownerGarbageCollector) Run() error {
+	err := c.collectResourceCaches()
+	if err != nil {
+		return err
+	}
+
+	destroyedContainers, err := c.db.ReapOrphanedContainers(c.buildGracePeriod)
+	if err != nil {
+		c.logger.Error("failed-to-reap-orphaned-containers", err)
+		return err
+	}
+
+	for ownerType, count := range destroyedContainers {
+		metric.ContainersDestroyed{
+			OwnerType:  ownerType,
+			Containers: count,
+		}.Emit(c.logger)
+	}
+
+	destroyedVolumes, err := c.db.ReapOrphanedVolumes(c.buildGracePeriod)
+	if err != nil {
+		c.logger.Error("failed-to-reap-orphaned-volumes", err)
+		return err
+	}
+
+	for ownerType, count := range destroyedVolumes {
+		metric.VolumesDestroyed{
+			OwnerType: ownerType,
+			Volumes:   count,
+		}.Emit(c.logger)
+	}
+
+	return nil
+}
+
+// resource caches are kept while they are the latest version of a resource
+// or the image of a job's latest builds; once no longer in use, any others
+// are deleted, taking their volumes with them
+func (c *ownerGarbageCollector) collectResourceCaches() error {
+	unusedCaches, err := c.db.GetUnusedResourceCaches(c.cacheGracePeriod)
+	if err != nil {
+		c.logger.Error("failed-to-get-unused-resource-caches", err)
+		return err
+	}
+
+	if len(unusedCaches) == 0 {
+		return nil
+	}
+
+	wantedCaches, err := c.wantedResourceCaches()
+	if err != nil {
+		return err
+	}
+
+	for _, cache := range unusedCaches {
+		if wantedCaches[cacheKey(cache.ResourceCacheIdentifier)] {
+			continue
+		}
+
+		err := c.db.DeleteResourceCache(cache.ID)
+		if err != nil {
+			c.logger.Error("failed-to-delete-resource-cache", err, lager.Data{"resource-cache": cache.ID})
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ownerGarbageCollector) wantedResourceCaches() (map[string]bool, error) {
+	wanted := map[string]bool{}
+
+	pipelines, err := c.db.GetAllPipelines()
+	if err != nil {
+		c.logger.Error("failed-to-get-pipelines", err)
+		return nil, err
+	}
+
+	for _, pipeline := range pipelines {
+		// archived pipelines no longer run, so their caches are not wanted
+		if pipeline.Archived {
+			continue
+		}
+
+		pipelineDB := c.pipelineDBFactory.Build(pipeline)
+
+		for _, pipelineResource := range pipeline.Config.Resources {
+			logger := c.logger.WithData(lager.Data{
+				"pipeline": pipeline.Name,
+				"resource": pipelineResource.Name,
+			})
+
+			latestVersion, found, err := pipelineDB.GetLatestEnabledVersionedResource(pipelineResource.Name)
+			if err != nil {
+				logger.Error("failed-to-get-latest-enabled-version", err)
+				return nil, err
+			}
+
+			if !found {
+				continue
+			}
+
+			wanted[cacheKey(db.ResourceCacheIdentifier{
+				ResourceVersion: atc.Version(latestVersion.VersionedResource.Version),
+				ResourceHash:    resource.GenerateResourceHash(pipelineResource.Source, pipelineResource.Type),
+			})] = true
+		}
+
+		for _, pipelineJob := range pipeline.Config.Jobs {
+			logger := c.logger.WithData(lager.Data{
+				"pipeline": pipeline.Name,
+				"job":      pipelineJob.Name,
+			})
+
+			finishedBuild, nextBuild, err := pipelineDB.GetJobFinishedAndNextBuild(pipelineJob.Name)
+			if err != nil {
+				logger.Error("failed-to-get-finished-and-next-builds", err)
+				return nil, err
+			}
+
+			for _, build := range []db.Build{finishedBuild, nextBuild} {
+				if build == nil {
+					continue
+				}
+
+				identifiers, err := build.GetImageResourceCacheIdentifiers()
+				if err != nil {
+					logger.Error("failed-to-get-image-resource-caches", err)
+					return nil, err
+				}
+
+				for _, identifier := range identifiers {
+					wanted[cacheKey(identifier)] = true
+				}
+			}
+		}
+	}
+
+	return wanted, nil
+}
+
+func cacheKey(identifier db.ResourceCacheIdentifier) string {
+	version, _ := json.Marshal(identifier.ResourceVersion)
+	return string(version) + identifier.ResourceHash
+}
@@ -0,0 +1,235 @@
+package ownergc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/gc/ownergc"
+	"github.com/concourse/atc/gc/ownergc/ownergcfakes"
+	"github.com/concourse/atc/resource"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OwnerGarbageCollector", func() {
+	var (
+		fakeDB                *ownergcfakes.FakeOwnerGarbageCollectorDB
+		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
+		fakePipelineDB        *dbfakes.FakePipelineDB
+
+		buildGracePeriod = 5 * time.Minute
+		cacheGracePeriod = 10 * time.Minute
+
+		collector ownergc.OwnerGarbageCollector
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(ownergcfakes.FakeOwnerGarbageCollectorDB)
+		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+		fakePipelineDB = new(dbfakes.FakePipelineDB)
+		fakePipelineDBFactory.BuildReturns(fakePipelineDB)
+
+		collector = ownergc.NewOwnerGarbageCollector(
+			lagertest.NewTestLogger("ownergc"),
+			fakeDB,
+			fakePipelineDBFactory,
+			buildGracePeriod,
+			cacheGracePeriod,
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = collector.Run()
+	})
+
+	It("reaps orphaned containers and volumes with the build grace period", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeDB.ReapOrphanedContainersCallCount()).To(Equal(1))
+		Expect(fakeDB.ReapOrphanedContainersArgsForCall(0)).To(Equal(buildGracePeriod))
+
+		Expect(fakeDB.ReapOrphanedVolumesCallCount()).To(Equal(1))
+		Expect(fakeDB.ReapOrphanedVolumesArgsForCall(0)).To(Equal(buildGracePeriod))
+	})
+
+	It("looks for unused resource caches with the cache grace period", func() {
+		Expect(fakeDB.GetUnusedResourceCachesCallCount()).To(Equal(1))
+		Expect(fakeDB.GetUnusedResourceCachesArgsForCall(0)).To(Equal(cacheGracePeriod))
+	})
+
+	Context("when there are no unused resource caches", func() {
+		It("does not look at the pipelines", func() {
+			Expect(fakeDB.GetAllPipelinesCallCount()).To(BeZero())
+			Expect(fakeDB.DeleteResourceCacheCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when there are unused resource caches", func() {
+		var (
+			source     = atc.Source{"some": "source"}
+			latestHash = resource.GenerateResourceHash(source, "some-type")
+		)
+
+		BeforeEach(func() {
+			fakeDB.GetUnusedResourceCachesReturns([]db.SavedResourceCache{
+				{
+					ID: 1,
+					ResourceCacheIdentifier: db.ResourceCacheIdentifier{
+						ResourceHash:    latestHash,
+						ResourceVersion: atc.Version{"version": "latest"},
+					},
+				},
+				{
+					ID: 2,
+					ResourceCacheIdentifier: db.ResourceCacheIdentifier{
+						ResourceHash:    latestHash,
+						ResourceVersion: atc.Version{"version": "old"},
+					},
+				},
+				{
+					ID: 3,
+					ResourceCacheIdentifier: db.ResourceCacheIdentifier{
+						ResourceHash:    "image-hash",
+						ResourceVersion: atc.Version{"version": "image"},
+					},
+				},
+			}, nil)
+
+			fakeDB.GetAllPipelinesReturns([]db.SavedPipeline{
+				{
+					Pipeline: db.Pipeline{
+						Name: "some-pipeline",
+						Config: atc.Config{
+							Resources: []atc.ResourceConfig{
+								{Name: "some-resource", Type: "some-type", Source: source},
+							},
+							Jobs: []atc.JobConfig{
+								{Name: "some-job"},
+							},
+						},
+					},
+				},
+			}, nil)
+
+			fakePipelineDB.GetLatestEnabledVersionedResourceReturns(db.SavedVersionedResource{
+				VersionedResource: db.VersionedResource{
+					Version: db.Version{"version": "latest"},
+				},
+			}, true, nil)
+
+			finishedBuild := new(dbfakes.FakeBuild)
+			finishedBuild.GetImageResourceCacheIdentifiersReturns([]db.ResourceCacheIdentifier{
+				{
+					ResourceHash:    "image-hash",
+					ResourceVersion: atc.Version{"version": "image"},
+				},
+			}, nil)
+
+			fakePipelineDB.GetJobFinishedAndNextBuildReturns(finishedBuild, nil, nil)
+		})
+
+		It("deletes only the caches that are no longer wanted", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(fakeDB.DeleteResourceCacheCallCount()).To(Equal(1))
+			Expect(fakeDB.DeleteResourceCacheArgsForCall(0)).To(Equal(2))
+		})
+
+		It("reaps orphaned containers and volumes after deleting the caches", func() {
+			Expect(fakeDB.ReapOrphanedVolumesCallCount()).To(Equal(1))
+		})
+
+		Context("when the pipeline is archived", func() {
+			BeforeEach(func() {
+				fakeDB.GetAllPipelinesReturns([]db.SavedPipeline{
+					{
+						Archived: true,
+						Pipeline: db.Pipeline{
+							Name: "some-pipeline",
+							Config: atc.Config{
+								Resources: []atc.ResourceConfig{
+									{Name: "some-resource", Type: "some-type", Source: source},
+								},
+								Jobs: []atc.JobConfig{
+									{Name: "some-job"},
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("deletes the caches that only it wanted", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+
+				Expect(fakeDB.DeleteResourceCacheCallCount()).To(Equal(3))
+				Expect(fakeDB.DeleteResourceCacheArgsForCall(0)).To(Equal(1))
+				Expect(fakeDB.DeleteResourceCacheArgsForCall(1)).To(Equal(2))
+				Expect(fakeDB.DeleteResourceCacheArgsForCall(2)).To(Equal(3))
+			})
+
+			It("does not look at its resources or jobs", func() {
+				Expect(fakePipelineDB.GetLatestEnabledVersionedResourceCallCount()).To(BeZero())
+				Expect(fakePipelineDB.GetJobFinishedAndNextBuildCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when deleting a cache fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.DeleteResourceCacheReturns(disaster)
+			})
+
+			It("returns the error without reaping", func() {
+				Expect(runErr).To(Equal(disaster))
+				Expect(fakeDB.ReapOrphanedContainersCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the pipelines fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDB.GetAllPipelinesReturns(nil, disaster)
+			})
+
+			It("returns the error without deleting anything", func() {
+				Expect(runErr).To(Equal(disaster))
+				Expect(fakeDB.DeleteResourceCacheCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when reaping orphaned containers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDB.ReapOrphanedContainersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+			Expect(fakeDB.ReapOrphanedVolumesCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when reaping orphaned volumes fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDB.ReapOrphanedVolumesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package ownergc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOwnergc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Owner GC Suite")
+}
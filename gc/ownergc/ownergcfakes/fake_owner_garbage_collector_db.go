@@ -0,0 +1,248 @@
+// This file was generated by counterfeiter
+package ownergcfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/gc/ownergc"
+)
+
+type FakeOwnerGarbageCollectorDB struct {
+	GetAllPipelinesStub        func() ([]db.SavedPipeline, error)
+	getAllPipelinesMutex       sync.RWMutex
+	getAllPipelinesArgsForCall []struct{}
+	getAllPipelinesReturns     struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
+	GetUnusedResourceCachesStub        func(gracePeriod time.Duration) ([]db.SavedResourceCache, error)
+	getUnusedResourceCachesMutex       sync.RWMutex
+	getUnusedResourceCachesArgsForCall []struct {
+		gracePeriod time.Duration
+	}
+	getUnusedResourceCachesReturns struct {
+		result1 []db.SavedResourceCache
+		result2 error
+	}
+	DeleteResourceCacheStub        func(id int) error
+	deleteResourceCacheMutex       sync.RWMutex
+	deleteResourceCacheArgsForCall []struct {
+		id int
+	}
+	deleteResourceCacheReturns struct {
+		result1 error
+	}
+	ReapOrphanedContainersStub        func(buildGracePeriod time.Duration) (map[db.OwnerType]int, error)
+	reapOrphanedContainersMutex       sync.RWMutex
+	reapOrphanedContainersArgsForCall []struct {
+		buildGracePeriod time.Duration
+	}
+	reapOrphanedContainersReturns struct {
+		result1 map[db.OwnerType]int
+		result2 error
+	}
+	ReapOrphanedVolumesStub        func(buildGracePeriod time.Duration) (map[db.OwnerType]int, error)
+	reapOrphanedVolumesMutex       sync.RWMutex
+	reapOrphanedVolumesArgsForCall []struct {
+		buildGracePeriod time.Duration
+	}
+	reapOrphanedVolumesReturns struct {
+		result1 map[db.OwnerType]int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetAllPipelines() ([]db.SavedPipeline, error) {
+	fake.getAllPipelinesMutex.Lock()
+	fake.getAllPipelinesArgsForCall = append(fake.getAllPipelinesArgsForCall, struct{}{})
+	fake.recordInvocation("GetAllPipelines", []interface{}{})
+	fake.getAllPipelinesMutex.Unlock()
+	if fake.GetAllPipelinesStub != nil {
+		return fake.GetAllPipelinesStub()
+	} else {
+		return fake.getAllPipelinesReturns.result1, fake.getAllPipelinesReturns.result2
+	}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetAllPipelinesCallCount() int {
+	fake.getAllPipelinesMutex.RLock()
+	defer fake.getAllPipelinesMutex.RUnlock()
+	return len(fake.getAllPipelinesArgsForCall)
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetAllPipelinesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetAllPipelinesStub = nil
+	fake.getAllPipelinesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetUnusedResourceCaches(gracePeriod time.Duration) ([]db.SavedResourceCache, error) {
+	fake.getUnusedResourceCachesMutex.Lock()
+	fake.getUnusedResourceCachesArgsForCall = append(fake.getUnusedResourceCachesArgsForCall, struct {
+		gracePeriod time.Duration
+	}{gracePeriod})
+	fake.recordInvocation("GetUnusedResourceCaches", []interface{}{gracePeriod})
+	fake.getUnusedResourceCachesMutex.Unlock()
+	if fake.GetUnusedResourceCachesStub != nil {
+		return fake.GetUnusedResourceCachesStub(gracePeriod)
+	} else {
+		return fake.getUnusedResourceCachesReturns.result1, fake.getUnusedResourceCachesReturns.result2
+	}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetUnusedResourceCachesCallCount() int {
+	fake.getUnusedResourceCachesMutex.RLock()
+	defer fake.getUnusedResourceCachesMutex.RUnlock()
+	return len(fake.getUnusedResourceCachesArgsForCall)
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetUnusedResourceCachesArgsForCall(i int) time.Duration {
+	fake.getUnusedResourceCachesMutex.RLock()
+	defer fake.getUnusedResourceCachesMutex.RUnlock()
+	return fake.getUnusedResourceCachesArgsForCall[i].gracePeriod
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) GetUnusedResourceCachesReturns(result1 []db.SavedResourceCache, result2 error) {
+	fake.GetUnusedResourceCachesStub = nil
+	fake.getUnusedResourceCachesReturns = struct {
+		result1 []db.SavedResourceCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) DeleteResourceCache(id int) error {
+	fake.deleteResourceCacheMutex.Lock()
+	fake.deleteResourceCacheArgsForCall = append(fake.deleteResourceCacheArgsForCall, struct {
+		id int
+	}{id})
+	fake.recordInvocation("DeleteResourceCache", []interface{}{id})
+	fake.deleteResourceCacheMutex.Unlock()
+	if fake.DeleteResourceCacheStub != nil {
+		return fake.DeleteResourceCacheStub(id)
+	} else {
+		return fake.deleteResourceCacheReturns.result1
+	}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) DeleteResourceCacheCallCount() int {
+	fake.deleteResourceCacheMutex.RLock()
+	defer fake.deleteResourceCacheMutex.RUnlock()
+	return len(fake.deleteResourceCacheArgsForCall)
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) DeleteResourceCacheArgsForCall(i int) int {
+	fake.deleteResourceCacheMutex.RLock()
+	defer fake.deleteResourceCacheMutex.RUnlock()
+	return fake.deleteResourceCacheArgsForCall[i].id
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) DeleteResourceCacheReturns(result1 error) {
+	fake.DeleteResourceCacheStub = nil
+	fake.deleteResourceCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedContainers(buildGracePeriod time.Duration) (map[db.OwnerType]int, error) {
+	fake.reapOrphanedContainersMutex.Lock()
+	fake.reapOrphanedContainersArgsForCall = append(fake.reapOrphanedContainersArgsForCall, struct {
+		buildGracePeriod time.Duration
+	}{buildGracePeriod})
+	fake.recordInvocation("ReapOrphanedContainers", []interface{}{buildGracePeriod})
+	fake.reapOrphanedContainersMutex.Unlock()
+	if fake.ReapOrphanedContainersStub != nil {
+		return fake.ReapOrphanedContainersStub(buildGracePeriod)
+	} else {
+		return fake.reapOrphanedContainersReturns.result1, fake.reapOrphanedContainersReturns.result2
+	}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedContainersCallCount() int {
+	fake.reapOrphanedContainersMutex.RLock()
+	defer fake.reapOrphanedContainersMutex.RUnlock()
+	return len(fake.reapOrphanedContainersArgsForCall)
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedContainersArgsForCall(i int) time.Duration {
+	fake.reapOrphanedContainersMutex.RLock()
+	defer fake.reapOrphanedContainersMutex.RUnlock()
+	return fake.reapOrphanedContainersArgsForCall[i].buildGracePeriod
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedContainersReturns(result1 map[db.OwnerType]int, result2 error) {
+	fake.ReapOrphanedContainersStub = nil
+	fake.reapOrphanedContainersReturns = struct {
+		result1 map[db.OwnerType]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedVolumes(buildGracePeriod time.Duration) (map[db.OwnerType]int, error) {
+	fake.reapOrphanedVolumesMutex.Lock()
+	fake.reapOrphanedVolumesArgsForCall = append(fake.reapOrphanedVolumesArgsForCall, struct {
+		buildGracePeriod time.Duration
+	}{buildGracePeriod})
+	fake.recordInvocation("ReapOrphanedVolumes", []interface{}{buildGracePeriod})
+	fake.reapOrphanedVolumesMutex.Unlock()
+	if fake.ReapOrphanedVolumesStub != nil {
+		return fake.ReapOrphanedVolumesStub(buildGracePeriod)
+	} else {
+		return fake.reapOrphanedVolumesReturns.result1, fake.reapOrphanedVolumesReturns.result2
+	}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedVolumesCallCount() int {
+	fake.reapOrphanedVolumesMutex.RLock()
+	defer fake.reapOrphanedVolumesMutex.RUnlock()
+	return len(fake.reapOrphanedVolumesArgsForCall)
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedVolumesArgsForCall(i int) time.Duration {
+	fake.reapOrphanedVolumesMutex.RLock()
+	defer fake.reapOrphanedVolumesMutex.RUnlock()
+	return fake.reapOrphanedVolumesArgsForCall[i].buildGracePeriod
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) ReapOrphanedVolumesReturns(result1 map[db.OwnerType]int, result2 error) {
+	fake.ReapOrphanedVolumesStub = nil
+	fake.reapOrphanedVolumesReturns = struct {
+		result1 map[db.OwnerType]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAllPipelinesMutex.RLock()
+	defer fake.getAllPipelinesMutex.RUnlock()
+	fake.getUnusedResourceCachesMutex.RLock()
+	defer fake.getUnusedResourceCachesMutex.RUnlock()
+	fake.deleteResourceCacheMutex.RLock()
+	defer fake.deleteResourceCacheMutex.RUnlock()
+	fake.reapOrphanedContainersMutex.RLock()
+	defer fake.reapOrphanedContainersMutex.RUnlock()
+	fake.reapOrphanedVolumesMutex.RLock()
+	defer fake.reapOrphanedVolumesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeOwnerGarbageCollectorDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ownergc.OwnerGarbageCollectorDB = new(FakeOwnerGarbageCollectorDB)
@@ -0,0 +1,168 @@
+// This file was generated by counterfeiter
+package ownergcfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/gc/ownergc"
+)
+
+type FakeWorkerReconcilerDB struct {
+	GetContainerHandlesForWorkerStub        func(workerName string) ([]string, error)
+	getContainerHandlesForWorkerMutex       sync.RWMutex
+	getContainerHandlesForWorkerArgsForCall []struct {
+		workerName string
+	}
+	getContainerHandlesForWorkerReturns struct {
+		result1 []string
+		result2 error
+	}
+	GetVolumeHandlesForWorkerStub        func(workerName string) ([]string, error)
+	getVolumeHandlesForWorkerMutex       sync.RWMutex
+	getVolumeHandlesForWorkerArgsForCall []struct {
+		workerName string
+	}
+	getVolumeHandlesForWorkerReturns struct {
+		result1 []string
+		result2 error
+	}
+	SetVolumeSizeInBytesStub        func(handle string, sizeInBytes int64) error
+	setVolumeSizeInBytesMutex       sync.RWMutex
+	setVolumeSizeInBytesArgsForCall []struct {
+		handle      string
+		sizeInBytes int64
+	}
+	setVolumeSizeInBytesReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerReconcilerDB) GetContainerHandlesForWorker(workerName string) ([]string, error) {
+	fake.getContainerHandlesForWorkerMutex.Lock()
+	fake.getContainerHandlesForWorkerArgsForCall = append(fake.getContainerHandlesForWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("GetContainerHandlesForWorker", []interface{}{workerName})
+	fake.getContainerHandlesForWorkerMutex.Unlock()
+	if fake.GetContainerHandlesForWorkerStub != nil {
+		return fake.GetContainerHandlesForWorkerStub(workerName)
+	} else {
+		return fake.getContainerHandlesForWorkerReturns.result1, fake.getContainerHandlesForWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerReconcilerDB) GetContainerHandlesForWorkerCallCount() int {
+	fake.getContainerHandlesForWorkerMutex.RLock()
+	defer fake.getContainerHandlesForWorkerMutex.RUnlock()
+	return len(fake.getContainerHandlesForWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerReconcilerDB) GetContainerHandlesForWorkerArgsForCall(i int) string {
+	fake.getContainerHandlesForWorkerMutex.RLock()
+	defer fake.getContainerHandlesForWorkerMutex.RUnlock()
+	return fake.getContainerHandlesForWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerReconcilerDB) GetContainerHandlesForWorkerReturns(result1 []string, result2 error) {
+	fake.GetContainerHandlesForWorkerStub = nil
+	fake.getContainerHandlesForWorkerReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerReconcilerDB) GetVolumeHandlesForWorker(workerName string) ([]string, error) {
+	fake.getVolumeHandlesForWorkerMutex.Lock()
+	fake.getVolumeHandlesForWorkerArgsForCall = append(fake.getVolumeHandlesForWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("GetVolumeHandlesForWorker", []interface{}{workerName})
+	fake.getVolumeHandlesForWorkerMutex.Unlock()
+	if fake.GetVolumeHandlesForWorkerStub != nil {
+		return fake.GetVolumeHandlesForWorkerStub(workerName)
+	} else {
+		return fake.getVolumeHandlesForWorkerReturns.result1, fake.getVolumeHandlesForWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerReconcilerDB) GetVolumeHandlesForWorkerCallCount() int {
+	fake.getVolumeHandlesForWorkerMutex.RLock()
+	defer fake.getVolumeHandlesForWorkerMutex.RUnlock()
+	return len(fake.getVolumeHandlesForWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerReconcilerDB) GetVolumeHandlesForWorkerArgsForCall(i int) string {
+	fake.getVolumeHandlesForWorkerMutex.RLock()
+	defer fake.getVolumeHandlesForWorkerMutex.RUnlock()
+	return fake.getVolumeHandlesForWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerReconcilerDB) GetVolumeHandlesForWorkerReturns(result1 []string, result2 error) {
+	fake.GetVolumeHandlesForWorkerStub = nil
+	fake.getVolumeHandlesForWorkerReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerReconcilerDB) SetVolumeSizeInBytes(handle string, sizeInBytes int64) error {
+	fake.setVolumeSizeInBytesMutex.Lock()
+	fake.setVolumeSizeInBytesArgsForCall = append(fake.setVolumeSizeInBytesArgsForCall, struct {
+		handle      string
+		sizeInBytes int64
+	}{handle, sizeInBytes})
+	fake.recordInvocation("SetVolumeSizeInBytes", []interface{}{handle, sizeInBytes})
+	fake.setVolumeSizeInBytesMutex.Unlock()
+	if fake.SetVolumeSizeInBytesStub != nil {
+		return fake.SetVolumeSizeInBytesStub(handle, sizeInBytes)
+	} else {
+		return fake.setVolumeSizeInBytesReturns.result1
+	}
+}
+
+func (fake *FakeWorkerReconcilerDB) SetVolumeSizeInBytesCallCount() int {
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	return len(fake.setVolumeSizeInBytesArgsForCall)
+}
+
+func (fake *FakeWorkerReconcilerDB) SetVolumeSizeInBytesArgsForCall(i int) (string, int64) {
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	return fake.setVolumeSizeInBytesArgsForCall[i].handle, fake.setVolumeSizeInBytesArgsForCall[i].sizeInBytes
+}
+
+func (fake *FakeWorkerReconcilerDB) SetVolumeSizeInBytesReturns(result1 error) {
+	fake.SetVolumeSizeInBytesStub = nil
+	fake.setVolumeSizeInBytesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerReconcilerDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getContainerHandlesForWorkerMutex.RLock()
+	defer fake.getContainerHandlesForWorkerMutex.RUnlock()
+	fake.getVolumeHandlesForWorkerMutex.RLock()
+	defer fake.getVolumeHandlesForWorkerMutex.RUnlock()
+	fake.setVolumeSizeInBytesMutex.RLock()
+	defer fake.setVolumeSizeInBytesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeWorkerReconcilerDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ownergc.WorkerReconcilerDB = new(FakeWorkerReconcilerDB)
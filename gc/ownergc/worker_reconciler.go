@@ -0,0 +1,196 @@
+package ownergc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/worker"
+)
+
+//go:generate counterfeiter . WorkerReconcilerDB
+
+type WorkerReconcilerDB interface {
+	GetContainerHandlesForWorker(workerName string) ([]string, error)
+	GetVolumeHandlesForWorker(workerName string) ([]string, error)
+	SetVolumeSizeInBytes(handle string, sizeInBytes int64) error
+}
+
+// WorkerReconciler destroys the containers and volumes on each worker that
+// the database no longer knows about, and records the sizes of the volumes
+// that it does, which count towards their team's quota.
+//
+// Containers are created on the worker before they are saved to the
+// database, so a handle is only destroyed once it has been unknown for two
+// consecutive runs.
+type WorkerReconciler interface {
+	Run() error
+}
+
+type workerReconciler struct {
+	logger       lager.Logger
+	workerClient worker.Client
+	db           WorkerReconcilerDB
+
+	unknownContainers map[string]map[string]bool
+	unknownVolumes    map[string]map[string]bool
+}
+
+func NewWorkerReconciler(
+	logger lager.Logger,
+	workerClient worker.Client,
+	db WorkerReconcilerDB,
+) WorkerReconciler {
+	return &workerReconciler{
+		logger:       logger,
+		workerClient: workerClient,
+		db:           db,
+
+		unknownContainers: map[string]map[string]bool{},
+		unknownVolumes:    map[string]map[string]bool{},
+	}
+}
+
+func (r *workerReconciler) Run() error {
+	workers, err := r.workerClient.Workers()
+	if err != nil {
+		r.logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	for _, w := range workers {
+		logger := r.logger.WithData(lager.Data{"worker": w.Name()})
+
+		// a worker that can't be reached shouldn't hold up the others; the
+		// errors are logged and the worker is retried on the next run
+		_ = r.reconcileContainers(logger, w)
+		_ = r.reconcileVolumes(logger, w)
+	}
+
+	return nil
+}
+
+func (r *workerReconciler) reconcileContainers(logger lager.Logger, w worker.Worker) error {
+	handles, err := w.ContainerHandles(logger)
+	if err != nil {
+		logger.Error("failed-to-list-containers", err)
+		return err
+	}
+
+	knownHandles, err := r.db.GetContainerHandlesForWorker(w.Name())
+	if err != nil {
+		logger.Error("failed-to-get-known-containers", err)
+		return err
+	}
+
+	previouslyUnknown := r.unknownContainers[w.Name()]
+	unknown := unknownHandles(handles, knownHandles)
+
+	destroyed := 0
+	for handle := range unknown {
+		if !previouslyUnknown[handle] {
+			continue
+		}
+
+		err := w.DestroyContainer(logger, handle)
+		if err != nil {
+			logger.Error("failed-to-destroy-container", err, lager.Data{"handle": handle})
+			continue
+		}
+
+		delete(unknown, handle)
+		destroyed++
+	}
+
+	r.unknownContainers[w.Name()] = unknown
+
+	if destroyed > 0 {
+		metric.ContainersDestroyed{
+			Containers: destroyed,
+		}.Emit(logger)
+	}
+
+	return nil
+}
+
+func (r *workerReconciler) reconcileVolumes(logger lager.Logger, w worker.Worker) error {
+	handles, err := w.VolumeHandles(logger)
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return err
+	}
+
+	knownHandles, err := r.db.GetVolumeHandlesForWorker(w.Name())
+	if err != nil {
+		logger.Error("failed-to-get-known-volumes", err)
+		return err
+	}
+
+	previouslyUnknown := r.unknownVolumes[w.Name()]
+	unknown := unknownHandles(handles, knownHandles)
+
+	expired := 0
+	for handle := range unknown {
+		if !previouslyUnknown[handle] {
+			continue
+		}
+
+		err := w.ExpireVolume(logger, handle)
+		if err != nil {
+			logger.Error("failed-to-expire-volume", err, lager.Data{"handle": handle})
+			continue
+		}
+
+		delete(unknown, handle)
+		expired++
+	}
+
+	r.unknownVolumes[w.Name()] = unknown
+
+	if expired > 0 {
+		metric.VolumesDestroyed{
+			Volumes: expired,
+		}.Emit(logger)
+	}
+
+	for _, handle := range handles {
+		if unknown[handle] {
+			continue
+		}
+
+		r.recordVolumeSize(logger, w, handle)
+	}
+
+	return nil
+}
+
+func (r *workerReconciler) recordVolumeSize(logger lager.Logger, w worker.Worker, handle string) {
+	size, found, err := w.VolumeSizeInBytes(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-get-volume-size", err, lager.Data{"handle": handle})
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	err = r.db.SetVolumeSizeInBytes(handle, size)
+	if err != nil {
+		logger.Error("failed-to-set-volume-size", err, lager.Data{"handle": handle})
+	}
+}
+
+func unknownHandles(handles []string, knownHandles []string) map[string]bool {
+	known := map[string]bool{}
+	for _, handle := range knownHandles {
+		known[handle] = true
+	}
+
+	unknown := map[string]bool{}
+	for _, handle := range handles {
+		if !known[handle] {
+			unknown[handle] = true
+		}
+	}
+
+	return unknown
+}
@@ -0,0 +1,155 @@
+package ownergc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/gc/ownergc"
+	"github.com/concourse/atc/gc/ownergc/ownergcfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerReconciler", func() {
+	var (
+		fakeWorkerClient *workerfakes.FakeClient
+		fakeWorker       *workerfakes.FakeWorker
+		fakeDB           *ownergcfakes.FakeWorkerReconcilerDB
+
+		reconciler ownergc.WorkerReconciler
+	)
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(workerfakes.FakeClient)
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorkerClient.WorkersReturns([]worker.Worker{fakeWorker}, nil)
+
+		fakeDB = new(ownergcfakes.FakeWorkerReconcilerDB)
+
+		fakeWorker.ContainerHandlesReturns([]string{"known-container", "unknown-container"}, nil)
+		fakeDB.GetContainerHandlesForWorkerReturns([]string{"known-container"}, nil)
+
+		fakeWorker.VolumeHandlesReturns([]string{"known-volume", "unknown-volume"}, nil)
+		fakeDB.GetVolumeHandlesForWorkerReturns([]string{"known-volume"}, nil)
+
+		reconciler = ownergc.NewWorkerReconciler(
+			lagertest.NewTestLogger("reconciler"),
+			fakeWorkerClient,
+			fakeDB,
+		)
+	})
+
+	It("looks up the handles known for each worker", func() {
+		Expect(reconciler.Run()).To(Succeed())
+
+		Expect(fakeDB.GetContainerHandlesForWorkerArgsForCall(0)).To(Equal("some-worker"))
+		Expect(fakeDB.GetVolumeHandlesForWorkerArgsForCall(0)).To(Equal("some-worker"))
+	})
+
+	It("leaves unknown handles alone on the first run", func() {
+		Expect(reconciler.Run()).To(Succeed())
+
+		Expect(fakeWorker.DestroyContainerCallCount()).To(BeZero())
+		Expect(fakeWorker.ExpireVolumeCallCount()).To(BeZero())
+	})
+
+	It("records the sizes of the known volumes", func() {
+		fakeWorker.VolumeSizeInBytesReturns(1024, true, nil)
+
+		Expect(reconciler.Run()).To(Succeed())
+
+		Expect(fakeWorker.VolumeSizeInBytesCallCount()).To(Equal(1))
+		_, handle := fakeWorker.VolumeSizeInBytesArgsForCall(0)
+		Expect(handle).To(Equal("known-volume"))
+
+		Expect(fakeDB.SetVolumeSizeInBytesCallCount()).To(Equal(1))
+		handle, size := fakeDB.SetVolumeSizeInBytesArgsForCall(0)
+		Expect(handle).To(Equal("known-volume"))
+		Expect(size).To(Equal(int64(1024)))
+	})
+
+	Context("when a known volume has gone from the worker", func() {
+		BeforeEach(func() {
+			fakeWorker.VolumeSizeInBytesReturns(0, false, nil)
+		})
+
+		It("does not record its size", func() {
+			Expect(reconciler.Run()).To(Succeed())
+			Expect(fakeDB.SetVolumeSizeInBytesCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a handle is still unknown on the next run", func() {
+		BeforeEach(func() {
+			Expect(reconciler.Run()).To(Succeed())
+		})
+
+		It("destroys the container and expires the volume", func() {
+			Expect(reconciler.Run()).To(Succeed())
+
+			Expect(fakeWorker.DestroyContainerCallCount()).To(Equal(1))
+			_, handle := fakeWorker.DestroyContainerArgsForCall(0)
+			Expect(handle).To(Equal("unknown-container"))
+
+			Expect(fakeWorker.ExpireVolumeCallCount()).To(Equal(1))
+			_, handle = fakeWorker.ExpireVolumeArgsForCall(0)
+			Expect(handle).To(Equal("unknown-volume"))
+		})
+
+		Context("when the handles have become known in the meantime", func() {
+			BeforeEach(func() {
+				fakeDB.GetContainerHandlesForWorkerReturns([]string{"known-container", "unknown-container"}, nil)
+				fakeDB.GetVolumeHandlesForWorkerReturns([]string{"known-volume", "unknown-volume"}, nil)
+			})
+
+			It("leaves them alone", func() {
+				Expect(reconciler.Run()).To(Succeed())
+
+				Expect(fakeWorker.DestroyContainerCallCount()).To(BeZero())
+				Expect(fakeWorker.ExpireVolumeCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when destroying the container fails", func() {
+			BeforeEach(func() {
+				fakeWorker.DestroyContainerReturns(errors.New("nope"))
+			})
+
+			It("tries again on the following run", func() {
+				Expect(reconciler.Run()).To(Succeed())
+				Expect(reconciler.Run()).To(Succeed())
+
+				Expect(fakeWorker.DestroyContainerCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when listing a worker's containers fails", func() {
+		BeforeEach(func() {
+			fakeWorker.ContainerHandlesReturns(nil, errors.New("nope"))
+		})
+
+		It("still reconciles its volumes", func() {
+			Expect(reconciler.Run()).To(Succeed())
+			Expect(reconciler.Run()).To(Succeed())
+
+			Expect(fakeWorker.ExpireVolumeCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the workers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerClient.WorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(reconciler.Run()).To(Equal(disaster))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package atc
+
+type HijackSession struct {
+	ID              int               `json:"id"`
+	TeamName        string            `json:"team_name"`
+	HijackedBy      string            `json:"hijacked_by"`
+	ContainerHandle string            `json:"container_handle"`
+	Process         HijackProcessSpec `json:"process"`
+	StartTime       int64             `json:"start_time"`
+	EndTime         int64             `json:"end_time,omitempty"`
+	ExitStatus      *int              `json:"exit_status,omitempty"`
+}
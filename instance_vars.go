@@ -0,0 +1,91 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// InstanceVarsQueryParam addresses an instance of a pipeline in API routes,
+// as a JSON object, e.g. ?instance_vars={"branch":"foo"}.
+const InstanceVarsQueryParam = "instance_vars"
+
+// InstanceVars identify one instance among the pipelines sharing a name, e.g.
+// one per branch. Each instance's config is the shared config with its vars
+// interpolated.
+type InstanceVars map[string]interface{}
+
+// ParseInstanceVars parses instance vars given as a JSON object. No vars, or
+// an empty object, address the pipeline that is not an instance.
+func ParseInstanceVars(payload string) (InstanceVars, error) {
+	if payload == "" {
+		return nil, nil
+	}
+
+	var vars InstanceVars
+	err := json.Unmarshal([]byte(payload), &vars)
+	if err != nil {
+		return nil, fmt.Errorf("malformed instance vars: %s", err)
+	}
+
+	if len(vars) == 0 {
+		return nil, nil
+	}
+
+	return vars, nil
+}
+
+var instanceVarRegexp = regexp.MustCompile(`\(\(([-\w]+)\)\)`)
+
+// Interpolate replaces ((var)) references in the strings of a decoded config
+// with the values of the vars. A string that is just a reference is replaced
+// with the value as-is, so that vars need not be strings. References to other
+// vars are left alone.
+func (vars InstanceVars) Interpolate(structure interface{}) interface{} {
+	switch value := structure.(type) {
+	case string:
+		if match := instanceVarRegexp.FindStringSubmatch(value); match != nil && match[0] == value {
+			if v, found := vars[match[1]]; found {
+				return v
+			}
+
+			return value
+		}
+
+		return instanceVarRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+			v, found := vars[instanceVarRegexp.FindStringSubmatch(reference)[1]]
+			if !found {
+				return reference
+			}
+
+			return fmt.Sprintf("%v", v)
+		})
+
+	case map[string]interface{}:
+		interpolated := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			interpolated[k] = vars.Interpolate(v)
+		}
+
+		return interpolated
+
+	case map[interface{}]interface{}:
+		interpolated := make(map[interface{}]interface{}, len(value))
+		for k, v := range value {
+			interpolated[k] = vars.Interpolate(v)
+		}
+
+		return interpolated
+
+	case []interface{}:
+		interpolated := make([]interface{}, len(value))
+		for i, v := range value {
+			interpolated[i] = vars.Interpolate(v)
+		}
+
+		return interpolated
+
+	default:
+		return structure
+	}
+}
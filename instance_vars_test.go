@@ -0,0 +1,74 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InstanceVars", func() {
+	Describe("ParseInstanceVars", func() {
+		It("parses a JSON object", func() {
+			vars, err := atc.ParseInstanceVars(`{"branch":"foo","shard":1}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(Equal(atc.InstanceVars{"branch": "foo", "shard": float64(1)}))
+		})
+
+		It("treats no vars as no instance", func() {
+			vars, err := atc.ParseInstanceVars("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(BeNil())
+
+			vars, err = atc.ParseInstanceVars("{}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vars).To(BeNil())
+		})
+
+		It("rejects anything but an object", func() {
+			_, err := atc.ParseInstanceVars(`["foo"]`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Interpolate", func() {
+		vars := atc.InstanceVars{
+			"branch":  "foo",
+			"tags":    []interface{}{"a", "b"},
+			"retries": float64(3),
+		}
+
+		It("replaces references throughout the config", func() {
+			Expect(vars.Interpolate(map[string]interface{}{
+				"resources": []interface{}{
+					map[interface{}]interface{}{
+						"name": "repo-((branch))",
+						"source": map[string]interface{}{
+							"branch": "((branch))",
+							"tags":   "((tags))",
+						},
+					},
+				},
+				"attempts": "((retries))",
+				"note":     "try ((retries)) times",
+			})).To(Equal(map[string]interface{}{
+				"resources": []interface{}{
+					map[interface{}]interface{}{
+						"name": "repo-foo",
+						"source": map[string]interface{}{
+							"branch": "foo",
+							"tags":   []interface{}{"a", "b"},
+						},
+					},
+				},
+				"attempts": float64(3),
+				"note":     "try 3 times",
+			}))
+		})
+
+		It("leaves references to other vars alone", func() {
+			Expect(vars.Interpolate("((password))")).To(Equal("((password))"))
+			Expect(vars.Interpolate("((branch))-((password))")).To(Equal("foo-((password))"))
+		})
+	})
+})
@@ -8,6 +8,7 @@ type Job struct {
 	DisableManualTrigger bool   `json:"disable_manual_trigger,omitempty"`
 	NextBuild            *Build `json:"next_build"`
 	FinishedBuild        *Build `json:"finished_build"`
+	NextScheduledRun     int64  `json:"next_scheduled_run,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs"`
 	Outputs []JobOutput `json:"outputs"`
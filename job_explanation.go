@@ -0,0 +1,29 @@
+package atc
+
+type JobExplanation struct {
+	Inputs          []InputExplanation `json:"inputs"`
+	InputsSatisfied bool               `json:"inputs_satisfied"`
+	Holds           []JobHold          `json:"holds"`
+}
+
+type InputExplanation struct {
+	Name          string                 `json:"name"`
+	Resource      string                 `json:"resource"`
+	Passed        []string               `json:"passed,omitempty"`
+	Trigger       bool                   `json:"trigger"`
+	Every         bool                   `json:"every,omitempty"`
+	PinnedVersion Version                `json:"pinned_version,omitempty"`
+	Candidates    []CandidateExplanation `json:"candidates"`
+	Truncated     bool                   `json:"truncated"`
+}
+
+type CandidateExplanation struct {
+	Version   VersionedResource `json:"version"`
+	Status    string            `json:"status"`
+	PassedJob string            `json:"passed_job,omitempty"`
+}
+
+type JobHold struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
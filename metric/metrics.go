@@ -199,6 +199,62 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type ResourceCacheStreamed struct {
+	ResourceType      string
+	SourceWorker      string
+	DestinationWorker string
+	Bytes             int64
+	Duration          time.Duration
+}
+
+func (event ResourceCacheStreamed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("resource-cache-streamed", lager.Data{
+			"resource-type":      event.ResourceType,
+			"source-worker":      event.SourceWorker,
+			"destination-worker": event.DestinationWorker,
+			"bytes":              event.Bytes,
+			"duration":           event.Duration.String(),
+		}),
+		goryman.Event{
+			Service: "resource cache streamed (bytes)",
+			Metric:  event.Bytes,
+			State:   "ok",
+			Attributes: map[string]string{
+				"resource_type":      event.ResourceType,
+				"source_worker":      event.SourceWorker,
+				"destination_worker": event.DestinationWorker,
+				"duration_ms":        strconv.FormatFloat(ms(event.Duration), 'f', -1, 64),
+			},
+		},
+	)
+}
+
+type ResourceCacheFetched struct {
+	ResourceType string
+	WorkerName   string
+	Bytes        int64
+}
+
+func (event ResourceCacheFetched) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("resource-cache-fetched", lager.Data{
+			"resource-type": event.ResourceType,
+			"worker":        event.WorkerName,
+			"bytes":         event.Bytes,
+		}),
+		goryman.Event{
+			Service: "resource cache fetched (bytes)",
+			Metric:  event.Bytes,
+			State:   "ok",
+			Attributes: map[string]string{
+				"resource_type": event.ResourceType,
+				"worker":        event.WorkerName,
+			},
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
@@ -237,3 +293,103 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 		},
 	)
 }
+
+type ContainerCreated struct {
+	WorkerName string
+	OwnerType  db.OwnerType
+}
+
+func (event ContainerCreated) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("container-created", lager.Data{
+			"worker":     event.WorkerName,
+			"owner-type": ownerTypeAttribute(event.OwnerType),
+		}),
+		goryman.Event{
+			Service: "containers created",
+			Metric:  1,
+			State:   "ok",
+			Attributes: map[string]string{
+				"worker":     event.WorkerName,
+				"owner_type": ownerTypeAttribute(event.OwnerType),
+			},
+		},
+	)
+}
+
+type ContainersDestroyed struct {
+	OwnerType  db.OwnerType
+	Containers int
+}
+
+func (event ContainersDestroyed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("containers-destroyed", lager.Data{
+			"owner-type": ownerTypeAttribute(event.OwnerType),
+			"containers": event.Containers,
+		}),
+		goryman.Event{
+			Service: "containers destroyed",
+			Metric:  event.Containers,
+			State:   "ok",
+			Attributes: map[string]string{
+				"owner_type": ownerTypeAttribute(event.OwnerType),
+			},
+		},
+	)
+}
+
+type VolumeCreated struct {
+	WorkerName string
+	OwnerType  db.OwnerType
+}
+
+func (event VolumeCreated) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volume-created", lager.Data{
+			"worker":     event.WorkerName,
+			"owner-type": ownerTypeAttribute(event.OwnerType),
+		}),
+		goryman.Event{
+			Service: "volumes created",
+			Metric:  1,
+			State:   "ok",
+			Attributes: map[string]string{
+				"worker":     event.WorkerName,
+				"owner_type": ownerTypeAttribute(event.OwnerType),
+			},
+		},
+	)
+}
+
+type VolumesDestroyed struct {
+	OwnerType db.OwnerType
+	Volumes   int
+}
+
+func (event VolumesDestroyed) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volumes-destroyed", lager.Data{
+			"owner-type": ownerTypeAttribute(event.OwnerType),
+			"volumes":    event.Volumes,
+		}),
+		goryman.Event{
+			Service: "volumes destroyed",
+			Metric:  event.Volumes,
+			State:   "ok",
+			Attributes: map[string]string{
+				"owner_type": ownerTypeAttribute(event.OwnerType),
+			},
+		},
+	)
+}
+
+// containers and volumes that have no owner, or that are destroyed on a
+// worker because the database does not know about them
+func ownerTypeAttribute(ownerType db.OwnerType) string {
+	if ownerType == "" {
+		return "none"
+	}
+
+	return string(ownerType)
+}
@@ -1,10 +1,12 @@
 package atc
 
 type Pipeline struct {
-	Name     string       `json:"name"`
-	URL      string       `json:"url"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	URL          string       `json:"url"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Archived     bool         `json:"archived"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+	TeamName     string       `json:"team_name"`
 }
@@ -0,0 +1,63 @@
+package pipelines
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/ifrit"
+)
+
+//go:generate counterfeiter . ATCHeartbeatDB
+
+type ATCHeartbeatDB interface {
+	HeartbeatATC(id string, peerURL string, ttl time.Duration) error
+	DeleteATC(id string) error
+}
+
+// NewATCHeartbeater registers the ATC as a member of the cluster for as long
+// as it is running. Its pipelines are handed over to the other ATCs when it
+// stops, or when its heartbeat expires.
+func NewATCHeartbeater(
+	logger lager.Logger,
+	heartbeatDB ATCHeartbeatDB,
+	clock clock.Clock,
+	atcID string,
+	peerURL string,
+	interval time.Duration,
+	ttl time.Duration,
+) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		logger := logger.WithData(lager.Data{"atc": atcID})
+
+		err := heartbeatDB.HeartbeatATC(atcID, peerURL, ttl)
+		if err != nil {
+			logger.Error("failed-to-heartbeat", err)
+			return err
+		}
+
+		ticker := clock.NewTicker(interval)
+		defer ticker.Stop()
+
+		close(ready)
+
+		for {
+			select {
+			case <-ticker.C():
+				err := heartbeatDB.HeartbeatATC(atcID, peerURL, ttl)
+				if err != nil {
+					logger.Error("failed-to-heartbeat", err)
+				}
+
+			case <-signals:
+				err := heartbeatDB.DeleteATC(atcID)
+				if err != nil {
+					logger.Error("failed-to-leave-cluster", err)
+				}
+
+				return nil
+			}
+		}
+	})
+}
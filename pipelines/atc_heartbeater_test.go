@@ -0,0 +1,79 @@
+package pipelines_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	"github.com/concourse/atc/pipelines"
+	"github.com/concourse/atc/pipelines/pipelinesfakes"
+)
+
+var _ = Describe("ATCHeartbeater", func() {
+	var (
+		heartbeatDB *pipelinesfakes.FakeATCHeartbeatDB
+		fakeClock   *fakeclock.FakeClock
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		heartbeatDB = new(pipelinesfakes.FakeATCHeartbeatDB)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(pipelines.NewATCHeartbeater(
+			lagertest.NewTestLogger("test"),
+			heartbeatDB,
+			fakeClock,
+			"some-atc",
+			"http://some-peer",
+			10*time.Second,
+			30*time.Second,
+		))
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("heartbeats immediately and then on an interval", func() {
+		Expect(heartbeatDB.HeartbeatATCCallCount()).To(Equal(1))
+		id, peerURL, ttl := heartbeatDB.HeartbeatATCArgsForCall(0)
+		Expect(id).To(Equal("some-atc"))
+		Expect(peerURL).To(Equal("http://some-peer"))
+		Expect(ttl).To(Equal(30 * time.Second))
+
+		fakeClock.Increment(11 * time.Second)
+
+		Eventually(heartbeatDB.HeartbeatATCCallCount).Should(Equal(2))
+	})
+
+	It("leaves the cluster when it is stopped", func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+
+		Expect(heartbeatDB.DeleteATCCallCount()).To(Equal(1))
+		Expect(heartbeatDB.DeleteATCArgsForCall(0)).To(Equal("some-atc"))
+	})
+
+	Context("when the first heartbeat fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			heartbeatDB.HeartbeatATCReturns(disaster)
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+		})
+	})
+})
@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package pipelinesfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/pipelines"
+)
+
+type FakeATCHeartbeatDB struct {
+	HeartbeatATCStub        func(id string, peerURL string, ttl time.Duration) error
+	heartbeatATCMutex       sync.RWMutex
+	heartbeatATCArgsForCall []struct {
+		id      string
+		peerURL string
+		ttl     time.Duration
+	}
+	heartbeatATCReturns struct {
+		result1 error
+	}
+	DeleteATCStub        func(id string) error
+	deleteATCMutex       sync.RWMutex
+	deleteATCArgsForCall []struct {
+		id string
+	}
+	deleteATCReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeATCHeartbeatDB) HeartbeatATC(id string, peerURL string, ttl time.Duration) error {
+	fake.heartbeatATCMutex.Lock()
+	fake.heartbeatATCArgsForCall = append(fake.heartbeatATCArgsForCall, struct {
+		id      string
+		peerURL string
+		ttl     time.Duration
+	}{id, peerURL, ttl})
+	fake.recordInvocation("HeartbeatATC", []interface{}{id, peerURL, ttl})
+	fake.heartbeatATCMutex.Unlock()
+	if fake.HeartbeatATCStub != nil {
+		return fake.HeartbeatATCStub(id, peerURL, ttl)
+	} else {
+		return fake.heartbeatATCReturns.result1
+	}
+}
+
+func (fake *FakeATCHeartbeatDB) HeartbeatATCCallCount() int {
+	fake.heartbeatATCMutex.RLock()
+	defer fake.heartbeatATCMutex.RUnlock()
+	return len(fake.heartbeatATCArgsForCall)
+}
+
+func (fake *FakeATCHeartbeatDB) HeartbeatATCArgsForCall(i int) (string, string, time.Duration) {
+	fake.heartbeatATCMutex.RLock()
+	defer fake.heartbeatATCMutex.RUnlock()
+	return fake.heartbeatATCArgsForCall[i].id, fake.heartbeatATCArgsForCall[i].peerURL, fake.heartbeatATCArgsForCall[i].ttl
+}
+
+func (fake *FakeATCHeartbeatDB) HeartbeatATCReturns(result1 error) {
+	fake.HeartbeatATCStub = nil
+	fake.heartbeatATCReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeATCHeartbeatDB) DeleteATC(id string) error {
+	fake.deleteATCMutex.Lock()
+	fake.deleteATCArgsForCall = append(fake.deleteATCArgsForCall, struct {
+		id string
+	}{id})
+	fake.recordInvocation("DeleteATC", []interface{}{id})
+	fake.deleteATCMutex.Unlock()
+	if fake.DeleteATCStub != nil {
+		return fake.DeleteATCStub(id)
+	} else {
+		return fake.deleteATCReturns.result1
+	}
+}
+
+func (fake *FakeATCHeartbeatDB) DeleteATCCallCount() int {
+	fake.deleteATCMutex.RLock()
+	defer fake.deleteATCMutex.RUnlock()
+	return len(fake.deleteATCArgsForCall)
+}
+
+func (fake *FakeATCHeartbeatDB) DeleteATCArgsForCall(i int) string {
+	fake.deleteATCMutex.RLock()
+	defer fake.deleteATCMutex.RUnlock()
+	return fake.deleteATCArgsForCall[i].id
+}
+
+func (fake *FakeATCHeartbeatDB) DeleteATCReturns(result1 error) {
+	fake.DeleteATCStub = nil
+	fake.deleteATCReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeATCHeartbeatDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.heartbeatATCMutex.RLock()
+	defer fake.heartbeatATCMutex.RUnlock()
+	fake.deleteATCMutex.RLock()
+	defer fake.deleteATCMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeATCHeartbeatDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ pipelines.ATCHeartbeatDB = new(FakeATCHeartbeatDB)
@@ -0,0 +1,84 @@
+// This file was generated by counterfeiter
+package pipelinesfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/pipelines"
+)
+
+type FakePipelineSharder struct {
+	OwnedPipelinesStub        func([]db.SavedPipeline) ([]db.SavedPipeline, error)
+	ownedPipelinesMutex       sync.RWMutex
+	ownedPipelinesArgsForCall []struct {
+		arg1 []db.SavedPipeline
+	}
+	ownedPipelinesReturns struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePipelineSharder) OwnedPipelines(arg1 []db.SavedPipeline) ([]db.SavedPipeline, error) {
+	var arg1Copy []db.SavedPipeline
+	if arg1 != nil {
+		arg1Copy = make([]db.SavedPipeline, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.ownedPipelinesMutex.Lock()
+	fake.ownedPipelinesArgsForCall = append(fake.ownedPipelinesArgsForCall, struct {
+		arg1 []db.SavedPipeline
+	}{arg1Copy})
+	fake.recordInvocation("OwnedPipelines", []interface{}{arg1Copy})
+	fake.ownedPipelinesMutex.Unlock()
+	if fake.OwnedPipelinesStub != nil {
+		return fake.OwnedPipelinesStub(arg1)
+	} else {
+		return fake.ownedPipelinesReturns.result1, fake.ownedPipelinesReturns.result2
+	}
+}
+
+func (fake *FakePipelineSharder) OwnedPipelinesCallCount() int {
+	fake.ownedPipelinesMutex.RLock()
+	defer fake.ownedPipelinesMutex.RUnlock()
+	return len(fake.ownedPipelinesArgsForCall)
+}
+
+func (fake *FakePipelineSharder) OwnedPipelinesArgsForCall(i int) []db.SavedPipeline {
+	fake.ownedPipelinesMutex.RLock()
+	defer fake.ownedPipelinesMutex.RUnlock()
+	return fake.ownedPipelinesArgsForCall[i].arg1
+}
+
+func (fake *FakePipelineSharder) OwnedPipelinesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.OwnedPipelinesStub = nil
+	fake.ownedPipelinesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineSharder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ownedPipelinesMutex.RLock()
+	defer fake.ownedPipelinesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePipelineSharder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ pipelines.PipelineSharder = new(FakePipelineSharder)
@@ -0,0 +1,69 @@
+// This file was generated by counterfeiter
+package pipelinesfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/pipelines"
+)
+
+type FakeShardDB struct {
+	GetActiveATCsStub        func() ([]db.SavedATC, error)
+	getActiveATCsMutex       sync.RWMutex
+	getActiveATCsArgsForCall []struct{}
+	getActiveATCsReturns     struct {
+		result1 []db.SavedATC
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeShardDB) GetActiveATCs() ([]db.SavedATC, error) {
+	fake.getActiveATCsMutex.Lock()
+	fake.getActiveATCsArgsForCall = append(fake.getActiveATCsArgsForCall, struct{}{})
+	fake.recordInvocation("GetActiveATCs", []interface{}{})
+	fake.getActiveATCsMutex.Unlock()
+	if fake.GetActiveATCsStub != nil {
+		return fake.GetActiveATCsStub()
+	} else {
+		return fake.getActiveATCsReturns.result1, fake.getActiveATCsReturns.result2
+	}
+}
+
+func (fake *FakeShardDB) GetActiveATCsCallCount() int {
+	fake.getActiveATCsMutex.RLock()
+	defer fake.getActiveATCsMutex.RUnlock()
+	return len(fake.getActiveATCsArgsForCall)
+}
+
+func (fake *FakeShardDB) GetActiveATCsReturns(result1 []db.SavedATC, result2 error) {
+	fake.GetActiveATCsStub = nil
+	fake.getActiveATCsReturns = struct {
+		result1 []db.SavedATC
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeShardDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getActiveATCsMutex.RLock()
+	defer fake.getActiveATCsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeShardDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ pipelines.ShardDB = new(FakeShardDB)
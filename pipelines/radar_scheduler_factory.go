@@ -11,7 +11,10 @@ import (
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/buildstarter"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue"
 	"github.com/concourse/atc/scheduler/buildstarter/maxinflight"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota"
+	"github.com/concourse/atc/scheduler/explainer"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
@@ -25,31 +28,38 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker            resource.Tracker
+	prefetcher         resource.Prefetcher
+	interval           time.Duration
+	engine             engine.Engine
+	maxBuildsPerWorker int
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
+	prefetcher resource.Prefetcher,
 	interval time.Duration,
 	engine engine.Engine,
+	maxBuildsPerWorker int,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:            tracker,
+		prefetcher:         prefetcher,
+		interval:           interval,
+		engine:             engine,
+		maxBuildsPerWorker: maxBuildsPerWorker,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.prefetcher, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
 	scanner := radar.NewResourceScanner(
 		clock.NewClock(),
 		rsf.tracker,
+		rsf.prefetcher,
 		rsf.interval,
 		pipelineDB,
 		externalURL,
@@ -63,6 +73,8 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 		BuildStarter: buildstarter.NewBuildStarter(
 			pipelineDB,
 			maxinflight.NewUpdater(pipelineDB),
+			teamquota.NewUpdater(pipelineDB),
+			buildqueue.NewUpdater(pipelineDB, rsf.maxBuildsPerWorker),
 			factory.NewBuildFactory(
 				pipelineDB.GetPipelineID(),
 				atc.NewPlanFactory(time.Now().Unix()),
@@ -70,5 +82,9 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 			rsf.engine,
 		),
 		Scanner: scanner,
+		Explainer: explainer.NewExplainer(
+			pipelineDB,
+			inputconfig.NewTransformer(pipelineDB),
+		),
 	}
 }
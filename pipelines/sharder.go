@@ -0,0 +1,160 @@
+package pipelines
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+// the number of points each ATC has on the hash ring; more points spread the
+// pipelines more evenly between ATCs
+const virtualNodesPerATC = 64
+
+//go:generate counterfeiter . PipelineSharder
+
+// PipelineSharder decides which pipelines this ATC runs the radar and
+// scheduler for.
+type PipelineSharder interface {
+	OwnedPipelines([]db.SavedPipeline) ([]db.SavedPipeline, error)
+}
+
+//go:generate counterfeiter . ShardDB
+
+type ShardDB interface {
+	GetActiveATCs() ([]db.SavedATC, error)
+}
+
+type consistentHashSharder struct {
+	logger lager.Logger
+	atcID  string
+	db     ShardDB
+
+	members []string
+}
+
+// NewConsistentHashSharder assigns each pipeline to exactly one of the
+// active ATCs by placing them on a hash ring. When an ATC joins or leaves
+// the cluster, only the pipelines on its part of the ring move.
+//
+// ATCs may briefly disagree on the membership while their heartbeats
+// propagate, so the scheduling and checking locks are still relied upon to
+// prevent duplicate work during a handoff.
+func NewConsistentHashSharder(logger lager.Logger, atcID string, db ShardDB) PipelineSharder {
+	return &consistentHashSharder{
+		logger: logger,
+		atcID:  atcID,
+		db:     db,
+	}
+}
+
+func (sharder *consistentHashSharder) OwnedPipelines(pipelines []db.SavedPipeline) ([]db.SavedPipeline, error) {
+	atcs, err := sharder.db.GetActiveATCs()
+	if err != nil {
+		sharder.logger.Error("failed-to-get-active-atcs", err)
+		return nil, err
+	}
+
+	members := []string{}
+	for _, atc := range atcs {
+		members = append(members, atc.ID)
+	}
+
+	if !sameMembers(members, sharder.members) {
+		sharder.logger.Info("membership-changed", lager.Data{
+			"members": members,
+		})
+
+		sharder.members = members
+	}
+
+	ring := newHashRing(members)
+
+	owned := []db.SavedPipeline{}
+	for _, pipeline := range pipelines {
+		if ring.owner(pipeline.ID) == sharder.atcID {
+			owned = append(owned, pipeline)
+		}
+	}
+
+	return owned, nil
+}
+
+type hashRing struct {
+	points []uint32
+	owners map[uint32]string
+}
+
+func newHashRing(members []string) hashRing {
+	ring := hashRing{
+		owners: map[uint32]string{},
+	}
+
+	for _, member := range members {
+		for i := 0; i < virtualNodesPerATC; i++ {
+			point := hashKey(member + "-" + strconv.Itoa(i))
+
+			// on the rare collision, the lowest ID wins so that every ATC
+			// agrees on the ring
+			if existing, found := ring.owners[point]; found && existing < member {
+				continue
+			}
+
+			if _, found := ring.owners[point]; !found {
+				ring.points = append(ring.points, point)
+			}
+
+			ring.owners[point] = member
+		}
+	}
+
+	sort.Sort(uint32Slice(ring.points))
+
+	return ring
+}
+
+func (ring hashRing) owner(pipelineID int) string {
+	if len(ring.points) == 0 {
+		return ""
+	}
+
+	key := hashKey(strconv.Itoa(pipelineID))
+
+	i := sort.Search(len(ring.points), func(i int) bool {
+		return ring.points[i] >= key
+	})
+
+	if i == len(ring.points) {
+		i = 0
+	}
+
+	return ring.owners[ring.points[i]]
+}
+
+func hashKey(key string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return hash.Sum32()
+}
+
+func sameMembers(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type uint32Slice []uint32
+
+func (s uint32Slice) Len() int           { return len(s) }
+func (s uint32Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s uint32Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,116 @@
+package pipelines_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/pipelines"
+	"github.com/concourse/atc/pipelines/pipelinesfakes"
+)
+
+var _ = Describe("ConsistentHashSharder", func() {
+	var (
+		shardDB *pipelinesfakes.FakeShardDB
+
+		allPipelines []db.SavedPipeline
+	)
+
+	sharderFor := func(atcID string) pipelines.PipelineSharder {
+		return pipelines.NewConsistentHashSharder(lagertest.NewTestLogger("test"), atcID, shardDB)
+	}
+
+	ownedIDs := func(atcID string) map[int]bool {
+		owned, err := sharderFor(atcID).OwnedPipelines(allPipelines)
+		Expect(err).NotTo(HaveOccurred())
+
+		ids := map[int]bool{}
+		for _, pipeline := range owned {
+			ids[pipeline.ID] = true
+		}
+
+		return ids
+	}
+
+	BeforeEach(func() {
+		shardDB = new(pipelinesfakes.FakeShardDB)
+		shardDB.GetActiveATCsReturns([]db.SavedATC{
+			{ID: "atc-a"},
+			{ID: "atc-b"},
+			{ID: "atc-c"},
+		}, nil)
+
+		allPipelines = []db.SavedPipeline{}
+		for i := 1; i <= 100; i++ {
+			allPipelines = append(allPipelines, db.SavedPipeline{ID: i})
+		}
+	})
+
+	It("assigns every pipeline to exactly one ATC", func() {
+		a := ownedIDs("atc-a")
+		b := ownedIDs("atc-b")
+		c := ownedIDs("atc-c")
+
+		for _, pipeline := range allPipelines {
+			owners := 0
+			for _, owned := range []map[int]bool{a, b, c} {
+				if owned[pipeline.ID] {
+					owners++
+				}
+			}
+
+			Expect(owners).To(Equal(1), "pipeline %d", pipeline.ID)
+		}
+
+		Expect(a).NotTo(BeEmpty())
+		Expect(b).NotTo(BeEmpty())
+		Expect(c).NotTo(BeEmpty())
+	})
+
+	Context("when an ATC leaves the cluster", func() {
+		It("only moves the pipelines that it owned", func() {
+			a := ownedIDs("atc-a")
+			b := ownedIDs("atc-b")
+
+			shardDB.GetActiveATCsReturns([]db.SavedATC{
+				{ID: "atc-a"},
+				{ID: "atc-b"},
+			}, nil)
+
+			newA := ownedIDs("atc-a")
+			newB := ownedIDs("atc-b")
+
+			for id := range a {
+				Expect(newA).To(HaveKey(id))
+			}
+
+			for id := range b {
+				Expect(newB).To(HaveKey(id))
+			}
+
+			Expect(len(newA) + len(newB)).To(Equal(len(allPipelines)))
+		})
+	})
+
+	Context("when the ATC is not an active member", func() {
+		It("owns no pipelines", func() {
+			Expect(ownedIDs("atc-d")).To(BeEmpty())
+		})
+	})
+
+	Context("when getting the active ATCs fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			shardDB.GetActiveATCsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			_, err := sharderFor("atc-a").OwnedPipelines(allPipelines)
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
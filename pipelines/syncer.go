@@ -22,6 +22,7 @@ type Syncer struct {
 	syncherDB             SyncherDB
 	pipelineDBFactory     db.PipelineDBFactory
 	pipelineRunnerFactory PipelineRunnerFactory
+	sharder               PipelineSharder
 
 	runningPipelines map[int]runningPipeline
 }
@@ -39,12 +40,14 @@ func NewSyncer(
 	syncherDB SyncherDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	pipelineRunnerFactory PipelineRunnerFactory,
+	sharder PipelineSharder,
 ) *Syncer {
 	return &Syncer{
 		logger:                logger,
 		syncherDB:             syncherDB,
 		pipelineDBFactory:     pipelineDBFactory,
 		pipelineRunnerFactory: pipelineRunnerFactory,
+		sharder:               sharder,
 
 		runningPipelines: map[int]runningPipeline{},
 	}
@@ -57,6 +60,13 @@ func (syncer *Syncer) Sync() {
 		return
 	}
 
+	// pipelines owned by another ATC are stopped here just like deleted ones
+	pipelines, err = syncer.sharder.OwnedPipelines(pipelines)
+	if err != nil {
+		syncer.logger.Error("failed-to-shard-pipelines", err)
+		return
+	}
+
 	for id, runningPipeline := range syncer.runningPipelines {
 		select {
 		case <-runningPipeline.Exited:
@@ -67,7 +77,7 @@ func (syncer *Syncer) Sync() {
 
 		var found bool
 		for _, pipeline := range pipelines {
-			if pipeline.Paused {
+			if pipeline.Paused || pipeline.Archived {
 				continue
 			}
 
@@ -84,7 +94,7 @@ func (syncer *Syncer) Sync() {
 	}
 
 	for _, pipeline := range pipelines {
-		if pipeline.Paused || syncer.isPipelineRunning(pipeline.ID) {
+		if pipeline.Paused || pipeline.Archived || syncer.isPipelineRunning(pipeline.ID) {
 			continue
 		}
 
@@ -19,6 +19,7 @@ import (
 var _ = Describe("Pipelines Syncer", func() {
 	var (
 		syncherDB             *pipelinesfakes.FakeSyncherDB
+		sharder               *pipelinesfakes.FakePipelineSharder
 		pipelineDB            *dbfakes.FakePipelineDB
 		otherPipelineDB       *dbfakes.FakePipelineDB
 		pipelineDBFactory     *dbfakes.FakePipelineDBFactory
@@ -33,6 +34,11 @@ var _ = Describe("Pipelines Syncer", func() {
 
 	BeforeEach(func() {
 		syncherDB = new(pipelinesfakes.FakeSyncherDB)
+
+		sharder = new(pipelinesfakes.FakePipelineSharder)
+		sharder.OwnedPipelinesStub = func(pipelines []db.SavedPipeline) ([]db.SavedPipeline, error) {
+			return pipelines, nil
+		}
 		pipelineDB = new(dbfakes.FakePipelineDB)
 
 		pipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
@@ -95,6 +101,7 @@ var _ = Describe("Pipelines Syncer", func() {
 			syncherDB,
 			pipelineDBFactory,
 			pipelineRunnerFactory,
+			sharder,
 		)
 	})
 
@@ -225,6 +232,105 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when a pipeline is archived", func() {
+		pipelines := []db.SavedPipeline{
+			{
+				ID:       1,
+				Archived: true,
+				Pipeline: db.Pipeline{
+					Name: "pipeline",
+				},
+			},
+			{
+				ID: 2,
+				Pipeline: db.Pipeline{
+					Name: "other-pipeline",
+				},
+			},
+		}
+
+		JustBeforeEach(func() {
+			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+			Eventually(otherFakeRunner.RunCallCount).Should(Equal(1))
+
+			syncherDB.GetAllPipelinesReturns(pipelines, nil)
+
+			syncer.Sync()
+		})
+
+		It("stops the process", func() {
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+		})
+
+		It("does not start it again", func() {
+			syncer.Sync()
+
+			Consistently(fakeRunner.RunCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when a pipeline is owned by another ATC", func() {
+		BeforeEach(func() {
+			sharder.OwnedPipelinesStub = func(pipelines []db.SavedPipeline) ([]db.SavedPipeline, error) {
+				owned := []db.SavedPipeline{}
+				for _, pipeline := range pipelines {
+					if pipeline.ID != 1 {
+						owned = append(owned, pipeline)
+					}
+				}
+
+				return owned, nil
+			}
+		})
+
+		It("only spawns processes for the pipelines it owns", func() {
+			Eventually(otherFakeRunner.RunCallCount).Should(Equal(1))
+			Consistently(fakeRunner.RunCallCount).Should(Equal(0))
+		})
+
+		It("shards all of the pipelines", func() {
+			Expect(sharder.OwnedPipelinesCallCount()).To(Equal(1))
+			Expect(sharder.OwnedPipelinesArgsForCall(0)).To(HaveLen(2))
+		})
+	})
+
+	Context("when a pipeline is handed over to another ATC", func() {
+		It("stops the process", func() {
+			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+			Eventually(otherFakeRunner.RunCallCount).Should(Equal(1))
+
+			sharder.OwnedPipelinesStub = nil
+			sharder.OwnedPipelinesReturns([]db.SavedPipeline{
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name: "other-pipeline",
+					},
+				},
+			}, nil)
+
+			syncer.Sync()
+
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+		})
+	})
+
+	Context("when sharding the pipelines fails", func() {
+		It("leaves the running processes alone", func() {
+			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+
+			sharder.OwnedPipelinesStub = nil
+			sharder.OwnedPipelinesReturns(nil, errors.New("disaster"))
+
+			syncer.Sync()
+
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Consistently(signals).ShouldNot(Receive())
+		})
+	})
+
 	Context("when the pipeline's process exits", func() {
 		BeforeEach(func() {
 			fakeRunnerExitChan <- nil
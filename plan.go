@@ -16,6 +16,7 @@ type Plan struct {
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+	SetPipeline  *SetPipelinePlan  `json:"set_pipeline,omitempty"`
 }
 
 type PlanID string
@@ -119,3 +120,8 @@ type TaskPlan struct {
 }
 
 type RetryPlan []Plan
+
+type SetPipelinePlan struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+}
@@ -0,0 +1,20 @@
+package atc
+
+type Provenance struct {
+	Builds       []Build             `json:"builds"`
+	Versions     []VersionedResource `json:"versions"`
+	Inputs       []ProvenanceEdge    `json:"inputs"`
+	Outputs      []ProvenanceEdge    `json:"outputs"`
+	SameVersions []ProvenanceLink    `json:"same_versions"`
+	Truncated    bool                `json:"truncated"`
+}
+
+type ProvenanceEdge struct {
+	BuildID   int `json:"build_id"`
+	VersionID int `json:"version_id"`
+}
+
+type ProvenanceLink struct {
+	VersionID      int `json:"version_id"`
+	OtherVersionID int `json:"other_version_id"`
+}
@@ -18,6 +18,7 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+		SetPipeline  *json.RawMessage `json:"set_pipeline,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -70,6 +71,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
 	return enc(public)
 }
 
@@ -199,6 +204,14 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func enc(public interface{}) *json.RawMessage {
 	enc, _ := json.Marshal(public)
 	return (*json.RawMessage)(&enc)
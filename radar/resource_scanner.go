@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -16,6 +17,7 @@ import (
 type resourceScanner struct {
 	clock           clock.Clock
 	tracker         resource.Tracker
+	prefetcher      resource.Prefetcher
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
@@ -24,6 +26,7 @@ type resourceScanner struct {
 func NewResourceScanner(
 	clock clock.Clock,
 	tracker resource.Tracker,
+	prefetcher resource.Prefetcher,
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
@@ -31,6 +34,7 @@ func NewResourceScanner(
 	return &resourceScanner{
 		clock:           clock,
 		tracker:         tracker,
+		prefetcher:      prefetcher,
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
@@ -274,11 +278,84 @@ func (scanner *resourceScanner) scan(
 		logger.Error("failed-to-save-versions", err, lager.Data{
 			"versions": newVersions,
 		})
+		return nil
 	}
 
+	scanner.prefetch(logger, savedResource, session, newVersions[len(newVersions)-1])
+
 	return nil
 }
 
+func (scanner *resourceScanner) prefetch(
+	logger lager.Logger,
+	savedResource db.SavedResource,
+	session resource.Session,
+	version atc.Version,
+) {
+	pipelineConfig := scanner.db.Config()
+
+	// images for custom resource types are fetched in containers that would
+	// collide with the ones used for checking
+	if _, found := pipelineConfig.ResourceTypes.Lookup(savedResource.Config.Type); found {
+		return
+	}
+
+	session.ID.Stage = db.ContainerStagePrefetch
+	session.Metadata.Type = db.ContainerTypeGet
+
+	for _, input := range triggeringInputs(pipelineConfig.Jobs, savedResource.Name) {
+		spec := resource.PrefetchSpec{
+			Session: session,
+			Metadata: resource.TrackerMetadata{
+				ResourceName: savedResource.Name,
+				PipelineName: savedResource.PipelineName,
+				ExternalURL:  scanner.externalURL,
+			},
+			Tags:          input.Tags,
+			TeamID:        scanner.db.TeamID(),
+			ResourceTypes: pipelineConfig.ResourceTypes,
+			Type:          resource.ResourceType(savedResource.Config.Type),
+			Source:        savedResource.Config.Source,
+			Params:        input.Params,
+			Version:       version,
+		}
+
+		scanner.prefetcher.Prefetch(logger.Session("prefetch"), spec)
+	}
+}
+
+// triggeringInputs returns the distinct inputs of the resource which trigger
+// jobs, as each combination of params and tags is cached separately.
+func triggeringInputs(jobs atc.JobConfigs, resourceName string) []config.JobInput {
+	var inputs []config.JobInput
+
+	for _, job := range jobs {
+		for _, input := range config.JobInputs(job) {
+			if input.Resource != resourceName || !input.Trigger {
+				continue
+			}
+
+			if input.Version != nil && input.Version.Pinned != nil {
+				continue
+			}
+
+			seen := false
+			for _, existing := range inputs {
+				if reflect.DeepEqual(existing.Params, input.Params) && reflect.DeepEqual(existing.Tags, input.Tags) {
+					seen = true
+					break
+				}
+			}
+
+			if !seen {
+				inputs = append(inputs, input)
+			}
+		}
+	}
+
+	return inputs
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -24,10 +24,11 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		fakeClock   *fakeclock.FakeClock
-		interval    time.Duration
+		fakeTracker    *rfakes.FakeTracker
+		fakePrefetcher *rfakes.FakePrefetcher
+		fakeRadarDB    *radarfakes.FakeRadarDB
+		fakeClock      *fakeclock.FakeClock
+		interval       time.Duration
 
 		scanner Scanner
 
@@ -41,6 +42,7 @@ var _ = Describe("ResourceScanner", func() {
 	BeforeEach(func() {
 		epoch = time.Unix(123, 456).UTC()
 		fakeTracker = new(rfakes.FakeTracker)
+		fakePrefetcher = new(rfakes.FakePrefetcher)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
 		interval = 1 * time.Minute
@@ -49,6 +51,7 @@ var _ = Describe("ResourceScanner", func() {
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeTracker,
+			fakePrefetcher,
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
@@ -673,6 +676,88 @@ var _ = Describe("ResourceScanner", func() {
 					}))
 
 				})
+
+				It("does not prefetch anything when no jobs trigger on the resource", func() {
+					Expect(fakePrefetcher.PrefetchCallCount()).To(BeZero())
+				})
+
+				Context("when jobs trigger on the resource", func() {
+					BeforeEach(func() {
+						fakeRadarDB.ConfigReturns(atc.Config{
+							Resources: atc.ResourceConfigs{resourceConfig},
+							Jobs: atc.JobConfigs{
+								{
+									Name: "some-job",
+									Plan: atc.PlanSequence{
+										{Get: "some-resource", Trigger: true},
+									},
+								},
+								{
+									Name: "some-other-job",
+									Plan: atc.PlanSequence{
+										{Get: "some-resource", Trigger: true},
+										{Get: "deep", Resource: "some-resource", Trigger: true, Params: atc.Params{"depth": 1}, Tags: atc.Tags{"some-tag"}},
+									},
+								},
+								{
+									Name: "some-manual-job",
+									Plan: atc.PlanSequence{
+										{Get: "some-resource", Params: atc.Params{"manual": true}},
+									},
+								},
+							},
+						})
+					})
+
+					It("prefetches the latest version once for each distinct triggering input", func() {
+						Expect(fakePrefetcher.PrefetchCallCount()).To(Equal(2))
+
+						var params []atc.Params
+						for i := 0; i < fakePrefetcher.PrefetchCallCount(); i++ {
+							_, spec := fakePrefetcher.PrefetchArgsForCall(i)
+							Expect(spec.Type).To(Equal(resource.ResourceType("git")))
+							Expect(spec.Source).To(Equal(resourceConfig.Source))
+							Expect(spec.Version).To(Equal(atc.Version{"version": "3"}))
+							Expect(spec.TeamID).To(Equal(teamID))
+							Expect(spec.Session.ID.ResourceID).To(Equal(39))
+							Expect(spec.Session.ID.Stage).To(Equal(db.ContainerStagePrefetch))
+							Expect(spec.Session.Metadata.Type).To(Equal(db.ContainerTypeGet))
+							Expect(spec.Session.Ephemeral).To(BeTrue())
+
+							params = append(params, spec.Params)
+						}
+
+						Expect(params).To(ConsistOf(atc.Params(nil), atc.Params{"depth": 1}))
+					})
+
+					Context("when saving versions fails", func() {
+						BeforeEach(func() {
+							fakeRadarDB.SaveResourceVersionsReturns(errors.New("failed"))
+						})
+
+						It("does not prefetch", func() {
+							Expect(fakePrefetcher.PrefetchCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the resource has a custom type", func() {
+						BeforeEach(func() {
+							pipelineConfig := fakeRadarDB.Config()
+							pipelineConfig.ResourceTypes = atc.ResourceTypes{
+								{
+									Name:   "git",
+									Type:   "docker-image",
+									Source: atc.Source{"custom": "source"},
+								},
+							}
+							fakeRadarDB.ConfigReturns(pipelineConfig)
+						})
+
+						It("does not prefetch", func() {
+							Expect(fakePrefetcher.PrefetchCallCount()).To(BeZero())
+						})
+					})
+				})
 			})
 
 			Context("when checking fails internally", func() {
@@ -32,6 +32,7 @@ type scanRunnerFactory struct {
 
 func NewScanRunnerFactory(
 	tracker resource.Tracker,
+	prefetcher resource.Prefetcher,
 	defaultInterval time.Duration,
 	db RadarDB,
 	clock clock.Clock,
@@ -40,6 +41,7 @@ func NewScanRunnerFactory(
 	resourceScanner := NewResourceScanner(
 		clock,
 		tracker,
+		prefetcher,
 		defaultInterval,
 		db,
 		externalURL,
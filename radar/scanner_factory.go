@@ -13,22 +13,25 @@ type ScannerFactory interface {
 
 type scannerFactory struct {
 	tracker         resource.Tracker
+	prefetcher      resource.Prefetcher
 	defaultInterval time.Duration
 	externalURL     string
 }
 
 func NewScannerFactory(
 	tracker resource.Tracker,
+	prefetcher resource.Prefetcher,
 	defaultInterval time.Duration,
 	externalURL string,
 ) ScannerFactory {
 	return &scannerFactory{
 		tracker:         tracker,
+		prefetcher:      prefetcher,
 		defaultInterval: defaultInterval,
 		externalURL:     externalURL,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.tracker, f.defaultInterval, db, f.externalURL)
+	return NewResourceScanner(clock.NewClock(), f.tracker, f.prefetcher, f.defaultInterval, db, f.externalURL)
 }
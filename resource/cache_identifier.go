@@ -4,7 +4,6 @@ import (
 	"crypto/sha512"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -12,8 +11,6 @@ import (
 	"github.com/concourse/atc/worker"
 )
 
-const reapExtraVolumeTTL = time.Minute
-
 //go:generate counterfeiter . CacheIdentifier
 
 type CacheIdentifier interface {
@@ -48,13 +45,9 @@ func (identifier ResourceCacheIdentifier) FindOn(logger lager.Logger, workerClie
 	}
 }
 
+// CreateOn creates the cache's volume, which is kept for as long as the
+// resource cache that owns it.
 func (identifier ResourceCacheIdentifier) CreateOn(logger lager.Logger, workerClient worker.Client) (worker.Volume, error) {
-	ttl := time.Duration(0)
-
-	if identifier.Version == nil {
-		ttl = worker.VolumeTTL
-	}
-
 	return workerClient.CreateVolume(
 		logger,
 		worker.VolumeSpec{
@@ -64,7 +57,6 @@ func (identifier ResourceCacheIdentifier) CreateOn(logger lager.Logger, workerCl
 			},
 			Properties: identifier.volumeProperties(),
 			Privileged: true,
-			TTL:        ttl,
 		},
 		0,
 	)
@@ -120,9 +112,11 @@ func selectLowestAlphabeticalVolume(logger lager.Logger, volumes []worker.Volume
 		}
 	}
 
+	// the other volumes belong to the same resource cache, and are destroyed
+	// along with it
 	for _, v := range volumes {
 		if v != lowestVolume {
-			v.Release(worker.FinalTTL(reapExtraVolumeTTL))
+			v.Release(nil)
 		}
 	}
 
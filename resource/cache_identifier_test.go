@@ -2,7 +2,6 @@ package resource_test
 
 import (
 	"errors"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -90,7 +89,7 @@ var _ = Describe("ResourceCacheIdentifier", func() {
 
 					Expect(aVolume.SetTTLCallCount()).To(Equal(0))
 					Expect(bVolume.ReleaseCallCount()).To(Equal(1))
-					Expect(bVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(time.Minute)))
+					Expect(bVolume.ReleaseArgsForCall(0)).To(BeNil())
 				})
 			})
 
@@ -105,7 +104,7 @@ var _ = Describe("ResourceCacheIdentifier", func() {
 
 					Expect(aVolume.SetTTLCallCount()).To(Equal(0))
 					Expect(bVolume.ReleaseCallCount()).To(Equal(1))
-					Expect(bVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(time.Minute)))
+					Expect(bVolume.ReleaseArgsForCall(0)).To(BeNil())
 				})
 			})
 		})
@@ -144,9 +143,9 @@ var _ = Describe("ResourceCacheIdentifier", func() {
 				fakeWorkerClient.CreateVolumeReturns(volume, nil)
 			})
 
-			It("sets the TTL to 5 minutes", func() {
+			It("does not expire the volume", func() {
 				_, spec, actualTeamID := fakeWorkerClient.CreateVolumeArgsForCall(0)
-				Expect(spec.TTL).To(Equal(5 * time.Minute))
+				Expect(spec.TTL).To(BeZero())
 				Expect(actualTeamID).To(BeZero())
 			})
 		})
@@ -3,7 +3,6 @@ package resource_test
 import (
 	"errors"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/garden"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
@@ -107,11 +106,10 @@ var _ = Describe("ContainerFetchSource", func() {
 
 	Describe("Release", func() {
 		It("releases container", func() {
-			finalTTL := worker.FinalTTL(5 * time.Second)
-			fetchSource.Release(finalTTL)
+			fetchSource.Release(nil)
 			Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
 			ttl := fakeContainer.ReleaseArgsForCall(0)
-			Expect(ttl).To(Equal(finalTTL))
+			Expect(ttl).To(BeNil())
 		})
 	})
 })
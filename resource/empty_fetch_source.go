@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/worker"
 )
 
@@ -86,6 +87,17 @@ func (s *emptyFetchSource) Initialize(signals <-chan os.Signal, ready chan<- str
 		return err
 	}
 
+	size, err := s.cache.Volume().SizeInBytes()
+	if err != nil {
+		s.logger.Error("failed-to-get-cache-size", err)
+	} else {
+		metric.ResourceCacheFetched{
+			ResourceType: string(s.resourceOptions.ResourceType()),
+			WorkerName:   s.worker.Name(),
+			Bytes:        size,
+		}.Emit(s.logger)
+	}
+
 	return nil
 }
 
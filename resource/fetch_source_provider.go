@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 )
 
@@ -40,13 +41,21 @@ type FetchSource interface {
 	Release(*time.Duration)
 }
 
+//go:generate counterfeiter . FetchSourceProviderDB
+
+type FetchSourceProviderDB interface {
+	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
+}
+
 type fetchSourceProviderFactory struct {
 	workerClient worker.Client
+	db           FetchSourceProviderDB
 }
 
-func NewFetchSourceProviderFactory(workerClient worker.Client) FetchSourceProviderFactory {
+func NewFetchSourceProviderFactory(workerClient worker.Client, db FetchSourceProviderDB) FetchSourceProviderFactory {
 	return &fetchSourceProviderFactory{
 		workerClient: workerClient,
+		db:           db,
 	}
 }
 
@@ -70,6 +79,7 @@ func (f *fetchSourceProviderFactory) NewFetchSourceProvider(
 		resourceOptions:  resourceOptions,
 		containerCreator: containerCreator,
 		workerClient:     f.workerClient,
+		db:               f.db,
 	}
 }
 
@@ -83,6 +93,7 @@ type fetchSourceProvider struct {
 	resourceOptions  ResourceOptions
 	workerClient     worker.Client
 	containerCreator FetchContainerCreator
+	db               FetchSourceProviderDB
 }
 
 func (f *fetchSourceProvider) Get() (FetchSource, error) {
@@ -124,6 +135,19 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		), nil
 	}
 
+	sourceVolume, sourceWorker, found := f.findCacheElsewhere(resourceSpec, chosenWorker)
+	if found {
+		return NewStreamedFetchSource(
+			f.logger,
+			sourceVolume,
+			sourceWorker,
+			chosenWorker,
+			f.cacheIdentifier,
+			f.containerCreator,
+			f.resourceOptions,
+		), nil
+	}
+
 	return NewEmptyFetchSource(
 		f.logger,
 		chosenWorker,
@@ -132,3 +156,50 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		f.resourceOptions,
 	), nil
 }
+
+// findCacheElsewhere looks up which other workers have the cache in the
+// database so it can be streamed rather than fetched again, only asking the
+// workers that have it whether it is initialized. Failures only mean falling
+// back to fetching.
+func (f *fetchSourceProvider) findCacheElsewhere(resourceSpec worker.WorkerSpec, chosenWorker worker.Worker) (worker.Volume, worker.Worker, bool) {
+	savedVolumes, err := f.db.GetVolumesByIdentifier(db.VolumeIdentifier(f.cacheIdentifier.VolumeIdentifier()))
+	if err != nil {
+		f.logger.Error("failed-to-look-up-cache-volumes", err)
+		return nil, nil, false
+	}
+
+	cacheWorkers := map[string]bool{}
+	for _, savedVolume := range savedVolumes {
+		if savedVolume.WorkerName != chosenWorker.Name() {
+			cacheWorkers[savedVolume.WorkerName] = true
+		}
+	}
+
+	if len(cacheWorkers) == 0 {
+		return nil, nil, false
+	}
+
+	compatibleWorkers, err := f.workerClient.AllSatisfying(resourceSpec, f.resourceTypes)
+	if err != nil {
+		f.logger.Error("failed-to-list-workers-for-cache", err)
+		return nil, nil, false
+	}
+
+	for _, otherWorker := range compatibleWorkers {
+		if !cacheWorkers[otherWorker.Name()] {
+			continue
+		}
+
+		cachedVolume, cacheFound, err := f.cacheIdentifier.FindOn(f.logger, otherWorker)
+		if err != nil {
+			f.logger.Error("failed-to-look-for-cache", err, lager.Data{"worker": otherWorker.Name()})
+			continue
+		}
+
+		if cacheFound {
+			return cachedVolume, otherWorker, true
+		}
+	}
+
+	return nil, nil, false
+}
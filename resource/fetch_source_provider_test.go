@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/resource"
 	"github.com/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/atc/worker"
@@ -18,6 +19,7 @@ import (
 var _ = Describe("FetchSourceProvider", func() {
 	var (
 		fakeWorkerClient     *workerfakes.FakeClient
+		fakeDB               *resourcefakes.FakeFetchSourceProviderDB
 		fakeContainerCreator *resourcefakes.FakeFetchContainerCreator
 		fetchSourceProvider  FetchSourceProvider
 
@@ -31,7 +33,8 @@ var _ = Describe("FetchSourceProvider", func() {
 
 	BeforeEach(func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
-		fetchSourceProviderFactory := NewFetchSourceProviderFactory(fakeWorkerClient)
+		fakeDB = new(resourcefakes.FakeFetchSourceProviderDB)
+		fetchSourceProviderFactory := NewFetchSourceProviderFactory(fakeWorkerClient, fakeDB)
 		logger = lagertest.NewTestLogger("test")
 		session := Session{}
 		cacheID = new(resourcefakes.FakeCacheIdentifier)
@@ -130,6 +133,112 @@ var _ = Describe("FetchSourceProvider", func() {
 						expectedSource := NewEmptyFetchSource(logger, fakeWorker, cacheID, fakeContainerCreator, resourceOptions)
 						Expect(source).To(Equal(expectedSource))
 					})
+
+					It("does not list the other workers", func() {
+						_, err := fetchSourceProvider.Get()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(BeZero())
+					})
+
+					Context("when the volume is found on another worker", func() {
+						var otherWorker *workerfakes.FakeWorker
+						var unrelatedWorker *workerfakes.FakeWorker
+						var otherVolume *workerfakes.FakeVolume
+
+						BeforeEach(func() {
+							fakeWorker.NameReturns("chosen-worker")
+
+							cacheID.VolumeIdentifierReturns(worker.VolumeIdentifier{
+								ResourceCache: &db.ResourceCacheIdentifier{
+									ResourceVersion: atc.Version{"some": "version"},
+									ResourceHash:    "some-hash",
+								},
+							})
+
+							fakeDB.GetVolumesByIdentifierReturns([]db.SavedVolume{
+								{Volume: db.Volume{WorkerName: "chosen-worker", Handle: "uninitialized-handle"}},
+								{Volume: db.Volume{WorkerName: "other-worker", Handle: "other-handle"}},
+							}, nil)
+
+							otherWorker = new(workerfakes.FakeWorker)
+							otherWorker.NameReturns("other-worker")
+
+							unrelatedWorker = new(workerfakes.FakeWorker)
+							unrelatedWorker.NameReturns("unrelated-worker")
+
+							fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{fakeWorker, unrelatedWorker, otherWorker}, nil)
+
+							otherVolume = new(workerfakes.FakeVolume)
+							cacheID.FindOnStub = func(logger lager.Logger, workerClient worker.Client) (worker.Volume, bool, error) {
+								if workerClient == otherWorker {
+									return otherVolume, true, nil
+								}
+
+								return nil, false, nil
+							}
+						})
+
+						It("returns a source streaming the volume from the other worker", func() {
+							source, err := fetchSourceProvider.Get()
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedSource := NewStreamedFetchSource(logger, otherVolume, otherWorker, fakeWorker, cacheID, fakeContainerCreator, resourceOptions)
+							Expect(source).To(Equal(expectedSource))
+						})
+
+						It("looks up the cache's volumes in the database", func() {
+							_, err := fetchSourceProvider.Get()
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(fakeDB.GetVolumesByIdentifierCallCount()).To(Equal(1))
+							Expect(fakeDB.GetVolumesByIdentifierArgsForCall(0)).To(Equal(db.VolumeIdentifier{
+								ResourceCache: &db.ResourceCacheIdentifier{
+									ResourceVersion: atc.Version{"some": "version"},
+									ResourceHash:    "some-hash",
+								},
+							}))
+						})
+
+						It("only looks for the cache on the workers that have it", func() {
+							_, err := fetchSourceProvider.Get()
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(cacheID.FindOnCallCount()).To(Equal(2))
+							_, workerClient := cacheID.FindOnArgsForCall(0)
+							Expect(workerClient).To(Equal(fakeWorker))
+							_, workerClient = cacheID.FindOnArgsForCall(1)
+							Expect(workerClient).To(Equal(otherWorker))
+						})
+
+						Context("when listing the other workers fails", func() {
+							BeforeEach(func() {
+								fakeWorkerClient.AllSatisfyingReturns(nil, errors.New("nope"))
+							})
+
+							It("returns empty source", func() {
+								source, err := fetchSourceProvider.Get()
+								Expect(err).NotTo(HaveOccurred())
+
+								expectedSource := NewEmptyFetchSource(logger, fakeWorker, cacheID, fakeContainerCreator, resourceOptions)
+								Expect(source).To(Equal(expectedSource))
+							})
+						})
+					})
+
+					Context("when looking up the cache's volumes fails", func() {
+						BeforeEach(func() {
+							fakeDB.GetVolumesByIdentifierReturns(nil, errors.New("nope"))
+						})
+
+						It("returns empty source", func() {
+							source, err := fetchSourceProvider.Get()
+							Expect(err).NotTo(HaveOccurred())
+
+							expectedSource := NewEmptyFetchSource(logger, fakeWorker, cacheID, fakeContainerCreator, resourceOptions)
+							Expect(source).To(Equal(expectedSource))
+						})
+					})
 				})
 			})
 
@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -46,6 +48,38 @@ type ResourceOptions interface {
 	LockName(workerName string) (string, error)
 }
 
+// FetchLockName returns the name of the task lock held while fetching the
+// given version of a resource onto a worker.
+func FetchLockName(
+	resourceType ResourceType,
+	version atc.Version,
+	source atc.Source,
+	params atc.Params,
+	workerName string,
+) (string, error) {
+	id := &fetchLeaseID{
+		Type:       resourceType,
+		Version:    version,
+		Source:     source,
+		Params:     params,
+		WorkerName: workerName,
+	}
+
+	taskNameJSON, err := json.Marshal(id)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(taskNameJSON)), nil
+}
+
+type fetchLeaseID struct {
+	Type       ResourceType `json:"type"`
+	Version    atc.Version  `json:"version"`
+	Source     atc.Source   `json:"source"`
+	Params     atc.Params   `json:"params"`
+	WorkerName string       `json:"worker_name"`
+}
+
 func NewFetcher(
 	clock clock.Clock,
 	db LockDB,
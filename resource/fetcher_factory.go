@@ -19,8 +19,13 @@ type LockDB interface {
 	GetTaskLock(logger lager.Logger, lockName string) (db.Lock, bool, error)
 }
 
+type FetcherFactoryDB interface {
+	LockDB
+	FetchSourceProviderDB
+}
+
 func NewFetcherFactory(
-	db LockDB,
+	db FetcherFactoryDB,
 	clock clock.Clock,
 ) FetcherFactory {
 	return &fetcherFactory{
@@ -30,10 +35,10 @@ func NewFetcherFactory(
 }
 
 type fetcherFactory struct {
-	db    LockDB
+	db    FetcherFactoryDB
 	clock clock.Clock
 }
 
 func (f *fetcherFactory) FetcherFor(workerClient worker.Client) Fetcher {
-	return NewFetcher(f.clock, f.db, NewFetchContainerCreatorFactory(), NewFetchSourceProviderFactory(workerClient))
+	return NewFetcher(f.clock, f.db, NewFetchContainerCreatorFactory(), NewFetchSourceProviderFactory(workerClient, f.db))
 }
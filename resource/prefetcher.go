@@ -0,0 +1,310 @@
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	"github.com/tedsuo/ifrit"
+)
+
+//go:generate counterfeiter . Prefetcher
+
+// Prefetcher fetches new versions of resources onto workers in the
+// background, ahead of the builds that will need them. Prefetches are queued
+// and only worked through while the Prefetcher is running.
+type Prefetcher interface {
+	ifrit.Runner
+
+	Prefetch(logger lager.Logger, spec PrefetchSpec)
+}
+
+type PrefetchSpec struct {
+	Session       Session
+	Metadata      Metadata
+	Tags          atc.Tags
+	TeamID        int
+	ResourceTypes atc.ResourceTypes
+
+	Type    ResourceType
+	Source  atc.Source
+	Params  atc.Params
+	Version atc.Version
+}
+
+// prefetching is only an optimization, so only a few prefetches run at once
+// and any that do not fit in the queue are dropped
+const (
+	prefetchConcurrency = 4
+	prefetchQueueSize   = 100
+)
+
+// NewPrefetcher returns a Prefetcher which warms the resource cache for a
+// version on up to workerCount workers. A workerCount of 0 disables
+// prefetching.
+func NewPrefetcher(
+	workerClient worker.Client,
+	db LockDB,
+	workerCount int,
+) Prefetcher {
+	return &prefetcher{
+		workerClient:                 workerClient,
+		db:                           db,
+		workerCount:                  workerCount,
+		fetchContainerCreatorFactory: NewFetchContainerCreatorFactory(),
+
+		queue:  make(chan prefetchRequest, prefetchQueueSize),
+		queued: map[string]bool{},
+	}
+}
+
+type prefetcher struct {
+	workerClient                 worker.Client
+	db                           LockDB
+	workerCount                  int
+	fetchContainerCreatorFactory FetchContainerCreatorFactory
+
+	queue chan prefetchRequest
+
+	// the prefetches which are queued or running, so that a version found by
+	// several checks is only fetched once
+	queuedL sync.Mutex
+	queued  map[string]bool
+}
+
+type prefetchRequest struct {
+	logger lager.Logger
+	key    string
+	spec   PrefetchSpec
+}
+
+func (p *prefetcher) Prefetch(logger lager.Logger, spec PrefetchSpec) {
+	if p.workerCount <= 0 {
+		return
+	}
+
+	key, err := prefetchKey(spec)
+	if err != nil {
+		logger.Error("failed-to-marshal-prefetch-key", err)
+		return
+	}
+
+	p.queuedL.Lock()
+	defer p.queuedL.Unlock()
+
+	if p.queued[key] {
+		logger.Debug("already-queued")
+		return
+	}
+
+	select {
+	case p.queue <- prefetchRequest{logger: logger, key: key, spec: spec}:
+		p.queued[key] = true
+	default:
+		logger.Info("queue-full")
+	}
+}
+
+// Run works through the queue until it is signalled, at which point the
+// prefetches in flight are interrupted and the rest of the queue is dropped.
+func (p *prefetcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	stop := make(chan struct{})
+	wg := new(sync.WaitGroup)
+
+	// every worker is interrupted with its own copy of the signal
+	workerSignals := make([]chan os.Signal, prefetchConcurrency)
+	for i := range workerSignals {
+		workerSignals[i] = make(chan os.Signal, 1)
+
+		wg.Add(1)
+		go func(signals <-chan os.Signal) {
+			defer wg.Done()
+			p.work(signals, stop)
+		}(workerSignals[i])
+	}
+
+	close(ready)
+
+	signal := <-signals
+
+	close(stop)
+
+	for _, workerSignal := range workerSignals {
+		workerSignal <- signal
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (p *prefetcher) work(signals <-chan os.Signal, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+
+		case request := <-p.queue:
+			err := p.prefetch(request.logger, request.spec, signals)
+			if err != nil {
+				request.logger.Error("failed-to-prefetch", err)
+			}
+
+			p.queuedL.Lock()
+			delete(p.queued, request.key)
+			p.queuedL.Unlock()
+		}
+	}
+}
+
+func (p *prefetcher) prefetch(logger lager.Logger, spec PrefetchSpec, signals <-chan os.Signal) error {
+	workers, err := p.workerClient.AllSatisfying(
+		worker.WorkerSpec{
+			ResourceType: string(spec.Type),
+			Tags:         spec.Tags,
+			TeamID:       spec.TeamID,
+		},
+		spec.ResourceTypes,
+	)
+	if err != nil {
+		logger.Error("failed-to-find-workers", err)
+		return err
+	}
+
+	cacheIdentifier := ResourceCacheIdentifier{
+		Type:    spec.Type,
+		Version: spec.Version,
+		Source:  spec.Source,
+		Params:  spec.Params,
+	}
+
+	warmed := 0
+	for _, chosenWorker := range workers {
+		if warmed >= p.workerCount {
+			break
+		}
+
+		workerLogger := logger.Session("prefetch", lager.Data{
+			"worker": chosenWorker.Name(),
+		})
+
+		err := p.prefetchOn(workerLogger, chosenWorker, cacheIdentifier, spec, signals)
+		if err == ErrAborted {
+			return err
+		}
+
+		if err != nil {
+			workerLogger.Error("failed-to-prefetch", err)
+			continue
+		}
+
+		warmed++
+	}
+
+	logger.Info("finished", lager.Data{"workers": warmed})
+
+	return nil
+}
+
+func (p *prefetcher) prefetchOn(
+	logger lager.Logger,
+	chosenWorker worker.Worker,
+	cacheIdentifier ResourceCacheIdentifier,
+	spec PrefetchSpec,
+	signals <-chan os.Signal,
+) error {
+	cachedVolume, found, err := cacheIdentifier.FindOn(logger, chosenWorker)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		logger.Debug("already-cached", lager.Data{"volume": cachedVolume.Handle()})
+		cachedVolume.Release(nil)
+		return nil
+	}
+
+	containerCreator := p.fetchContainerCreatorFactory.NewFetchContainerCreator(
+		logger,
+		spec.ResourceTypes,
+		spec.Tags,
+		spec.TeamID,
+		spec.Session,
+		spec.Metadata,
+		worker.NoopImageFetchingDelegate{},
+	)
+
+	source := NewEmptyFetchSource(logger, chosenWorker, cacheIdentifier, containerCreator, prefetchResourceOptions{spec})
+
+	lockName, err := source.LockName()
+	if err != nil {
+		return err
+	}
+
+	lock, acquired, err := p.db.GetTaskLock(logger, lockName)
+	if err != nil {
+		return err
+	}
+
+	if !acquired {
+		logger.Debug("already-fetching")
+		return nil
+	}
+
+	defer lock.Release()
+
+	err = source.Initialize(signals, make(chan struct{}))
+	if err != nil {
+		return err
+	}
+
+	source.Release(nil)
+
+	logger.Info("prefetched")
+
+	return nil
+}
+
+func prefetchKey(spec PrefetchSpec) (string, error) {
+	key, err := json.Marshal([]interface{}{
+		spec.TeamID,
+		spec.Tags,
+		spec.Type,
+		spec.Source,
+		spec.Params,
+		spec.Version,
+	})
+
+	return string(key), err
+}
+
+type prefetchResourceOptions struct {
+	spec PrefetchSpec
+}
+
+func (o prefetchResourceOptions) IOConfig() IOConfig {
+	return IOConfig{}
+}
+
+func (o prefetchResourceOptions) Source() atc.Source {
+	return o.spec.Source
+}
+
+func (o prefetchResourceOptions) Params() atc.Params {
+	return o.spec.Params
+}
+
+func (o prefetchResourceOptions) Version() atc.Version {
+	return o.spec.Version
+}
+
+func (o prefetchResourceOptions) ResourceType() ResourceType {
+	return o.spec.Type
+}
+
+func (o prefetchResourceOptions) LockName(workerName string) (string, error) {
+	return FetchLockName(o.spec.Type, o.spec.Version, o.spec.Source, o.spec.Params, workerName)
+}
@@ -0,0 +1,249 @@
+package resource_test
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	. "github.com/concourse/atc/resource"
+	"github.com/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prefetcher", func() {
+	var (
+		fakeWorkerClient *workerfakes.FakeClient
+		fakeLockDB       *resourcefakes.FakeLockDB
+		fakeLock         *dbfakes.FakeLock
+		workerCount      int
+
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+
+		logger *lagertest.TestLogger
+		spec   PrefetchSpec
+
+		prefetcher Prefetcher
+		process    ifrit.Process
+	)
+
+	// every prefetch logs either that it finished or that it failed
+	prefetchesDone := func() int {
+		done := 0
+		for _, message := range logger.LogMessages() {
+			if message == "test.finished" || message == "test.failed-to-prefetch" {
+				done++
+			}
+		}
+		return done
+	}
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(workerfakes.FakeClient)
+		fakeLockDB = new(resourcefakes.FakeLockDB)
+		fakeLock = new(dbfakes.FakeLock)
+		workerCount = 2
+
+		workerA = new(workerfakes.FakeWorker)
+		workerA.NameReturns("worker-a")
+		workerB = new(workerfakes.FakeWorker)
+		workerB.NameReturns("worker-b")
+		workerC = new(workerfakes.FakeWorker)
+		workerC.NameReturns("worker-c")
+
+		fakeWorkerClient.AllSatisfyingReturns([]worker.Worker{workerA, workerB, workerC}, nil)
+		fakeLockDB.GetTaskLockReturns(fakeLock, true, nil)
+
+		logger = lagertest.NewTestLogger("test")
+
+		spec = PrefetchSpec{
+			Session: Session{
+				ID: worker.Identifier{
+					ResourceID:  42,
+					Stage:       db.ContainerStagePrefetch,
+					CheckType:   "git",
+					CheckSource: atc.Source{"some": "source"},
+				},
+				Metadata: worker.Metadata{
+					Type: db.ContainerTypeGet,
+				},
+				Ephemeral: true,
+			},
+			Metadata: EmptyMetadata{},
+			Tags:     atc.Tags{"some-tag"},
+			TeamID:   123,
+			Type:     ResourceType("git"),
+			Source:   atc.Source{"some": "source"},
+			Params:   atc.Params{"some": "params"},
+			Version:  atc.Version{"some": "version"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		prefetcher = NewPrefetcher(fakeWorkerClient, fakeLockDB, workerCount)
+		process = ifrit.Invoke(prefetcher)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	Context("when a version is queued", func() {
+		JustBeforeEach(func() {
+			prefetcher.Prefetch(logger, spec)
+			Eventually(prefetchesDone).Should(Equal(1))
+		})
+
+		It("looks for workers satisfying the resource's type, tags and team", func() {
+			Expect(fakeWorkerClient.AllSatisfyingCallCount()).To(Equal(1))
+
+			workerSpec, _ := fakeWorkerClient.AllSatisfyingArgsForCall(0)
+			Expect(workerSpec).To(Equal(worker.WorkerSpec{
+				ResourceType: "git",
+				Tags:         []string{"some-tag"},
+				TeamID:       123,
+			}))
+		})
+
+		Context("when the version is already cached on the workers", func() {
+			var cachedVolume *workerfakes.FakeVolume
+
+			BeforeEach(func() {
+				cachedVolume = new(workerfakes.FakeVolume)
+				workerA.ListVolumesReturns([]worker.Volume{cachedVolume}, nil)
+				workerB.ListVolumesReturns([]worker.Volume{cachedVolume}, nil)
+			})
+
+			It("does not fetch it again", func() {
+				Expect(fakeLockDB.GetTaskLockCallCount()).To(Equal(0))
+				Expect(workerA.CreateVolumeCallCount()).To(Equal(0))
+				Expect(workerB.CreateVolumeCallCount()).To(Equal(0))
+			})
+
+			It("releases the cached volumes", func() {
+				Expect(cachedVolume.ReleaseCallCount()).To(Equal(2))
+			})
+
+			It("stops once enough workers are warm", func() {
+				Expect(workerC.ListVolumesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the version is not cached", func() {
+			BeforeEach(func() {
+				workerA.CreateVolumeReturns(nil, errors.New("nope"))
+				workerB.CreateVolumeReturns(nil, errors.New("nope"))
+				workerC.CreateVolumeReturns(nil, errors.New("nope"))
+			})
+
+			It("fetches under the same lock as get steps", func() {
+				Expect(fakeLockDB.GetTaskLockCallCount()).To(BeNumerically(">=", 1))
+
+				_, lockName := fakeLockDB.GetTaskLockArgsForCall(0)
+				expectedLockName, err := FetchLockName(spec.Type, spec.Version, spec.Source, spec.Params, "worker-a")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lockName).To(Equal(expectedLockName))
+			})
+
+			It("creates the cache volume on the worker", func() {
+				Expect(workerA.CreateVolumeCallCount()).To(Equal(1))
+			})
+
+			It("moves on to the next worker when fetching fails", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.finished"))
+				Expect(workerB.CreateVolumeCallCount()).To(Equal(1))
+				Expect(workerC.CreateVolumeCallCount()).To(Equal(1))
+			})
+
+			It("releases the lock", func() {
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(3))
+			})
+
+			Context("when another fetch holds the lock", func() {
+				BeforeEach(func() {
+					fakeLockDB.GetTaskLockReturns(nil, false, nil)
+				})
+
+				It("leaves the worker to it", func() {
+					Expect(workerA.CreateVolumeCallCount()).To(Equal(0))
+					Expect(workerB.CreateVolumeCallCount()).To(Equal(0))
+					Expect(workerC.ListVolumesCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when finding workers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeWorkerClient.AllSatisfyingReturns(nil, disaster)
+			})
+
+			It("logs the error", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-prefetch"))
+			})
+		})
+
+		It("can queue the version again once it has been prefetched", func() {
+			prefetcher.Prefetch(logger, spec)
+			Eventually(prefetchesDone).Should(Equal(2))
+		})
+	})
+
+	Context("while versions are being prefetched", func() {
+		var blocking chan struct{}
+
+		BeforeEach(func() {
+			blocking = make(chan struct{})
+
+			fakeWorkerClient.AllSatisfyingStub = func(worker.WorkerSpec, atc.ResourceTypes) ([]worker.Worker, error) {
+				<-blocking
+				return nil, nil
+			}
+		})
+
+		AfterEach(func() {
+			close(blocking)
+		})
+
+		It("does not queue the same version twice", func() {
+			prefetcher.Prefetch(logger, spec)
+			Eventually(fakeWorkerClient.AllSatisfyingCallCount).Should(Equal(1))
+
+			prefetcher.Prefetch(logger, spec)
+			Expect(logger.LogMessages()).To(ContainElement("test.already-queued"))
+		})
+
+		It("only prefetches a few versions at once", func() {
+			for i := 0; i < 10; i++ {
+				spec.Version = atc.Version{"some": strconv.Itoa(i)}
+				prefetcher.Prefetch(logger, spec)
+			}
+
+			Eventually(fakeWorkerClient.AllSatisfyingCallCount).Should(Equal(4))
+			Consistently(fakeWorkerClient.AllSatisfyingCallCount).Should(Equal(4))
+		})
+	})
+
+	Context("when prefetching is disabled", func() {
+		BeforeEach(func() {
+			workerCount = 0
+		})
+
+		It("does nothing", func() {
+			prefetcher.Prefetch(logger, spec)
+			Consistently(fakeWorkerClient.AllSatisfyingCallCount).Should(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package resourcefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/resource"
+)
+
+type FakeFetchSourceProviderDB struct {
+	GetVolumesByIdentifierStub        func(db.VolumeIdentifier) ([]db.SavedVolume, error)
+	getVolumesByIdentifierMutex       sync.RWMutex
+	getVolumesByIdentifierArgsForCall []struct {
+		arg1 db.VolumeIdentifier
+	}
+	getVolumesByIdentifierReturns struct {
+		result1 []db.SavedVolume
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFetchSourceProviderDB) GetVolumesByIdentifier(arg1 db.VolumeIdentifier) ([]db.SavedVolume, error) {
+	fake.getVolumesByIdentifierMutex.Lock()
+	fake.getVolumesByIdentifierArgsForCall = append(fake.getVolumesByIdentifierArgsForCall, struct {
+		arg1 db.VolumeIdentifier
+	}{arg1})
+	fake.recordInvocation("GetVolumesByIdentifier", []interface{}{arg1})
+	fake.getVolumesByIdentifierMutex.Unlock()
+	if fake.GetVolumesByIdentifierStub != nil {
+		return fake.GetVolumesByIdentifierStub(arg1)
+	} else {
+		return fake.getVolumesByIdentifierReturns.result1, fake.getVolumesByIdentifierReturns.result2
+	}
+}
+
+func (fake *FakeFetchSourceProviderDB) GetVolumesByIdentifierCallCount() int {
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	return len(fake.getVolumesByIdentifierArgsForCall)
+}
+
+func (fake *FakeFetchSourceProviderDB) GetVolumesByIdentifierArgsForCall(i int) db.VolumeIdentifier {
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	return fake.getVolumesByIdentifierArgsForCall[i].arg1
+}
+
+func (fake *FakeFetchSourceProviderDB) GetVolumesByIdentifierReturns(result1 []db.SavedVolume, result2 error) {
+	fake.GetVolumesByIdentifierStub = nil
+	fake.getVolumesByIdentifierReturns = struct {
+		result1 []db.SavedVolume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFetchSourceProviderDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getVolumesByIdentifierMutex.RLock()
+	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeFetchSourceProviderDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ resource.FetchSourceProviderDB = new(FakeFetchSourceProviderDB)
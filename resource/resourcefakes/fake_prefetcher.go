@@ -0,0 +1,113 @@
+// This file was generated by counterfeiter
+package resourcefakes
+
+import (
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/resource"
+)
+
+type FakePrefetcher struct {
+	RunStub        func(signals <-chan os.Signal, ready chan<- struct{}) error
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		signals <-chan os.Signal
+		ready   chan<- struct{}
+	}
+	runReturns struct {
+		result1 error
+	}
+	PrefetchStub        func(logger lager.Logger, spec resource.PrefetchSpec)
+	prefetchMutex       sync.RWMutex
+	prefetchArgsForCall []struct {
+		logger lager.Logger
+		spec   resource.PrefetchSpec
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePrefetcher) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	fake.runMutex.Lock()
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		signals <-chan os.Signal
+		ready   chan<- struct{}
+	}{signals, ready})
+	fake.recordInvocation("Run", []interface{}{signals, ready})
+	fake.runMutex.Unlock()
+	if fake.RunStub != nil {
+		return fake.RunStub(signals, ready)
+	} else {
+		return fake.runReturns.result1
+	}
+}
+
+func (fake *FakePrefetcher) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *FakePrefetcher) RunArgsForCall(i int) (<-chan os.Signal, chan<- struct{}) {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return fake.runArgsForCall[i].signals, fake.runArgsForCall[i].ready
+}
+
+func (fake *FakePrefetcher) RunReturns(result1 error) {
+	fake.RunStub = nil
+	fake.runReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePrefetcher) Prefetch(logger lager.Logger, spec resource.PrefetchSpec) {
+	fake.prefetchMutex.Lock()
+	fake.prefetchArgsForCall = append(fake.prefetchArgsForCall, struct {
+		logger lager.Logger
+		spec   resource.PrefetchSpec
+	}{logger, spec})
+	fake.recordInvocation("Prefetch", []interface{}{logger, spec})
+	fake.prefetchMutex.Unlock()
+	if fake.PrefetchStub != nil {
+		fake.PrefetchStub(logger, spec)
+	}
+}
+
+func (fake *FakePrefetcher) PrefetchCallCount() int {
+	fake.prefetchMutex.RLock()
+	defer fake.prefetchMutex.RUnlock()
+	return len(fake.prefetchArgsForCall)
+}
+
+func (fake *FakePrefetcher) PrefetchArgsForCall(i int) (lager.Logger, resource.PrefetchSpec) {
+	fake.prefetchMutex.RLock()
+	defer fake.prefetchMutex.RUnlock()
+	return fake.prefetchArgsForCall[i].logger, fake.prefetchArgsForCall[i].spec
+}
+
+func (fake *FakePrefetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	fake.prefetchMutex.RLock()
+	defer fake.prefetchMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePrefetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ resource.Prefetcher = new(FakePrefetcher)
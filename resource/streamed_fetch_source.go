@@ -0,0 +1,152 @@
+package resource
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/worker"
+)
+
+type streamedFetchSource struct {
+	logger           lager.Logger
+	sourceVolume     worker.Volume
+	sourceWorker     worker.Worker
+	worker           worker.Worker
+	cache            Cache
+	versionedSource  VersionedSource
+	cacheIdentifier  CacheIdentifier
+	containerCreator FetchContainerCreator
+	resourceOptions  ResourceOptions
+
+	fallback FetchSource
+}
+
+// NewStreamedFetchSource returns a FetchSource which populates the cache on
+// the chosen worker by streaming an initialized cache volume from another
+// worker, falling back to fetching the resource if streaming fails.
+func NewStreamedFetchSource(
+	logger lager.Logger,
+	sourceVolume worker.Volume,
+	sourceWorker worker.Worker,
+	worker worker.Worker,
+	cacheIdentifier CacheIdentifier,
+	containerCreator FetchContainerCreator,
+	resourceOptions ResourceOptions,
+) FetchSource {
+	return &streamedFetchSource{
+		logger:           logger,
+		sourceVolume:     sourceVolume,
+		sourceWorker:     sourceWorker,
+		worker:           worker,
+		cache:            noopCache{},
+		cacheIdentifier:  cacheIdentifier,
+		containerCreator: containerCreator,
+		resourceOptions:  resourceOptions,
+	}
+}
+
+func (s *streamedFetchSource) IsInitialized() (bool, error) {
+	return s.cache.IsInitialized()
+}
+
+func (s *streamedFetchSource) VersionedSource() VersionedSource {
+	if s.fallback != nil {
+		return s.fallback.VersionedSource()
+	}
+
+	return s.versionedSource
+}
+
+func (s *streamedFetchSource) LockName() (string, error) {
+	return s.resourceOptions.LockName(s.worker.Name())
+}
+
+func (s *streamedFetchSource) Initialize(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := s.stream()
+	s.sourceVolume.Release(nil)
+
+	if err != nil {
+		s.logger.Info("falling-back-to-fetching", lager.Data{"error": err.Error()})
+
+		s.fallback = NewEmptyFetchSource(
+			s.logger,
+			s.worker,
+			s.cacheIdentifier,
+			s.containerCreator,
+			s.resourceOptions,
+		)
+
+		return s.fallback.Initialize(signals, ready)
+	}
+
+	if stdout := s.resourceOptions.IOConfig().Stdout; stdout != nil {
+		fmt.Fprintf(stdout, "streamed version of resource from cache on worker %s\n", s.sourceWorker.Name())
+	}
+
+	close(ready)
+
+	return nil
+}
+
+func (s *streamedFetchSource) Release(finalTTL *time.Duration) {
+	if s.fallback != nil {
+		s.fallback.Release(finalTTL)
+		return
+	}
+
+	if s.cache.Volume() != nil {
+		s.cache.Volume().Release(finalTTL)
+	}
+}
+
+func (s *streamedFetchSource) stream() error {
+	logger := s.logger.Session("stream-cache", lager.Data{
+		"from": s.sourceWorker.Name(),
+		"to":   s.worker.Name(),
+	})
+
+	cachedVolume, err := s.cacheIdentifier.CreateOn(logger, s.worker)
+	if err != nil {
+		logger.Error("failed-to-create-cache", err)
+		return err
+	}
+
+	start := time.Now()
+
+	bytes, err := worker.StreamVolume(logger, s.sourceVolume, cachedVolume)
+	if err != nil {
+		destroyErr := cachedVolume.Destroy()
+		if destroyErr != nil {
+			logger.Error("failed-to-destroy-partial-cache", destroyErr)
+		}
+
+		cachedVolume.Release(nil)
+
+		return err
+	}
+
+	cache := volumeCache{cachedVolume}
+
+	err = cache.Initialize()
+	if err != nil {
+		logger.Error("failed-to-initialize-cache", err)
+		cachedVolume.Release(nil)
+		return err
+	}
+
+	metric.ResourceCacheStreamed{
+		ResourceType:      string(s.resourceOptions.ResourceType()),
+		SourceWorker:      s.sourceWorker.Name(),
+		DestinationWorker: s.worker.Name(),
+		Bytes:             bytes,
+		Duration:          time.Since(start),
+	}.Emit(logger)
+
+	s.cache = cache
+	s.versionedSource = NewGetVersionedSource(cachedVolume, s.resourceOptions.Version(), nil)
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package resource_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/garden"
+	gfakes "code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/resource"
+	"github.com/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamedFetchSource", func() {
+	var (
+		fetchSource FetchSource
+
+		fakeContainer        *workerfakes.FakeContainer
+		fakeContainerCreator *resourcefakes.FakeFetchContainerCreator
+		resourceOptions      *resourcefakes.FakeResourceOptions
+		sourceVolume         *workerfakes.FakeVolume
+		sourceWorker         *workerfakes.FakeWorker
+		newVolume            *workerfakes.FakeVolume
+		fakeWorker           *workerfakes.FakeWorker
+		cacheID              *resourcefakes.FakeCacheIdentifier
+
+		signals <-chan os.Signal
+		ready   chan struct{}
+
+		initErr error
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("test")
+		signals = make(<-chan os.Signal)
+		ready = make(chan struct{})
+
+		resourceOptions = new(resourcefakes.FakeResourceOptions)
+		resourceOptions.ResourceTypeReturns(ResourceType("fake-resource-type"))
+
+		fakeContainer = new(workerfakes.FakeContainer)
+		fakeContainer.PropertyReturns("", errors.New("nope"))
+		inProcess := new(gfakes.FakeProcess)
+		inProcess.IDReturns("process-id")
+		inProcess.WaitStub = func() (int, error) {
+			return 0, nil
+		}
+		fakeContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+			_, err := io.Stdout.Write([]byte("{}"))
+			Expect(err).NotTo(HaveOccurred())
+
+			return inProcess, nil
+		}
+
+		fakeContainerCreator = new(resourcefakes.FakeFetchContainerCreator)
+		fakeContainerCreator.CreateWithVolumeReturns(fakeContainer, nil)
+
+		sourceVolume = new(workerfakes.FakeVolume)
+		sourceVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar-stream")), nil)
+		sourceWorker = new(workerfakes.FakeWorker)
+		sourceWorker.NameReturns("source-worker")
+
+		newVolume = new(workerfakes.FakeVolume)
+		fakeWorker = new(workerfakes.FakeWorker)
+		fakeWorker.NameReturns("chosen-worker")
+
+		cacheID = new(resourcefakes.FakeCacheIdentifier)
+		cacheID.CreateOnReturns(newVolume, nil)
+
+		fetchSource = NewStreamedFetchSource(
+			logger,
+			sourceVolume,
+			sourceWorker,
+			fakeWorker,
+			cacheID,
+			fakeContainerCreator,
+			resourceOptions,
+		)
+	})
+
+	Describe("Initialize", func() {
+		JustBeforeEach(func() {
+			initErr = fetchSource.Initialize(signals, ready)
+		})
+
+		It("creates the cache volume on the chosen worker", func() {
+			Expect(initErr).NotTo(HaveOccurred())
+			Expect(cacheID.CreateOnCallCount()).To(Equal(1))
+			_, worker := cacheID.CreateOnArgsForCall(0)
+			Expect(worker).To(Equal(fakeWorker))
+		})
+
+		It("streams the source volume into the new cache volume", func() {
+			Expect(initErr).NotTo(HaveOccurred())
+			Expect(sourceVolume.StreamOutCallCount()).To(Equal(1))
+			Expect(newVolume.StreamInCallCount()).To(Equal(1))
+		})
+
+		It("initializes the cache without running the resource", func() {
+			Expect(initErr).NotTo(HaveOccurred())
+			Expect(newVolume.SetPropertyCallCount()).To(Equal(1))
+			Expect(fakeContainerCreator.CreateWithVolumeCallCount()).To(Equal(0))
+		})
+
+		It("releases the source volume", func() {
+			Expect(sourceVolume.ReleaseCallCount()).To(Equal(1))
+		})
+
+		It("is ready", func() {
+			Expect(ready).To(BeClosed())
+		})
+
+		Context("when streaming fails", func() {
+			BeforeEach(func() {
+				newVolume.StreamInReturns(errors.New("nope"))
+				cacheID.FindOnReturns(nil, false, nil)
+			})
+
+			It("destroys the partial cache", func() {
+				Expect(newVolume.DestroyCallCount()).To(Equal(1))
+			})
+
+			It("falls back to fetching the resource on the chosen worker", func() {
+				Expect(initErr).NotTo(HaveOccurred())
+				Expect(cacheID.CreateOnCallCount()).To(Equal(2))
+				Expect(fakeContainerCreator.CreateWithVolumeCallCount()).To(Equal(1))
+				_, _, worker := fakeContainerCreator.CreateWithVolumeArgsForCall(0)
+				Expect(worker).To(Equal(fakeWorker))
+				Expect(fakeContainer.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when creating the cache volume fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				cacheID.CreateOnReturns(nil, disaster)
+			})
+
+			It("does not stream", func() {
+				Expect(sourceVolume.StreamOutCallCount()).To(Equal(0))
+			})
+
+			It("returns the error from fetching", func() {
+				Expect(initErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		BeforeEach(func() {
+			err := fetchSource.Initialize(signals, ready)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("releases the streamed cache volume", func() {
+			fetchSource.Release(nil)
+			Expect(newVolume.ReleaseCallCount()).To(Equal(1))
+			Expect(newVolume.ReleaseArgsForCall(0)).To(BeNil())
+		})
+	})
+})
@@ -3,14 +3,12 @@ package resource_test
 import (
 	"errors"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/garden"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/concourse/atc/resource"
 	"github.com/concourse/atc/resource/resourcefakes"
-	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -124,11 +122,10 @@ var _ = Describe("VolumeFetchSource", func() {
 
 	Describe("Release", func() {
 		It("releases volume", func() {
-			finalTTL := worker.FinalTTL(5 * time.Second)
-			fetchSource.Release(finalTTL)
+			fetchSource.Release(nil)
 			Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
 			ttl := fakeVolume.ReleaseArgsForCall(0)
-			Expect(ttl).To(Equal(finalTTL))
+			Expect(ttl).To(BeNil())
 		})
 
 		Context("when initialized", func() {
@@ -138,11 +135,10 @@ var _ = Describe("VolumeFetchSource", func() {
 			})
 
 			It("releases container", func() {
-				finalTTL := worker.FinalTTL(5 * time.Second)
-				fetchSource.Release(finalTTL)
+				fetchSource.Release(nil)
 				Expect(fakeContainer.ReleaseCallCount()).To(Equal(1))
 				ttl := fakeContainer.ReleaseArgsForCall(0)
-				Expect(ttl).To(Equal(finalTTL))
+				Expect(ttl).To(BeNil())
 			})
 		})
 	})
@@ -14,18 +14,24 @@ const (
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
-
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetBuildProvenance  = "GetBuildProvenance"
+	GetBuildTestReports = "GetBuildTestReports"
+
+	ListBuildQueue = "ListBuildQueue"
+
+	GetJob               = "GetJob"
+	CreateJobBuild       = "CreateJobBuild"
+	ListJobs             = "ListJobs"
+	ListJobBuilds        = "ListJobBuilds"
+	ListJobInputs        = "ListJobInputs"
+	ExplainJob           = "ExplainJob"
+	GetJobTestStatistics = "GetJobTestStatistics"
+	GetJobBuild          = "GetJobBuild"
+	PauseJob             = "PauseJob"
+	UnpauseJob           = "UnpauseJob"
+	GetVersionsDB        = "GetVersionsDB"
+	JobBadge             = "JobBadge"
+	MainJobBadge         = "MainJobBadge"
 
 	ListResources   = "ListResources"
 	GetResource     = "GetResource"
@@ -38,6 +44,7 @@ const (
 	DisableResourceVersion        = "DisableResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
+	GetVersionProvenance          = "GetVersionProvenance"
 
 	ListAllPipelines = "ListAllPipelines"
 	ListPipelines    = "ListPipelines"
@@ -46,6 +53,7 @@ const (
 	OrderPipelines   = "OrderPipelines"
 	PausePipeline    = "PausePipeline"
 	UnpausePipeline  = "UnpausePipeline"
+	ArchivePipeline  = "ArchivePipeline"
 	ExposePipeline   = "ExposePipeline"
 	HidePipeline     = "HidePipeline"
 	RenamePipeline   = "RenamePipeline"
@@ -54,8 +62,9 @@ const (
 	WritePipe  = "WritePipe"
 	ReadPipe   = "ReadPipe"
 
-	RegisterWorker = "RegisterWorker"
-	ListWorkers    = "ListWorkers"
+	RegisterWorker  = "RegisterWorker"
+	HeartbeatWorker = "HeartbeatWorker"
+	ListWorkers     = "ListWorkers"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -63,18 +72,32 @@ const (
 	DownloadCLI = "DownloadCLI"
 	GetInfo     = "Info"
 
-	ListContainers  = "ListContainers"
-	GetContainer    = "GetContainer"
-	HijackContainer = "HijackContainer"
+	ListContainers    = "ListContainers"
+	GetContainer      = "GetContainer"
+	HijackContainer   = "HijackContainer"
+	InspectContainers = "InspectContainers"
+	DestroyContainer  = "DestroyContainer"
+
+	ListHijackSessions        = "ListHijackSessions"
+	GetHijackSessionRecording = "GetHijackSessionRecording"
 
-	ListVolumes = "ListVolumes"
+	ListVolumes    = "ListVolumes"
+	InspectVolumes = "InspectVolumes"
+	DestroyVolume  = "DestroyVolume"
 
 	ListAuthMethods = "ListAuthMethods"
 	GetAuthToken    = "GetAuthToken"
 	GetUser         = "GetUser"
+	GetSigningKeys  = "GetSigningKeys"
+
+	ListTeams  = "ListTeams"
+	SetTeam    = "SetTeam"
+	ExportTeam = "ExportTeam"
+	ImportTeam = "ImportTeam"
 
-	ListTeams = "ListTeams"
-	SetTeam   = "SetTeam"
+	ListAPITokens  = "ListAPITokens"
+	CreateAPIToken = "CreateAPIToken"
+	RevokeAPIToken = "RevokeAPIToken"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -89,12 +112,18 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/test-reports", Method: "GET", Name: GetBuildTestReports},
+	{Path: "/api/v1/teams/:team_name/builds/:build_id/provenance", Method: "GET", Name: GetBuildProvenance},
+
+	{Path: "/api/v1/build-queue", Method: "GET", Name: ListBuildQueue},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/explain", Method: "GET", Name: ExplainJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/test-statistics", Method: "GET", Name: GetJobTestStatistics},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -108,6 +137,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/archive", Method: "PUT", Name: ArchivePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/expose", Method: "PUT", Name: ExposePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
@@ -124,6 +154,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/provenance", Method: "GET", Name: GetVersionProvenance},
 
 	{Path: "/api/v1/pipes", Method: "POST", Name: CreatePipe},
 	{Path: "/api/v1/pipes/:pipe_id", Method: "PUT", Name: WritePipe},
@@ -131,6 +162,7 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
+	{Path: "/api/v1/workers/heartbeat", Method: "GET", Name: HeartbeatWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -141,13 +173,27 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/containers", Method: "GET", Name: ListContainers},
 	{Path: "/api/v1/containers/:id", Method: "GET", Name: GetContainer},
 	{Path: "/api/v1/containers/:id/hijack", Method: "GET", Name: HijackContainer},
+	{Path: "/api/v1/containers/:id", Method: "DELETE", Name: DestroyContainer},
+	{Path: "/api/v1/inspect/containers", Method: "GET", Name: InspectContainers},
+
+	{Path: "/api/v1/hijack-sessions", Method: "GET", Name: ListHijackSessions},
+	{Path: "/api/v1/hijack-sessions/:hijack_session_id/recording", Method: "GET", Name: GetHijackSessionRecording},
 
 	{Path: "/api/v1/volumes", Method: "GET", Name: ListVolumes},
+	{Path: "/api/v1/volumes/:handle", Method: "DELETE", Name: DestroyVolume},
+	{Path: "/api/v1/inspect/volumes", Method: "GET", Name: InspectVolumes},
 
 	{Path: "/api/v1/teams/:team_name/auth/methods", Method: "GET", Name: ListAuthMethods},
 	{Path: "/api/v1/teams/:team_name/auth/token", Method: "GET", Name: GetAuthToken},
 	{Path: "/api/v1/user", Method: "GET", Name: GetUser},
+	{Path: "/api/v1/auth/keys", Method: "GET", Name: GetSigningKeys},
 
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
+	{Path: "/api/v1/teams/:team_name/export", Method: "GET", Name: ExportTeam},
+	{Path: "/api/v1/teams/:team_name/import", Method: "PUT", Name: ImportTeam},
+
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "GET", Name: ListAPITokens},
+	{Path: "/api/v1/teams/:team_name/api-tokens", Method: "POST", Name: CreateAPIToken},
+	{Path: "/api/v1/teams/:team_name/api-tokens/:api_token_name", Method: "DELETE", Name: RevokeAPIToken},
 })
@@ -0,0 +1,84 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue"
+)
+
+type FakeUpdater struct {
+	UpdateBuildQueueBlockedStub        func(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+	updateBuildQueueBlockedMutex       sync.RWMutex
+	updateBuildQueueBlockedArgsForCall []struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+		buildID   int
+	}
+	updateBuildQueueBlockedReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpdater) UpdateBuildQueueBlocked(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+	fake.updateBuildQueueBlockedMutex.Lock()
+	fake.updateBuildQueueBlockedArgsForCall = append(fake.updateBuildQueueBlockedArgsForCall, struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+		buildID   int
+	}{logger, jobConfig, buildID})
+	fake.recordInvocation("UpdateBuildQueueBlocked", []interface{}{logger, jobConfig, buildID})
+	fake.updateBuildQueueBlockedMutex.Unlock()
+	if fake.UpdateBuildQueueBlockedStub != nil {
+		return fake.UpdateBuildQueueBlockedStub(logger, jobConfig, buildID)
+	} else {
+		return fake.updateBuildQueueBlockedReturns.result1, fake.updateBuildQueueBlockedReturns.result2
+	}
+}
+
+func (fake *FakeUpdater) UpdateBuildQueueBlockedCallCount() int {
+	fake.updateBuildQueueBlockedMutex.RLock()
+	defer fake.updateBuildQueueBlockedMutex.RUnlock()
+	return len(fake.updateBuildQueueBlockedArgsForCall)
+}
+
+func (fake *FakeUpdater) UpdateBuildQueueBlockedArgsForCall(i int) (lager.Logger, atc.JobConfig, int) {
+	fake.updateBuildQueueBlockedMutex.RLock()
+	defer fake.updateBuildQueueBlockedMutex.RUnlock()
+	return fake.updateBuildQueueBlockedArgsForCall[i].logger, fake.updateBuildQueueBlockedArgsForCall[i].jobConfig, fake.updateBuildQueueBlockedArgsForCall[i].buildID
+}
+
+func (fake *FakeUpdater) UpdateBuildQueueBlockedReturns(result1 bool, result2 error) {
+	fake.UpdateBuildQueueBlockedStub = nil
+	fake.updateBuildQueueBlockedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.updateBuildQueueBlockedMutex.RLock()
+	defer fake.updateBuildQueueBlockedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUpdater) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.Updater = new(FakeUpdater)
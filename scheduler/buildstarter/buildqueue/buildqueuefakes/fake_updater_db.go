@@ -0,0 +1,114 @@
+// This file was generated by counterfeiter
+package buildqueuefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue"
+)
+
+type FakeUpdaterDB struct {
+	GetBuildQueueStub        func() (db.BuildQueue, error)
+	getBuildQueueMutex       sync.RWMutex
+	getBuildQueueArgsForCall []struct{}
+	getBuildQueueReturns     struct {
+		result1 db.BuildQueue
+		result2 error
+	}
+	SetBuildQueueBlockedStub        func(jobName string, blocked bool) error
+	setBuildQueueBlockedMutex       sync.RWMutex
+	setBuildQueueBlockedArgsForCall []struct {
+		jobName string
+		blocked bool
+	}
+	setBuildQueueBlockedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpdaterDB) GetBuildQueue() (db.BuildQueue, error) {
+	fake.getBuildQueueMutex.Lock()
+	fake.getBuildQueueArgsForCall = append(fake.getBuildQueueArgsForCall, struct{}{})
+	fake.recordInvocation("GetBuildQueue", []interface{}{})
+	fake.getBuildQueueMutex.Unlock()
+	if fake.GetBuildQueueStub != nil {
+		return fake.GetBuildQueueStub()
+	} else {
+		return fake.getBuildQueueReturns.result1, fake.getBuildQueueReturns.result2
+	}
+}
+
+func (fake *FakeUpdaterDB) GetBuildQueueCallCount() int {
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
+	return len(fake.getBuildQueueArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) GetBuildQueueReturns(result1 db.BuildQueue, result2 error) {
+	fake.GetBuildQueueStub = nil
+	fake.getBuildQueueReturns = struct {
+		result1 db.BuildQueue
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdaterDB) SetBuildQueueBlocked(jobName string, blocked bool) error {
+	fake.setBuildQueueBlockedMutex.Lock()
+	fake.setBuildQueueBlockedArgsForCall = append(fake.setBuildQueueBlockedArgsForCall, struct {
+		jobName string
+		blocked bool
+	}{jobName, blocked})
+	fake.recordInvocation("SetBuildQueueBlocked", []interface{}{jobName, blocked})
+	fake.setBuildQueueBlockedMutex.Unlock()
+	if fake.SetBuildQueueBlockedStub != nil {
+		return fake.SetBuildQueueBlockedStub(jobName, blocked)
+	} else {
+		return fake.setBuildQueueBlockedReturns.result1
+	}
+}
+
+func (fake *FakeUpdaterDB) SetBuildQueueBlockedCallCount() int {
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	return len(fake.setBuildQueueBlockedArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) SetBuildQueueBlockedArgsForCall(i int) (string, bool) {
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	return fake.setBuildQueueBlockedArgsForCall[i].jobName, fake.setBuildQueueBlockedArgsForCall[i].blocked
+}
+
+func (fake *FakeUpdaterDB) SetBuildQueueBlockedReturns(result1 error) {
+	fake.SetBuildQueueBlockedStub = nil
+	fake.setBuildQueueBlockedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUpdaterDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildQueueMutex.RLock()
+	defer fake.getBuildQueueMutex.RUnlock()
+	fake.setBuildQueueBlockedMutex.RLock()
+	defer fake.setBuildQueueBlockedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUpdaterDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ buildqueue.UpdaterDB = new(FakeUpdaterDB)
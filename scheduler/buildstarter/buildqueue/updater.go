@@ -0,0 +1,69 @@
+package buildqueue
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Updater
+
+type Updater interface {
+	UpdateBuildQueueBlocked(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+}
+
+//go:generate counterfeiter . UpdaterDB
+
+type UpdaterDB interface {
+	GetBuildQueue() (db.BuildQueue, error)
+	SetBuildQueueBlocked(jobName string, blocked bool) error
+}
+
+// NewUpdater returns an Updater which only lets builds leave the queue while
+// the workers have room for them. A maxBuildsPerWorker of zero means the
+// workers' capacity is unlimited.
+func NewUpdater(db UpdaterDB, maxBuildsPerWorker int) Updater {
+	return &updater{
+		db:                 db,
+		maxBuildsPerWorker: maxBuildsPerWorker,
+	}
+}
+
+type updater struct {
+	db                 UpdaterDB
+	maxBuildsPerWorker int
+}
+
+func (u *updater) UpdateBuildQueueBlocked(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+	logger = logger.Session("is-build-queue-blocked")
+
+	blocked := false
+
+	if u.maxBuildsPerWorker > 0 {
+		queue, err := u.db.GetBuildQueue()
+		if err != nil {
+			logger.Error("failed-to-get-build-queue", err)
+			return false, err
+		}
+
+		slots := queue.Workers*u.maxBuildsPerWorker - queue.RunningBuilds
+
+		position, found := queue.Position(buildID)
+		if found && position > slots {
+			logger.Info("waiting-for-worker-capacity", lager.Data{
+				"position": position,
+				"slots":    slots,
+			})
+
+			blocked = true
+		}
+	}
+
+	err := u.db.SetBuildQueueBlocked(jobConfig.Name, blocked)
+	if err != nil {
+		logger.Error("failed-to-set-build-queue-blocked", err)
+		return false, err
+	}
+
+	return blocked, nil
+}
@@ -0,0 +1,131 @@
+package buildqueue_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue/buildqueuefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updater", func() {
+	var (
+		fakeDB             *buildqueuefakes.FakeUpdaterDB
+		maxBuildsPerWorker int
+		disaster           error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(buildqueuefakes.FakeUpdaterDB)
+		maxBuildsPerWorker = 2
+		disaster = errors.New("bad thing")
+	})
+
+	Describe("UpdateBuildQueueBlocked", func() {
+		var queue db.BuildQueue
+		var queueErr error
+		var updateErr error
+		var blocked bool
+
+		BeforeEach(func() {
+			queue = db.BuildQueue{
+				Builds: []db.QueuedBuild{
+					{BuildID: 11},
+					{BuildID: 22},
+					{BuildID: 33},
+				},
+				RunningBuilds: 2,
+				Workers:       2,
+			}
+			queueErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeDB.GetBuildQueueReturns(queue, queueErr)
+
+			updater := buildqueue.NewUpdater(fakeDB, maxBuildsPerWorker)
+			blocked, updateErr = updater.UpdateBuildQueueBlocked(
+				lagertest.NewTestLogger("test"),
+				atc.JobConfig{Name: "some-job"},
+				22,
+			)
+		})
+
+		itSetsBlockedTo := func(expected bool) {
+			It("returns whether the build is blocked and no error", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(blocked).To(Equal(expected))
+			})
+
+			It("records whether the job is blocked", func() {
+				Expect(fakeDB.SetBuildQueueBlockedCallCount()).To(Equal(1))
+				jobName, actualBlocked := fakeDB.SetBuildQueueBlockedArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(actualBlocked).To(Equal(expected))
+			})
+		}
+
+		Context("when the workers have room for the build's position in the queue", func() {
+			itSetsBlockedTo(false)
+		})
+
+		Context("when the workers do not have room for the build's position in the queue", func() {
+			BeforeEach(func() {
+				queue.RunningBuilds = 3
+			})
+
+			itSetsBlockedTo(true)
+		})
+
+		Context("when the build is not in the queue", func() {
+			BeforeEach(func() {
+				queue.RunningBuilds = 4
+				queue.Builds = []db.QueuedBuild{{BuildID: 11}}
+			})
+
+			itSetsBlockedTo(false)
+		})
+
+		Context("when the workers' capacity is unlimited", func() {
+			BeforeEach(func() {
+				maxBuildsPerWorker = 0
+				queue.RunningBuilds = 100
+			})
+
+			itSetsBlockedTo(false)
+
+			It("does not look at the queue", func() {
+				Expect(fakeDB.GetBuildQueueCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when getting the build queue fails", func() {
+			BeforeEach(func() {
+				queueErr = disaster
+			})
+
+			It("returns the error", func() {
+				Expect(updateErr).To(Equal(disaster))
+			})
+
+			It("does not update the job", func() {
+				Expect(fakeDB.SetBuildQueueBlockedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when recording whether the job is blocked fails", func() {
+			BeforeEach(func() {
+				fakeDB.SetBuildQueueBlockedReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(updateErr).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -5,7 +5,9 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue"
 	"github.com/concourse/atc/scheduler/buildstarter/maxinflight"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota"
 )
 
 //go:generate counterfeiter . BuildStarter
@@ -45,12 +47,16 @@ type BuildFactory interface {
 func NewBuildStarter(
 	db BuildStarterDB,
 	maxInFlightUpdater maxinflight.Updater,
+	teamQuotaUpdater teamquota.Updater,
+	buildQueueUpdater buildqueue.Updater,
 	factory BuildFactory,
 	execEngine engine.Engine,
 ) BuildStarter {
 	return &buildStarter{
 		db:                 db,
 		maxInFlightUpdater: maxInFlightUpdater,
+		teamQuotaUpdater:   teamQuotaUpdater,
+		buildQueueUpdater:  buildQueueUpdater,
 		factory:            factory,
 		execEngine:         execEngine,
 	}
@@ -59,6 +65,8 @@ func NewBuildStarter(
 type buildStarter struct {
 	db                 BuildStarterDB
 	maxInFlightUpdater maxinflight.Updater
+	teamQuotaUpdater   teamquota.Updater
+	buildQueueUpdater  buildqueue.Updater
 	factory            BuildFactory
 	execEngine         engine.Engine
 }
@@ -104,6 +112,22 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	reachedTeamQuota, err := s.teamQuotaUpdater.UpdateTeamQuotaReached(logger, jobConfig)
+	if err != nil {
+		return false, err
+	}
+	if reachedTeamQuota {
+		return false, nil
+	}
+
+	blockedInQueue, err := s.buildQueueUpdater.UpdateBuildQueueBlocked(logger, jobConfig, nextPendingBuild.ID())
+	if err != nil {
+		return false, err
+	}
+	if blockedInQueue {
+		return false, nil
+	}
+
 	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
 	if err != nil {
 		logger.Error("failed-to-get-next-build-inputs", err)
@@ -141,8 +165,10 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, err
 	}
 
+	// the team quota is checked again as the build is scheduled, as another
+	// scheduler may have taken the team's last slot since
 	if !updated {
-		logger.Debug("build-already-scheduled")
+		logger.Debug("build-already-scheduled-or-team-quota-reached")
 		return false, nil
 	}
 
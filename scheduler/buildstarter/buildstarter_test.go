@@ -11,8 +11,10 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
 	"github.com/concourse/atc/scheduler/buildstarter"
+	"github.com/concourse/atc/scheduler/buildstarter/buildqueue/buildqueuefakes"
 	"github.com/concourse/atc/scheduler/buildstarter/buildstarterfakes"
 	"github.com/concourse/atc/scheduler/buildstarter/maxinflight/maxinflightfakes"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota/teamquotafakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,6 +24,8 @@ var _ = Describe("I'm a BuildStarter", func() {
 	var (
 		fakeDB        *buildstarterfakes.FakeBuildStarterDB
 		fakeUpdater   *maxinflightfakes.FakeUpdater
+		fakeQuota     *teamquotafakes.FakeUpdater
+		fakeQueue     *buildqueuefakes.FakeUpdater
 		fakeFactory   *buildstarterfakes.FakeBuildFactory
 		fakeEngine    *enginefakes.FakeEngine
 		pendingBuilds []db.Build
@@ -34,11 +38,13 @@ var _ = Describe("I'm a BuildStarter", func() {
 	BeforeEach(func() {
 		fakeDB = new(buildstarterfakes.FakeBuildStarterDB)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
+		fakeQuota = new(teamquotafakes.FakeUpdater)
+		fakeQueue = new(buildqueuefakes.FakeUpdater)
 		fakeFactory = new(buildstarterfakes.FakeBuildFactory)
 		fakeEngine = new(enginefakes.FakeEngine)
 		pendingBuilds = []db.Build{new(dbfakes.FakeBuild)}
 
-		buildStarter = buildstarter.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeEngine)
+		buildStarter = buildstarter.NewBuildStarter(fakeDB, fakeUpdater, fakeQuota, fakeQueue, fakeFactory, fakeEngine)
 
 		disaster = errors.New("bad thing")
 	})
@@ -324,6 +330,61 @@ var _ = Describe("I'm a BuildStarter", func() {
 					itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 				})
 
+				Context("when updating team quota reached fails", func() {
+					BeforeEach(func() {
+						fakeQuota.UpdateTeamQuotaReachedReturns(false, disaster)
+					})
+
+					itReturnsTheError()
+					itUpdatedMaxInFlightForTheFirstBuild()
+				})
+
+				Context("when the team quota is reached", func() {
+					BeforeEach(func() {
+						fakeQuota.UpdateTeamQuotaReachedReturns(true, nil)
+					})
+
+					itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+
+					It("updated team quota reached for the job", func() {
+						Expect(fakeQuota.UpdateTeamQuotaReachedCallCount()).To(Equal(1))
+						_, actualJobConfig := fakeQuota.UpdateTeamQuotaReachedArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+					})
+
+					It("doesn't look for next build inputs", func() {
+						Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when updating build queue blocked fails", func() {
+					BeforeEach(func() {
+						fakeQueue.UpdateBuildQueueBlockedReturns(false, disaster)
+					})
+
+					itReturnsTheError()
+					itUpdatedMaxInFlightForTheFirstBuild()
+				})
+
+				Context("when the build is blocked in the build queue", func() {
+					BeforeEach(func() {
+						fakeQueue.UpdateBuildQueueBlockedReturns(true, nil)
+					})
+
+					itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+
+					It("checked the build queue for the first build", func() {
+						Expect(fakeQueue.UpdateBuildQueueBlockedCallCount()).To(Equal(1))
+						_, actualJobConfig, actualBuildID := fakeQueue.UpdateBuildQueueBlockedArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+						Expect(actualBuildID).To(Equal(99))
+					})
+
+					It("doesn't look for next build inputs", func() {
+						Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+					})
+				})
+
 				Context("when getting the next build inputs fails", func() {
 					BeforeEach(func() {
 						fakeDB.GetNextBuildInputsReturns(nil, false, disaster)
@@ -0,0 +1,13 @@
+package teamquota_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTeamquota(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Teamquota Suite")
+}
@@ -0,0 +1,82 @@
+// This file was generated by counterfeiter
+package teamquotafakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota"
+)
+
+type FakeUpdater struct {
+	UpdateTeamQuotaReachedStub        func(logger lager.Logger, jobConfig atc.JobConfig) (bool, error)
+	updateTeamQuotaReachedMutex       sync.RWMutex
+	updateTeamQuotaReachedArgsForCall []struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+	}
+	updateTeamQuotaReachedReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpdater) UpdateTeamQuotaReached(logger lager.Logger, jobConfig atc.JobConfig) (bool, error) {
+	fake.updateTeamQuotaReachedMutex.Lock()
+	fake.updateTeamQuotaReachedArgsForCall = append(fake.updateTeamQuotaReachedArgsForCall, struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+	}{logger, jobConfig})
+	fake.recordInvocation("UpdateTeamQuotaReached", []interface{}{logger, jobConfig})
+	fake.updateTeamQuotaReachedMutex.Unlock()
+	if fake.UpdateTeamQuotaReachedStub != nil {
+		return fake.UpdateTeamQuotaReachedStub(logger, jobConfig)
+	} else {
+		return fake.updateTeamQuotaReachedReturns.result1, fake.updateTeamQuotaReachedReturns.result2
+	}
+}
+
+func (fake *FakeUpdater) UpdateTeamQuotaReachedCallCount() int {
+	fake.updateTeamQuotaReachedMutex.RLock()
+	defer fake.updateTeamQuotaReachedMutex.RUnlock()
+	return len(fake.updateTeamQuotaReachedArgsForCall)
+}
+
+func (fake *FakeUpdater) UpdateTeamQuotaReachedArgsForCall(i int) (lager.Logger, atc.JobConfig) {
+	fake.updateTeamQuotaReachedMutex.RLock()
+	defer fake.updateTeamQuotaReachedMutex.RUnlock()
+	return fake.updateTeamQuotaReachedArgsForCall[i].logger, fake.updateTeamQuotaReachedArgsForCall[i].jobConfig
+}
+
+func (fake *FakeUpdater) UpdateTeamQuotaReachedReturns(result1 bool, result2 error) {
+	fake.UpdateTeamQuotaReachedStub = nil
+	fake.updateTeamQuotaReachedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.updateTeamQuotaReachedMutex.RLock()
+	defer fake.updateTeamQuotaReachedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUpdater) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ teamquota.Updater = new(FakeUpdater)
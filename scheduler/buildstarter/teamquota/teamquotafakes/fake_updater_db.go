@@ -0,0 +1,116 @@
+// This file was generated by counterfeiter
+package teamquotafakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota"
+)
+
+type FakeUpdaterDB struct {
+	GetTeamQuotaAndUsageStub        func() (db.TeamQuota, db.TeamQuotaUsage, error)
+	getTeamQuotaAndUsageMutex       sync.RWMutex
+	getTeamQuotaAndUsageArgsForCall []struct{}
+	getTeamQuotaAndUsageReturns     struct {
+		result1 db.TeamQuota
+		result2 db.TeamQuotaUsage
+		result3 error
+	}
+	SetTeamQuotaReachedStub        func(jobName string, reached bool) error
+	setTeamQuotaReachedMutex       sync.RWMutex
+	setTeamQuotaReachedArgsForCall []struct {
+		jobName string
+		reached bool
+	}
+	setTeamQuotaReachedReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpdaterDB) GetTeamQuotaAndUsage() (db.TeamQuota, db.TeamQuotaUsage, error) {
+	fake.getTeamQuotaAndUsageMutex.Lock()
+	fake.getTeamQuotaAndUsageArgsForCall = append(fake.getTeamQuotaAndUsageArgsForCall, struct{}{})
+	fake.recordInvocation("GetTeamQuotaAndUsage", []interface{}{})
+	fake.getTeamQuotaAndUsageMutex.Unlock()
+	if fake.GetTeamQuotaAndUsageStub != nil {
+		return fake.GetTeamQuotaAndUsageStub()
+	} else {
+		return fake.getTeamQuotaAndUsageReturns.result1, fake.getTeamQuotaAndUsageReturns.result2, fake.getTeamQuotaAndUsageReturns.result3
+	}
+}
+
+func (fake *FakeUpdaterDB) GetTeamQuotaAndUsageCallCount() int {
+	fake.getTeamQuotaAndUsageMutex.RLock()
+	defer fake.getTeamQuotaAndUsageMutex.RUnlock()
+	return len(fake.getTeamQuotaAndUsageArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) GetTeamQuotaAndUsageReturns(result1 db.TeamQuota, result2 db.TeamQuotaUsage, result3 error) {
+	fake.GetTeamQuotaAndUsageStub = nil
+	fake.getTeamQuotaAndUsageReturns = struct {
+		result1 db.TeamQuota
+		result2 db.TeamQuotaUsage
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeUpdaterDB) SetTeamQuotaReached(jobName string, reached bool) error {
+	fake.setTeamQuotaReachedMutex.Lock()
+	fake.setTeamQuotaReachedArgsForCall = append(fake.setTeamQuotaReachedArgsForCall, struct {
+		jobName string
+		reached bool
+	}{jobName, reached})
+	fake.recordInvocation("SetTeamQuotaReached", []interface{}{jobName, reached})
+	fake.setTeamQuotaReachedMutex.Unlock()
+	if fake.SetTeamQuotaReachedStub != nil {
+		return fake.SetTeamQuotaReachedStub(jobName, reached)
+	} else {
+		return fake.setTeamQuotaReachedReturns.result1
+	}
+}
+
+func (fake *FakeUpdaterDB) SetTeamQuotaReachedCallCount() int {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return len(fake.setTeamQuotaReachedArgsForCall)
+}
+
+func (fake *FakeUpdaterDB) SetTeamQuotaReachedArgsForCall(i int) (string, bool) {
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return fake.setTeamQuotaReachedArgsForCall[i].jobName, fake.setTeamQuotaReachedArgsForCall[i].reached
+}
+
+func (fake *FakeUpdaterDB) SetTeamQuotaReachedReturns(result1 error) {
+	fake.SetTeamQuotaReachedStub = nil
+	fake.setTeamQuotaReachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUpdaterDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getTeamQuotaAndUsageMutex.RLock()
+	defer fake.getTeamQuotaAndUsageMutex.RUnlock()
+	fake.setTeamQuotaReachedMutex.RLock()
+	defer fake.setTeamQuotaReachedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUpdaterDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ teamquota.UpdaterDB = new(FakeUpdaterDB)
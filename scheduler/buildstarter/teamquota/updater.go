@@ -0,0 +1,53 @@
+package teamquota
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Updater
+
+type Updater interface {
+	UpdateTeamQuotaReached(logger lager.Logger, jobConfig atc.JobConfig) (bool, error)
+}
+
+//go:generate counterfeiter . UpdaterDB
+
+type UpdaterDB interface {
+	GetTeamQuotaAndUsage() (db.TeamQuota, db.TeamQuotaUsage, error)
+	SetTeamQuotaReached(jobName string, reached bool) error
+}
+
+func NewUpdater(db UpdaterDB) Updater {
+	return &updater{db: db}
+}
+
+type updater struct {
+	db UpdaterDB
+}
+
+func (u *updater) UpdateTeamQuotaReached(logger lager.Logger, jobConfig atc.JobConfig) (bool, error) {
+	logger = logger.Session("is-team-quota-reached")
+
+	quota, usage, err := u.db.GetTeamQuotaAndUsage()
+	if err != nil {
+		logger.Error("failed-to-get-team-quota-and-usage", err)
+		return false, err
+	}
+
+	reason := quota.BuildsReached(usage)
+	if reason != "" {
+		logger.Info("team-quota-reached", lager.Data{"reason": reason})
+	}
+
+	reached := reason != ""
+
+	err = u.db.SetTeamQuotaReached(jobConfig.Name, reached)
+	if err != nil {
+		logger.Error("failed-to-set-team-quota-reached", err)
+		return false, err
+	}
+
+	return reached, nil
+}
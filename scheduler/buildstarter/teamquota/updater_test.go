@@ -0,0 +1,138 @@
+package teamquota_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota"
+	"github.com/concourse/atc/scheduler/buildstarter/teamquota/teamquotafakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updater", func() {
+	var (
+		fakeDB   *teamquotafakes.FakeUpdaterDB
+		updater  teamquota.Updater
+		disaster error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(teamquotafakes.FakeUpdaterDB)
+		updater = teamquota.NewUpdater(fakeDB)
+		disaster = errors.New("bad thing")
+	})
+
+	Describe("UpdateTeamQuotaReached", func() {
+		var quota db.TeamQuota
+		var usage db.TeamQuotaUsage
+		var quotaErr error
+		var updateErr error
+		var reached bool
+
+		BeforeEach(func() {
+			quota = db.TeamQuota{}
+			usage = db.TeamQuotaUsage{
+				RunningBuilds: 3,
+				Containers:    10,
+				VolumeBytes:   1024,
+			}
+			quotaErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeDB.GetTeamQuotaAndUsageReturns(quota, usage, quotaErr)
+
+			reached, updateErr = updater.UpdateTeamQuotaReached(
+				lagertest.NewTestLogger("test"),
+				atc.JobConfig{Name: "some-job"},
+			)
+		})
+
+		itReturnsFalseAndNoError := func() {
+			It("returns false and no error", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeFalse())
+				Expect(fakeDB.SetTeamQuotaReachedCallCount()).To(Equal(1))
+				jobName, actualReached := fakeDB.SetTeamQuotaReachedArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(actualReached).To(BeFalse())
+			})
+		}
+
+		itReturnsTrueAndNoError := func() {
+			It("returns true and no error", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeTrue())
+				Expect(fakeDB.SetTeamQuotaReachedCallCount()).To(Equal(1))
+				jobName, actualReached := fakeDB.SetTeamQuotaReachedArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(actualReached).To(BeTrue())
+			})
+		}
+
+		Context("when the team has no quota", func() {
+			itReturnsFalseAndNoError()
+		})
+
+		Context("when the team is below all of its quotas", func() {
+			BeforeEach(func() {
+				quota = db.TeamQuota{
+					MaxConcurrentBuilds: 4,
+					MaxContainers:       11,
+					MaxVolumeBytes:      2048,
+				}
+			})
+
+			itReturnsFalseAndNoError()
+		})
+
+		Context("when the team has reached its concurrent build quota", func() {
+			BeforeEach(func() {
+				quota.MaxConcurrentBuilds = 3
+			})
+
+			itReturnsTrueAndNoError()
+		})
+
+		Context("when the team has reached its container quota", func() {
+			BeforeEach(func() {
+				quota.MaxContainers = 10
+			})
+
+			itReturnsTrueAndNoError()
+		})
+
+		Context("when the team has reached its volume quota", func() {
+			BeforeEach(func() {
+				quota.MaxVolumeBytes = 512
+			})
+
+			itReturnsTrueAndNoError()
+		})
+
+		Context("when getting the quota fails", func() {
+			BeforeEach(func() {
+				quotaErr = disaster
+			})
+
+			It("returns the error without updating the job", func() {
+				Expect(updateErr).To(Equal(disaster))
+				Expect(fakeDB.SetTeamQuotaReachedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when setting team quota reached fails", func() {
+			BeforeEach(func() {
+				fakeDB.SetTeamQuotaReachedReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(updateErr).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -0,0 +1,370 @@
+package explainer
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/scheduler/inputmapper/inputconfig"
+)
+
+// the number of most recent versions of each input's resource to explain
+const candidateLimit = 20
+
+type CandidateStatus string
+
+const (
+	CandidateChosen        CandidateStatus = CandidateStatus(algorithm.CandidateChosen)
+	CandidateSatisfiable   CandidateStatus = CandidateStatus(algorithm.CandidateSatisfiable)
+	CandidateNotLatest     CandidateStatus = CandidateStatus(algorithm.CandidateNotLatest)
+	CandidateNotPinned     CandidateStatus = CandidateStatus(algorithm.CandidateNotPinned)
+	CandidateNotPassed     CandidateStatus = CandidateStatus(algorithm.CandidateNotPassed)
+	CandidateUnsatisfiable CandidateStatus = CandidateStatus(algorithm.CandidateUnsatisfiable)
+	CandidateUnavailable   CandidateStatus = CandidateStatus(algorithm.CandidateUnavailable)
+	CandidateDisabled      CandidateStatus = "disabled"
+)
+
+type HoldReason string
+
+const (
+	HoldInputsUnsatisfied HoldReason = "inputs_unsatisfied"
+	HoldNoTrigger         HoldReason = "no_trigger"
+	HoldPausedPipeline    HoldReason = "paused_pipeline"
+	HoldPausedJob         HoldReason = "paused_job"
+	HoldMaxInFlight       HoldReason = "max_in_flight"
+	HoldSerialGroups      HoldReason = "serial_groups"
+)
+
+type Explanation struct {
+	Inputs          []InputExplanation
+	InputsSatisfied bool
+	Holds           []Hold
+}
+
+type InputExplanation struct {
+	Name          string
+	Resource      string
+	Passed        []string
+	Trigger       bool
+	Every         bool
+	PinnedVersion atc.Version
+
+	Candidates []Candidate
+
+	// true when the resource has more versions than were explained
+	Truncated bool
+}
+
+type Candidate struct {
+	Version db.SavedVersionedResource
+	Status  CandidateStatus
+
+	// the passed job that eliminated the version, when the status is
+	// CandidateNotPassed
+	PassedJob string
+}
+
+type Hold struct {
+	Reason  HoldReason
+	Message string
+}
+
+//go:generate counterfeiter . Explainer
+
+type Explainer interface {
+	ExplainJob(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job atc.JobConfig,
+	) (Explanation, error)
+}
+
+//go:generate counterfeiter . ExplainerDB
+
+type ExplainerDB interface {
+	GetResourceVersions(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error)
+	IsPaused() (bool, error)
+	GetJob(job string) (db.SavedJob, bool, error)
+	GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error)
+	GetPendingBuildsForJob(jobName string) ([]db.Build, error)
+	GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error)
+}
+
+func NewExplainer(db ExplainerDB, transformer inputconfig.Transformer) Explainer {
+	return &explainer{db: db, transformer: transformer}
+}
+
+type explainer struct {
+	db          ExplainerDB
+	transformer inputconfig.Transformer
+}
+
+func (e *explainer) ExplainJob(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job atc.JobConfig,
+) (Explanation, error) {
+	logger = logger.Session("explain-job", lager.Data{"job": job.Name})
+
+	jobInputs := config.JobInputs(job)
+
+	algorithmInputConfigs, err := e.transformer.TransformInputConfigs(versions, job.Name, jobInputs)
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{
+		Inputs: []InputExplanation{},
+		Holds:  []Hold{},
+	}
+
+	candidateVersions := map[string][]db.SavedVersionedResource{}
+	candidateIDs := map[string][]int{}
+
+	for _, jobInput := range jobInputs {
+		inputExplanation := InputExplanation{
+			Name:       jobInput.Name,
+			Resource:   jobInput.Resource,
+			Passed:     jobInput.Passed,
+			Trigger:    jobInput.Trigger,
+			Candidates: []Candidate{},
+		}
+
+		if jobInput.Version != nil {
+			inputExplanation.Every = jobInput.Version.Every
+			inputExplanation.PinnedVersion = jobInput.Version.Pinned
+		}
+
+		svrs, pagination, found, err := e.db.GetResourceVersions(jobInput.Resource, db.Page{Limit: candidateLimit})
+		if err != nil {
+			logger.Error("failed-to-get-resource-versions", err, lager.Data{"resource": jobInput.Resource})
+			return Explanation{}, err
+		}
+
+		if found {
+			inputExplanation.Truncated = pagination.Next != nil
+			candidateVersions[jobInput.Name] = svrs
+
+			for _, svr := range svrs {
+				if svr.Enabled {
+					candidateIDs[jobInput.Name] = append(candidateIDs[jobInput.Name], svr.ID)
+				}
+			}
+		}
+
+		explanation.Inputs = append(explanation.Inputs, inputExplanation)
+	}
+
+	algorithmExplanations, resolved := algorithmInputConfigs.Explain(versions, candidateIDs)
+
+	// pinned inputs whose version could not be found are left out of the
+	// algorithm's input configs, which would otherwise let the job resolve
+	explanation.InputsSatisfied = resolved && len(algorithmInputConfigs) == len(jobInputs)
+
+	jobNames := map[int]string{}
+	for name, id := range versions.JobIDs {
+		jobNames[id] = name
+	}
+
+	for i, inputExplanation := range explanation.Inputs {
+		var algorithmExplanation algorithm.InputExplanation
+		explained := false
+		for _, ae := range algorithmExplanations {
+			if ae.Name == inputExplanation.Name {
+				algorithmExplanation = ae
+				explained = true
+				break
+			}
+		}
+
+		statuses := map[int]algorithm.CandidateExplanation{}
+		for _, candidate := range algorithmExplanation.Candidates {
+			statuses[candidate.VersionID] = candidate
+		}
+
+		for _, svr := range candidateVersions[inputExplanation.Name] {
+			candidate := Candidate{Version: svr}
+
+			if !svr.Enabled {
+				candidate.Status = CandidateDisabled
+			} else if !explained && inputExplanation.PinnedVersion != nil {
+				candidate.Status = CandidateNotPinned
+			} else if status, found := statuses[svr.ID]; found {
+				candidate.Status = CandidateStatus(status.Status)
+				candidate.PassedJob = jobNames[status.JobID]
+			} else {
+				candidate.Status = CandidateUnavailable
+			}
+
+			inputExplanation.Candidates = append(inputExplanation.Candidates, candidate)
+		}
+
+		explanation.Inputs[i] = inputExplanation
+	}
+
+	if !explanation.InputsSatisfied {
+		explanation.Holds = append(explanation.Holds, Hold{
+			Reason:  HoldInputsUnsatisfied,
+			Message: "no set of versions satisfies all of the job's inputs",
+		})
+	}
+
+	pendingBuilds, err := e.db.GetPendingBuildsForJob(job.Name)
+	if err != nil {
+		logger.Error("failed-to-get-pending-builds", err)
+		return Explanation{}, err
+	}
+
+	if len(pendingBuilds) == 0 && explanation.InputsSatisfied {
+		if hold, held := e.triggerHold(versions, algorithmInputConfigs, jobInputs); held {
+			explanation.Holds = append(explanation.Holds, hold)
+		}
+	}
+
+	paused, err := e.db.IsPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return Explanation{}, err
+	}
+
+	if paused {
+		explanation.Holds = append(explanation.Holds, Hold{
+			Reason:  HoldPausedPipeline,
+			Message: "the pipeline is paused",
+		})
+	}
+
+	savedJob, found, err := e.db.GetJob(job.Name)
+	if err != nil {
+		logger.Error("failed-to-check-if-job-is-paused", err)
+		return Explanation{}, err
+	}
+
+	if found && savedJob.Paused {
+		explanation.Holds = append(explanation.Holds, Hold{
+			Reason:  HoldPausedJob,
+			Message: "the job is paused",
+		})
+	}
+
+	hold, held, err := e.maxInFlightHold(logger, job, pendingBuilds)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	if held {
+		explanation.Holds = append(explanation.Holds, hold)
+	}
+
+	return explanation, nil
+}
+
+func (e *explainer) triggerHold(
+	versions *algorithm.VersionsDB,
+	algorithmInputConfigs algorithm.InputConfigs,
+	jobInputs []config.JobInput,
+) (Hold, bool) {
+	mapping, _ := algorithmInputConfigs.Resolve(versions)
+
+	hasTrigger := false
+	for _, jobInput := range jobInputs {
+		if !jobInput.Trigger {
+			continue
+		}
+
+		hasTrigger = true
+
+		inputVersion, found := mapping[jobInput.Name]
+		if found && inputVersion.FirstOccurrence {
+			return Hold{}, false
+		}
+	}
+
+	if !hasTrigger {
+		return Hold{
+			Reason:  HoldNoTrigger,
+			Message: "none of the job's inputs have trigger: true; builds must be triggered manually",
+		}, true
+	}
+
+	return Hold{
+		Reason:  HoldNoTrigger,
+		Message: "no triggering input has a version that has not already been used by the job",
+	}, true
+}
+
+func (e *explainer) maxInFlightHold(logger lager.Logger, job atc.JobConfig, pendingBuilds []db.Build) (Hold, bool, error) {
+	maxInFlight := job.MaxInFlight()
+	if maxInFlight == 0 {
+		return Hold{}, false, nil
+	}
+
+	serialGroups := job.GetSerialGroups()
+
+	runningBuilds, err := e.db.GetRunningBuildsBySerialGroup(job.Name, serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-running-builds-by-serial-group", err)
+		return Hold{}, false, err
+	}
+
+	if len(runningBuilds) >= maxInFlight {
+		running := []string{}
+		otherJobs := false
+		for _, build := range runningBuilds {
+			running = append(running, fmt.Sprintf("%s #%s", build.JobName(), build.Name()))
+			if build.JobName() != job.Name {
+				otherJobs = true
+			}
+		}
+
+		if otherJobs {
+			return Hold{
+				Reason: HoldSerialGroups,
+				Message: fmt.Sprintf(
+					"serial groups %s are occupied by %s",
+					strings.Join(serialGroups, ", "),
+					strings.Join(running, ", "),
+				),
+			}, true, nil
+		}
+
+		return Hold{
+			Reason: HoldMaxInFlight,
+			Message: fmt.Sprintf(
+				"%d of %d builds are in flight: %s",
+				len(runningBuilds),
+				maxInFlight,
+				strings.Join(running, ", "),
+			),
+		}, true, nil
+	}
+
+	if len(pendingBuilds) == 0 {
+		return Hold{}, false, nil
+	}
+
+	nextBuild, found, err := e.db.GetNextPendingBuildBySerialGroup(job.Name, serialGroups)
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-build-by-serial-group", err)
+		return Hold{}, false, err
+	}
+
+	if found && nextBuild.ID() != pendingBuilds[0].ID() {
+		return Hold{
+			Reason: HoldSerialGroups,
+			Message: fmt.Sprintf(
+				"waiting behind %s #%s in serial groups %s",
+				nextBuild.JobName(),
+				nextBuild.Name(),
+				strings.Join(serialGroups, ", "),
+			),
+		}, true, nil
+	}
+
+	return Hold{}, false, nil
+}
@@ -0,0 +1,13 @@
+package explainer_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestExplainer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Explainer Suite")
+}
@@ -0,0 +1,346 @@
+package explainer_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/scheduler/explainer"
+	"github.com/concourse/atc/scheduler/explainer/explainerfakes"
+	"github.com/concourse/atc/scheduler/inputmapper/inputconfig/inputconfigfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explainer", func() {
+	var (
+		fakeDB          *explainerfakes.FakeExplainerDB
+		fakeTransformer *inputconfigfakes.FakeTransformer
+
+		versionsDB *algorithm.VersionsDB
+		jobConfig  atc.JobConfig
+
+		versionA1 db.SavedVersionedResource
+		versionA3 db.SavedVersionedResource
+		versionA4 db.SavedVersionedResource
+		versionB2 db.SavedVersionedResource
+
+		explanation explainer.Explanation
+		explainErr  error
+
+		disaster error
+	)
+
+	savedVersion := func(id int, resource string, enabled bool) db.SavedVersionedResource {
+		return db.SavedVersionedResource{
+			ID:      id,
+			Enabled: enabled,
+			VersionedResource: db.VersionedResource{
+				Resource: resource,
+				Type:     "git",
+				Version:  db.Version{"ref": resource + "-version"},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		fakeDB = new(explainerfakes.FakeExplainerDB)
+		fakeTransformer = new(inputconfigfakes.FakeTransformer)
+
+		disaster = errors.New("bad thing")
+
+		versionsDB = &algorithm.VersionsDB{
+			JobIDs:      map[string]int{"some-job": 1, "upstream": 2},
+			ResourceIDs: map[string]int{"a": 11, "b": 12},
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 12, CheckOrder: 1},
+				{VersionID: 3, ResourceID: 11, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					BuildID:         98,
+					JobID:           2,
+				},
+			},
+		}
+
+		jobConfig = atc.JobConfig{
+			Name: "some-job",
+			Plan: atc.PlanSequence{
+				{Get: "a", Passed: []string{"upstream"}, Trigger: true},
+				{Get: "b"},
+			},
+		}
+
+		fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+			{
+				Name:       "a",
+				JobName:    "some-job",
+				Passed:     algorithm.JobSet{2: struct{}{}},
+				ResourceID: 11,
+				JobID:      1,
+			},
+			{
+				Name:       "b",
+				JobName:    "some-job",
+				Passed:     algorithm.JobSet{},
+				ResourceID: 12,
+				JobID:      1,
+			},
+		}, nil)
+
+		versionA1 = savedVersion(1, "a", true)
+		versionA3 = savedVersion(3, "a", true)
+		versionA4 = savedVersion(4, "a", false)
+		versionB2 = savedVersion(2, "b", true)
+
+		fakeDB.GetResourceVersionsStub = func(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error) {
+			switch resourceName {
+			case "a":
+				return []db.SavedVersionedResource{versionA4, versionA3, versionA1}, db.Pagination{}, true, nil
+			case "b":
+				return []db.SavedVersionedResource{versionB2}, db.Pagination{Next: &db.Page{Until: 2}}, true, nil
+			default:
+				return nil, db.Pagination{}, false, nil
+			}
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanation, explainErr = explainer.NewExplainer(fakeDB, fakeTransformer).ExplainJob(
+			lagertest.NewTestLogger("test"),
+			versionsDB,
+			jobConfig,
+		)
+	})
+
+	It("transforms the job's inputs for the algorithm", func() {
+		Expect(fakeTransformer.TransformInputConfigsCallCount()).To(Equal(1))
+
+		actualVersionsDB, actualJobName, actualInputs := fakeTransformer.TransformInputConfigsArgsForCall(0)
+		Expect(actualVersionsDB).To(Equal(versionsDB))
+		Expect(actualJobName).To(Equal("some-job"))
+		Expect(actualInputs).To(HaveLen(2))
+	})
+
+	It("explains each of the recent versions of every input", func() {
+		Expect(explainErr).NotTo(HaveOccurred())
+		Expect(explanation.InputsSatisfied).To(BeTrue())
+		Expect(explanation.Inputs).To(Equal([]explainer.InputExplanation{
+			{
+				Name:     "a",
+				Resource: "a",
+				Passed:   []string{"upstream"},
+				Trigger:  true,
+				Candidates: []explainer.Candidate{
+					{Version: versionA4, Status: explainer.CandidateDisabled},
+					{Version: versionA3, Status: explainer.CandidateNotPassed, PassedJob: "upstream"},
+					{Version: versionA1, Status: explainer.CandidateChosen},
+				},
+			},
+			{
+				Name:     "b",
+				Resource: "b",
+				Candidates: []explainer.Candidate{
+					{Version: versionB2, Status: explainer.CandidateChosen},
+				},
+				Truncated: true,
+			},
+		}))
+	})
+
+	It("does not report any holds", func() {
+		Expect(explanation.Holds).To(BeEmpty())
+	})
+
+	Context("when the triggering input's version has already been used", func() {
+		BeforeEach(func() {
+			versionsDB.BuildInputs = []algorithm.BuildInput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					BuildID:         99,
+					JobID:           1,
+					InputName:       "a",
+				},
+			}
+		})
+
+		It("reports that nothing will trigger the job", func() {
+			Expect(explanation.Holds).To(ConsistOf(explainer.Hold{
+				Reason:  explainer.HoldNoTrigger,
+				Message: "no triggering input has a version that has not already been used by the job",
+			}))
+		})
+
+		Context("when a build is already pending", func() {
+			BeforeEach(func() {
+				fakeDB.GetPendingBuildsForJobReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+			})
+
+			It("does not report the trigger", func() {
+				Expect(explanation.Holds).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when no versions satisfy the passed constraints", func() {
+		BeforeEach(func() {
+			versionsDB.BuildOutputs = []algorithm.BuildOutput{}
+		})
+
+		It("reports the inputs as unsatisfied", func() {
+			Expect(explanation.InputsSatisfied).To(BeFalse())
+			Expect(explanation.Holds).To(ConsistOf(explainer.Hold{
+				Reason:  explainer.HoldInputsUnsatisfied,
+				Message: "no set of versions satisfies all of the job's inputs",
+			}))
+		})
+
+		It("explains why each version was eliminated", func() {
+			Expect(explanation.Inputs[0].Candidates[2]).To(Equal(explainer.Candidate{
+				Version:   versionA1,
+				Status:    explainer.CandidateNotPassed,
+				PassedJob: "upstream",
+			}))
+		})
+	})
+
+	Context("when an input's pinned version does not exist", func() {
+		BeforeEach(func() {
+			jobConfig.Plan[0] = atc.PlanConfig{
+				Get:     "a",
+				Version: &atc.VersionConfig{Pinned: atc.Version{"ref": "missing"}},
+			}
+
+			fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+				{
+					Name:       "b",
+					JobName:    "some-job",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 12,
+					JobID:      1,
+				},
+			}, nil)
+		})
+
+		It("reports the inputs as unsatisfied", func() {
+			Expect(explanation.InputsSatisfied).To(BeFalse())
+		})
+
+		It("eliminates every enabled version as not pinned", func() {
+			Expect(explanation.Inputs[0].PinnedVersion).To(Equal(atc.Version{"ref": "missing"}))
+			Expect(explanation.Inputs[0].Candidates).To(Equal([]explainer.Candidate{
+				{Version: versionA4, Status: explainer.CandidateDisabled},
+				{Version: versionA3, Status: explainer.CandidateNotPinned},
+				{Version: versionA1, Status: explainer.CandidateNotPinned},
+			}))
+		})
+	})
+
+	Context("when the pipeline and job are paused", func() {
+		BeforeEach(func() {
+			fakeDB.IsPausedReturns(true, nil)
+			fakeDB.GetJobReturns(db.SavedJob{Paused: true}, true, nil)
+		})
+
+		It("reports both", func() {
+			Expect(explanation.Holds).To(ConsistOf(
+				explainer.Hold{Reason: explainer.HoldPausedPipeline, Message: "the pipeline is paused"},
+				explainer.Hold{Reason: explainer.HoldPausedJob, Message: "the job is paused"},
+			))
+		})
+	})
+
+	Context("when the job is serial", func() {
+		var runningBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			jobConfig.Serial = true
+
+			runningBuild = new(dbfakes.FakeBuild)
+			runningBuild.JobNameReturns("some-job")
+			runningBuild.NameReturns("3")
+		})
+
+		Context("when a build of the job is running", func() {
+			BeforeEach(func() {
+				fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{runningBuild}, nil)
+			})
+
+			It("reports that max in flight has been reached", func() {
+				jobName, serialGroups := fakeDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(serialGroups).To(Equal([]string{"some-job"}))
+
+				Expect(explanation.Holds).To(ConsistOf(explainer.Hold{
+					Reason:  explainer.HoldMaxInFlight,
+					Message: "1 of 1 builds are in flight: some-job #3",
+				}))
+			})
+		})
+
+		Context("when a build of another job in the serial group is running", func() {
+			BeforeEach(func() {
+				jobConfig.SerialGroups = []string{"deploys"}
+				runningBuild.JobNameReturns("other-job")
+
+				fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{runningBuild}, nil)
+			})
+
+			It("reports the serial group", func() {
+				Expect(explanation.Holds).To(ConsistOf(explainer.Hold{
+					Reason:  explainer.HoldSerialGroups,
+					Message: "serial groups deploys are occupied by other-job #3",
+				}))
+			})
+		})
+
+		Context("when a pending build is queued behind another job's build", func() {
+			BeforeEach(func() {
+				pendingBuild := new(dbfakes.FakeBuild)
+				pendingBuild.IDReturns(10)
+				fakeDB.GetPendingBuildsForJobReturns([]db.Build{pendingBuild}, nil)
+
+				nextBuild := new(dbfakes.FakeBuild)
+				nextBuild.IDReturns(9)
+				nextBuild.JobNameReturns("other-job")
+				nextBuild.NameReturns("7")
+				fakeDB.GetNextPendingBuildBySerialGroupReturns(nextBuild, true, nil)
+			})
+
+			It("reports the build it is waiting for", func() {
+				Expect(explanation.Holds).To(ConsistOf(explainer.Hold{
+					Reason:  explainer.HoldSerialGroups,
+					Message: "waiting behind other-job #7 in serial groups some-job",
+				}))
+			})
+		})
+	})
+
+	Context("when getting resource versions fails", func() {
+		BeforeEach(func() {
+			fakeDB.GetResourceVersionsStub = nil
+			fakeDB.GetResourceVersionsReturns(nil, db.Pagination{}, false, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(explainErr).To(Equal(disaster))
+		})
+	})
+
+	Context("when transforming the inputs fails", func() {
+		BeforeEach(func() {
+			fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(explainErr).To(Equal(disaster))
+		})
+	})
+})
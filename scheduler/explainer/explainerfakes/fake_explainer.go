@@ -0,0 +1,85 @@
+// This file was generated by counterfeiter
+package explainerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
+	"github.com/concourse/atc/scheduler/explainer"
+)
+
+type FakeExplainer struct {
+	ExplainJobStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (explainer.Explanation, error)
+	explainJobMutex       sync.RWMutex
+	explainJobArgsForCall []struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}
+	explainJobReturns struct {
+		result1 explainer.Explanation
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExplainer) ExplainJob(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (explainer.Explanation, error) {
+	fake.explainJobMutex.Lock()
+	fake.explainJobArgsForCall = append(fake.explainJobArgsForCall, struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}{logger, versions, job})
+	fake.recordInvocation("ExplainJob", []interface{}{logger, versions, job})
+	fake.explainJobMutex.Unlock()
+	if fake.ExplainJobStub != nil {
+		return fake.ExplainJobStub(logger, versions, job)
+	} else {
+		return fake.explainJobReturns.result1, fake.explainJobReturns.result2
+	}
+}
+
+func (fake *FakeExplainer) ExplainJobCallCount() int {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return len(fake.explainJobArgsForCall)
+}
+
+func (fake *FakeExplainer) ExplainJobArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig) {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return fake.explainJobArgsForCall[i].logger, fake.explainJobArgsForCall[i].versions, fake.explainJobArgsForCall[i].job
+}
+
+func (fake *FakeExplainer) ExplainJobReturns(result1 explainer.Explanation, result2 error) {
+	fake.ExplainJobStub = nil
+	fake.explainJobReturns = struct {
+		result1 explainer.Explanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExplainer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeExplainer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ explainer.Explainer = new(FakeExplainer)
@@ -0,0 +1,318 @@
+// This file was generated by counterfeiter
+package explainerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler/explainer"
+)
+
+type FakeExplainerDB struct {
+	GetResourceVersionsStub        func(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error)
+	getResourceVersionsMutex       sync.RWMutex
+	getResourceVersionsArgsForCall []struct {
+		resourceName string
+		page         db.Page
+	}
+	getResourceVersionsReturns struct {
+		result1 []db.SavedVersionedResource
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}
+	IsPausedStub        func() (bool, error)
+	isPausedMutex       sync.RWMutex
+	isPausedArgsForCall []struct{}
+	isPausedReturns     struct {
+		result1 bool
+		result2 error
+	}
+	GetJobStub        func(job string) (db.SavedJob, bool, error)
+	getJobMutex       sync.RWMutex
+	getJobArgsForCall []struct {
+		job string
+	}
+	getJobReturns struct {
+		result1 db.SavedJob
+		result2 bool
+		result3 error
+	}
+	GetRunningBuildsBySerialGroupStub        func(jobName string, serialGroups []string) ([]db.Build, error)
+	getRunningBuildsBySerialGroupMutex       sync.RWMutex
+	getRunningBuildsBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups []string
+	}
+	getRunningBuildsBySerialGroupReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetPendingBuildsForJobStub        func(jobName string) ([]db.Build, error)
+	getPendingBuildsForJobMutex       sync.RWMutex
+	getPendingBuildsForJobArgsForCall []struct {
+		jobName string
+	}
+	getPendingBuildsForJobReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	GetNextPendingBuildBySerialGroupStub        func(jobName string, serialGroups []string) (db.Build, bool, error)
+	getNextPendingBuildBySerialGroupMutex       sync.RWMutex
+	getNextPendingBuildBySerialGroupArgsForCall []struct {
+		jobName      string
+		serialGroups []string
+	}
+	getNextPendingBuildBySerialGroupReturns struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExplainerDB) GetResourceVersions(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error) {
+	fake.getResourceVersionsMutex.Lock()
+	fake.getResourceVersionsArgsForCall = append(fake.getResourceVersionsArgsForCall, struct {
+		resourceName string
+		page         db.Page
+	}{resourceName, page})
+	fake.recordInvocation("GetResourceVersions", []interface{}{resourceName, page})
+	fake.getResourceVersionsMutex.Unlock()
+	if fake.GetResourceVersionsStub != nil {
+		return fake.GetResourceVersionsStub(resourceName, page)
+	} else {
+		return fake.getResourceVersionsReturns.result1, fake.getResourceVersionsReturns.result2, fake.getResourceVersionsReturns.result3, fake.getResourceVersionsReturns.result4
+	}
+}
+
+func (fake *FakeExplainerDB) GetResourceVersionsCallCount() int {
+	fake.getResourceVersionsMutex.RLock()
+	defer fake.getResourceVersionsMutex.RUnlock()
+	return len(fake.getResourceVersionsArgsForCall)
+}
+
+func (fake *FakeExplainerDB) GetResourceVersionsArgsForCall(i int) (string, db.Page) {
+	fake.getResourceVersionsMutex.RLock()
+	defer fake.getResourceVersionsMutex.RUnlock()
+	return fake.getResourceVersionsArgsForCall[i].resourceName, fake.getResourceVersionsArgsForCall[i].page
+}
+
+func (fake *FakeExplainerDB) GetResourceVersionsReturns(result1 []db.SavedVersionedResource, result2 db.Pagination, result3 bool, result4 error) {
+	fake.GetResourceVersionsStub = nil
+	fake.getResourceVersionsReturns = struct {
+		result1 []db.SavedVersionedResource
+		result2 db.Pagination
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeExplainerDB) IsPaused() (bool, error) {
+	fake.isPausedMutex.Lock()
+	fake.isPausedArgsForCall = append(fake.isPausedArgsForCall, struct{}{})
+	fake.recordInvocation("IsPaused", []interface{}{})
+	fake.isPausedMutex.Unlock()
+	if fake.IsPausedStub != nil {
+		return fake.IsPausedStub()
+	} else {
+		return fake.isPausedReturns.result1, fake.isPausedReturns.result2
+	}
+}
+
+func (fake *FakeExplainerDB) IsPausedCallCount() int {
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	return len(fake.isPausedArgsForCall)
+}
+
+func (fake *FakeExplainerDB) IsPausedReturns(result1 bool, result2 error) {
+	fake.IsPausedStub = nil
+	fake.isPausedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExplainerDB) GetJob(job string) (db.SavedJob, bool, error) {
+	fake.getJobMutex.Lock()
+	fake.getJobArgsForCall = append(fake.getJobArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("GetJob", []interface{}{job})
+	fake.getJobMutex.Unlock()
+	if fake.GetJobStub != nil {
+		return fake.GetJobStub(job)
+	} else {
+		return fake.getJobReturns.result1, fake.getJobReturns.result2, fake.getJobReturns.result3
+	}
+}
+
+func (fake *FakeExplainerDB) GetJobCallCount() int {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	return len(fake.getJobArgsForCall)
+}
+
+func (fake *FakeExplainerDB) GetJobArgsForCall(i int) string {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	return fake.getJobArgsForCall[i].job
+}
+
+func (fake *FakeExplainerDB) GetJobReturns(result1 db.SavedJob, result2 bool, result3 error) {
+	fake.GetJobStub = nil
+	fake.getJobReturns = struct {
+		result1 db.SavedJob
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeExplainerDB) GetRunningBuildsBySerialGroup(jobName string, serialGroups []string) ([]db.Build, error) {
+	var serialGroupsCopy []string
+	if serialGroups != nil {
+		serialGroupsCopy = make([]string, len(serialGroups))
+		copy(serialGroupsCopy, serialGroups)
+	}
+	fake.getRunningBuildsBySerialGroupMutex.Lock()
+	fake.getRunningBuildsBySerialGroupArgsForCall = append(fake.getRunningBuildsBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups []string
+	}{jobName, serialGroupsCopy})
+	fake.recordInvocation("GetRunningBuildsBySerialGroup", []interface{}{jobName, serialGroupsCopy})
+	fake.getRunningBuildsBySerialGroupMutex.Unlock()
+	if fake.GetRunningBuildsBySerialGroupStub != nil {
+		return fake.GetRunningBuildsBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getRunningBuildsBySerialGroupReturns.result1, fake.getRunningBuildsBySerialGroupReturns.result2
+	}
+}
+
+func (fake *FakeExplainerDB) GetRunningBuildsBySerialGroupCallCount() int {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return len(fake.getRunningBuildsBySerialGroupArgsForCall)
+}
+
+func (fake *FakeExplainerDB) GetRunningBuildsBySerialGroupArgsForCall(i int) (string, []string) {
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	return fake.getRunningBuildsBySerialGroupArgsForCall[i].jobName, fake.getRunningBuildsBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakeExplainerDB) GetRunningBuildsBySerialGroupReturns(result1 []db.Build, result2 error) {
+	fake.GetRunningBuildsBySerialGroupStub = nil
+	fake.getRunningBuildsBySerialGroupReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExplainerDB) GetPendingBuildsForJob(jobName string) ([]db.Build, error) {
+	fake.getPendingBuildsForJobMutex.Lock()
+	fake.getPendingBuildsForJobArgsForCall = append(fake.getPendingBuildsForJobArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetPendingBuildsForJob", []interface{}{jobName})
+	fake.getPendingBuildsForJobMutex.Unlock()
+	if fake.GetPendingBuildsForJobStub != nil {
+		return fake.GetPendingBuildsForJobStub(jobName)
+	} else {
+		return fake.getPendingBuildsForJobReturns.result1, fake.getPendingBuildsForJobReturns.result2
+	}
+}
+
+func (fake *FakeExplainerDB) GetPendingBuildsForJobCallCount() int {
+	fake.getPendingBuildsForJobMutex.RLock()
+	defer fake.getPendingBuildsForJobMutex.RUnlock()
+	return len(fake.getPendingBuildsForJobArgsForCall)
+}
+
+func (fake *FakeExplainerDB) GetPendingBuildsForJobArgsForCall(i int) string {
+	fake.getPendingBuildsForJobMutex.RLock()
+	defer fake.getPendingBuildsForJobMutex.RUnlock()
+	return fake.getPendingBuildsForJobArgsForCall[i].jobName
+}
+
+func (fake *FakeExplainerDB) GetPendingBuildsForJobReturns(result1 []db.Build, result2 error) {
+	fake.GetPendingBuildsForJobStub = nil
+	fake.getPendingBuildsForJobReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeExplainerDB) GetNextPendingBuildBySerialGroup(jobName string, serialGroups []string) (db.Build, bool, error) {
+	var serialGroupsCopy []string
+	if serialGroups != nil {
+		serialGroupsCopy = make([]string, len(serialGroups))
+		copy(serialGroupsCopy, serialGroups)
+	}
+	fake.getNextPendingBuildBySerialGroupMutex.Lock()
+	fake.getNextPendingBuildBySerialGroupArgsForCall = append(fake.getNextPendingBuildBySerialGroupArgsForCall, struct {
+		jobName      string
+		serialGroups []string
+	}{jobName, serialGroupsCopy})
+	fake.recordInvocation("GetNextPendingBuildBySerialGroup", []interface{}{jobName, serialGroupsCopy})
+	fake.getNextPendingBuildBySerialGroupMutex.Unlock()
+	if fake.GetNextPendingBuildBySerialGroupStub != nil {
+		return fake.GetNextPendingBuildBySerialGroupStub(jobName, serialGroups)
+	} else {
+		return fake.getNextPendingBuildBySerialGroupReturns.result1, fake.getNextPendingBuildBySerialGroupReturns.result2, fake.getNextPendingBuildBySerialGroupReturns.result3
+	}
+}
+
+func (fake *FakeExplainerDB) GetNextPendingBuildBySerialGroupCallCount() int {
+	fake.getNextPendingBuildBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	return len(fake.getNextPendingBuildBySerialGroupArgsForCall)
+}
+
+func (fake *FakeExplainerDB) GetNextPendingBuildBySerialGroupArgsForCall(i int) (string, []string) {
+	fake.getNextPendingBuildBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	return fake.getNextPendingBuildBySerialGroupArgsForCall[i].jobName, fake.getNextPendingBuildBySerialGroupArgsForCall[i].serialGroups
+}
+
+func (fake *FakeExplainerDB) GetNextPendingBuildBySerialGroupReturns(result1 db.Build, result2 bool, result3 error) {
+	fake.GetNextPendingBuildBySerialGroupStub = nil
+	fake.getNextPendingBuildBySerialGroupReturns = struct {
+		result1 db.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeExplainerDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getResourceVersionsMutex.RLock()
+	defer fake.getResourceVersionsMutex.RUnlock()
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	fake.getRunningBuildsBySerialGroupMutex.RLock()
+	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
+	fake.getPendingBuildsForJobMutex.RLock()
+	defer fake.getPendingBuildsForJobMutex.RUnlock()
+	fake.getNextPendingBuildBySerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeExplainerDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ explainer.ExplainerDB = new(FakeExplainerDB)
@@ -234,6 +234,13 @@ func (factory *buildFactory) constructUnhookedPlan(
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name: planConfig.SetPipeline,
+			File: planConfig.TaskConfigPath,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
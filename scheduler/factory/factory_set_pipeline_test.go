@@ -0,0 +1,67 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		input = atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Get: "some-resource",
+				},
+				{
+					SetPipeline:    "some-pipeline",
+					TaskConfigPath: "some-resource/ci/pipeline.yml",
+				},
+			},
+		}
+	})
+
+	It("returns the correct plan", func() {
+		actual, err := buildFactory.Create(input, resources, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+			expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:       "git",
+				Name:       "some-resource",
+				Resource:   "some-resource",
+				Source:     atc.Source{"uri": "git://some-resource"},
+				PipelineID: 42,
+			}),
+			expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+				Name: "some-pipeline",
+				File: "some-resource/ci/pipeline.yml",
+			}),
+		})
+
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+})
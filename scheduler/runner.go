@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/scheduler/explainer"
 )
 
 //go:generate counterfeiter . BuildScheduler
@@ -29,6 +30,7 @@ type BuildScheduler interface {
 		resourceTypes atc.ResourceTypes,
 	) (db.Build, Waiter, error)
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
+	ExplainJob(logger lager.Logger, job atc.JobConfig) (explainer.Explanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
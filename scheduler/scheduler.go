@@ -7,9 +7,11 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/buildstarter"
+	"github.com/concourse/atc/scheduler/explainer"
 	"github.com/concourse/atc/scheduler/inputmapper"
 )
 
@@ -18,6 +20,7 @@ type Scheduler struct {
 	InputMapper  inputmapper.InputMapper
 	BuildStarter buildstarter.BuildStarter
 	Scanner      Scanner
+	Explainer    explainer.Explainer
 }
 
 //go:generate counterfeiter . SchedulerDB
@@ -29,6 +32,9 @@ type SchedulerDB interface {
 	Reload() (bool, error)
 	Config() atc.Config
 	CreateJobBuild(job string) (db.Build, error)
+	CreateScheduledJobBuild(job string, scheduledAt time.Time) (db.Build, error)
+	GetJobLastScheduled(job string) (time.Time, bool, error)
+	SetJobLastScheduled(job string, lastScheduled time.Time) error
 	EnsurePendingBuildExists(jobName string) error
 	AcquireResourceCheckingForJobLock(logger lager.Logger, job string) (db.Lock, bool, error)
 	GetAllPendingBuilds() (map[string][]db.Build, error)
@@ -52,7 +58,14 @@ func (s *Scheduler) Schedule(
 
 	for _, jobConfig := range jobConfigs {
 		jStart := time.Now()
-		err := s.ensurePendingBuildExists(logger, versions, jobConfig)
+
+		err := s.ensureScheduledBuildExists(logger, jobConfig, jStart)
+		if err != nil {
+			jobSchedulingTime[jobConfig.Name] = time.Since(jStart)
+			return jobSchedulingTime, err
+		}
+
+		err = s.ensurePendingBuildExists(logger, versions, jobConfig)
 		jobSchedulingTime[jobConfig.Name] = time.Since(jStart)
 
 		if err != nil {
@@ -112,6 +125,56 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+func (s *Scheduler) ensureScheduledBuildExists(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+	now time.Time,
+) error {
+	if jobConfig.Schedule == nil {
+		return nil
+	}
+
+	logger = logger.Session("schedule", lager.Data{"job": jobConfig.Name})
+
+	schedule, err := cron.Parse(jobConfig.Schedule.Cron, jobConfig.Schedule.Location)
+	if err != nil {
+		logger.Error("failed-to-parse-schedule", err)
+		return nil
+	}
+
+	lastScheduled, found, err := s.DB.GetJobLastScheduled(jobConfig.Name)
+	if err != nil {
+		logger.Error("failed-to-get-last-scheduled", err)
+		return err
+	}
+
+	// start counting from the first time the schedule is seen, so that adding
+	// a schedule does not trigger a build straight away
+	if !found {
+		err := s.DB.SetJobLastScheduled(jobConfig.Name, now)
+		if err != nil {
+			logger.Error("failed-to-set-last-scheduled", err)
+			return err
+		}
+
+		return nil
+	}
+
+	if schedule.Next(lastScheduled).After(now) {
+		return nil
+	}
+
+	build, err := s.DB.CreateScheduledJobBuild(jobConfig.Name, now)
+	if err != nil {
+		logger.Error("failed-to-create-scheduled-build", err)
+		return err
+	}
+
+	logger.Info("created-scheduled-build", lager.Data{"build": build.ID()})
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -204,3 +267,13 @@ func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig)
 	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job)
 	return err
 }
+
+func (s *Scheduler) ExplainJob(logger lager.Logger, job atc.JobConfig) (explainer.Explanation, error) {
+	versions, err := s.DB.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return explainer.Explanation{}, err
+	}
+
+	return s.Explainer.ExplainJob(logger, versions, job)
+}
@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -11,6 +12,8 @@ import (
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/buildstarter/buildstarterfakes"
+	"github.com/concourse/atc/scheduler/explainer"
+	"github.com/concourse/atc/scheduler/explainer/explainerfakes"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 	. "github.com/onsi/ginkgo"
@@ -23,6 +26,7 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *buildstarterfakes.FakeBuildStarter
 		fakeScanner      *schedulerfakes.FakeScanner
+		fakeExplainer    *explainerfakes.FakeExplainer
 
 		scheduler *Scheduler
 
@@ -34,12 +38,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(buildstarterfakes.FakeBuildStarter)
 		fakeScanner = new(schedulerfakes.FakeScanner)
+		fakeExplainer = new(explainerfakes.FakeExplainer)
 
 		scheduler = &Scheduler{
 			DB:           fakeDB,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			Explainer:    fakeExplainer,
 		}
 
 		disaster = errors.New("bad thing")
@@ -234,6 +240,87 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			BeforeEach(func() {
+				jobConfigs = atc.JobConfigs{
+					{
+						Name:     "some-job",
+						Schedule: &atc.ScheduleConfig{Cron: "0 * * * *"},
+					},
+				}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the job has never been scheduled", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Time{}, false, nil)
+				})
+
+				It("starts counting from now without creating a build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+
+					Expect(fakeDB.SetJobLastScheduledCallCount()).To(Equal(1))
+					jobName, lastScheduled := fakeDB.SetJobLastScheduledArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(lastScheduled).To(BeTemporally("~", time.Now(), time.Second))
+
+					Expect(fakeDB.CreateScheduledJobBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the next run is due", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Now().Add(-2*time.Hour), true, nil)
+					fakeDB.CreateScheduledJobBuildReturns(new(dbfakes.FakeBuild), nil)
+				})
+
+				It("creates a scheduled build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+
+					Expect(fakeDB.CreateScheduledJobBuildCallCount()).To(Equal(1))
+					jobName, scheduledAt := fakeDB.CreateScheduledJobBuildArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(scheduledAt).To(BeTemporally("~", time.Now(), time.Second))
+				})
+
+				It("still saves the next input mapping", func() {
+					Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(Equal(1))
+				})
+
+				Context("when creating the build fails", func() {
+					BeforeEach(func() {
+						fakeDB.CreateScheduledJobBuildReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the next run is not due yet", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Now(), true, nil)
+				})
+
+				It("does not create a build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeDB.CreateScheduledJobBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when getting the last scheduled time fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Time{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(scheduleErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("TriggerImmediately", func() {
@@ -560,4 +647,52 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("ExplainJob", func() {
+		var (
+			explanation explainer.Explanation
+			explainErr  error
+		)
+
+		JustBeforeEach(func() {
+			explanation, explainErr = scheduler.ExplainJob(lagertest.NewTestLogger("test"), atc.JobConfig{Name: "some-job"})
+		})
+
+		Context("when loading the versions DB fails", func() {
+			BeforeEach(func() {
+				fakeDB.LoadVersionsDBReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+
+			It("does not explain the job", func() {
+				Expect(fakeExplainer.ExplainJobCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when loading the versions DB succeeds", func() {
+			var versionsDB *algorithm.VersionsDB
+
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"j1": 1}}
+				fakeDB.LoadVersionsDBReturns(versionsDB, nil)
+
+				fakeExplainer.ExplainJobReturns(explainer.Explanation{InputsSatisfied: true}, nil)
+			})
+
+			It("explains the job with the versions DB", func() {
+				Expect(fakeExplainer.ExplainJobCallCount()).To(Equal(1))
+				_, actualVersionsDB, actualJobConfig := fakeExplainer.ExplainJobArgsForCall(0)
+				Expect(actualVersionsDB).To(Equal(versionsDB))
+				Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+			})
+
+			It("returns the explanation", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanation).To(Equal(explainer.Explanation{InputsSatisfied: true}))
+			})
+		})
+	})
 })
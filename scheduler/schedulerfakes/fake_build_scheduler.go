@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/scheduler/explainer"
 )
 
 type FakeBuildScheduler struct {
@@ -48,6 +49,16 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturns struct {
 		result1 error
 	}
+	ExplainJobStub        func(logger lager.Logger, job atc.JobConfig) (explainer.Explanation, error)
+	explainJobMutex       sync.RWMutex
+	explainJobArgsForCall []struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}
+	explainJobReturns struct {
+		result1 explainer.Explanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -162,6 +173,41 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) ExplainJob(logger lager.Logger, job atc.JobConfig) (explainer.Explanation, error) {
+	fake.explainJobMutex.Lock()
+	fake.explainJobArgsForCall = append(fake.explainJobArgsForCall, struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}{logger, job})
+	fake.recordInvocation("ExplainJob", []interface{}{logger, job})
+	fake.explainJobMutex.Unlock()
+	if fake.ExplainJobStub != nil {
+		return fake.ExplainJobStub(logger, job)
+	} else {
+		return fake.explainJobReturns.result1, fake.explainJobReturns.result2
+	}
+}
+
+func (fake *FakeBuildScheduler) ExplainJobCallCount() int {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return len(fake.explainJobArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainJobArgsForCall(i int) (lager.Logger, atc.JobConfig) {
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
+	return fake.explainJobArgsForCall[i].logger, fake.explainJobArgsForCall[i].job
+}
+
+func (fake *FakeBuildScheduler) ExplainJobReturns(result1 explainer.Explanation, result2 error) {
+	fake.ExplainJobStub = nil
+	fake.explainJobReturns = struct {
+		result1 explainer.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -171,6 +217,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainJobMutex.RLock()
+	defer fake.explainJobMutex.RUnlock()
 	return fake.invocations
 }
 
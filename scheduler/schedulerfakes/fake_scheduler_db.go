@@ -59,6 +59,35 @@ type FakeSchedulerDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateScheduledJobBuildStub        func(job string, scheduledAt time.Time) (db.Build, error)
+	createScheduledJobBuildMutex       sync.RWMutex
+	createScheduledJobBuildArgsForCall []struct {
+		job         string
+		scheduledAt time.Time
+	}
+	createScheduledJobBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	GetJobLastScheduledStub        func(job string) (time.Time, bool, error)
+	getJobLastScheduledMutex       sync.RWMutex
+	getJobLastScheduledArgsForCall []struct {
+		job string
+	}
+	getJobLastScheduledReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	SetJobLastScheduledStub        func(job string, lastScheduled time.Time) error
+	setJobLastScheduledMutex       sync.RWMutex
+	setJobLastScheduledArgsForCall []struct {
+		job           string
+		lastScheduled time.Time
+	}
+	setJobLastScheduledReturns struct {
+		result1 error
+	}
 	EnsurePendingBuildExistsStub        func(jobName string) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
@@ -270,6 +299,110 @@ func (fake *FakeSchedulerDB) CreateJobBuildReturns(result1 db.Build, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeSchedulerDB) CreateScheduledJobBuild(job string, scheduledAt time.Time) (db.Build, error) {
+	fake.createScheduledJobBuildMutex.Lock()
+	fake.createScheduledJobBuildArgsForCall = append(fake.createScheduledJobBuildArgsForCall, struct {
+		job         string
+		scheduledAt time.Time
+	}{job, scheduledAt})
+	fake.recordInvocation("CreateScheduledJobBuild", []interface{}{job, scheduledAt})
+	fake.createScheduledJobBuildMutex.Unlock()
+	if fake.CreateScheduledJobBuildStub != nil {
+		return fake.CreateScheduledJobBuildStub(job, scheduledAt)
+	} else {
+		return fake.createScheduledJobBuildReturns.result1, fake.createScheduledJobBuildReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) CreateScheduledJobBuildCallCount() int {
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	return len(fake.createScheduledJobBuildArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) CreateScheduledJobBuildArgsForCall(i int) (string, time.Time) {
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	return fake.createScheduledJobBuildArgsForCall[i].job, fake.createScheduledJobBuildArgsForCall[i].scheduledAt
+}
+
+func (fake *FakeSchedulerDB) CreateScheduledJobBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateScheduledJobBuildStub = nil
+	fake.createScheduledJobBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduled(job string) (time.Time, bool, error) {
+	fake.getJobLastScheduledMutex.Lock()
+	fake.getJobLastScheduledArgsForCall = append(fake.getJobLastScheduledArgsForCall, struct {
+		job string
+	}{job})
+	fake.recordInvocation("GetJobLastScheduled", []interface{}{job})
+	fake.getJobLastScheduledMutex.Unlock()
+	if fake.GetJobLastScheduledStub != nil {
+		return fake.GetJobLastScheduledStub(job)
+	} else {
+		return fake.getJobLastScheduledReturns.result1, fake.getJobLastScheduledReturns.result2, fake.getJobLastScheduledReturns.result3
+	}
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledCallCount() int {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return len(fake.getJobLastScheduledArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledArgsForCall(i int) string {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return fake.getJobLastScheduledArgsForCall[i].job
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobLastScheduledStub = nil
+	fake.getJobLastScheduledReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSchedulerDB) SetJobLastScheduled(job string, lastScheduled time.Time) error {
+	fake.setJobLastScheduledMutex.Lock()
+	fake.setJobLastScheduledArgsForCall = append(fake.setJobLastScheduledArgsForCall, struct {
+		job           string
+		lastScheduled time.Time
+	}{job, lastScheduled})
+	fake.recordInvocation("SetJobLastScheduled", []interface{}{job, lastScheduled})
+	fake.setJobLastScheduledMutex.Unlock()
+	if fake.SetJobLastScheduledStub != nil {
+		return fake.SetJobLastScheduledStub(job, lastScheduled)
+	} else {
+		return fake.setJobLastScheduledReturns.result1
+	}
+}
+
+func (fake *FakeSchedulerDB) SetJobLastScheduledCallCount() int {
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
+	return len(fake.setJobLastScheduledArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) SetJobLastScheduledArgsForCall(i int) (string, time.Time) {
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
+	return fake.setJobLastScheduledArgsForCall[i].job, fake.setJobLastScheduledArgsForCall[i].lastScheduled
+}
+
+func (fake *FakeSchedulerDB) SetJobLastScheduledReturns(result1 error) {
+	fake.SetJobLastScheduledStub = nil
+	fake.setJobLastScheduledReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSchedulerDB) EnsurePendingBuildExists(jobName string) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
@@ -414,6 +547,12 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createScheduledJobBuildMutex.RLock()
+	defer fake.createScheduledJobBuildMutex.RUnlock()
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	fake.setJobLastScheduledMutex.RLock()
+	defer fake.setJobLastScheduledMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
 	fake.acquireResourceCheckingForJobLockMutex.RLock()
@@ -31,6 +31,9 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Test reports written by the task, to be summarized once it exits.
+	Reports []TaskReportConfig `json:"reports,omitempty" yaml:"reports,omitempty" mapstructure:"reports"`
 }
 
 type ImageResource struct {
@@ -110,6 +113,10 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if len(other.Reports) != 0 {
+		config.Reports = other.Reports
+	}
+
 	return config
 }
 
@@ -125,6 +132,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateReports()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -327,6 +335,22 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateReports() []string {
+	messages := []string{}
+
+	for i, report := range config.Reports {
+		if report.Format != ReportFormatJUnit {
+			messages = append(messages, fmt.Sprintf("  report in position %d has unknown format '%s'", i, report.Format))
+		}
+
+		if report.Path == "" {
+			messages = append(messages, fmt.Sprintf("  report in position %d is missing a path", i))
+		}
+	}
+
+	return messages
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -360,6 +384,15 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+const ReportFormatJUnit = "junit"
+
+type TaskReportConfig struct {
+	Format string `json:"format" yaml:"format"`
+
+	// The path to the report, relative to the task's working directory.
+	Path string `json:"path" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
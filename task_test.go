@@ -234,6 +234,36 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has reports", func() {
+			BeforeEach(func() {
+				validConfig.Reports = append(validConfig.Reports, TaskReportConfig{Format: "junit", Path: "out/junit.xml"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when report.format is unknown", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Format: "tap", Path: "out/tap.txt"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 has unknown format 'tap'")))
+				})
+			})
+
+			Context("when report.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Reports = append(invalidConfig.Reports, TaskReportConfig{Format: "junit"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 is missing a path")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
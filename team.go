@@ -12,6 +12,19 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+	OIDCAuth     *OIDCAuth     `json:"oidc_auth,omitempty"`
+
+	Quota *TeamQuota `json:"quota,omitempty"`
+
+	// nil leaves the setting as it is when setting a team
+	RequireHijackRecording *bool `json:"require_hijack_recording,omitempty"`
+}
+
+type TeamQuota struct {
+	MaxConcurrentBuilds int   `json:"max_concurrent_builds,omitempty"`
+	MaxContainers       int   `json:"max_containers,omitempty"`
+	MaxVolumeBytes      int64 `json:"max_volume_bytes,omitempty"`
+	BuildQueueWeight    int   `json:"build_queue_weight,omitempty"`
 }
 
 type BasicAuth struct {
@@ -54,3 +67,14 @@ type GenericOAuth struct {
 	AuthURLParams map[string]string `json:"auth_url_params,omitempty"`
 	Scope         string            `json:"scope,omitempty"`
 }
+
+type OIDCAuth struct {
+	DisplayName  string   `json:"display_name,omitempty"`
+	Issuer       string   `json:"issuer,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	GroupsClaim  string   `json:"groups_claim,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	EmailDomains []string `json:"email_domains,omitempty"`
+}
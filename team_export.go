@@ -0,0 +1,100 @@
+package atc
+
+import "encoding/json"
+
+// TeamExportVersion is bumped whenever the export format changes in a way
+// that older ATCs cannot import.
+const TeamExportVersion = 2
+
+// A team export is a portable archive of a team's pipelines and their
+// history, for moving the team to another Concourse. It is a stream of
+// records, one JSON object per line, so that it never has to be held in
+// memory as a whole.
+//
+// The stream starts with a header. Each pipeline is followed by its resources
+// and then its jobs; each resource by its versions, oldest first; and each job
+// by its finished builds, oldest first. The stream ends with an end record,
+// so that a truncated export can be told apart from a complete one.
+//
+// IDs within it are the exporting ATC's, and are only used to refer to
+// versions; they are remapped on import.
+type TeamExportRecord struct {
+	Header   *TeamExportHeader `json:"header,omitempty"`
+	Pipeline *PipelineExport   `json:"pipeline,omitempty"`
+	Resource *ResourceExport   `json:"resource,omitempty"`
+	Version  *VersionExport    `json:"version,omitempty"`
+	Job      *JobExport        `json:"job,omitempty"`
+	Build    *BuildExport      `json:"build,omitempty"`
+	End      bool              `json:"end,omitempty"`
+}
+
+type TeamExportHeader struct {
+	Version int    `json:"version"`
+	Team    string `json:"team"`
+}
+
+// TeamImport lists the pipelines an import recreated, and the ones it skipped
+// because the team already had them.
+type TeamImport struct {
+	Imported []string `json:"imported"`
+	Skipped  []string `json:"skipped"`
+}
+
+type PipelineExport struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Config       Config       `json:"config"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Archived     bool         `json:"archived"`
+}
+
+type ResourceExport struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+type VersionExport struct {
+	ID       int             `json:"id"`
+	Type     string          `json:"type"`
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata,omitempty"`
+	Enabled  bool            `json:"enabled"`
+}
+
+type JobExport struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+type BuildExport struct {
+	Name          string          `json:"name"`
+	Status        BuildStatus     `json:"status"`
+	StartTime     int64           `json:"start_time,omitempty"`
+	EndTime       int64           `json:"end_time,omitempty"`
+	TriggerReason string          `json:"trigger_reason,omitempty"`
+	Annotations   json.RawMessage `json:"annotations,omitempty"`
+
+	Inputs  []BuildInputExport  `json:"inputs"`
+	Outputs []BuildOutputExport `json:"outputs"`
+
+	// Events are only exported when asked for, as they make up the bulk of
+	// the archive.
+	Events []EventExport `json:"events,omitempty"`
+}
+
+type BuildInputExport struct {
+	Name      string `json:"name"`
+	VersionID int    `json:"version_id"`
+}
+
+type BuildOutputExport struct {
+	VersionID int  `json:"version_id"`
+	Explicit  bool `json:"explicit"`
+}
+
+type EventExport struct {
+	Type    EventType       `json:"type"`
+	Version EventVersion    `json:"version"`
+	Payload json.RawMessage `json:"payload"`
+}
@@ -0,0 +1,54 @@
+package atc
+
+type TestReport struct {
+	StepName string `json:"step_name"`
+	PlanID   PlanID `json:"plan_id"`
+
+	Format string `json:"format"`
+	Path   string `json:"path"`
+
+	Tests    int     `json:"tests"`
+	Failures int     `json:"failures"`
+	Errors   int     `json:"errors"`
+	Skipped  int     `json:"skipped"`
+	Duration float64 `json:"duration"`
+
+	FailedCases []TestCaseFailure `json:"failed_cases,omitempty"`
+}
+
+type TestCaseFailure struct {
+	Suite     string `json:"suite,omitempty"`
+	Classname string `json:"classname,omitempty"`
+	Name      string `json:"name"`
+
+	// true if the test case errored, rather than failed an assertion
+	Errored bool `json:"errored,omitempty"`
+
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type JobTestStatistics struct {
+	// the number of builds with test reports that the statistics cover
+	Builds int `json:"builds"`
+
+	Tests []TestCaseStatistics `json:"tests"`
+}
+
+type TestCaseStatistics struct {
+	StepName  string `json:"step_name"`
+	Suite     string `json:"suite,omitempty"`
+	Classname string `json:"classname,omitempty"`
+	Name      string `json:"name"`
+
+	// the number of builds whose reports on the test case's step show whether
+	// it passed, and how many of them the test case failed in
+	Runs     int `json:"runs"`
+	Failures int `json:"failures"`
+
+	// true if the test case both failed and passed in builds that ran with the
+	// same inputs
+	Flaky bool `json:"flaky"`
+
+	LastFailedBuild string `json:"last_failed_build"`
+}
@@ -9,3 +9,13 @@ type Volume struct {
 	Identifier        string `json:"identifier"`
 	SizeInBytes       int64  `json:"size_in_bytes"`
 }
+
+type VolumeDetails struct {
+	Volume
+
+	TeamName             string   `json:"team_name"`
+	Owner                *Owner   `json:"owner,omitempty"`
+	ContainerHandle      string   `json:"container_handle,omitempty"`
+	OriginalVolumeHandle string   `json:"original_volume_handle,omitempty"`
+	ChildVolumeHandles   []string `json:"child_volume_handles"`
+}
@@ -9,7 +9,11 @@ type Worker struct {
 	HTTPSProxyURL string `json:"https_proxy_url,omitempty"`
 	NoProxy       string `json:"no_proxy,omitempty"`
 
-	ActiveContainers int `json:"active_containers"`
+	ActiveContainers int     `json:"active_containers"`
+	ActiveVolumes    int     `json:"active_volumes"`
+	CPUPressure      float64 `json:"cpu_pressure"`
+	MemoryPressure   float64 `json:"memory_pressure"`
+	DiskPressure     float64 `json:"disk_pressure"`
 
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
@@ -18,6 +22,26 @@ type Worker struct {
 	Team      string   `json:"team"`
 	Name      string   `json:"name"`
 	StartTime int64    `json:"start_time"`
+
+	State string `json:"state,omitempty"`
+}
+
+const (
+	WorkerStateRunning = "running"
+
+	// the worker's heartbeat stream dropped; it is not given any new work
+	// until it reconnects
+	WorkerStateStalled = "stalled"
+)
+
+// WorkerLoad is reported by a worker on every tick of its heartbeat stream.
+// Pressures range from 0, idle, to 1, exhausted.
+type WorkerLoad struct {
+	ActiveContainers int     `json:"active_containers"`
+	ActiveVolumes    int     `json:"active_volumes"`
+	CPUPressure      float64 `json:"cpu_pressure"`
+	MemoryPressure   float64 `json:"memory_pressure"`
+	DiskPressure     float64 `json:"disk_pressure"`
 }
 
 type WorkerResourceType struct {
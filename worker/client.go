@@ -43,15 +43,19 @@ type VolumeSpec struct {
 	Strategy   Strategy
 	Properties VolumeProperties
 	Privileged bool
-	TTL        time.Duration
+
+	// TTL is how long the volume is kept in the database while it has no
+	// owner. A TTL of 0 keeps it until its owner is gone.
+	TTL time.Duration
 }
 
+// baggageclaimVolumeSpec never sets a TTL, as volumes are only destroyed on
+// the worker once the database no longer knows about them.
 func (spec VolumeSpec) baggageclaimVolumeSpec() baggageclaim.VolumeSpec {
 	return baggageclaim.VolumeSpec{
 		Strategy:   spec.Strategy.baggageclaimStrategy(),
 		Privileged: spec.Privileged,
 		Properties: baggageclaim.VolumeProperties(spec.Properties),
-		TTL:        spec.TTL,
 	}
 }
 
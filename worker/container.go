@@ -6,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/metric"
@@ -19,18 +18,12 @@ type gardenWorkerContainer struct {
 	garden.Container
 
 	gardenClient garden.Client
-	db           GardenWorkerDB
 
 	volumes      []Volume
 	volumeMounts []VolumeMount
 
 	user string
 
-	clock clock.Clock
-
-	release      chan *time.Duration
-	heartbeating *sync.WaitGroup
-
 	releaseOnce sync.Once
 
 	workerName string
@@ -41,8 +34,6 @@ func newGardenWorkerContainer(
 	container garden.Container,
 	gardenClient garden.Client,
 	baggageclaimClient baggageclaim.Client,
-	db GardenWorkerDB,
-	clock clock.Clock,
 	volumeFactory VolumeFactory,
 	workerName string,
 ) (Container, error) {
@@ -52,23 +43,10 @@ func newGardenWorkerContainer(
 		Container: container,
 
 		gardenClient: gardenClient,
-		db:           db,
-
-		clock: clock,
 
-		heartbeating: new(sync.WaitGroup),
-		release:      make(chan *time.Duration, 1),
-		workerName:   workerName,
+		workerName: workerName,
 	}
 
-	workerContainer.heartbeat(logger.Session("initial-heartbeat"), ContainerTTL)
-
-	workerContainer.heartbeating.Add(1)
-	go workerContainer.heartbeatContinuously(
-		logger.Session("continuous-heartbeat"),
-		clock.NewTicker(containerKeepalive),
-	)
-
 	metric.TrackedContainers.Inc()
 
 	properties, err := workerContainer.Properties()
@@ -101,14 +79,15 @@ func (container *gardenWorkerContainer) WorkerName() string {
 	return container.workerName
 }
 
+// Release stops tracking the container and its volumes. The container is not
+// destroyed, and does not expire; it is garbage collected once its owner is
+// gone. The final TTL is ignored.
 func (container *gardenWorkerContainer) Release(finalTTL *time.Duration) {
 	container.releaseOnce.Do(func() {
-		container.release <- finalTTL
-		container.heartbeating.Wait()
 		metric.TrackedContainers.Dec()
 
 		for _, v := range container.volumes {
-			v.Release(finalTTL)
+			v.Release(nil)
 		}
 	})
 }
@@ -218,40 +197,3 @@ func (container *gardenWorkerContainer) setVolumes(
 	container.volumes = volumes
 	return volumesByHandle, nil
 }
-
-func (container *gardenWorkerContainer) heartbeatContinuously(logger lager.Logger, pacemaker clock.Ticker) {
-	defer container.heartbeating.Done()
-	defer pacemaker.Stop()
-
-	logger.Debug("start")
-	defer logger.Debug("done")
-
-	for {
-		select {
-		case <-pacemaker.C():
-			container.heartbeat(logger.Session("tick"), ContainerTTL)
-
-		case finalTTL := <-container.release:
-			if finalTTL != nil {
-				container.heartbeat(logger.Session("final"), *finalTTL)
-			}
-
-			return
-		}
-	}
-}
-
-func (container *gardenWorkerContainer) heartbeat(logger lager.Logger, ttl time.Duration) {
-	logger.Debug("start")
-	defer logger.Debug("done")
-
-	err := container.db.UpdateExpiresAtOnContainer(container.Handle(), ttl)
-	if err != nil {
-		logger.Error("failed-to-heartbeat-to-db", err)
-	}
-
-	err = container.SetGraceTime(ttl)
-	if err != nil {
-		logger.Error("failed-to-heartbeat-to-container", err)
-	}
-}
@@ -11,6 +11,7 @@ import (
 	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/concourse/retryhttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -22,15 +23,12 @@ type WorkerDB interface {
 	CreateContainer(container db.Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (db.SavedContainer, error)
 	GetContainer(string) (db.SavedContainer, bool, error)
 	FindContainerByIdentifier(db.ContainerIdentifier) (db.SavedContainer, bool, error)
-	UpdateExpiresAtOnContainer(handle string, ttl time.Duration) error
 	ReapContainer(handle string) error
 	GetPipelineByID(pipelineID int) (db.SavedPipeline, error)
 	InsertVolume(db.Volume) error
 	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
 	ReapVolume(handle string) error
-	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
-	SetVolumeTTL(string, time.Duration) error
 }
 
 type dbProvider struct {
@@ -68,10 +66,16 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(savedWorkers))
+	workers := []Worker{}
 
-	for i, savedWorker := range savedWorkers {
-		workers[i] = provider.newGardenWorker(tikTok, savedWorker)
+	for _, savedWorker := range savedWorkers {
+		// stalled workers are still listed, but are not given any new work
+		// until they reconnect
+		if savedWorker.State == db.WorkerStateStalled {
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -122,10 +126,7 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.S
 		bClient = bclient.New(savedWorker.BaggageclaimURL)
 	}
 
-	volumeFactory := NewVolumeFactory(
-		provider.db,
-		tikTok,
-	)
+	volumeFactory := NewVolumeFactory(provider.db)
 
 	volumeClient := NewVolumeClient(
 		bClient,
@@ -144,7 +145,13 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.S
 		provider.db,
 		provider,
 		tikTok,
-		savedWorker.ActiveContainers,
+		atc.WorkerLoad{
+			ActiveContainers: savedWorker.ActiveContainers,
+			ActiveVolumes:    savedWorker.ActiveVolumes,
+			CPUPressure:      savedWorker.CPUPressure,
+			MemoryPressure:   savedWorker.MemoryPressure,
+			DiskPressure:     savedWorker.DiskPressure,
+		},
 		savedWorker.ResourceTypes,
 		savedWorker.Platform,
 		savedWorker.Tags,
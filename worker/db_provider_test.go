@@ -116,6 +116,9 @@ var _ = Describe("DBProvider", func() {
 							GardenAddr:       gardenAddr,
 							BaggageclaimURL:  baggageclaimServer.URL(),
 							ActiveContainers: 2,
+							ActiveVolumes:    4,
+							CPUPressure:      0.5,
+							DiskPressure:     0.25,
 							ResourceTypes: []atc.WorkerResourceType{
 								{Type: "some-resource-a", Image: "some-image-a"},
 							},
@@ -142,6 +145,41 @@ var _ = Describe("DBProvider", func() {
 				Expect(workers).To(HaveLen(2))
 			})
 
+			It("exposes the load reported by each worker", func() {
+				Expect(workers[0].Load()).To(Equal(atc.WorkerLoad{
+					ActiveContainers: 2,
+					ActiveVolumes:    4,
+					CPUPressure:      0.5,
+					DiskPressure:     0.25,
+				}))
+			})
+
+			Context("when a worker has stalled", func() {
+				BeforeEach(func() {
+					fakeDB.WorkersReturns([]db.SavedWorker{
+						{
+							WorkerInfo: db.WorkerInfo{
+								Name:       "some-worker",
+								GardenAddr: gardenAddr,
+								State:      db.WorkerStateRunning,
+							},
+						},
+						{
+							WorkerInfo: db.WorkerInfo{
+								Name:       "some-stalled-worker",
+								GardenAddr: gardenAddr,
+								State:      db.WorkerStateStalled,
+							},
+						},
+					}, nil)
+				})
+
+				It("leaves it out", func() {
+					Expect(workers).To(HaveLen(1))
+					Expect(workers[0].Name()).To(Equal("some-worker"))
+				})
+			})
+
 			Context("creating the connection to garden", func() {
 				var id Identifier
 				var spec ContainerSpec
@@ -1,7 +0,0 @@
-package worker
-
-import "time"
-
-func FinalTTL(ttl time.Duration) *time.Duration {
-	return &ttl
-}
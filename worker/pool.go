@@ -126,10 +126,32 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
+
+	candidates := []Worker{}
+	for _, worker := range compatibleWorkers {
+		if !underPressure(worker.Load()) {
+			candidates = append(candidates, worker)
+		}
+	}
+
+	if len(candidates) == 0 {
+		candidates = compatibleWorkers
+	}
+
+	randomWorker := candidates[pool.rand.Intn(len(candidates))]
 	return randomWorker, nil
 }
 
+// workers reporting this much pressure on any resource are only chosen when
+// every compatible worker is under pressure
+const highPressure = 0.9
+
+func underPressure(load atc.WorkerLoad) bool {
+	return load.CPUPressure >= highPressure ||
+		load.MemoryPressure >= highPressure ||
+		load.DiskPressure >= highPressure
+}
+
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
 	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
 	if err != nil {
@@ -165,6 +165,31 @@ var _ = Describe("Pool", func() {
 					}))
 				})
 			})
+
+			Context("when a satisfying worker is under pressure", func() {
+				BeforeEach(func() {
+					workerA.LoadReturns(atc.WorkerLoad{MemoryPressure: 0.95})
+				})
+
+				It("avoids it", func() {
+					for i := 0; i < 20; i++ {
+						satisfyingWorker, satisfyingErr = pool.Satisfying(spec, resourceTypes)
+						Expect(satisfyingErr).NotTo(HaveOccurred())
+						Expect(satisfyingWorker).To(Equal(workerB))
+					}
+				})
+
+				Context("when every satisfying worker is under pressure", func() {
+					BeforeEach(func() {
+						workerB.LoadReturns(atc.WorkerLoad{CPUPressure: 1})
+					})
+
+					It("still returns one of them", func() {
+						Expect(satisfyingErr).NotTo(HaveOccurred())
+						Expect([]Worker{workerA, workerB}).To(ContainElement(satisfyingWorker))
+					})
+				})
+			})
 		})
 
 		Context("with no workers", func() {
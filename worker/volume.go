@@ -4,21 +4,15 @@ import (
 	"sync"
 	"time"
 
-	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
 
-const volumeKeepalive = 30 * time.Second
-
 //go:generate counterfeiter . VolumeFactoryDB
 
 type VolumeFactoryDB interface {
 	GetVolumeTTL(volumeHandle string) (time.Duration, bool, error)
-	ReapVolume(handle string) error
-	SetVolumeTTLAndSizeInBytes(handle string, ttl time.Duration, sizeInBytes int64) error
-	SetVolumeTTL(handle string, ttl time.Duration) error
 }
 
 //go:generate counterfeiter . VolumeFactory
@@ -28,33 +22,27 @@ type VolumeFactory interface {
 }
 
 type volumeFactory struct {
-	db    VolumeFactoryDB
-	clock clock.Clock
+	db VolumeFactoryDB
 }
 
-func NewVolumeFactory(db VolumeFactoryDB, clock clock.Clock) VolumeFactory {
+func NewVolumeFactory(db VolumeFactoryDB) VolumeFactory {
 	return &volumeFactory{
-		db:    db,
-		clock: clock,
+		db: db,
 	}
 }
 
+// Build wraps a volume that the database knows about. Volumes are not
+// heartbeated; they are kept until their owner is gone, or, without an owner,
+// until they expire in the database.
 func (vf *volumeFactory) Build(logger lager.Logger, bcVol baggageclaim.Volume) (Volume, bool, error) {
+	// the baggageclaim client heartbeats volumes it creates or looks up
 	bcVol.Release(nil)
 
 	logger = logger.WithData(lager.Data{"volume": bcVol.Handle()})
 
-	vol := &volume{
-		Volume: bcVol,
-		db:     vf.db,
-
-		heartbeating: new(sync.WaitGroup),
-		release:      make(chan *time.Duration, 1),
-	}
-
-	ttl, found, err := vf.db.GetVolumeTTL(vol.Handle())
+	_, found, err := vf.db.GetVolumeTTL(bcVol.Handle())
 	if err != nil {
-		logger.Error("failed-to-lookup-expiration-of-volume", err)
+		logger.Error("failed-to-lookup-volume", err)
 		return nil, false, err
 	}
 
@@ -62,18 +50,9 @@ func (vf *volumeFactory) Build(logger lager.Logger, bcVol baggageclaim.Volume) (
 		return nil, false, nil
 	}
 
-	vol.heartbeat(logger.Session("initial-heartbeat"), ttl)
-
-	vol.heartbeating.Add(1)
-	go vol.heartbeatContinuously(
-		logger.Session("continuous-heartbeat"),
-		vf.clock.NewTicker(volumeKeepalive),
-		ttl,
-	)
-
 	metric.TrackedVolumes.Inc()
 
-	return vol, true, nil
+	return &volume{Volume: bcVol}, true, nil
 }
 
 //go:generate counterfeiter . Volume
@@ -88,11 +67,7 @@ type Volume interface {
 type volume struct {
 	baggageclaim.Volume
 
-	db VolumeFactoryDB
-
-	release      chan *time.Duration
-	heartbeating *sync.WaitGroup
-	releaseOnce  sync.Once
+	releaseOnce sync.Once
 }
 
 type VolumeMount struct {
@@ -102,79 +77,10 @@ type VolumeMount struct {
 
 func (*volume) HeartbeatingToDB() {}
 
+// Release stops tracking the volume. The final TTL is ignored, as volumes no
+// longer expire on the worker.
 func (v *volume) Release(finalTTL *time.Duration) {
 	v.releaseOnce.Do(func() {
-		v.release <- finalTTL
-		v.heartbeating.Wait()
 		metric.TrackedVolumes.Dec()
 	})
 }
-
-func (v *volume) heartbeatContinuously(logger lager.Logger, pacemaker clock.Ticker, initialTTL time.Duration) {
-	defer v.heartbeating.Done()
-	defer pacemaker.Stop()
-
-	logger.Debug("start")
-	defer logger.Debug("done")
-
-	ttlToSet := initialTTL
-
-	for {
-		select {
-		case <-pacemaker.C():
-			ttl, found, err := v.db.GetVolumeTTL(v.Handle())
-			if err != nil {
-				logger.Error("failed-to-lookup-volume-ttl", err)
-			} else {
-				if !found {
-					logger.Info("volume-expired-from-database")
-					return
-				}
-
-				ttlToSet = ttl
-			}
-
-			v.heartbeat(logger.Session("tick"), ttlToSet)
-
-		case finalTTL := <-v.release:
-			if finalTTL != nil {
-				v.heartbeat(logger.Session("final"), *finalTTL)
-			}
-
-			return
-		}
-	}
-}
-
-func (v *volume) heartbeat(logger lager.Logger, ttl time.Duration) {
-	logger.Debug("start")
-	defer logger.Debug("done")
-
-	err := v.SetTTL(ttl)
-	if err != nil {
-		if err == baggageclaim.ErrVolumeNotFound {
-			err = v.db.ReapVolume(v.Handle())
-			if err != nil {
-				logger.Error("failed-to-delete-volume-from-database", err)
-			}
-		}
-		logger.Error("failed-to-heartbeat-to-volume", err)
-	}
-
-	size, err := v.SizeInBytes()
-	if err != nil {
-		logger.Error("failed-to-get-volume-size", err)
-
-		err = v.db.SetVolumeTTL(v.Handle(), ttl)
-		if err != nil {
-			logger.Error("failed-to-set-volume-ttl", err)
-		}
-
-		return
-	}
-
-	err = v.db.SetVolumeTTLAndSizeInBytes(v.Handle(), ttl, size)
-	if err != nil {
-		logger.Error("failed-to-set-volume-ttl-and-size", err)
-	}
-}
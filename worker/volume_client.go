@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -102,6 +103,12 @@ func (c *volumeClient) CreateVolume(
 		return nil, err
 	}
 
+	ownerType, _ := db.VolumeOwnerType(volumeSpec.Strategy.dbIdentifier())
+	metric.VolumeCreated{
+		WorkerName: c.workerName,
+		OwnerType:  ownerType,
+	}.Emit(logger)
+
 	volume, found, err := c.volumeFactory.Build(logger, bcVolume)
 	if err != nil {
 		logger.Error("failed-build-volume", err)
@@ -193,21 +200,16 @@ func (c *volumeClient) selectLowestAlphabeticalVolume(logger lager.Logger, volum
 	return lowestVolume, nil
 }
 
+// expireVolume forgets the volume, after which it is destroyed on the worker
+// by the garbage collector.
 func (c *volumeClient) expireVolume(logger lager.Logger, handle string) error {
 	logger.Info("expiring")
 
-	wVol, found, err := c.LookupVolume(logger, handle)
+	err := c.db.ReapVolume(handle)
 	if err != nil {
-		logger.Error("failed-to-look-up-volume", err)
+		logger.Error("failed-to-reap-volume", err)
 		return err
 	}
 
-	if !found {
-		logger.Debug("volume-already-gone")
-		return nil
-	}
-
-	wVol.Release(FinalTTL(VolumeTTL))
-
 	return nil
 }
@@ -95,9 +95,6 @@ var _ = Describe("VolumeClient", func() {
 		})
 
 		Context("when many matching volumes are found in the db", func() {
-			var bcVol2, bcVol3 *bfakes.FakeVolume
-			var wVol2, wVol3 *wfakes.FakeVolume
-
 			BeforeEach(func() {
 				version1 := "some-version"
 				importVolumeIdentifier := db.VolumeIdentifier{
@@ -137,60 +134,29 @@ var _ = Describe("VolumeClient", func() {
 
 					return []db.SavedVolume{}, nil
 				}
-
-				bcVol2 = new(bfakes.FakeVolume)
-				bcVol3 = new(bfakes.FakeVolume)
-
-				fakeBaggageclaimClient.LookupVolumeStub = func(testLogger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
-					switch {
-					case handle == "vol-2-handle":
-						return bcVol2, true, nil
-					case handle == "vol-3-handle":
-						return bcVol3, true, nil
-					}
-					return new(bfakes.FakeVolume), true, nil
-				}
-
-				wVol2 = new(wfakes.FakeVolume)
-				wVol3 = new(wfakes.FakeVolume)
-
-				fakeVolumeFactory.BuildStub = func(testLogger lager.Logger, volume baggageclaim.Volume) (worker.Volume, bool, error) {
-					switch {
-					case volume == bcVol2:
-						return wVol2, true, nil
-					case volume == bcVol3:
-						return wVol3, true, nil
-					}
-
-					return new(wfakes.FakeVolume), true, nil
-				}
 			})
 
-			It("releases all of the volumes except the oldest one", func() {
-				Expect(wVol2.ReleaseCallCount()).To(Equal(1))
-				Expect(wVol2.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(5 * time.Minute)))
+			It("forgets all of the volumes except the oldest one", func() {
+				Expect(fakeGardenWorkerDB.ReapVolumeCallCount()).To(Equal(2))
+				Expect(fakeGardenWorkerDB.ReapVolumeArgsForCall(0)).To(Equal("vol-2-handle"))
+				Expect(fakeGardenWorkerDB.ReapVolumeArgsForCall(1)).To(Equal("vol-3-handle"))
+			})
 
-				Expect(wVol3.ReleaseCallCount()).To(Equal(1))
-				Expect(wVol3.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(5 * time.Minute)))
+			It("looks up the oldest volume", func() {
+				Expect(fakeBaggageclaimClient.LookupVolumeCallCount()).To(Equal(1))
+				_, actualHandle := fakeBaggageclaimClient.LookupVolumeArgsForCall(0)
+				Expect(actualHandle).To(Equal("vol-1-handle"))
 			})
 
 			It("does not return an error", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
-			Context("when looking up any of the extra volumes fails", func() {
+			Context("when forgetting any of the extra volumes fails", func() {
 				disaster := errors.New("some-error")
 
 				BeforeEach(func() {
-					fakeBaggageclaimClient.LookupVolumeStub = func(testLogger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
-						switch {
-						case handle == "vol-2-handle":
-							return nil, false, disaster
-						case handle == "vol-3-handle":
-							return bcVol3, true, nil
-						}
-						return new(bfakes.FakeVolume), true, nil
-					}
+					fakeGardenWorkerDB.ReapVolumeReturns(disaster)
 				})
 
 				It("returns the error", func() {
@@ -198,26 +164,7 @@ var _ = Describe("VolumeClient", func() {
 				})
 
 				It("does not continue to the next volume", func() {
-					Expect(wVol3.ReleaseCallCount()).To(Equal(0))
-				})
-			})
-
-			Context("when a volume which is going to be expired can't be found", func() {
-				BeforeEach(func() {
-					fakeBaggageclaimClient.LookupVolumeStub = func(testLogger lager.Logger, handle string) (baggageclaim.Volume, bool, error) {
-						switch {
-						case handle == "vol-2-handle":
-							return nil, false, nil
-						case handle == "vol-3-handle":
-							return bcVol3, true, nil
-						}
-						return new(bfakes.FakeVolume), true, nil
-					}
-				})
-
-				It("should continue to the next volume", func() {
-					Expect(wVol3.ReleaseCallCount()).To(Equal(1))
-					Expect(wVol3.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(5 * time.Minute)))
+					Expect(fakeGardenWorkerDB.ReapVolumeCallCount()).To(Equal(1))
 				})
 			})
 		})
@@ -421,7 +368,6 @@ var _ = Describe("VolumeClient", func() {
 					Expect(spec).To(Equal(baggageclaim.VolumeSpec{
 						Strategy:   baggageclaim.EmptyStrategy{},
 						Properties: baggageclaim.VolumeProperties(volumeSpec.Properties),
-						TTL:        volumeSpec.TTL,
 						Privileged: volumeSpec.Privileged,
 					}))
 				})
@@ -518,7 +464,6 @@ var _ = Describe("VolumeClient", func() {
 					Expect(spec).To(Equal(baggageclaim.VolumeSpec{
 						Strategy:   baggageclaim.EmptyStrategy{},
 						Properties: baggageclaim.VolumeProperties(volumeSpec.Properties),
-						TTL:        volumeSpec.TTL,
 						Privileged: volumeSpec.Privileged,
 					}))
 				})
@@ -615,7 +560,6 @@ var _ = Describe("VolumeClient", func() {
 					Expect(spec).To(Equal(baggageclaim.VolumeSpec{
 						Strategy:   baggageclaim.ImportStrategy{Path: "some-image-path"},
 						Properties: baggageclaim.VolumeProperties(volumeSpec.Properties),
-						TTL:        volumeSpec.TTL,
 						Privileged: volumeSpec.Privileged,
 					}))
 				})
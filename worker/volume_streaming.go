@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// StreamVolume copies the contents of a volume on one worker into a volume on
+// another, returning the number of bytes transferred.
+//
+// baggageclaim only exposes streaming in and out of a volume, so the tar
+// stream is relayed through the ATC, without being buffered.
+func StreamVolume(logger lager.Logger, source Volume, destination Volume) (int64, error) {
+	logger = logger.Session("stream-volume", lager.Data{
+		"source":      source.Handle(),
+		"destination": destination.Handle(),
+	})
+
+	out, err := source.StreamOut(".")
+	if err != nil {
+		logger.Error("failed-to-stream-out", err)
+		return 0, err
+	}
+
+	defer out.Close()
+
+	counter := &countingReader{Reader: out}
+
+	err = destination.StreamIn(".", counter)
+	if err != nil {
+		logger.Error("failed-to-stream-in", err)
+		return counter.count, err
+	}
+
+	logger.Debug("streamed", lager.Data{"bytes": counter.count})
+
+	return counter.count, nil
+}
+
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,93 @@
+package worker_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+)
+
+var _ = Describe("StreamVolume", func() {
+	var (
+		sourceVolume      *workerfakes.FakeVolume
+		destinationVolume *workerfakes.FakeVolume
+
+		source      worker.Volume
+		destination worker.Volume
+
+		streamedIn []byte
+
+		bytesStreamed int64
+		streamErr     error
+	)
+
+	BeforeEach(func() {
+		sourceVolume = new(workerfakes.FakeVolume)
+		sourceVolume.HandleReturns("source-handle")
+		sourceVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar-stream")), nil)
+
+		streamedIn = nil
+
+		destinationVolume = new(workerfakes.FakeVolume)
+		destinationVolume.HandleReturns("destination-handle")
+		destinationVolume.StreamInStub = func(path string, tarStream io.Reader) error {
+			var err error
+			streamedIn, err = ioutil.ReadAll(tarStream)
+			return err
+		}
+
+		source = sourceVolume
+		destination = destinationVolume
+	})
+
+	JustBeforeEach(func() {
+		bytesStreamed, streamErr = worker.StreamVolume(lagertest.NewTestLogger("test"), source, destination)
+	})
+
+	It("streams the root of the source volume into the root of the destination", func() {
+		Expect(streamErr).NotTo(HaveOccurred())
+
+		Expect(sourceVolume.StreamOutArgsForCall(0)).To(Equal("."))
+
+		path, _ := destinationVolume.StreamInArgsForCall(0)
+		Expect(path).To(Equal("."))
+		Expect(string(streamedIn)).To(Equal("some-tar-stream"))
+	})
+
+	It("returns the number of bytes streamed", func() {
+		Expect(bytesStreamed).To(Equal(int64(len("some-tar-stream"))))
+	})
+
+	Context("when streaming out fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			sourceVolume.StreamOutReturns(nil, disaster)
+		})
+
+		It("returns the error without streaming in", func() {
+			Expect(streamErr).To(Equal(disaster))
+			Expect(destinationVolume.StreamInCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when streaming in fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			destinationVolume.StreamInStub = nil
+			destinationVolume.StreamInReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(streamErr).To(Equal(disaster))
+		})
+	})
+})
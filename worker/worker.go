@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -33,11 +34,15 @@ func (err MalformedMetadataError) Error() string {
 	return fmt.Sprintf("malformed image metadata: %s", err.UnmarshalError)
 }
 
-const containerKeepalive = 30 * time.Second
+// ContainerTTL is how long a container without an owner is kept.
 const ContainerTTL = 5 * time.Minute
 
+// VolumeTTL is how long a volume without an owner is kept, e.g. a volume that
+// has not yet been attached to a container.
 const VolumeTTL = 5 * time.Minute
 
+const expiredVolumeTTL = time.Second
+
 const ephemeralPropertyName = "concourse:ephemeral"
 const volumePropertyName = "concourse:volumes"
 const volumeMountsPropertyName = "concourse:volume-mounts"
@@ -50,6 +55,13 @@ type Worker interface {
 	Client
 
 	ActiveContainers() int
+	Load() atc.WorkerLoad
+
+	ContainerHandles(lager.Logger) ([]string, error)
+	DestroyContainer(lager.Logger, string) error
+	VolumeHandles(lager.Logger) ([]string, error)
+	ExpireVolume(lager.Logger, string) error
+	VolumeSizeInBytes(lager.Logger, string) (int64, bool, error)
 
 	Description() string
 	Name() string
@@ -62,11 +74,10 @@ type Worker interface {
 type GardenWorkerDB interface {
 	CreateContainer(container db.Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (db.SavedContainer, error)
 	GetContainer(handle string) (db.SavedContainer, bool, error)
-	UpdateExpiresAtOnContainer(handle string, ttl time.Duration) error
 	ReapContainer(string) error
 	GetPipelineByID(pipelineID int) (db.SavedPipeline, error)
 	InsertVolume(db.Volume) error
-	SetVolumeTTLAndSizeInBytes(string, time.Duration, int64) error
+	ReapVolume(handle string) error
 	GetVolumeTTL(string) (time.Duration, bool, error)
 	GetVolumesByIdentifier(db.VolumeIdentifier) ([]db.SavedVolume, error)
 }
@@ -84,16 +95,16 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             atc.Tags
-	teamID           int
-	name             string
-	startTime        int64
-	httpProxyURL     string
-	httpsProxyURL    string
-	noProxy          string
+	load          atc.WorkerLoad
+	resourceTypes []atc.WorkerResourceType
+	platform      string
+	tags          atc.Tags
+	teamID        int
+	name          string
+	startTime     int64
+	httpProxyURL  string
+	httpsProxyURL string
+	noProxy       string
 }
 
 func NewGardenWorker(
@@ -106,7 +117,7 @@ func NewGardenWorker(
 	db GardenWorkerDB,
 	provider WorkerProvider,
 	clock clock.Clock,
-	activeContainers int,
+	load atc.WorkerLoad,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
@@ -127,7 +138,7 @@ func NewGardenWorker(
 		provider:           provider,
 		clock:              clock,
 		pipelineDBFactory:  pipelineDBFactory,
-		activeContainers:   activeContainers,
+		load:               load,
 		resourceTypes:      resourceTypes,
 		platform:           platform,
 		tags:               tags,
@@ -474,12 +485,19 @@ func (worker *gardenWorker) CreateContainer(
 
 	id.ResourceTypeVersion = resourceTypeVersion
 
+	// containers with an owner are kept until their owner is gone
+	ttl := ContainerTTL
+	owner, owned := db.ContainerOwner(db.ContainerIdentifier(id))
+	if owned {
+		ttl = 0
+	}
+
 	_, err = worker.db.CreateContainer(
 		db.Container{
 			ContainerIdentifier: db.ContainerIdentifier(id),
 			ContainerMetadata:   db.ContainerMetadata(metadata),
 		},
-		ContainerTTL,
+		ttl,
 		worker.maxContainerLifetime(metadata),
 		volumeHandles,
 	)
@@ -487,13 +505,16 @@ func (worker *gardenWorker) CreateContainer(
 		return nil, err
 	}
 
+	metric.ContainerCreated{
+		WorkerName: worker.name,
+		OwnerType:  owner.Type,
+	}.Emit(logger)
+
 	return newGardenWorkerContainer(
 		logger,
 		gardenContainer,
 		worker.gardenClient,
 		worker.baggageclaimClient,
-		worker.db,
-		worker.clock,
 		worker.volumeFactory,
 		worker.name,
 	)
@@ -579,8 +600,6 @@ func (worker *gardenWorker) LookupContainer(logger lager.Logger, handle string)
 		gardenContainer,
 		worker.gardenClient,
 		worker.baggageclaimClient,
-		worker.db,
-		worker.clock,
 		worker.volumeFactory,
 		worker.name,
 	)
@@ -594,7 +613,94 @@ func (worker *gardenWorker) LookupContainer(logger lager.Logger, handle string)
 }
 
 func (worker *gardenWorker) ActiveContainers() int {
-	return worker.activeContainers
+	return worker.load.ActiveContainers
+}
+
+func (worker *gardenWorker) Load() atc.WorkerLoad {
+	return worker.load
+}
+
+// ContainerHandles lists every container on the worker, including those that
+// the database does not know about.
+func (worker *gardenWorker) ContainerHandles(logger lager.Logger) ([]string, error) {
+	gardenContainers, err := worker.gardenClient.Containers(garden.Properties{})
+	if err != nil {
+		logger.Error("failed-to-list-containers", err)
+		return nil, err
+	}
+
+	handles := []string{}
+	for _, gardenContainer := range gardenContainers {
+		handles = append(handles, gardenContainer.Handle())
+	}
+
+	return handles, nil
+}
+
+func (worker *gardenWorker) DestroyContainer(logger lager.Logger, handle string) error {
+	return worker.gardenClient.Destroy(handle)
+}
+
+// VolumeHandles lists every volume on the worker, including those that the
+// database does not know about.
+func (worker *gardenWorker) VolumeHandles(logger lager.Logger) ([]string, error) {
+	if worker.baggageclaimClient == nil {
+		return []string{}, nil
+	}
+
+	bcVolumes, err := worker.baggageclaimClient.ListVolumes(logger, baggageclaim.VolumeProperties{})
+	if err != nil {
+		logger.Error("failed-to-list-volumes", err)
+		return nil, err
+	}
+
+	handles := []string{}
+	for _, bcVolume := range bcVolumes {
+		handles = append(handles, bcVolume.Handle())
+	}
+
+	return handles, nil
+}
+
+// ExpireVolume has baggageclaim destroy the volume as soon as it can, i.e.
+// once nothing else is using it.
+func (worker *gardenWorker) ExpireVolume(logger lager.Logger, handle string) error {
+	if worker.baggageclaimClient == nil {
+		return ErrNoVolumeManager
+	}
+
+	bcVolume, found, err := worker.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	return bcVolume.SetTTL(expiredVolumeTTL)
+}
+
+func (worker *gardenWorker) VolumeSizeInBytes(logger lager.Logger, handle string) (int64, bool, error) {
+	if worker.baggageclaimClient == nil {
+		return 0, false, ErrNoVolumeManager
+	}
+
+	bcVolume, found, err := worker.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !found {
+		return 0, false, nil
+	}
+
+	size, err := bcVolume.SizeInBytes()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return size, true, nil
 }
 
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
@@ -38,7 +38,7 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider     *wfakes.FakeWorkerProvider
 		fakeClock              *fakeclock.FakeClock
 		fakePipelineDBFactory  *dbfakes.FakePipelineDBFactory
-		activeContainers       int
+		load                   atc.WorkerLoad
 		resourceTypes          []atc.WorkerResourceType
 		platform               string
 		tags                   atc.Tags
@@ -67,7 +67,7 @@ var _ = Describe("Worker", func() {
 		fakeWorkerProvider = new(wfakes.FakeWorkerProvider)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
-		activeContainers = 42
+		load = atc.WorkerLoad{ActiveContainers: 42}
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -94,7 +94,7 @@ var _ = Describe("Worker", func() {
 			fakeGardenWorkerDB,
 			fakeWorkerProvider,
 			fakeClock,
-			activeContainers,
+			load,
 			resourceTypes,
 			platform,
 			tags,
@@ -214,11 +214,25 @@ var _ = Describe("Worker", func() {
 				}),
 			}))
 
-			Expect(ttl).To(Equal(ContainerTTL))
+			Expect(ttl).To(BeZero())
 			Expect(maxContainerLifetime).To(Equal(time.Duration(0)))
 			Expect(volumeHandles).To(BeEmpty())
 		})
 
+		Context("when the container has no owner", func() {
+			BeforeEach(func() {
+				containerID = Identifier{
+					ResourceTypeVersion: atc.Version{"some": "version"},
+				}
+			})
+
+			It("creates the container in the db with a ttl", func() {
+				Expect(fakeGardenWorkerDB.CreateContainerCallCount()).To(Equal(1))
+				_, ttl, _, _ := fakeGardenWorkerDB.CreateContainerArgsForCall(0)
+				Expect(ttl).To(Equal(ContainerTTL))
+			})
+		})
+
 		Context("when the spec does not specify ImageURL", func() {
 			BeforeEach(func() {
 				containerSpec.ImageSpec.ImageURL = ""
@@ -247,61 +261,12 @@ var _ = Describe("Worker", func() {
 				By("destroying via garden")
 				Expect(fakeGardenClient.DestroyCallCount()).To(Equal(1))
 				Expect(fakeGardenClient.DestroyArgsForCall(0)).To(Equal("some-container-handle"))
-
-				By("no longer heartbeating")
-				fakeClock.Increment(30 * time.Second)
-				Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(1))
-			})
-
-			It("performs an initial heartbeat synchronously on the returned container", func() {
-				Expect(fakeContainer.SetGraceTimeCallCount()).To(Equal(1))
-				Expect(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount()).To(Equal(1))
 			})
 
-			It("heartbeats to the database and the container", func() {
-				fakeClock.Increment(30 * time.Second)
-
-				Eventually(fakeContainer.SetGraceTimeCallCount).Should(Equal(2))
-				Expect(fakeContainer.SetGraceTimeArgsForCall(1)).To(Equal(5 * time.Minute))
-
-				Eventually(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(2))
-				handle, interval := fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(1)
-				Expect(handle).To(Equal("some-container-handle"))
-				Expect(interval).To(Equal(5 * time.Minute))
-
-				fakeClock.Increment(30 * time.Second)
-
-				Eventually(fakeContainer.SetGraceTimeCallCount).Should(Equal(3))
-				Expect(fakeContainer.SetGraceTimeArgsForCall(2)).To(Equal(5 * time.Minute))
-
-				Eventually(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(3))
-				handle, interval = fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(2)
-				Expect(handle).To(Equal("some-container-handle"))
-				Expect(interval).To(Equal(5 * time.Minute))
-			})
-
-			It("sets a final ttl on the container and stops heartbeating when the container is released", func() {
-				createdContainer.Release(FinalTTL(30 * time.Minute))
-
-				Expect(fakeContainer.SetGraceTimeCallCount()).Should(Equal(2))
-				Expect(fakeContainer.SetGraceTimeArgsForCall(1)).To(Equal(30 * time.Minute))
-
-				Expect(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount()).Should(Equal(2))
-				handle, interval := fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(1)
-				Expect(handle).To(Equal("some-container-handle"))
-				Expect(interval).To(Equal(30 * time.Minute))
-
-				fakeClock.Increment(30 * time.Second)
-
-				Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(2))
-				Consistently(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(2))
-			})
-
-			It("does not perform a final heartbeat when there is no final ttl", func() {
+			It("never sets a grace time on the container", func() {
 				createdContainer.Release(nil)
 
-				Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(1))
-				Consistently(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(1))
+				Expect(fakeContainer.SetGraceTimeCallCount()).To(BeZero())
 			})
 
 			Context("when creating the container in the db fails", func() {
@@ -1331,7 +1296,7 @@ var _ = Describe("Worker", func() {
 								fakeGardenWorkerDB,
 								fakeWorkerProvider,
 								fakeClock,
-								activeContainers,
+								load,
 								resourceTypes,
 								platform,
 								tags,
@@ -1395,7 +1360,7 @@ var _ = Describe("Worker", func() {
 								fakeGardenWorkerDB,
 								fakeWorkerProvider,
 								fakeClock,
-								activeContainers,
+								load,
 								resourceTypes,
 								platform,
 								tags,
@@ -1417,13 +1382,13 @@ var _ = Describe("Worker", func() {
 
 				Describe("Release", func() {
 					It("releases the container's volumes once and only once", func() {
-						foundContainer.Release(FinalTTL(time.Minute))
+						foundContainer.Release(nil)
 						Expect(expectedHandle1Volume.ReleaseCallCount()).To(Equal(1))
-						Expect(expectedHandle1Volume.ReleaseArgsForCall(0)).To(Equal(FinalTTL(time.Minute)))
+						Expect(expectedHandle1Volume.ReleaseArgsForCall(0)).To(BeNil())
 						Expect(expectedHandle2Volume.ReleaseCallCount()).To(Equal(1))
-						Expect(expectedHandle2Volume.ReleaseArgsForCall(0)).To(Equal(FinalTTL(time.Minute)))
+						Expect(expectedHandle2Volume.ReleaseArgsForCall(0)).To(BeNil())
 
-						foundContainer.Release(FinalTTL(time.Hour))
+						foundContainer.Release(nil)
 						Expect(expectedHandle1Volume.ReleaseCallCount()).To(Equal(1))
 						Expect(expectedHandle2Volume.ReleaseCallCount()).To(Equal(1))
 					})
@@ -1855,67 +1820,12 @@ var _ = Describe("Worker", func() {
 					By("destroying via garden")
 					Expect(fakeGardenClient.DestroyCallCount()).To(Equal(1))
 					Expect(fakeGardenClient.DestroyArgsForCall(0)).To(Equal("provider-handle"))
-
-					By("no longer heartbeating")
-					fakeClock.Increment(30 * time.Second)
-					Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(1))
-				})
-
-				It("performs an initial heartbeat synchronously", func() {
-					Expect(fakeContainer.SetGraceTimeCallCount()).To(Equal(1))
-					Expect(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount()).To(Equal(1))
 				})
 
-				Describe("every 30 seconds", func() {
-					It("heartbeats to the database and the container", func() {
-						fakeClock.Increment(30 * time.Second)
-
-						Eventually(fakeContainer.SetGraceTimeCallCount).Should(Equal(2))
-						Expect(fakeContainer.SetGraceTimeArgsForCall(1)).To(Equal(5 * time.Minute))
-
-						Eventually(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(2))
-						handle, interval := fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(1)
-						Expect(handle).To(Equal("provider-handle"))
-						Expect(interval).To(Equal(5 * time.Minute))
-
-						fakeClock.Increment(30 * time.Second)
-
-						Eventually(fakeContainer.SetGraceTimeCallCount).Should(Equal(3))
-						Expect(fakeContainer.SetGraceTimeArgsForCall(2)).To(Equal(5 * time.Minute))
-
-						Eventually(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(3))
-						handle, interval = fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(2)
-						Expect(handle).To(Equal("provider-handle"))
-						Expect(interval).To(Equal(5 * time.Minute))
-					})
-				})
-
-				Describe("releasing", func() {
-					It("sets a final ttl on the container and stops heartbeating", func() {
-						foundContainer.Release(FinalTTL(30 * time.Minute))
-
-						Expect(fakeContainer.SetGraceTimeCallCount()).Should(Equal(2))
-						Expect(fakeContainer.SetGraceTimeArgsForCall(1)).To(Equal(30 * time.Minute))
-
-						Expect(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount()).Should(Equal(2))
-						handle, interval := fakeGardenWorkerDB.UpdateExpiresAtOnContainerArgsForCall(1)
-						Expect(handle).To(Equal("provider-handle"))
-						Expect(interval).To(Equal(30 * time.Minute))
-
-						fakeClock.Increment(30 * time.Second)
-
-						Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(2))
-						Consistently(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(2))
-					})
-
-					Context("with no final ttl", func() {
-						It("does not perform a final heartbeat", func() {
-							foundContainer.Release(nil)
+				It("never sets a grace time on the container", func() {
+					foundContainer.Release(nil)
 
-							Consistently(fakeContainer.SetGraceTimeCallCount).Should(Equal(1))
-							Consistently(fakeGardenWorkerDB.UpdateExpiresAtOnContainerCallCount).Should(Equal(1))
-						})
-					})
+					Expect(fakeContainer.SetGraceTimeCallCount()).To(BeZero())
 				})
 
 				It("can be released multiple times", func() {
@@ -32,15 +32,6 @@ type FakeGardenWorkerDB struct {
 		result2 bool
 		result3 error
 	}
-	UpdateExpiresAtOnContainerStub        func(handle string, ttl time.Duration) error
-	updateExpiresAtOnContainerMutex       sync.RWMutex
-	updateExpiresAtOnContainerArgsForCall []struct {
-		handle string
-		ttl    time.Duration
-	}
-	updateExpiresAtOnContainerReturns struct {
-		result1 error
-	}
 	ReapContainerStub        func(string) error
 	reapContainerMutex       sync.RWMutex
 	reapContainerArgsForCall []struct {
@@ -66,16 +57,6 @@ type FakeGardenWorkerDB struct {
 	insertVolumeReturns struct {
 		result1 error
 	}
-	SetVolumeTTLAndSizeInBytesStub        func(string, time.Duration, int64) error
-	setVolumeTTLAndSizeInBytesMutex       sync.RWMutex
-	setVolumeTTLAndSizeInBytesArgsForCall []struct {
-		arg1 string
-		arg2 time.Duration
-		arg3 int64
-	}
-	setVolumeTTLAndSizeInBytesReturns struct {
-		result1 error
-	}
 	GetVolumeTTLStub        func(string) (time.Duration, bool, error)
 	getVolumeTTLMutex       sync.RWMutex
 	getVolumeTTLArgsForCall []struct {
@@ -95,6 +76,14 @@ type FakeGardenWorkerDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	ReapVolumeStub        func(handle string) error
+	reapVolumeMutex       sync.RWMutex
+	reapVolumeArgsForCall []struct {
+		handle string
+	}
+	reapVolumeReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -176,40 +165,6 @@ func (fake *FakeGardenWorkerDB) GetContainerReturns(result1 db.SavedContainer, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeGardenWorkerDB) UpdateExpiresAtOnContainer(handle string, ttl time.Duration) error {
-	fake.updateExpiresAtOnContainerMutex.Lock()
-	fake.updateExpiresAtOnContainerArgsForCall = append(fake.updateExpiresAtOnContainerArgsForCall, struct {
-		handle string
-		ttl    time.Duration
-	}{handle, ttl})
-	fake.recordInvocation("UpdateExpiresAtOnContainer", []interface{}{handle, ttl})
-	fake.updateExpiresAtOnContainerMutex.Unlock()
-	if fake.UpdateExpiresAtOnContainerStub != nil {
-		return fake.UpdateExpiresAtOnContainerStub(handle, ttl)
-	} else {
-		return fake.updateExpiresAtOnContainerReturns.result1
-	}
-}
-
-func (fake *FakeGardenWorkerDB) UpdateExpiresAtOnContainerCallCount() int {
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
-	return len(fake.updateExpiresAtOnContainerArgsForCall)
-}
-
-func (fake *FakeGardenWorkerDB) UpdateExpiresAtOnContainerArgsForCall(i int) (string, time.Duration) {
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
-	return fake.updateExpiresAtOnContainerArgsForCall[i].handle, fake.updateExpiresAtOnContainerArgsForCall[i].ttl
-}
-
-func (fake *FakeGardenWorkerDB) UpdateExpiresAtOnContainerReturns(result1 error) {
-	fake.UpdateExpiresAtOnContainerStub = nil
-	fake.updateExpiresAtOnContainerReturns = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeGardenWorkerDB) ReapContainer(arg1 string) error {
 	fake.reapContainerMutex.Lock()
 	fake.reapContainerArgsForCall = append(fake.reapContainerArgsForCall, struct {
@@ -310,41 +265,6 @@ func (fake *FakeGardenWorkerDB) InsertVolumeReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeGardenWorkerDB) SetVolumeTTLAndSizeInBytes(arg1 string, arg2 time.Duration, arg3 int64) error {
-	fake.setVolumeTTLAndSizeInBytesMutex.Lock()
-	fake.setVolumeTTLAndSizeInBytesArgsForCall = append(fake.setVolumeTTLAndSizeInBytesArgsForCall, struct {
-		arg1 string
-		arg2 time.Duration
-		arg3 int64
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("SetVolumeTTLAndSizeInBytes", []interface{}{arg1, arg2, arg3})
-	fake.setVolumeTTLAndSizeInBytesMutex.Unlock()
-	if fake.SetVolumeTTLAndSizeInBytesStub != nil {
-		return fake.SetVolumeTTLAndSizeInBytesStub(arg1, arg2, arg3)
-	} else {
-		return fake.setVolumeTTLAndSizeInBytesReturns.result1
-	}
-}
-
-func (fake *FakeGardenWorkerDB) SetVolumeTTLAndSizeInBytesCallCount() int {
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
-	return len(fake.setVolumeTTLAndSizeInBytesArgsForCall)
-}
-
-func (fake *FakeGardenWorkerDB) SetVolumeTTLAndSizeInBytesArgsForCall(i int) (string, time.Duration, int64) {
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
-	return fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg1, fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg2, fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg3
-}
-
-func (fake *FakeGardenWorkerDB) SetVolumeTTLAndSizeInBytesReturns(result1 error) {
-	fake.SetVolumeTTLAndSizeInBytesStub = nil
-	fake.setVolumeTTLAndSizeInBytesReturns = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeGardenWorkerDB) GetVolumeTTL(arg1 string) (time.Duration, bool, error) {
 	fake.getVolumeTTLMutex.Lock()
 	fake.getVolumeTTLArgsForCall = append(fake.getVolumeTTLArgsForCall, struct {
@@ -414,6 +334,39 @@ func (fake *FakeGardenWorkerDB) GetVolumesByIdentifierReturns(result1 []db.Saved
 	}{result1, result2}
 }
 
+func (fake *FakeGardenWorkerDB) ReapVolume(handle string) error {
+	fake.reapVolumeMutex.Lock()
+	fake.reapVolumeArgsForCall = append(fake.reapVolumeArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("ReapVolume", []interface{}{handle})
+	fake.reapVolumeMutex.Unlock()
+	if fake.ReapVolumeStub != nil {
+		return fake.ReapVolumeStub(handle)
+	} else {
+		return fake.reapVolumeReturns.result1
+	}
+}
+
+func (fake *FakeGardenWorkerDB) ReapVolumeCallCount() int {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return len(fake.reapVolumeArgsForCall)
+}
+
+func (fake *FakeGardenWorkerDB) ReapVolumeArgsForCall(i int) string {
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
+	return fake.reapVolumeArgsForCall[i].handle
+}
+
+func (fake *FakeGardenWorkerDB) ReapVolumeReturns(result1 error) {
+	fake.ReapVolumeStub = nil
+	fake.reapVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -421,20 +374,18 @@ func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.createContainerMutex.RUnlock()
 	fake.getContainerMutex.RLock()
 	defer fake.getContainerMutex.RUnlock()
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
 	fake.reapContainerMutex.RLock()
 	defer fake.reapContainerMutex.RUnlock()
 	fake.getPipelineByIDMutex.RLock()
 	defer fake.getPipelineByIDMutex.RUnlock()
 	fake.insertVolumeMutex.RLock()
 	defer fake.insertVolumeMutex.RUnlock()
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
 	fake.getVolumeTTLMutex.RLock()
 	defer fake.getVolumeTTLMutex.RUnlock()
 	fake.getVolumesByIdentifierMutex.RLock()
 	defer fake.getVolumesByIdentifierMutex.RUnlock()
+	fake.reapVolumeMutex.RLock()
+	defer fake.reapVolumeMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -177,6 +177,59 @@ type FakeWorker struct {
 	isOwnedByTeamReturns     struct {
 		result1 bool
 	}
+	ContainerHandlesStub        func(lager.Logger) ([]string, error)
+	containerHandlesMutex       sync.RWMutex
+	containerHandlesArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	containerHandlesReturns struct {
+		result1 []string
+		result2 error
+	}
+	DestroyContainerStub        func(lager.Logger, string) error
+	destroyContainerMutex       sync.RWMutex
+	destroyContainerArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	destroyContainerReturns struct {
+		result1 error
+	}
+	VolumeHandlesStub        func(lager.Logger) ([]string, error)
+	volumeHandlesMutex       sync.RWMutex
+	volumeHandlesArgsForCall []struct {
+		arg1 lager.Logger
+	}
+	volumeHandlesReturns struct {
+		result1 []string
+		result2 error
+	}
+	ExpireVolumeStub        func(lager.Logger, string) error
+	expireVolumeMutex       sync.RWMutex
+	expireVolumeArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	expireVolumeReturns struct {
+		result1 error
+	}
+	LoadStub        func() atc.WorkerLoad
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct{}
+	loadReturns     struct {
+		result1 atc.WorkerLoad
+	}
+	VolumeSizeInBytesStub        func(lager.Logger, string) (int64, bool, error)
+	volumeSizeInBytesMutex       sync.RWMutex
+	volumeSizeInBytesArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	volumeSizeInBytesReturns struct {
+		result1 int64
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -759,6 +812,203 @@ func (fake *FakeWorker) IsOwnedByTeamReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) ContainerHandles(arg1 lager.Logger) ([]string, error) {
+	fake.containerHandlesMutex.Lock()
+	fake.containerHandlesArgsForCall = append(fake.containerHandlesArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("ContainerHandles", []interface{}{arg1})
+	fake.containerHandlesMutex.Unlock()
+	if fake.ContainerHandlesStub != nil {
+		return fake.ContainerHandlesStub(arg1)
+	} else {
+		return fake.containerHandlesReturns.result1, fake.containerHandlesReturns.result2
+	}
+}
+
+func (fake *FakeWorker) ContainerHandlesCallCount() int {
+	fake.containerHandlesMutex.RLock()
+	defer fake.containerHandlesMutex.RUnlock()
+	return len(fake.containerHandlesArgsForCall)
+}
+
+func (fake *FakeWorker) ContainerHandlesArgsForCall(i int) lager.Logger {
+	fake.containerHandlesMutex.RLock()
+	defer fake.containerHandlesMutex.RUnlock()
+	return fake.containerHandlesArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) ContainerHandlesReturns(result1 []string, result2 error) {
+	fake.ContainerHandlesStub = nil
+	fake.containerHandlesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) DestroyContainer(arg1 lager.Logger, arg2 string) error {
+	fake.destroyContainerMutex.Lock()
+	fake.destroyContainerArgsForCall = append(fake.destroyContainerArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DestroyContainer", []interface{}{arg1, arg2})
+	fake.destroyContainerMutex.Unlock()
+	if fake.DestroyContainerStub != nil {
+		return fake.DestroyContainerStub(arg1, arg2)
+	} else {
+		return fake.destroyContainerReturns.result1
+	}
+}
+
+func (fake *FakeWorker) DestroyContainerCallCount() int {
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	return len(fake.destroyContainerArgsForCall)
+}
+
+func (fake *FakeWorker) DestroyContainerArgsForCall(i int) (lager.Logger, string) {
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	return fake.destroyContainerArgsForCall[i].arg1, fake.destroyContainerArgsForCall[i].arg2
+}
+
+func (fake *FakeWorker) DestroyContainerReturns(result1 error) {
+	fake.DestroyContainerStub = nil
+	fake.destroyContainerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) VolumeHandles(arg1 lager.Logger) ([]string, error) {
+	fake.volumeHandlesMutex.Lock()
+	fake.volumeHandlesArgsForCall = append(fake.volumeHandlesArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("VolumeHandles", []interface{}{arg1})
+	fake.volumeHandlesMutex.Unlock()
+	if fake.VolumeHandlesStub != nil {
+		return fake.VolumeHandlesStub(arg1)
+	} else {
+		return fake.volumeHandlesReturns.result1, fake.volumeHandlesReturns.result2
+	}
+}
+
+func (fake *FakeWorker) VolumeHandlesCallCount() int {
+	fake.volumeHandlesMutex.RLock()
+	defer fake.volumeHandlesMutex.RUnlock()
+	return len(fake.volumeHandlesArgsForCall)
+}
+
+func (fake *FakeWorker) VolumeHandlesArgsForCall(i int) lager.Logger {
+	fake.volumeHandlesMutex.RLock()
+	defer fake.volumeHandlesMutex.RUnlock()
+	return fake.volumeHandlesArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) VolumeHandlesReturns(result1 []string, result2 error) {
+	fake.VolumeHandlesStub = nil
+	fake.volumeHandlesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ExpireVolume(arg1 lager.Logger, arg2 string) error {
+	fake.expireVolumeMutex.Lock()
+	fake.expireVolumeArgsForCall = append(fake.expireVolumeArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ExpireVolume", []interface{}{arg1, arg2})
+	fake.expireVolumeMutex.Unlock()
+	if fake.ExpireVolumeStub != nil {
+		return fake.ExpireVolumeStub(arg1, arg2)
+	} else {
+		return fake.expireVolumeReturns.result1
+	}
+}
+
+func (fake *FakeWorker) ExpireVolumeCallCount() int {
+	fake.expireVolumeMutex.RLock()
+	defer fake.expireVolumeMutex.RUnlock()
+	return len(fake.expireVolumeArgsForCall)
+}
+
+func (fake *FakeWorker) ExpireVolumeArgsForCall(i int) (lager.Logger, string) {
+	fake.expireVolumeMutex.RLock()
+	defer fake.expireVolumeMutex.RUnlock()
+	return fake.expireVolumeArgsForCall[i].arg1, fake.expireVolumeArgsForCall[i].arg2
+}
+
+func (fake *FakeWorker) ExpireVolumeReturns(result1 error) {
+	fake.ExpireVolumeStub = nil
+	fake.expireVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) Load() atc.WorkerLoad {
+	fake.loadMutex.Lock()
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct{}{})
+	fake.recordInvocation("Load", []interface{}{})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub()
+	} else {
+		return fake.loadReturns.result1
+	}
+}
+
+func (fake *FakeWorker) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeWorker) LoadReturns(result1 atc.WorkerLoad) {
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 atc.WorkerLoad
+	}{result1}
+}
+
+func (fake *FakeWorker) VolumeSizeInBytes(arg1 lager.Logger, arg2 string) (int64, bool, error) {
+	fake.volumeSizeInBytesMutex.Lock()
+	fake.volumeSizeInBytesArgsForCall = append(fake.volumeSizeInBytesArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("VolumeSizeInBytes", []interface{}{arg1, arg2})
+	fake.volumeSizeInBytesMutex.Unlock()
+	if fake.VolumeSizeInBytesStub != nil {
+		return fake.VolumeSizeInBytesStub(arg1, arg2)
+	} else {
+		return fake.volumeSizeInBytesReturns.result1, fake.volumeSizeInBytesReturns.result2, fake.volumeSizeInBytesReturns.result3
+	}
+}
+
+func (fake *FakeWorker) VolumeSizeInBytesCallCount() int {
+	fake.volumeSizeInBytesMutex.RLock()
+	defer fake.volumeSizeInBytesMutex.RUnlock()
+	return len(fake.volumeSizeInBytesArgsForCall)
+}
+
+func (fake *FakeWorker) VolumeSizeInBytesArgsForCall(i int) (lager.Logger, string) {
+	fake.volumeSizeInBytesMutex.RLock()
+	defer fake.volumeSizeInBytesMutex.RUnlock()
+	return fake.volumeSizeInBytesArgsForCall[i].arg1, fake.volumeSizeInBytesArgsForCall[i].arg2
+}
+
+func (fake *FakeWorker) VolumeSizeInBytesReturns(result1 int64, result2 bool, result3 error) {
+	fake.VolumeSizeInBytesStub = nil
+	fake.volumeSizeInBytesReturns = struct {
+		result1 int64
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -798,6 +1048,18 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.uptimeMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
 	defer fake.isOwnedByTeamMutex.RUnlock()
+	fake.containerHandlesMutex.RLock()
+	defer fake.containerHandlesMutex.RUnlock()
+	fake.destroyContainerMutex.RLock()
+	defer fake.destroyContainerMutex.RUnlock()
+	fake.volumeHandlesMutex.RLock()
+	defer fake.volumeHandlesMutex.RUnlock()
+	fake.expireVolumeMutex.RLock()
+	defer fake.expireVolumeMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	fake.volumeSizeInBytesMutex.RLock()
+	defer fake.volumeSizeInBytesMutex.RUnlock()
 	return fake.invocations
 }
 
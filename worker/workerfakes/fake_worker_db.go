@@ -59,15 +59,6 @@ type FakeWorkerDB struct {
 		result2 bool
 		result3 error
 	}
-	UpdateExpiresAtOnContainerStub        func(handle string, ttl time.Duration) error
-	updateExpiresAtOnContainerMutex       sync.RWMutex
-	updateExpiresAtOnContainerArgsForCall []struct {
-		handle string
-		ttl    time.Duration
-	}
-	updateExpiresAtOnContainerReturns struct {
-		result1 error
-	}
 	ReapContainerStub        func(handle string) error
 	reapContainerMutex       sync.RWMutex
 	reapContainerArgsForCall []struct {
@@ -120,25 +111,6 @@ type FakeWorkerDB struct {
 	reapVolumeReturns struct {
 		result1 error
 	}
-	SetVolumeTTLAndSizeInBytesStub        func(string, time.Duration, int64) error
-	setVolumeTTLAndSizeInBytesMutex       sync.RWMutex
-	setVolumeTTLAndSizeInBytesArgsForCall []struct {
-		arg1 string
-		arg2 time.Duration
-		arg3 int64
-	}
-	setVolumeTTLAndSizeInBytesReturns struct {
-		result1 error
-	}
-	SetVolumeTTLStub        func(string, time.Duration) error
-	setVolumeTTLMutex       sync.RWMutex
-	setVolumeTTLArgsForCall []struct {
-		arg1 string
-		arg2 time.Duration
-	}
-	setVolumeTTLReturns struct {
-		result1 error
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -316,40 +288,6 @@ func (fake *FakeWorkerDB) FindContainerByIdentifierReturns(result1 db.SavedConta
 	}{result1, result2, result3}
 }
 
-func (fake *FakeWorkerDB) UpdateExpiresAtOnContainer(handle string, ttl time.Duration) error {
-	fake.updateExpiresAtOnContainerMutex.Lock()
-	fake.updateExpiresAtOnContainerArgsForCall = append(fake.updateExpiresAtOnContainerArgsForCall, struct {
-		handle string
-		ttl    time.Duration
-	}{handle, ttl})
-	fake.recordInvocation("UpdateExpiresAtOnContainer", []interface{}{handle, ttl})
-	fake.updateExpiresAtOnContainerMutex.Unlock()
-	if fake.UpdateExpiresAtOnContainerStub != nil {
-		return fake.UpdateExpiresAtOnContainerStub(handle, ttl)
-	} else {
-		return fake.updateExpiresAtOnContainerReturns.result1
-	}
-}
-
-func (fake *FakeWorkerDB) UpdateExpiresAtOnContainerCallCount() int {
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
-	return len(fake.updateExpiresAtOnContainerArgsForCall)
-}
-
-func (fake *FakeWorkerDB) UpdateExpiresAtOnContainerArgsForCall(i int) (string, time.Duration) {
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
-	return fake.updateExpiresAtOnContainerArgsForCall[i].handle, fake.updateExpiresAtOnContainerArgsForCall[i].ttl
-}
-
-func (fake *FakeWorkerDB) UpdateExpiresAtOnContainerReturns(result1 error) {
-	fake.UpdateExpiresAtOnContainerStub = nil
-	fake.updateExpiresAtOnContainerReturns = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeWorkerDB) ReapContainer(handle string) error {
 	fake.reapContainerMutex.Lock()
 	fake.reapContainerArgsForCall = append(fake.reapContainerArgsForCall, struct {
@@ -552,75 +490,6 @@ func (fake *FakeWorkerDB) ReapVolumeReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeWorkerDB) SetVolumeTTLAndSizeInBytes(arg1 string, arg2 time.Duration, arg3 int64) error {
-	fake.setVolumeTTLAndSizeInBytesMutex.Lock()
-	fake.setVolumeTTLAndSizeInBytesArgsForCall = append(fake.setVolumeTTLAndSizeInBytesArgsForCall, struct {
-		arg1 string
-		arg2 time.Duration
-		arg3 int64
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("SetVolumeTTLAndSizeInBytes", []interface{}{arg1, arg2, arg3})
-	fake.setVolumeTTLAndSizeInBytesMutex.Unlock()
-	if fake.SetVolumeTTLAndSizeInBytesStub != nil {
-		return fake.SetVolumeTTLAndSizeInBytesStub(arg1, arg2, arg3)
-	} else {
-		return fake.setVolumeTTLAndSizeInBytesReturns.result1
-	}
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLAndSizeInBytesCallCount() int {
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
-	return len(fake.setVolumeTTLAndSizeInBytesArgsForCall)
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLAndSizeInBytesArgsForCall(i int) (string, time.Duration, int64) {
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
-	return fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg1, fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg2, fake.setVolumeTTLAndSizeInBytesArgsForCall[i].arg3
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLAndSizeInBytesReturns(result1 error) {
-	fake.SetVolumeTTLAndSizeInBytesStub = nil
-	fake.setVolumeTTLAndSizeInBytesReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTL(arg1 string, arg2 time.Duration) error {
-	fake.setVolumeTTLMutex.Lock()
-	fake.setVolumeTTLArgsForCall = append(fake.setVolumeTTLArgsForCall, struct {
-		arg1 string
-		arg2 time.Duration
-	}{arg1, arg2})
-	fake.recordInvocation("SetVolumeTTL", []interface{}{arg1, arg2})
-	fake.setVolumeTTLMutex.Unlock()
-	if fake.SetVolumeTTLStub != nil {
-		return fake.SetVolumeTTLStub(arg1, arg2)
-	} else {
-		return fake.setVolumeTTLReturns.result1
-	}
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLCallCount() int {
-	fake.setVolumeTTLMutex.RLock()
-	defer fake.setVolumeTTLMutex.RUnlock()
-	return len(fake.setVolumeTTLArgsForCall)
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLArgsForCall(i int) (string, time.Duration) {
-	fake.setVolumeTTLMutex.RLock()
-	defer fake.setVolumeTTLMutex.RUnlock()
-	return fake.setVolumeTTLArgsForCall[i].arg1, fake.setVolumeTTLArgsForCall[i].arg2
-}
-
-func (fake *FakeWorkerDB) SetVolumeTTLReturns(result1 error) {
-	fake.SetVolumeTTLStub = nil
-	fake.setVolumeTTLReturns = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -634,8 +503,6 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.getContainerMutex.RUnlock()
 	fake.findContainerByIdentifierMutex.RLock()
 	defer fake.findContainerByIdentifierMutex.RUnlock()
-	fake.updateExpiresAtOnContainerMutex.RLock()
-	defer fake.updateExpiresAtOnContainerMutex.RUnlock()
 	fake.reapContainerMutex.RLock()
 	defer fake.reapContainerMutex.RUnlock()
 	fake.getPipelineByIDMutex.RLock()
@@ -648,10 +515,6 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.getVolumeTTLMutex.RUnlock()
 	fake.reapVolumeMutex.RLock()
 	defer fake.reapVolumeMutex.RUnlock()
-	fake.setVolumeTTLAndSizeInBytesMutex.RLock()
-	defer fake.setVolumeTTLAndSizeInBytesMutex.RUnlock()
-	fake.setVolumeTTLMutex.RLock()
-	defer fake.setVolumeTTLMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -46,6 +46,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.DownloadCLI,
 			atc.ListAuthMethods,
 			atc.GetInfo,
+			atc.GetSigningKeys,
 			atc.ListTeams,
 			atc.ListAllPipelines,
 			atc.ListPipelines,
@@ -60,6 +61,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
+			atc.GetBuildTestReports,
 			atc.BuildEvents:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
@@ -74,6 +76,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.GetJobTestStatistics,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -91,14 +94,24 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListWorkers,
 			atc.ReadPipe,
 			atc.RegisterWorker,
+			atc.HeartbeatWorker,
 			atc.SetTeam,
 			atc.WritePipe,
 			atc.ListVolumes,
+			atc.InspectContainers,
+			atc.InspectVolumes,
+			atc.ListBuildQueue,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.ListHijackSessions,
+			atc.GetHijackSessionRecording,
+			atc.DestroyContainer,
+			atc.DestroyVolume,
+			atc.ExportTeam,
+			atc.ImportTeam:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
@@ -110,6 +123,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ExplainJob,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
@@ -117,10 +131,16 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RenamePipeline,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
+			atc.ArchivePipeline,
 			atc.UnpauseResource,
 			atc.ExposePipeline,
 			atc.HidePipeline,
-			atc.SaveConfig:
+			atc.SaveConfig,
+			atc.ListAPITokens,
+			atc.CreateAPIToken,
+			atc.RevokeAPIToken,
+			atc.GetBuildProvenance,
+			atc.GetVersionProvenance:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
@@ -156,6 +156,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetInfo:          unauthenticated(inputHandlers[atc.GetInfo]),
 				atc.DownloadCLI:      unauthenticated(inputHandlers[atc.DownloadCLI]),
 				atc.ListAuthMethods:  unauthenticated(inputHandlers[atc.ListAuthMethods]),
+				atc.GetSigningKeys:   unauthenticated(inputHandlers[atc.GetSigningKeys]),
 				atc.ListAllPipelines: unauthenticated(inputHandlers[atc.ListAllPipelines]),
 				atc.ListBuilds:       unauthenticated(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:    unauthenticated(inputHandlers[atc.ListPipelines]),
@@ -170,6 +171,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized or public pipeline and public job
 				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildTestReports: checksIfPrivateJob(inputHandlers[atc.GetBuildTestReports]),
 
 				// resource belongs to authorized team
 				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
@@ -181,6 +183,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.GetJobTestStatistics:          openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobTestStatistics]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
@@ -195,9 +198,15 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
+
+				atc.InspectContainers: authenticated(inputHandlers[atc.InspectContainers]),
+				atc.InspectVolumes:    authenticated(inputHandlers[atc.InspectVolumes]),
+
+				atc.ListBuildQueue:  authenticated(inputHandlers[atc.ListBuildQueue]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
 				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
 				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
 
 				atc.SetTeam:   authenticated(inputHandlers[atc.SetTeam]),
 				atc.WritePipe: authenticated(inputHandlers[atc.WritePipe]),
@@ -207,6 +216,15 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 
+				atc.ListHijackSessions:        authenticatedAndAdmin(inputHandlers[atc.ListHijackSessions]),
+				atc.GetHijackSessionRecording: authenticatedAndAdmin(inputHandlers[atc.GetHijackSessionRecording]),
+
+				atc.DestroyContainer: authenticatedAndAdmin(inputHandlers[atc.DestroyContainer]),
+				atc.DestroyVolume:    authenticatedAndAdmin(inputHandlers[atc.DestroyVolume]),
+
+				atc.ExportTeam: authenticatedAndAdmin(inputHandlers[atc.ExportTeam]),
+				atc.ImportTeam: authenticatedAndAdmin(inputHandlers[atc.ImportTeam]),
+
 				// authorized (requested team matches resource team)
 				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
 				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
@@ -216,6 +234,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJob:             authorized(inputHandlers[atc.ExplainJob]),
 				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
 				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
@@ -224,9 +243,15 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
 				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
 				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ArchivePipeline:        authorized(inputHandlers[atc.ArchivePipeline]),
 				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
 				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
 				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.ListAPITokens:          authorized(inputHandlers[atc.ListAPITokens]),
+				atc.CreateAPIToken:         authorized(inputHandlers[atc.CreateAPIToken]),
+				atc.RevokeAPIToken:         authorized(inputHandlers[atc.RevokeAPIToken]),
+				atc.GetBuildProvenance:     authorized(inputHandlers[atc.GetBuildProvenance]),
+				atc.GetVersionProvenance:   authorized(inputHandlers[atc.GetVersionProvenance]),
 			}
 		})
 
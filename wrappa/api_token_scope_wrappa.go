@@ -0,0 +1,59 @@
+package wrappa
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+// APITokenScopeWrappa limits what requests made with API tokens may do. It
+// must be applied before (i.e. inside) the APIAuthWrappa, which is what
+// reads the token's scope into the request.
+type APITokenScopeWrappa struct{}
+
+func NewAPITokenScopeWrappa() APITokenScopeWrappa {
+	return APITokenScopeWrappa{}
+}
+
+func (wrappa APITokenScopeWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	rejector := auth.UnauthorizedRejector{}
+
+	for name, handler := range handlers {
+		wrapped[name] = auth.CheckAPITokenScopeHandler(handler, rejector, requiredAPITokenScope(name))
+	}
+
+	return wrapped
+}
+
+func requiredAPITokenScope(name string) atc.APITokenScope {
+	switch name {
+	// never with an API token
+	case atc.GetAuthToken,
+		atc.SetTeam,
+		atc.RegisterWorker,
+		atc.HijackContainer,
+		atc.GetLogLevel,
+		atc.SetLogLevel,
+		atc.ListHijackSessions,
+		atc.GetHijackSessionRecording,
+		atc.ListAPITokens,
+		atc.CreateAPIToken,
+		atc.RevokeAPIToken:
+		return ""
+
+	case atc.CreateJobBuild,
+		atc.AbortBuild,
+		atc.CheckResource:
+		return atc.APITokenScopeTriggerBuilds
+	}
+
+	for _, route := range atc.Routes {
+		if route.Name == name && route.Method == "GET" {
+			return atc.APITokenScopeReadOnly
+		}
+	}
+
+	return atc.APITokenScopeSetPipelines
+}
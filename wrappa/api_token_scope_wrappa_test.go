@@ -0,0 +1,91 @@
+package wrappa_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APITokenScopeWrappa", func() {
+	requires := func(scope atc.APITokenScope) func(http.Handler) http.Handler {
+		return func(handler http.Handler) http.Handler {
+			return auth.CheckAPITokenScopeHandler(handler, auth.UnauthorizedRejector{}, scope)
+		}
+	}
+
+	noAPITokens := requires("")
+	readOnly := requires(atc.APITokenScopeReadOnly)
+	triggerBuilds := requires(atc.APITokenScopeTriggerBuilds)
+	setPipelines := requires(atc.APITokenScopeSetPipelines)
+
+	Describe("Wrap", func() {
+		var (
+			inputHandlers    rata.Handlers
+			expectedHandlers rata.Handlers
+
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+
+			expectedHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				if route.Method == "GET" {
+					expectedHandlers[route.Name] = readOnly(inputHandlers[route.Name])
+				} else {
+					expectedHandlers[route.Name] = setPipelines(inputHandlers[route.Name])
+				}
+			}
+
+			for _, name := range []string{
+				atc.CreateJobBuild,
+				atc.AbortBuild,
+				atc.CheckResource,
+			} {
+				expectedHandlers[name] = triggerBuilds(inputHandlers[name])
+			}
+
+			for _, name := range []string{
+				atc.GetAuthToken,
+				atc.SetTeam,
+				atc.RegisterWorker,
+				atc.HijackContainer,
+				atc.GetLogLevel,
+				atc.SetLogLevel,
+				atc.ListHijackSessions,
+				atc.GetHijackSessionRecording,
+				atc.ListAPITokens,
+				atc.CreateAPIToken,
+				atc.RevokeAPIToken,
+			} {
+				expectedHandlers[name] = noAPITokens(inputHandlers[name])
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewAPITokenScopeWrappa().Wrap(inputHandlers)
+		})
+
+		It("requires the right scope for each route", func() {
+			for name := range inputHandlers {
+				Expect(wrappedHandlers[name]).To(BeIdenticalTo(expectedHandlers[name]), name)
+			}
+		})
+
+		It("requires set-pipelines to save config", func() {
+			Expect(wrappedHandlers[atc.SaveConfig]).To(BeIdenticalTo(setPipelines(inputHandlers[atc.SaveConfig])))
+		})
+	})
+})